@@ -0,0 +1,179 @@
+// config.go config command code
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	_ "github.com/tphakala/birdnet-go/internal/backup/targets" // registers backup target connection tests
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// Command creates the config parent command
+func Command(settings *conf.Settings) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Commands related to the BirdNET-Go configuration",
+	}
+
+	configCmd.AddCommand(ListCommand())
+	configCmd.AddCommand(SourcesCommand())
+	configCmd.AddCommand(TestBackupsCommand(settings))
+
+	return configCmd
+}
+
+// ListCommand creates the list subcommand
+func ListCommand() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all settings with their types, defaults and accepted values",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			settings := conf.DescribeSettings()
+
+			switch format {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(settings)
+			case "table":
+				return printTable(settings)
+			default:
+				return fmt.Errorf("unsupported format %q, use table or json", format)
+			}
+		},
+	}
+
+	listCmd.Flags().String("format", "table", "Output format, table or json")
+
+	return listCmd
+}
+
+// SourcesCommand creates the sources subcommand
+func SourcesCommand() *cobra.Command {
+	sourcesCmd := &cobra.Command{
+		Use:   "sources",
+		Short: "Show whether each setting comes from the config file, environment, defaults or an override",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			only, _ := cmd.Flags().GetString("source")
+			sources := conf.SettingSources()
+			if sources == nil {
+				return fmt.Errorf("settings are not loaded")
+			}
+			if only != "" {
+				maps.DeleteFunc(sources, func(_, source string) bool { return source != only })
+			}
+
+			switch format {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(sources)
+			case "table":
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "PATH\tSOURCE")
+				for _, path := range slices.Sorted(maps.Keys(sources)) {
+					fmt.Fprintf(w, "%s\t%s\n", path, sources[path])
+				}
+				return w.Flush()
+			default:
+				return fmt.Errorf("unsupported format %q, use table or json", format)
+			}
+		},
+	}
+
+	sourcesCmd.Flags().String("format", "table", "Output format, table or json")
+	sourcesCmd.Flags().String("source", "", "Only show settings from this source: file, env, default or override")
+
+	return sourcesCmd
+}
+
+// TestBackupsCommand creates the test-backups subcommand
+func TestBackupsCommand(settings *conf.Settings) *cobra.Command {
+	testCmd := &cobra.Command{
+		Use:   "test-backups",
+		Short: "Test the connection and write access of the enabled backup targets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			targets := settings.Backup.Targets
+			results := settings.Backup.TestAll(ctx)
+			if len(results) == 0 {
+				fmt.Println("No backup targets are enabled")
+				return nil
+			}
+
+			failed := 0
+			for i := range targets {
+				err, tested := results[i]
+				switch {
+				case !tested:
+					continue
+				case err != nil:
+					failed++
+					fmt.Printf("FAIL  target %d (%s): %v\n", i, targets[i].Type, err)
+				default:
+					fmt.Printf("OK    target %d (%s)\n", i, targets[i].Type)
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d backup targets failed the connection test", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	testCmd.Flags().Duration("timeout", 2*time.Minute, "Timeout for testing all backup targets")
+
+	return testCmd
+}
+
+// printTable prints settings as an aligned table
+func printTable(settings []conf.SettingMeta) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tTYPE\tDEFAULT\tACCEPTS")
+	for i := range settings {
+		setting := &settings[i]
+		defaultValue := fmt.Sprint(setting.Default)
+		switch {
+		case setting.Sensitive:
+			defaultValue = "(sensitive)"
+		case setting.Default == nil:
+			defaultValue = ""
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", setting.Path, setting.Type, defaultValue, accepts(setting))
+	}
+	return w.Flush()
+}
+
+// accepts describes the accepted values of a setting
+func accepts(setting *conf.SettingMeta) string {
+	switch {
+	case len(setting.Enum) > 0:
+		values := make([]string, len(setting.Enum))
+		for i, value := range setting.Enum {
+			values[i] = fmt.Sprint(value)
+		}
+		return strings.Join(values, ", ")
+	case setting.Min != nil && setting.Max != nil:
+		return fmt.Sprintf("%g to %g", *setting.Min, *setting.Max)
+	case setting.Min != nil:
+		return fmt.Sprintf(">= %g", *setting.Min)
+	case setting.Max != nil:
+		return fmt.Sprintf("<= %g", *setting.Max)
+	default:
+		return ""
+	}
+}
@@ -73,8 +73,11 @@ func Command(settings *conf.Settings) *cobra.Command {
 func setupFlags(cmd *cobra.Command, settings *conf.Settings) error {
 	cmd.Flags().BoolVarP(&settings.Input.Recursive, "recursive", "r", false, "Recursively analyze subdirectories")
 	cmd.Flags().BoolVarP(&settings.Input.Watch, "watch", "w", false, "Watch directory for new files")
+	cmd.Flags().IntVar(&settings.Input.Workers, "workers", 1, "Number of files to analyze in parallel")
+	cmd.Flags().StringVar(&settings.Input.FilePattern, "pattern", "", "Analyze only files with names matching this glob, e.g. \"*_2024*.wav\"")
+	cmd.Flags().BoolVar(&settings.Input.SkipExisting, "skip-existing", true, "Skip files with results in the output directory")
 	cmd.Flags().StringVarP(&settings.Output.File.Path, "output", "o", viper.GetString("output.file.path"), "Path to output directory")
-	cmd.Flags().StringVar(&settings.Output.File.Type, "type", viper.GetString("output.file.type"), "Output type: table, csv")
+	cmd.Flags().StringVar(&settings.Output.File.Type, "type", viper.GetString("output.file.type"), "Output type: table, csv, json")
 
 	if err := viper.BindPFlags(cmd.Flags()); err != nil {
 		return fmt.Errorf("error binding flags: %w", err)
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -372,11 +370,14 @@ func (cm *ControlMonitor) handleUpdateDetectionIntervals() {
 	// Note: If EventTracker cleanup becomes necessary in the future,
 	// get the current tracker here and perform cleanup before replacement
 
-	// Create a new EventTracker with updated settings
-	newTracker := processor.NewEventTrackerWithConfig(
-		globalInterval,
-		settings.Realtime.Species.Config,
-	)
+	// Create a new EventTracker with updated settings, per-species intervals
+	// are resolved from the settings on each event
+	newTracker := processor.NewEventTrackerWithResolver(func(species string) time.Duration {
+		if interval := settings.DetectionInterval(species); interval > 0 {
+			return interval
+		}
+		return globalInterval
+	})
 
 	// Clean up the old EventTracker if possible
 	// Note: If cleanup becomes necessary in the future, consider adding a Close()
@@ -516,23 +517,6 @@ func (cm *ControlMonitor) validateListenAddress(address string) error {
 	if address == "" {
 		return fmt.Errorf("listen address cannot be empty")
 	}
-	
-	// Check if it contains a colon (for port)
-	if !strings.Contains(address, ":") {
-		return fmt.Errorf("listen address must include port (e.g., '0.0.0.0:8090')")
-	}
-	
-	// Split and validate components
-	parts := strings.Split(address, ":")
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid address format, expected 'host:port'")
-	}
-	
-	// Validate port is numeric
-	port := parts[1]
-	if _, err := strconv.Atoi(port); err != nil {
-		return fmt.Errorf("invalid port number: %s", port)
-	}
-	
-	return nil
+	_, err := conf.NormalizeListenAddress(address)
+	return err
 }
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -33,10 +35,37 @@ func cleanupProcessingFiles(outputPath string) {
 	}
 }
 
-// isProcessed checks if a file has already been processed
-func isProcessed(path, outputPath string, processedFiles map[string]bool) bool {
+// processedFiles tracks the files analyzed during a directory analysis run,
+// it is shared by the analysis workers
+type processedFiles struct {
+	mu    sync.Mutex
+	paths map[string]bool
+}
+
+// newProcessedFiles returns an empty set of processed files
+func newProcessedFiles() *processedFiles {
+	return &processedFiles{paths: make(map[string]bool)}
+}
+
+// contains reports whether a file has been processed
+func (p *processedFiles) contains(path string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paths[path]
+}
+
+// add marks a file as processed
+func (p *processedFiles) add(path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paths[path] = true
+}
+
+// isProcessed checks if a file has already been processed, files with results
+// in the output directory count as processed when skipExisting is true
+func isProcessed(path, outputPath string, processedFiles *processedFiles, skipExisting bool) bool {
 	// Check if we have already processed this file in memory
-	if processedFiles[path] {
+	if processedFiles.contains(path) {
 		return true
 	}
 
@@ -46,16 +75,17 @@ func isProcessed(path, outputPath string, processedFiles map[string]bool) bool {
 	// Check for output files
 	outputPathCSV := filepath.Join(outputPath, baseName+".csv")
 	outputPathTable := filepath.Join(outputPath, baseName+".txt")
+	outputPathJSON := filepath.Join(outputPath, baseName+".json")
 	outputPathProcessing := filepath.Join(outputPath, baseName+".processing")
 
 	// Check if any of the output files exist
-	if _, err := os.Stat(outputPathCSV); err == nil {
-		processedFiles[path] = true
-		return true
-	}
-	if _, err := os.Stat(outputPathTable); err == nil {
-		processedFiles[path] = true
-		return true
+	if skipExisting {
+		for _, outputFile := range []string{outputPathCSV, outputPathTable, outputPathJSON} {
+			if _, err := os.Stat(outputFile); err == nil {
+				processedFiles.add(path)
+				return true
+			}
+		}
 	}
 
 	// Check for processing lock file
@@ -175,8 +205,8 @@ func isFileReadyForProcessing(path string, ctx context.Context) (bool, error) {
 }
 
 // processFile handles the analysis of a single audio file
-func processFile(path string, settings *conf.Settings, processedFiles map[string]bool, ctx context.Context) (bool, error) {
-	if isProcessed(path, settings.Output.File.Path, processedFiles) {
+func processFile(path string, settings *conf.Settings, processedFiles *processedFiles, ctx context.Context) (bool, error) {
+	if isProcessed(path, settings.Output.File.Path, processedFiles, settings.Input.SkipExisting) {
 		return false, nil // File was already processed
 	}
 
@@ -217,9 +247,10 @@ func processFile(path string, settings *conf.Settings, processedFiles map[string
 		log.Printf("Failed to close lock file: %v", err)
 	}
 
-	// Save the original path and restore it after processing
-	origPath := settings.Input.Path
-	settings.Input.Path = path
+	// Analyze the file with its own copy of the settings, workers analyze
+	// files in parallel
+	fileSettings := *settings
+	fileSettings.Input.Path = path
 
 	// Create a new context with cancellation for FileAnalysis
 	analysisCtx, cancelAnalysis := context.WithCancel(ctx)
@@ -228,7 +259,7 @@ func processFile(path string, settings *conf.Settings, processedFiles map[string
 	// Run FileAnalysis in a goroutine so we can handle interruption
 	analysisDone := make(chan error)
 	go func() {
-		analysisDone <- FileAnalysis(settings, analysisCtx)
+		analysisDone <- FileAnalysis(&fileSettings, analysisCtx)
 	}()
 
 	// Wait for either completion or interruption
@@ -242,8 +273,6 @@ func processFile(path string, settings *conf.Settings, processedFiles map[string
 		analysisErr = <-analysisDone // Wait for FileAnalysis to clean up
 	}
 
-	settings.Input.Path = origPath
-
 	// Remove lock file regardless of processing result
 	if removeErr := os.Remove(lockFile); removeErr != nil {
 		log.Printf("Warning: failed to remove lock file %s: %v", lockFile, removeErr)
@@ -257,15 +286,39 @@ func processFile(path string, settings *conf.Settings, processedFiles map[string
 	}
 
 	// Mark as processed
-	processedFiles[path] = true
+	processedFiles.add(path)
 	return true, nil
 }
 
-// scanDirectory scans a directory for audio files and processes them
-func scanDirectory(watchDir string, settings *conf.Settings, processedFiles map[string]bool, ctx context.Context) error {
+// scanDirectory scans a directory for audio files and processes them with
+// the configured number of workers
+func scanDirectory(watchDir string, settings *conf.Settings, processedFiles *processedFiles, ctx context.Context) error {
 	log.Printf("Scanning directory: %s", watchDir)
 	startTime := time.Now()
-	filesAnalyzed := 0
+	var filesAnalyzed atomic.Int64
+
+	// Start workers analyzing the files found by the directory walk
+	paths := make(chan string)
+	var wg sync.WaitGroup
+	for range settings.Input.EffectiveWorkers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				wasProcessed, err := processFile(path, settings, processedFiles, ctx)
+				if err != nil {
+					// Log errors other than cancellation but continue processing other files
+					if !errors.Is(err, context.Canceled) {
+						log.Printf("Error processing file '%s': %v", path, err)
+					}
+					continue
+				}
+				if wasProcessed {
+					filesAnalyzed.Add(1)
+				}
+			}
+		}()
+	}
 
 	err := filepath.WalkDir(watchDir, func(path string, d os.DirEntry, err error) error {
 		// Check for context cancellation
@@ -287,26 +340,23 @@ func scanDirectory(watchDir string, settings *conf.Settings, processedFiles map[
 			return nil
 		}
 
-		// Check for both .wav and .flac files (case-insensitive)
+		// Check for both .wav and .flac files (case-insensitive) matching the file pattern
 		ext := strings.ToLower(filepath.Ext(d.Name()))
-		if ext == ".wav" || ext == ".flac" {
-			wasProcessed, err := processFile(path, settings, processedFiles, ctx)
-			if err != nil {
-				if errors.Is(err, context.Canceled) {
-					return context.Canceled
-				}
-				// Log other errors but continue processing other files
-				log.Printf("Error processing file '%s': %v", path, err)
-				return nil
-			}
-			if wasProcessed {
-				filesAnalyzed++
+		if (ext == ".wav" || ext == ".flac") && settings.Input.MatchesFile(path) {
+			select {
+			case paths <- path:
+			case <-ctx.Done():
+				return ctx.Err()
 			}
 		}
 		return nil
 	})
 
-	if errors.Is(err, context.Canceled) {
+	// Wait for the workers to finish the files already handed to them
+	close(paths)
+	wg.Wait()
+
+	if errors.Is(err, context.Canceled) || ctx.Err() != nil {
 		return context.Canceled
 	}
 
@@ -314,9 +364,9 @@ func scanDirectory(watchDir string, settings *conf.Settings, processedFiles map[
 		return fmt.Errorf("error walking directory: %w", err)
 	}
 
-	if filesAnalyzed > 0 {
+	if analyzed := filesAnalyzed.Load(); analyzed > 0 {
 		scanDuration := time.Since(startTime)
-		log.Printf("Directory analysis completed, processed %d new file(s) in %v", filesAnalyzed, scanDuration)
+		log.Printf("Directory analysis completed, processed %d new file(s) in %v", analyzed, scanDuration)
 	} else {
 		log.Printf("Directory scan completed, no new files to analyze")
 	}
@@ -324,7 +374,7 @@ func scanDirectory(watchDir string, settings *conf.Settings, processedFiles map[
 }
 
 // watchDirectory continuously monitors a directory for new files
-func watchDirectory(watchDir string, settings *conf.Settings, processedFiles map[string]bool, ctx context.Context) error {
+func watchDirectory(watchDir string, settings *conf.Settings, processedFiles *processedFiles, ctx context.Context) error {
 	log.Printf("Starting directory watch on %s (Press Ctrl+C to stop)", watchDir)
 	watchStartTime := time.Now()
 
@@ -357,6 +407,11 @@ func watchDirectory(watchDir string, settings *conf.Settings, processedFiles map
 
 // DirectoryAnalysis processes all audio files in the given directory.
 func DirectoryAnalysis(settings *conf.Settings, ctx context.Context) error {
+	// Validate the analysis options set by command line flags
+	if err := settings.Input.Validate(); err != nil {
+		return err
+	}
+
 	// Initialize BirdNET interpreter
 	if err := initializeBirdNET(settings); err != nil {
 		log.Printf("Failed to initialize BirdNET: %v", err)
@@ -373,7 +428,7 @@ func DirectoryAnalysis(settings *conf.Settings, ctx context.Context) error {
 	}
 
 	// Create a map to track processed files
-	processedFiles := make(map[string]bool)
+	processedFiles := newProcessedFiles()
 
 	// Do initial scan
 	log.Printf("Performing initial directory scan...")
@@ -676,18 +676,21 @@ func writeResults(settings *conf.Settings, notes []datastore.Note) error {
 		outputFile = filepath.Join(settings.Output.File.Path, filepath.Base(settings.Input.Path))
 	}
 
-	// Output the notes based on the desired output type in the configuration.
-	// If OutputType is not specified or if it's set to "table", output as a table format.
-	if settings.Output.File.Type == "" || settings.Output.File.Type == "table" {
-		if err := observation.WriteNotesTable(settings, notes, outputFile); err != nil {
-			return fmt.Errorf("failed to write notes table: %w", err)
-		}
-	}
-	// If OutputType is set to "csv", output as CSV format.
-	if settings.Output.File.Type == "csv" {
+	// Output the notes based on the desired output type in the configuration,
+	// an unset or unknown type is output in table format.
+	switch settings.OutputFileType() {
+	case conf.OutputTypeCSV:
 		if err := observation.WriteNotesCsv(settings, notes, outputFile); err != nil {
 			return fmt.Errorf("failed to write notes CSV: %w", err)
 		}
+	case conf.OutputTypeJSON:
+		if err := observation.WriteNotesJSON(settings, notes, outputFile); err != nil {
+			return fmt.Errorf("failed to write notes JSON: %w", err)
+		}
+	default:
+		if err := observation.WriteNotesTable(settings, notes, outputFile); err != nil {
+			return fmt.Errorf("failed to write notes table: %w", err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,62 @@
+// action_limiter.go
+package processor
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// errActionDropped is returned for command actions dropped over the limit
+var errActionDropped = fmt.Errorf("command action dropped, maximum concurrent actions are running")
+
+// actionLimiter is a semaphore limiting the number of command actions running
+// at once. The limit and overflow policy are read from the settings for every
+// action so that changes apply without a restart.
+type actionLimiter struct {
+	settings *conf.Settings
+	mu       sync.Mutex
+	limit    int           // limit of slots
+	slots    chan struct{} // one element per running action
+}
+
+// newActionLimiter returns a limiter following the action limit of settings
+func newActionLimiter(settings *conf.Settings) *actionLimiter {
+	return &actionLimiter{settings: settings}
+}
+
+// acquire takes a slot for a command action, waiting for one to free up or
+// failing with errActionDropped depending on the overflow policy. The
+// returned function releases the slot.
+func (l *actionLimiter) acquire() (release func(), err error) {
+	limit, overflow := l.settings.ActionLimit()
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	slots := l.slotsFor(limit)
+	if overflow == conf.ActionOverflowDrop {
+		select {
+		case slots <- struct{}{}:
+		default:
+			return nil, errActionDropped
+		}
+	} else {
+		slots <- struct{}{}
+	}
+	return func() { <-slots }, nil
+}
+
+// slotsFor returns the semaphore for limit, replacing it when the limit
+// changed. Actions holding a slot of a replaced semaphore release it there.
+func (l *actionLimiter) slotsFor(limit int) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.slots == nil || l.limit != limit {
+		l.limit = limit
+		l.slots = make(chan struct{}, limit)
+	}
+	return l.slots
+}
@@ -0,0 +1,117 @@
+package processor
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// runLimited runs n actions through limiter, each holding its slot for hold,
+// and returns the highest number running at once and the number dropped
+func runLimited(limiter *actionLimiter, n int, hold time.Duration) (peak, dropped int32) {
+	var running atomic.Int32
+	var peakRunning, droppedCount atomic.Int32
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := limiter.acquire()
+			if errors.Is(err, errActionDropped) {
+				droppedCount.Add(1)
+				return
+			}
+			defer release()
+			current := running.Add(1)
+			for {
+				seen := peakRunning.Load()
+				if current <= seen || peakRunning.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(hold)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+	return peakRunning.Load(), droppedCount.Load()
+}
+
+func TestActionLimiterQueue(t *testing.T) {
+	t.Parallel()
+
+	settings := &conf.Settings{}
+	settings.Realtime.MaxConcurrentActions = 2
+	settings.Realtime.OnActionOverflow = conf.ActionOverflowQueue
+
+	peak, dropped := runLimited(newActionLimiter(settings), 8, 20*time.Millisecond)
+	if peak > 2 {
+		t.Errorf("peak concurrent actions = %d, want at most 2", peak)
+	}
+	if dropped != 0 {
+		t.Errorf("dropped actions = %d, want 0 when queueing", dropped)
+	}
+}
+
+func TestActionLimiterDrop(t *testing.T) {
+	t.Parallel()
+
+	settings := &conf.Settings{}
+	settings.Realtime.MaxConcurrentActions = 1
+	settings.Realtime.OnActionOverflow = conf.ActionOverflowDrop
+	limiter := newActionLimiter(settings)
+
+	release, err := limiter.acquire()
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	if _, err := limiter.acquire(); !errors.Is(err, errActionDropped) {
+		t.Errorf("acquire() over the limit error = %v, want %v", err, errActionDropped)
+	}
+	release()
+	release, err = limiter.acquire()
+	if err != nil {
+		t.Fatalf("acquire() after release error = %v", err)
+	}
+	release()
+}
+
+func TestActionLimiterUnlimited(t *testing.T) {
+	t.Parallel()
+
+	settings := &conf.Settings{}
+	settings.Realtime.OnActionOverflow = conf.ActionOverflowDrop
+
+	peak, dropped := runLimited(newActionLimiter(settings), 5, 50*time.Millisecond)
+	if dropped != 0 {
+		t.Errorf("dropped actions = %d, want 0 without a limit", dropped)
+	}
+	if peak < 2 {
+		t.Errorf("peak concurrent actions = %d, want them to run at once", peak)
+	}
+}
+
+func TestActionLimiterLimitChange(t *testing.T) {
+	t.Parallel()
+
+	settings := &conf.Settings{}
+	settings.Realtime.MaxConcurrentActions = 1
+	settings.Realtime.OnActionOverflow = conf.ActionOverflowDrop
+	limiter := newActionLimiter(settings)
+
+	release, err := limiter.acquire()
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	settings.Realtime.MaxConcurrentActions = 2
+	second, err := limiter.acquire()
+	if err != nil {
+		t.Fatalf("acquire() after raising the limit error = %v", err)
+	}
+	release()
+	second()
+}
@@ -364,6 +364,8 @@ func (a *MqttAction) Execute(data interface{}) error {
 
 	// Wrap note with bird image
 	noteWithBirdImage := NoteWithBirdImage{Note: a.Note, BirdImage: birdImage}
+	// Report confidence in the configured format
+	noteWithBirdImage.Note.Confidence = a.Settings.ConfidenceValue(a.Note.Confidence)
 
 	// Create a JSON representation of the note
 	noteJson, err := json.Marshal(noteWithBirdImage)
@@ -401,13 +403,15 @@ func (a *MqttAction) Execute(data interface{}) error {
 	return nil
 }
 
-// Execute updates the range filter species list, this is run every day
+// Execute updates the range filter species list, this is run every
+// rangefilter update interval
 func (a *UpdateRangeFilterAction) Execute(data interface{}) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	today := time.Now().Truncate(24 * time.Hour)
-	if today.After(a.Settings.BirdNET.RangeFilter.LastUpdated) {
+	now := time.Now()
+	if a.Settings.BirdNET.RangeFilter.NeedsUpdate(now) {
+		today := now.Truncate(24 * time.Hour)
 		// Update location based species list
 		speciesScores, err := a.Bn.GetProbableSpecies(today, 0.0)
 		if err != nil {
@@ -0,0 +1,46 @@
+package processor
+
+import (
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// detectionDedup collapses the detections of a species within the dedup
+// window of the settings into one pending detection. Without a window the
+// pending detections collapse detections within the flush delay and keep the
+// most confident one.
+type detectionDedup struct {
+	window    time.Duration // collapse window, 0 when deduplication is disabled
+	keepFirst bool          // true to keep the first detection instead of the most confident one
+}
+
+// newDetectionDedup returns the deduplication of the realtime settings
+func newDetectionDedup(settings *conf.RealtimeSettings) detectionDedup {
+	if settings.DedupWindow <= 0 {
+		return detectionDedup{}
+	}
+	return detectionDedup{
+		window:    settings.DedupWindow,
+		keepFirst: settings.DedupStrategyOrDefault() == conf.DedupStrategyFirst,
+	}
+}
+
+// holdDuration returns how long a new pending detection collects duplicates
+// before it is flushed, at least delay
+func (d detectionDedup) holdDuration(delay time.Duration) time.Duration {
+	return max(delay, d.window)
+}
+
+// isDuplicate reports whether a detection starting at start is a duplicate
+// of the pending detection of its species, detections starting a window or
+// more after the pending one are detections of their own
+func (d detectionDedup) isDuplicate(pending *PendingDetection, start time.Time) bool {
+	return d.window <= 0 || start.Sub(pending.FirstDetected) < d.window
+}
+
+// replaces reports whether a duplicate detection with confidence replaces
+// the detection kept by the pending detection
+func (d detectionDedup) replaces(pending *PendingDetection, confidence float64) bool {
+	return !d.keepFirst && confidence > pending.Confidence
+}
@@ -0,0 +1,84 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+func TestDetectionDedupWindow(t *testing.T) {
+	t.Parallel()
+
+	first := time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)
+	pending := &PendingDetection{FirstDetected: first, Confidence: 0.8}
+	dedup := newDetectionDedup(&conf.RealtimeSettings{DedupWindow: 30 * time.Second})
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  bool
+	}{
+		{"same time", first, true},
+		{"inside window", first.Add(29 * time.Second), true},
+		{"just before boundary", first.Add(30*time.Second - time.Nanosecond), true},
+		{"window boundary", first.Add(30 * time.Second), false},
+		{"after window", first.Add(45 * time.Second), false},
+	}
+	for _, tt := range tests {
+		if got := dedup.isDuplicate(pending, tt.start); got != tt.want {
+			t.Errorf("%s: isDuplicate() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got := dedup.holdDuration(15 * time.Second); got != 30*time.Second {
+		t.Errorf("holdDuration() = %s, want the 30s window", got)
+	}
+	short := newDetectionDedup(&conf.RealtimeSettings{DedupWindow: 5 * time.Second})
+	if got := short.holdDuration(15 * time.Second); got != 15*time.Second {
+		t.Errorf("holdDuration() with a short window = %s, want the 15s flush delay", got)
+	}
+}
+
+func TestDetectionDedupDisabled(t *testing.T) {
+	t.Parallel()
+
+	first := time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)
+	pending := &PendingDetection{FirstDetected: first, Confidence: 0.8}
+	dedup := newDetectionDedup(&conf.RealtimeSettings{DedupStrategy: conf.DedupStrategyFirst})
+
+	// Without a window every held detection is merged keeping the most confident one
+	if !dedup.isDuplicate(pending, first.Add(time.Hour)) {
+		t.Error("isDuplicate() without a window = false, want true")
+	}
+	if !dedup.replaces(pending, 0.9) {
+		t.Error("replaces() without a window ignored a more confident detection")
+	}
+	if got := dedup.holdDuration(15 * time.Second); got != 15*time.Second {
+		t.Errorf("holdDuration() without a window = %s, want the flush delay", got)
+	}
+}
+
+func TestDetectionDedupStrategy(t *testing.T) {
+	t.Parallel()
+
+	pending := &PendingDetection{Confidence: 0.8}
+	tests := []struct {
+		strategy   string
+		confidence float64
+		want       bool
+	}{
+		{"", 0.9, true},
+		{conf.DedupStrategyHighestConfidence, 0.9, true},
+		{conf.DedupStrategyHighestConfidence, 0.7, false},
+		{conf.DedupStrategyHighestConfidence, 0.8, false},
+		{conf.DedupStrategyFirst, 0.9, false},
+		{conf.DedupStrategyFirst, 0.7, false},
+	}
+	for _, tt := range tests {
+		dedup := newDetectionDedup(&conf.RealtimeSettings{DedupWindow: time.Minute, DedupStrategy: tt.strategy})
+		if got := dedup.replaces(pending, tt.confidence); got != tt.want {
+			t.Errorf("replaces() with strategy %q and confidence %v = %v, want %v", tt.strategy, tt.confidence, got, tt.want)
+		}
+	}
+}
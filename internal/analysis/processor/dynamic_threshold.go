@@ -85,7 +85,7 @@ func (p *Processor) updateDynamicThreshold(commonName string, confidence float64
 		defer p.thresholdsMutex.Unlock()
 
 		// Check if the species already has a dynamic threshold
-		if dt, exists := p.DynamicThresholds[commonName]; exists && confidence > float64(p.getBaseConfidenceThreshold(commonName)) {
+		if dt, exists := p.DynamicThresholds[commonName]; exists && confidence > float64(p.getBaseConfidenceThreshold(commonName, "")) {
 			// Update the timer to extend the threshold's validity
 			dt.Timer = time.Now().Add(time.Duration(dt.ValidHours) * time.Hour)
 			// Since we're modifying a struct in the map, we need to reassign it
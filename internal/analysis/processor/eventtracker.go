@@ -84,6 +84,10 @@ type EventTracker struct {
 	SpeciesConfigs  map[string]conf.SpeciesConfig // Add this: Store species-specific configurations
 	DefaultInterval time.Duration                 // Add this: Store the global default interval
 	Mutex           sync.RWMutex                  // Mutex to ensure thread-safe access
+
+	// intervalFor resolves the interval of a species when set, in place of
+	// SpeciesConfigs and DefaultInterval
+	intervalFor func(species string) time.Duration
 }
 
 // Add this new struct to hold configuration
@@ -129,6 +133,15 @@ func NewEventTrackerWithConfig(defaultInterval time.Duration, speciesConfigs map
 	return initEventTracker(defaultInterval, speciesConfigs)
 }
 
+// NewEventTrackerWithResolver creates a new EventTracker that resolves the
+// interval of a species with intervalFor on each event, such as
+// conf.Settings.DetectionInterval, so that changed intervals apply at once.
+func NewEventTrackerWithResolver(intervalFor func(species string) time.Duration) *EventTracker {
+	et := initEventTracker(0, nil)
+	et.intervalFor = intervalFor
+	return et
+}
+
 // TrackEvent checks if an event for a given species and event type should be processed.
 // It utilizes the respective event handler to make this determination, considering species-specific intervals.
 func (et *EventTracker) TrackEvent(species string, eventType EventType) bool {
@@ -149,7 +162,7 @@ func (et *EventTracker) TrackEvent(species string, eventType EventType) bool {
 	// Determine the effective timeout for this species and event type
 	effectiveTimeout := et.DefaultInterval // Start with the global default
 
-	if speciesConfig, ok := et.SpeciesConfigs[normalizedSpecies]; ok {
+	if speciesConfig, ok := et.SpeciesConfigs[normalizedSpecies]; ok && et.intervalFor == nil {
 		if speciesConfig.Interval > 0 {
 			// Custom interval is set and valid (positive value)
 			effectiveTimeout = time.Duration(speciesConfig.Interval) * time.Second
@@ -169,6 +182,12 @@ func (et *EventTracker) TrackEvent(species string, eventType EventType) bool {
 	//    By releasing the outer lock first, we establish a consistent lock ordering
 	et.Mutex.RUnlock()
 
+	// The resolver is set at construction and may take the settings mutex, it
+	// is called without holding the tracker mutex
+	if et.intervalFor != nil {
+		effectiveTimeout = et.intervalFor(species)
+	}
+
 	// 3. Now we lock the handler's mutex to safely access and update its LastEventTime map
 	//    This ensures thread-safety for the specific handler while allowing other event types
 	//    to be processed concurrently
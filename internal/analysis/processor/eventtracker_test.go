@@ -0,0 +1,54 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+func TestEventTrackerWithResolver(t *testing.T) {
+	t.Parallel()
+
+	settings := &conf.Settings{}
+	settings.Realtime.Species.Config = map[string]conf.SpeciesConfig{
+		"American Robin": {Interval: 3600},
+	}
+	tracker := NewEventTrackerWithResolver(settings.DetectionInterval)
+
+	// The per-species interval of the settings applies to the lowercased
+	// common names passed by the actions
+	if !tracker.TrackEvent("american robin", DatabaseSave) {
+		t.Fatal("TrackEvent() of first robin event = false, want true")
+	}
+	if tracker.TrackEvent("american robin", DatabaseSave) {
+		t.Error("TrackEvent() of robin within its interval = true, want false")
+	}
+
+	// Species without a per-species interval use the global interval
+	for range 2 {
+		if !tracker.TrackEvent("blue jay", DatabaseSave) {
+			t.Error("TrackEvent() of blue jay with zero global interval = false, want true")
+		}
+	}
+
+	// Intervals are resolved on each event, removing the config applies at once
+	settings.RemoveSpeciesConfig("American Robin")
+	if !tracker.TrackEvent("american robin", DatabaseSave) {
+		t.Error("TrackEvent() of robin after removing its interval = false, want true")
+	}
+}
+
+func TestEventTrackerResolverInterval(t *testing.T) {
+	t.Parallel()
+
+	var resolved []string
+	tracker := NewEventTrackerWithResolver(func(species string) time.Duration {
+		resolved = append(resolved, species)
+		return time.Hour
+	})
+	tracker.TrackEvent("Blue Jay", MQTTPublish)
+	if len(resolved) != 1 || resolved[0] != "Blue Jay" {
+		t.Errorf("resolver called with %v, want [Blue Jay]", resolved)
+	}
+}
@@ -2,6 +2,7 @@
 package processor
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -10,13 +11,18 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 
+	"github.com/tphakala/birdnet-go/internal/conf"
 	"github.com/tphakala/birdnet-go/internal/datastore"
 )
 
 type ExecuteCommandAction struct {
 	Command string
 	Params  map[string]interface{}
+	Limiter *actionLimiter    // limits the commands running at once, nil for unlimited
+	Timeout time.Duration     // time the command may run before it is killed, 0 for conf.DefaultActionTimeout
+	Env     map[string]string // environment variables merged over the clean environment
 }
 
 // GetDescription returns a description of the action
@@ -45,16 +51,40 @@ func (a ExecuteCommandAction) Execute(data interface{}) error {
 		return fmt.Errorf("error building arguments: %w", err)
 	}
 
+	// Wait for or give up on a slot when the maximum number of commands run
+	if a.Limiter != nil {
+		release, err := a.Limiter.acquire()
+		if err != nil {
+			log.Printf("[analysis/processor/execute] Skipping command %s: %v\n", a.Command, err)
+			return err
+		}
+		defer release()
+	}
+
 	log.Printf("[analysis/processor/execute] Command: %s, Args: %v\n", cmdPath, args)
 
-	// Create command with validated path and arguments
-	cmd := exec.Command(cmdPath, args...)
+	// Create command with validated path and arguments, killing its process
+	// group when the timeout expires
+	timeout := a.Timeout
+	if timeout <= 0 {
+		timeout = conf.DefaultActionTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, cmdPath, args...)
+	setupCommandProcessGroup(cmd)
+	cmd.Cancel = func() error { return killCommandProcessGroup(cmd) }
+	// Stop waiting for output held open by orphaned children
+	cmd.WaitDelay = time.Second
 
-	// Set a clean environment
-	cmd.Env = getCleanEnvironment()
+	// Set a clean environment with the action environment merged over it
+	cmd.Env = conf.SpeciesAction{Env: a.Env}.Environment(getCleanEnvironment())
 
 	// Execute the command
 	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("command timed out after %s and was killed, output: %s", timeout, string(output))
+	}
 	if err != nil {
 		return fmt.Errorf("error executing command: %w, output: %s", err, string(output))
 	}
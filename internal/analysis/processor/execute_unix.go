@@ -0,0 +1,29 @@
+//go:build !windows
+
+package processor
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setupCommandProcessGroup starts the command in its own process group so
+// that its children are killed with it
+func setupCommandProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+}
+
+// killCommandProcessGroup kills the command and its children
+func killCommandProcessGroup(cmd *exec.Cmd) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	// Ignore "no such process" errors as the process may have already exited
+	if err == syscall.ESRCH {
+		return nil
+	}
+	return err
+}
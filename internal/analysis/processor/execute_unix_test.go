@@ -0,0 +1,48 @@
+//go:build !windows
+
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeScript writes an executable shell script to a temporary directory
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "action.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestExecuteCommandActionTimeout(t *testing.T) {
+	t.Parallel()
+
+	// The child sleep keeps the output open unless the process group is killed
+	action := ExecuteCommandAction{Command: writeScript(t, "sleep 30 & sleep 30"), Timeout: 200 * time.Millisecond}
+	start := time.Now()
+	err := action.Execute(Detections{})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Execute() error = %v, want timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Execute() returned after %s, want the command killed at the timeout", elapsed)
+	}
+}
+
+func TestExecuteCommandActionEnv(t *testing.T) {
+	t.Parallel()
+
+	action := ExecuteCommandAction{
+		Command: writeScript(t, `test "$ALERT_LEVEL" = high`),
+		Env:     map[string]string{"alert_level": "high"},
+	}
+	if err := action.Execute(Detections{}); err != nil {
+		t.Errorf("Execute() error = %v, want the environment variable set", err)
+	}
+}
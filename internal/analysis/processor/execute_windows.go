@@ -0,0 +1,29 @@
+//go:build windows
+
+package processor
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+)
+
+// setupCommandProcessGroup starts the command in its own process group so
+// that its children are killed with it
+func setupCommandProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP,
+	}
+}
+
+// killCommandProcessGroup kills the command and its children
+func killCommandProcessGroup(cmd *exec.Cmd) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	if err := exec.Command("taskkill", "/F", "/T", "/PID", fmt.Sprint(cmd.Process.Pid)).Run(); err != nil {
+		// If taskkill fails, try direct process termination
+		return cmd.Process.Kill()
+	}
+	return nil
+}
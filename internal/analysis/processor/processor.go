@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -40,7 +39,9 @@ type Processor struct {
 	DynamicThresholds   map[string]*DynamicThreshold
 	thresholdsMutex     sync.RWMutex // Mutex to protect access to DynamicThresholds
 	pendingDetections   map[string]PendingDetection
-	pendingMutex        sync.Mutex // Mutex to protect access to pendingDetections
+	pendingMutex        sync.Mutex              // Mutex to protect access to pendingDetections
+	occurrences         *conf.OccurrenceTracker // Recent detections of species with minimum occurrences
+	actionLimiter       *actionLimiter          // Limits the command actions running at once
 	lastDogDetectionLog map[string]time.Time
 	dogDetectionMutex   sync.Mutex
 	detectionMutex      sync.RWMutex // Mutex to protect LastDogDetection and LastHumanDetection maps
@@ -91,19 +92,18 @@ var mutex sync.Mutex
 // func New(settings *conf.Settings, ds datastore.Interface, bn *birdnet.BirdNET, audioBuffers map[string]*myaudio.AudioBuffer, metrics *observability.Metrics) *Processor {
 func New(settings *conf.Settings, ds datastore.Interface, bn *birdnet.BirdNET, metrics *observability.Metrics, birdImageCache *imageprovider.BirdImageCache) *Processor {
 	p := &Processor{
-		Settings:       settings,
-		Ds:             ds,
-		Bn:             bn,
-		BirdImageCache: birdImageCache,
-		EventTracker: NewEventTrackerWithConfig(
-			time.Duration(settings.Realtime.Interval)*time.Second,
-			settings.Realtime.Species.Config,
-		),
+		Settings:            settings,
+		Ds:                  ds,
+		Bn:                  bn,
+		BirdImageCache:      birdImageCache,
+		EventTracker:        NewEventTrackerWithResolver(settings.DetectionInterval),
 		Metrics:             metrics,
 		LastDogDetection:    make(map[string]time.Time),
 		LastHumanDetection:  make(map[string]time.Time),
 		DynamicThresholds:   make(map[string]*DynamicThreshold),
 		pendingDetections:   make(map[string]PendingDetection),
+		occurrences:         conf.NewOccurrenceTracker(),
+		actionLimiter:       newActionLimiter(settings),
 		lastDogDetectionLog: make(map[string]time.Time),
 		controlChan:         make(chan string, 10),  // Buffered channel to prevent blocking
 		JobQueue:            jobqueue.NewJobQueue(), // Initialize the job queue
@@ -118,6 +118,9 @@ func New(settings *conf.Settings, ds datastore.Interface, bn *birdnet.BirdNET, m
 	// Start the held detection flusher
 	p.pendingDetectionsFlusher()
 
+	// Start the periodic BirdWeather soundscape uploads
+	p.soundscapeUploader()
+
 	// Initialize BirdWeather client if enabled in settings
 	if settings.Realtime.Birdweather.Enabled {
 		var err error
@@ -156,6 +159,9 @@ func (p *Processor) processDetections(item *birdnet.Results) {
 	// TODO: make this configurable
 	const delay = 15 * time.Second
 
+	// Duplicates of a species within the dedup window are collapsed into one detection
+	dedup := newDetectionDedup(&p.Settings.Realtime)
+
 	// processResults() returns a slice of detections, we iterate through each and process them
 	// detections are put into pendingDetections map where they are held until flush deadline is reached
 	// once deadline is reached detections are delivered to workers for actions (save to db etc) processing
@@ -168,9 +174,17 @@ func (p *Processor) processDetections(item *birdnet.Results) {
 		// Lock the mutex to ensure thread-safe access to shared resources
 		p.pendingMutex.Lock()
 
-		if existing, exists := p.pendingDetections[commonName]; exists {
+		existing, exists := p.pendingDetections[commonName]
+		if exists && !dedup.isDuplicate(&existing, item.StartTime) {
+			// The pending detection is final once a detection after its dedup window arrives
+			p.flushPendingDetection(&existing, commonName, p.minDetections())
+			delete(p.pendingDetections, commonName)
+			exists = false
+		}
+
+		if exists {
 			// Update the existing detection if it's already in pendingDetections map
-			if confidence > existing.Confidence {
+			if dedup.replaces(&existing, confidence) {
 				existing.Detection = detection
 				existing.Confidence = confidence
 				existing.Source = item.Source
@@ -185,7 +199,7 @@ func (p *Processor) processDetections(item *birdnet.Results) {
 				Confidence:    confidence,
 				Source:        item.Source,
 				FirstDetected: item.StartTime,
-				FlushDeadline: item.StartTime.Add(delay),
+				FlushDeadline: item.StartTime.Add(dedup.holdDuration(delay)),
 				Count:         1,
 			}
 		}
@@ -247,7 +261,7 @@ func (p *Processor) processResults(item *birdnet.Results) []Detections {
 		p.handleHumanDetection(item, speciesLowercase, result)
 
 		// Determine base confidence threshold
-		baseThreshold := p.getBaseConfidenceThreshold(speciesLowercase)
+		baseThreshold := p.getBaseConfidenceThreshold(speciesLowercase, scientificName)
 
 		// If result is human and detection exceeds base threshold, discard it
 		// due to privacy reasons we do not want human detections to reach actions stage
@@ -283,7 +297,7 @@ func (p *Processor) processResults(item *birdnet.Results) []Detections {
 		}
 
 		// Create file name for audio clip
-		clipName := p.generateClipName(scientificName, result.Confidence)
+		clipName := p.generateClipName(scientificName, commonName, result.Confidence)
 
 		// set begin and end time for note
 		// TODO: adjust end time based on detection pending delay
@@ -334,9 +348,9 @@ func (p *Processor) handleHumanDetection(item *birdnet.Results, speciesLowercase
 }
 
 // getBaseConfidenceThreshold retrieves the confidence threshold for a species, using custom or global thresholds.
-func (p *Processor) getBaseConfidenceThreshold(speciesLowercase string) float32 {
+func (p *Processor) getBaseConfidenceThreshold(speciesLowercase, scientificName string) float32 {
 	// Check if species has a custom threshold in the new structure
-	if config, exists := p.Settings.Realtime.Species.Config[speciesLowercase]; exists {
+	if config, exists := p.Settings.LookupSpeciesConfig(speciesLowercase, scientificName); exists {
 		if p.Settings.Debug {
 			log.Printf("\nUsing custom confidence threshold of %.2f for %s\n", config.Threshold, speciesLowercase)
 		}
@@ -347,31 +361,24 @@ func (p *Processor) getBaseConfidenceThreshold(speciesLowercase string) float32
 	return float32(p.Settings.BirdNET.Threshold)
 }
 
-// generateClipName generates a clip name for the given scientific name and confidence.
-func (p *Processor) generateClipName(scientificName string, confidence float32) string {
-	// Replace whitespaces with underscores and convert to lowercase
-	formattedName := strings.ToLower(strings.ReplaceAll(scientificName, " ", "_"))
-
-	// Normalize the confidence value to a percentage and append 'p'
-	normalizedConfidence := confidence * 100
-	formattedConfidence := fmt.Sprintf("%.0fp", normalizedConfidence)
-
-	// Get the current time
-	currentTime := time.Now()
-
-	// Format the timestamp in ISO 8601 format
-	timestamp := currentTime.Format("20060102T150405Z")
-
-	// Extract the year and month for directory structure
-	year := currentTime.Format("2006")
-	month := currentTime.Format("01")
-
-	// Get the file extension from the export settings
-	fileType := myaudio.GetFileExtension(p.Settings.Realtime.Audio.Export.Type)
+// generateClipName generates a clip name for the given species and confidence
+// using the configured audio export filename template.
+func (p *Processor) generateClipName(scientificName, commonName string, confidence float32) string {
+	meta := conf.ClipMeta{
+		ScientificName: scientificName,
+		CommonName:     commonName,
+		Confidence:     float64(confidence),
+		Time:           time.Now(),
+		Extension:      myaudio.GetFileExtension(p.Settings.Realtime.Audio.Export.Type),
+	}
 
-	// Construct the clip name with the new pattern, including year and month subdirectories
-	// Use filepath.ToSlash to convert the path to a forward slash for web URLs
-	clipName := filepath.ToSlash(filepath.Join(year, month, fmt.Sprintf("%s_%s_%s.%s", formattedName, formattedConfidence, timestamp, fileType)))
+	clipName, err := p.Settings.Realtime.Audio.Export.RenderFilename(meta)
+	if err != nil {
+		// Templates are validated when settings are loaded, fall back to the default naming
+		log.Printf("Failed to render audio clip filename, using default naming: %v", err)
+		defaultExport := conf.ExportSettings{}
+		clipName, _ = defaultExport.RenderFilename(meta)
+	}
 
 	return clipName
 }
@@ -439,12 +446,36 @@ func (p *Processor) processApprovedDetection(item *PendingDetection, species str
 	}
 }
 
+// minDetections returns the number of matches a pending detection needs to be
+// approved, based on the overlap setting
+func (p *Processor) minDetections() int {
+	segmentLength := math.Max(0.1, 3.0-p.Settings.BirdNET.Overlap)
+	return int(math.Max(1, 3/segmentLength))
+}
+
+// flushPendingDetection discards a pending detection or sends it to the
+// worker queue when it is approved, the caller must hold pendingMutex
+func (p *Processor) flushPendingDetection(item *PendingDetection, species string, minDetections int) {
+	if shouldDiscard, reason := p.shouldDiscardDetection(item, minDetections); shouldDiscard {
+		log.Printf("Discarding detection of %s from source %s due to %s\n",
+			species, item.Source, reason)
+		return
+	}
+
+	// Hold back species until they reach their minimum occurrences
+	if minOccurrences, window := p.Settings.OccurrenceGate(item.Detection.Note.CommonName, item.Detection.Note.ScientificName); minOccurrences > 1 &&
+		!p.occurrences.Record(item.Detection.Note.CommonName, item.FirstDetected, minOccurrences, window) {
+		log.Printf("Holding detection of %s from source %s, occurred %d/%d times\n",
+			species, item.Source, p.occurrences.Count(item.Detection.Note.CommonName, item.FirstDetected, window), minOccurrences)
+		return
+	}
+	p.processApprovedDetection(item, species)
+}
+
 // pendingDetectionsFlusher runs a goroutine that periodically checks the pending detections
 // and flushes them to the worker queue if their deadline has passed.
 func (p *Processor) pendingDetectionsFlusher() {
-	// Calculate minimum detections based on overlap setting
-	segmentLength := math.Max(0.1, 3.0-p.Settings.BirdNET.Overlap)
-	minDetections := int(math.Max(1, 3/segmentLength))
+	minDetections := p.minDetections()
 
 	go func() {
 		ticker := time.NewTicker(1 * time.Second)
@@ -458,14 +489,7 @@ func (p *Processor) pendingDetectionsFlusher() {
 			for species := range p.pendingDetections {
 				item := p.pendingDetections[species]
 				if now.After(item.FlushDeadline) {
-					if shouldDiscard, reason := p.shouldDiscardDetection(&item, minDetections); shouldDiscard {
-						log.Printf("Discarding detection of %s from source %s due to %s\n",
-							species, item.Source, reason)
-						delete(p.pendingDetections, species)
-						continue
-					}
-
-					p.processApprovedDetection(&item, species)
+					p.flushPendingDetection(&item, species, minDetections)
 					delete(p.pendingDetections, species)
 				}
 			}
@@ -488,25 +512,30 @@ func contains(slice []string, item string) bool {
 
 // getActionsForItem determines the actions to be taken for a given detection.
 func (p *Processor) getActionsForItem(detection *Detections) []Action {
-	speciesName := strings.ToLower(detection.Note.CommonName)
-
 	// Check if species has custom configuration
-	if speciesConfig, exists := p.Settings.Realtime.Species.Config[speciesName]; exists {
+	if speciesConfig, exists := p.Settings.LookupSpeciesConfig(detection.Note.CommonName, detection.Note.ScientificName); exists {
 		if p.Settings.Debug {
 			log.Println("Species config exists for custom actions")
 		}
 
 		var actions []Action
-		var executeDefaults bool
+		var executeDefaults, suppressed bool
+		quiet := p.Settings.QuietHoursSuppress(conf.QuietHoursActions, time.Now())
 
 		// Add custom actions from the new structure
 		for _, actionConfig := range speciesConfig.Actions {
 			switch actionConfig.Type {
 			case "ExecuteCommand":
-				if len(actionConfig.Parameters) > 0 {
+				if len(actionConfig.Parameters) > 0 && quiet {
+					log.Printf("Skipping command action for %s during quiet hours", detection.Note.CommonName)
+					suppressed = true
+				} else if len(actionConfig.Parameters) > 0 {
 					actions = append(actions, &ExecuteCommandAction{
 						Command: actionConfig.Command,
 						Params:  parseCommandParams(actionConfig.Parameters, detection),
+						Limiter: p.actionLimiter,
+						Timeout: actionConfig.Timeout(),
+						Env:     actionConfig.Env,
 					})
 				}
 			case "SendNotification":
@@ -519,13 +548,14 @@ func (p *Processor) getActionsForItem(detection *Detections) []Action {
 			}
 		}
 
-		// If there are custom actions, return only those unless executeDefaults is true
-		if len(actions) > 0 && !executeDefaults {
+		// If there are custom actions, return only those unless executeDefaults is true.
+		// Actions suppressed by quiet hours still replace the default actions.
+		if (len(actions) > 0 || suppressed) && !executeDefaults {
 			return actions
 		}
 
 		// If executeDefaults is true, combine custom and default actions
-		if len(actions) > 0 && executeDefaults {
+		if (len(actions) > 0 || suppressed) && executeDefaults {
 			defaultActions := p.getDefaultActions(detection)
 			return append(actions, defaultActions...)
 		}
@@ -569,8 +599,8 @@ func (p *Processor) getDefaultActions(detection *Detections) []Action {
 			Ds:           p.Ds})
 	}
 
-	// Add BirdWeatherAction if enabled and client is initialized
-	if p.Settings.Realtime.Birdweather.Enabled {
+	// Add BirdWeatherAction if detections are uploaded and client is initialized
+	if p.Settings.Realtime.Birdweather.Enabled && p.Settings.Realtime.Birdweather.ResolvedUploadMode().Detections {
 		bwClient := p.GetBwClient() // Use getter for thread safety
 		if bwClient != nil {
 			// Create BirdWeather retry config from settings
@@ -593,8 +623,10 @@ func (p *Processor) getDefaultActions(detection *Detections) []Action {
 		}
 	}
 
-	// Add MQTT action if enabled and client is available
-	if p.Settings.Realtime.MQTT.Enabled {
+	// Add MQTT action if enabled and client is available, unless quiet hours suppress it
+	if p.Settings.Realtime.MQTT.Enabled && p.Settings.QuietHoursSuppress(conf.QuietHoursMQTT, time.Now()) {
+		log.Printf("Skipping MQTT publish for %s during quiet hours", detection.Note.CommonName)
+	} else if p.Settings.Realtime.MQTT.Enabled {
 		mqttClient := p.GetMQTTClient()
 		if mqttClient != nil && mqttClient.IsConnected() {
 			// Create MQTT retry config from settings
@@ -638,11 +670,10 @@ func (p *Processor) getDefaultActions(detection *Detections) []Action {
 		})
 	}
 
-	// Check if UpdateRangeFilterAction needs to be executed for the day
-	today := time.Now().Truncate(24 * time.Hour) // Current date with time set to midnight
-	if p.Settings.BirdNET.RangeFilter.LastUpdated.Before(today) {
+	// Check if UpdateRangeFilterAction needs to be executed by the update schedule
+	if p.Settings.BirdNET.RangeFilter.NeedsUpdate(time.Now()) {
 		fmt.Println("Updating species range filter")
-		// Add UpdateRangeFilterAction if it hasn't been executed today
+		// Add UpdateRangeFilterAction if the update interval has passed
 		actions = append(actions, &UpdateRangeFilterAction{
 			Bn:       p.Bn,
 			Settings: p.Settings,
@@ -785,7 +816,7 @@ func (p *Processor) NewWithSpeciesInfo(
 	elapsedTime time.Duration) datastore.Note {
 
 	// detectionTime is time now minus 3 seconds to account for the delay in the detection
-	now := time.Now()
+	now := time.Now().In(p.Settings.Location())
 	date := now.Format("2006-01-02")
 	detectionTime := now.Add(-2 * time.Second)
 	timeStr := detectionTime.Format("15:04:05")
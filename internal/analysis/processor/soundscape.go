@@ -0,0 +1,74 @@
+// soundscape.go
+package processor
+
+import (
+	"log"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+	"github.com/tphakala/birdnet-go/internal/myaudio"
+)
+
+// soundscapeTimestampLayout is the timestamp format of BirdWeather uploads
+const soundscapeTimestampLayout = "2006-01-02T15:04:05.000-0700"
+
+// soundscapeUploader runs a goroutine uploading periodic soundscapes of every
+// audio source to BirdWeather when the upload mode includes soundscapes. The
+// mode and interval are read before every upload so changes apply without a
+// restart.
+func (p *Processor) soundscapeUploader() {
+	go func() {
+		for {
+			mode := p.Settings.Realtime.Birdweather.ResolvedUploadMode()
+			time.Sleep(mode.SoundscapeInterval)
+
+			if !p.Settings.Realtime.Birdweather.Enabled || !p.Settings.Realtime.Birdweather.ResolvedUploadMode().Soundscapes {
+				continue
+			}
+			p.uploadSoundscapes(time.Now())
+		}
+	}()
+}
+
+// uploadSoundscapes uploads the SoundscapeLength of audio ending shortly
+// before now of every audio source to BirdWeather. With the privacy filter
+// enabled, sources where a human voice was detected since the start of the
+// soundscape are skipped, like detection clips are discarded.
+func (p *Processor) uploadSoundscapes(now time.Time) {
+	bwClient := p.GetBwClient()
+	if bwClient == nil {
+		return
+	}
+
+	// Leave a second for the latest audio to reach the capture buffer
+	start := now.Add(-conf.SoundscapeLength - time.Second)
+	for _, source := range myaudio.CaptureBufferSources() {
+		if p.Settings.Realtime.PrivacyFilter.Enabled && p.humanDetectedBetween(source, start, now) {
+			if p.Settings.Realtime.Birdweather.Debug {
+				log.Printf("Skipping soundscape from source %s, human voice detected", conf.SanitizeRTSPUrl(source))
+			}
+			continue
+		}
+		pcmData, err := myaudio.ReadSegmentFromCaptureBuffer(source, start, int(conf.SoundscapeLength.Seconds()))
+		if err != nil {
+			log.Printf("Failed to read soundscape audio from source %s: %v", conf.SanitizeRTSPUrl(source), err)
+			continue
+		}
+		if _, err := bwClient.UploadSoundscape(start.Format(soundscapeTimestampLayout), pcmData); err != nil {
+			log.Printf("Failed to upload soundscape from source %s to BirdWeather: %v", conf.SanitizeRTSPUrl(source), sanitizeError(err))
+			continue
+		}
+		if p.Settings.Realtime.Birdweather.Debug {
+			log.Printf("Uploaded soundscape from source %s to BirdWeather", conf.SanitizeRTSPUrl(source))
+		}
+	}
+}
+
+// humanDetectedBetween reports whether the last human voice detection of
+// source falls within start and end
+func (p *Processor) humanDetectedBetween(source string, start, end time.Time) bool {
+	p.detectionMutex.RLock()
+	lastHumanDetection, exists := p.LastHumanDetection[source]
+	p.detectionMutex.RUnlock()
+	return exists && !lastHumanDetection.Before(start) && !lastHumanDetection.After(end)
+}
@@ -0,0 +1,31 @@
+package processor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanDetectedBetween(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	start := now.Add(-30 * time.Second)
+	p := &Processor{LastHumanDetection: map[string]time.Time{
+		"inside": now.Add(-10 * time.Second),
+		"before": start.Add(-time.Second),
+	}}
+
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{"inside", true},
+		{"before", false},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		if got := p.humanDetectedBetween(tt.source, start, now); got != tt.want {
+			t.Errorf("humanDetectedBetween(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
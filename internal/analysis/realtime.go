@@ -610,23 +610,16 @@ func setupImageProviderRegistry(ds datastore.Interface, metrics *observability.M
 
 // selectDefaultImageProvider determines the default image provider based on configuration
 func selectDefaultImageProvider(registry *imageprovider.ImageProviderRegistry) *imageprovider.BirdImageCache {
-	preferredProvider := conf.Setting().Realtime.Dashboard.Thumbnails.ImageProvider
 	var defaultCache *imageprovider.BirdImageCache
 
-	if preferredProvider == "auto" {
-		// Use wikimedia as the default provider in auto mode, if available
-		defaultCache, _ = registry.GetCache("wikimedia")
-		log.Println("Using WikiMedia as the default image provider (auto mode)")
-	} else {
-		// User has specified a specific provider
-		if cache, ok := registry.GetCache(preferredProvider); ok {
+	// Use the first registered provider in the configured preference order
+	for _, name := range conf.Setting().Realtime.Dashboard.Thumbnails.ProviderOrder() {
+		if cache, ok := registry.GetCache(name); ok {
 			defaultCache = cache
-			log.Printf("Using %s as the preferred image provider", preferredProvider)
-		} else {
-			// Fallback to wikimedia if preferred provider doesn't exist or isn't registered
-			defaultCache, _ = registry.GetCache("wikimedia")
-			log.Printf("Preferred provider '%s' not available, falling back to WikiMedia (if available)", preferredProvider)
+			log.Printf("Using %s as the default image provider", name)
+			break
 		}
+		log.Printf("Image provider '%s' not available, trying next provider", name)
 	}
 
 	// If we still don't have a default cache (e.g., wikimedia failed registration), try any available provider.
@@ -749,6 +742,23 @@ func initBirdImageCache(ds datastore.Interface, metrics *observability.Metrics)
 		return nil
 	}
 
+	// Warn when the configured thumbnails cannot be shown, now and whenever
+	// the settings are validated again
+	checkThumbnails := func(settings *conf.Settings) []conf.ValidationIssue {
+		available := func(provider string) bool {
+			_, ok := registry.GetCache(provider)
+			return ok
+		}
+		if warning := settings.Realtime.Dashboard.Thumbnails.AvailabilityWarning(available); warning != "" {
+			return []conf.ValidationIssue{{Field: "realtime.dashboard.thumbnails", Message: warning, Severity: conf.SeverityWarning}}
+		}
+		return nil
+	}
+	conf.RegisterValidator("dashboard-thumbnails", checkThumbnails)
+	for _, issue := range checkThumbnails(conf.Setting()) {
+		log.Printf("Configuration warning: %s", issue.Message)
+	}
+
 	// 2. Select the default cache based on settings and availability
 	defaultCache := selectDefaultImageProvider(registry)
 
@@ -175,6 +175,15 @@ func (c *Controller) GetDailySpeciesSummary(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, result)
 }
 
+// currentTime returns the current time in the configured time zone, dates
+// default to the current day of that zone
+func (c *Controller) currentTime() time.Time {
+	if c.Settings == nil {
+		return time.Now()
+	}
+	return time.Now().In(c.Settings.Location())
+}
+
 // parseDailySpeciesSummaryParams parses and validates query parameters for the daily summary.
 func (c *Controller) parseDailySpeciesSummaryParams(ctx echo.Context) (selectedDate string, minConfidence float64, limit int, err error) {
 	ip := ctx.RealIP()
@@ -183,7 +192,7 @@ func (c *Controller) parseDailySpeciesSummaryParams(ctx echo.Context) (selectedD
 	// Parse and validate date
 	selectedDate = ctx.QueryParam("date")
 	if selectedDate == "" {
-		selectedDate = time.Now().Format("2006-01-02")
+		selectedDate = c.currentTime().Format("2006-01-02")
 	} else if _, parseErr := time.Parse("2006-01-02", selectedDate); parseErr != nil {
 		if c.apiLogger != nil {
 			c.apiLogger.Error("Invalid date format parameter", "date", selectedDate, "error", parseErr.Error(), "ip", ip, "path", path)
@@ -807,10 +816,10 @@ func (c *Controller) GetTimeOfDayDistribution(ctx echo.Context) error {
 
 	// Set default date range if not provided (before validation)
 	if startDate == "" {
-		startDate = time.Now().AddDate(0, 0, -30).Format("2006-01-02")
+		startDate = c.currentTime().AddDate(0, 0, -30).Format("2006-01-02")
 	}
 	if endDate == "" {
-		endDate = time.Now().Format("2006-01-02")
+		endDate = c.currentTime().Format("2006-01-02")
 	}
 
 	if c.apiLogger != nil {
@@ -925,10 +934,10 @@ func (c *Controller) GetNewSpeciesDetections(ctx echo.Context) error {
 
 	// Set default date range if not provided (e.g., last 30 days)
 	if startDate == "" {
-		startDate = time.Now().AddDate(0, 0, -30).Format("2006-01-02")
+		startDate = c.currentTime().AddDate(0, 0, -30).Format("2006-01-02")
 	}
 	if endDate == "" {
-		endDate = time.Now().Format("2006-01-02")
+		endDate = c.currentTime().Format("2006-01-02")
 	}
 
 	if c.apiLogger != nil {
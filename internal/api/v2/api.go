@@ -78,50 +78,30 @@ var (
 	errAuthServiceNil      = errors.New("internal configuration error: auth service is nil")
 )
 
-// Custom IP Extractor prioritizing CF-Connecting-IP
-func ipExtractorFromCloudflareHeader(req *http.Request) string {
-	// 1. Check CF-Connecting-IP
-	cfIP := req.Header.Get("CF-Connecting-IP")
-	if cfIP != "" {
-		ip := net.ParseIP(cfIP)
-		if ip != nil {
-			return ip.String() // Return valid IP
-		}
-	}
-
-	// 2. Check X-Forwarded-For (taking the first valid IP)
-	xff := req.Header.Get(echo.HeaderXForwardedFor)
-	if xff != "" {
-		parts := strings.Split(xff, ",")
-		for _, part := range parts {
-			ipStr := strings.TrimSpace(part)
-			ip := net.ParseIP(ipStr)
-			if ip != nil {
-				return ip.String() // Return first valid IP found
+// ipExtractorFromTrustedProxies returns an IP extractor prioritizing
+// CF-Connecting-IP, then X-Forwarded-For and X-Real-IP. The headers are
+// honored only from the trusted proxies of the security settings, otherwise
+// clients could spoof their address.
+func ipExtractorFromTrustedProxies(settings *conf.Settings) echo.IPExtractor {
+	return func(req *http.Request) string {
+		trusted := settings.Security.TrustedProxies
+
+		// 1. Check CF-Connecting-IP
+		if conf.IsTrustedProxy(req, trusted) {
+			if ip := net.ParseIP(req.Header.Get("CF-Connecting-IP")); ip != nil {
+				return ip.String() // Return valid IP
 			}
 		}
-	}
 
-	// 3. Check X-Real-IP
-	xri := req.Header.Get(echo.HeaderXRealIP)
-	if xri != "" {
-		ip := net.ParseIP(xri)
-		if ip != nil {
-			return ip.String() // Return valid IP
+		// 2. Check X-Forwarded-For and X-Real-IP, falling back to Remote Address
+		if ip := conf.RealClientIP(req, trusted); ip != nil {
+			return ip.String()
 		}
-	}
 
-	// 4. Fallback to Remote Address (might be proxy)
-	// Use SplitHostPort for robustness, ignoring potential errors
-	remoteAddr, _, _ := net.SplitHostPort(req.RemoteAddr)
-	ip := net.ParseIP(remoteAddr)
-	if ip != nil {
-		return ip.String() // Return valid IP if RemoteAddr is just an IP
+		// If RemoteAddr was invalid, return the raw host
+		remoteAddr, _, _ := net.SplitHostPort(req.RemoteAddr)
+		return remoteAddr
 	}
-
-	// If RemoteAddr contained a port or was invalid, return the raw string
-	// (though ideally, it should be a valid IP:port format)
-	return remoteAddr
 }
 
 // TunnelDetectionMiddleware inspects headers to determine if the request is likely proxied
@@ -162,14 +142,10 @@ func New(e *echo.Echo, ds datastore.Interface, settings *conf.Settings,
 	}
 
 	// --- Configure IP Extractor ---
-	// IMPORTANT: For this to be secure in production, you would typically
-	// combine this with middleware that verifies the request came *from*
-	// a trusted proxy (like Cloudflare's IP ranges). Echo doesn't have
-	// built-in trusted proxy IP range checking, so this might require
-	// custom middleware or careful infrastructure setup (e.g., firewall rules).
-	// Without trusting the proxy, these headers can be spoofed.
-	e.IPExtractor = ipExtractorFromCloudflareHeader
-	logger.Println("Configured custom IP extractor prioritizing CF-Connecting-IP")
+	// Forwarded headers are honored only from security.trustedproxies,
+	// without trusting the proxy these headers can be spoofed.
+	e.IPExtractor = ipExtractorFromTrustedProxies(settings)
+	logger.Println("Configured custom IP extractor prioritizing CF-Connecting-IP from trusted proxies")
 	// --- End IP Extractor Configuration ---
 
 	// Validate and Initialize SecureFS for the media export path
@@ -282,7 +258,15 @@ func New(e *echo.Echo, ds datastore.Interface, settings *conf.Settings,
 	c.Group.Use(middleware.Recover())          // Recover should be early
 	c.Group.Use(c.TunnelDetectionMiddleware()) // Add tunnel detection **before** logging
 	// c.Group.Use(middleware.Logger())        // Removed: Use custom LoggingMiddleware below for structured logging
-	c.Group.Use(middleware.CORS())     // CORS handling
+	// CORS handling, without allowed origins only same-origin requests are
+	// allowed and no CORS headers are sent
+	if policy := settings.WebServer.CORS.Policy(); policy.Enabled() {
+		c.Group.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins:     policy.AllowedOrigins,
+			AllowMethods:     policy.AllowedMethods,
+			AllowCredentials: policy.AllowCredentials,
+		}))
+	}
 	c.Group.Use(c.LoggingMiddleware()) // Use custom structured logging middleware
 
 	// Initialize start time for uptime tracking
@@ -6,7 +6,6 @@ import (
 	"log"
 	"net/http"
 	"regexp"
-	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -945,7 +944,7 @@ func (c *Controller) addSpeciesToIgnoredList(species string) error {
 	settings := conf.GetSettings()
 
 	// Check if species is already in the excluded list
-	isExcluded := slices.Contains(settings.Realtime.Species.Exclude, species)
+	isExcluded := settings.Realtime.Species.IsExcluded(species, "")
 
 	// If not already excluded, add it
 	if !isExcluded {
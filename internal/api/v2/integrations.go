@@ -78,13 +78,15 @@ func (c *Controller) GetMQTTStatus(ctx echo.Context) error {
 	// Get MQTT configuration from settings
 	mqttConfig := c.Settings.Realtime.MQTT
 
-	// Prepare status response
+	// Prepare status response, reporting the primary broker
 	status := MQTTStatus{
 		Connected: false, // Default to not connected
-		Broker:    mqttConfig.Broker,
 		Topic:     mqttConfig.Topic,
 		ClientID:  c.Settings.Main.Name, // Use the application name as client ID
 	}
+	if brokers := mqttConfig.BrokerList(); len(brokers) > 0 {
+		status.Broker = brokers[0]
+	}
 
 	// If MQTT is not enabled, return status as-is
 	if !mqttConfig.Enabled {
@@ -212,7 +214,7 @@ func (c *Controller) TestMQTTConnection(ctx echo.Context) error {
 	}
 
 	// Validate MQTT configuration
-	if mqttConfig.Broker == "" {
+	if len(mqttConfig.BrokerList()) == 0 {
 		return ctx.JSON(http.StatusBadRequest, MQTTTestResult{
 			Success: false,
 			Message: "MQTT broker not configured",
@@ -338,7 +338,7 @@ func (c *Controller) ServeSpectrogramByID(ctx echo.Context) error {
 		return c.HandleError(ctx, fmt.Errorf("no audio file found"), "No audio clip available for this note", http.StatusNotFound)
 	}
 
-	width := 800 // Default width
+	width := 0 // 0 uses the configured spectrogram width
 	widthStr := ctx.QueryParam("width")
 	if widthStr != "" {
 		parsedWidth, err := strconv.Atoi(widthStr)
@@ -378,7 +378,7 @@ func (c *Controller) ServeAudioByQueryID(ctx echo.Context) error {
 func (c *Controller) ServeSpectrogram(ctx echo.Context) error {
 	filename := ctx.Param("filename")
 
-	width := 800 // Default width
+	width := 0 // 0 uses the configured spectrogram width
 	widthStr := ctx.QueryParam("width")
 	if widthStr != "" {
 		parsedWidth, err := strconv.Atoi(widthStr)
@@ -519,8 +519,11 @@ func (c *Controller) generateSpectrogram(ctx context.Context, audioPath string,
 	relBaseFilename := strings.TrimSuffix(filepath.Base(relAudioPath), filepath.Ext(relAudioPath))
 	relAudioDir := filepath.Dir(relAudioPath)
 
+	// Resolve the image size and style from the spectrogram settings
+	params := c.Settings.Realtime.Audio.Export.Spectrogram.Params(width)
+
 	// Generate spectrogram filename with width (relative path)
-	spectrogramFilename := fmt.Sprintf("%s_%d.png", relBaseFilename, width)
+	spectrogramFilename := fmt.Sprintf("%s_%d.png", relBaseFilename, params.Width)
 
 	// Since we're constructing the spectrogram path from an already-validated audio path
 	// and appending a simple formatted filename, we can safely construct the path without
@@ -532,7 +535,7 @@ func (c *Controller) generateSpectrogram(ctx context.Context, audioPath string,
 
 	// Generate a unique key for this spectrogram generation request
 	// Include both the path and width to ensure uniqueness
-	spectrogramKey := fmt.Sprintf("%s:%d", relSpectrogramPath, width)
+	spectrogramKey := fmt.Sprintf("%s:%d", relSpectrogramPath, params.Width)
 
 	// Use singleflight to prevent duplicate generations
 	_, err, _ = spectrogramGroup.Do(spectrogramKey, func() (interface{}, error) {
@@ -545,10 +548,10 @@ func (c *Controller) generateSpectrogram(ctx context.Context, audioPath string,
 		}
 
 		// --- Generate Spectrogram ---
-		if err := createSpectrogramWithSoX(ctx, absAudioPath, absSpectrogramPath, width, c.Settings); err != nil {
+		if err := createSpectrogramWithSoX(ctx, absAudioPath, absSpectrogramPath, params, c.Settings); err != nil {
 			log.Printf("SoX failed for '%s', falling back to FFmpeg: %v", absAudioPath, err)
 			// Pass the context down to the fallback function as well.
-			if err2 := createSpectrogramWithFFmpeg(ctx, absAudioPath, absSpectrogramPath, width, c.Settings); err2 != nil {
+			if err2 := createSpectrogramWithFFmpeg(ctx, absAudioPath, absSpectrogramPath, params, c.Settings); err2 != nil {
 				// Check for context errors specifically (propagate them up)
 				if errors.Is(err, context.DeadlineExceeded) || errors.Is(err2, context.DeadlineExceeded) {
 					// Return the specific context error to be handled by the caller
@@ -588,7 +591,7 @@ func (c *Controller) generateSpectrogram(ctx context.Context, audioPath string,
 // createSpectrogramWithSoX generates a spectrogram using ffmpeg and SoX.
 // Accepts a context for timeout and cancellation.
 // Requires absolute paths for external commands.
-func createSpectrogramWithSoX(ctx context.Context, absAudioClipPath, absSpectrogramPath string, width int, settings *conf.Settings) error {
+func createSpectrogramWithSoX(ctx context.Context, absAudioClipPath, absSpectrogramPath string, params conf.SpectrogramParams, settings *conf.Settings) error {
 	ffmpegBinary := settings.Realtime.Audio.FfmpegPath
 	soxBinary := settings.Realtime.Audio.SoxPath
 
@@ -621,15 +624,12 @@ func createSpectrogramWithSoX(ctx context.Context, absAudioClipPath, absSpectrog
 	spectrogramSemaphore <- struct{}{}
 	defer func() { <-spectrogramSemaphore }()
 
-	heightStr := strconv.Itoa(width / 2)
-	widthStr := strconv.Itoa(width)
-
 	var cmd *exec.Cmd
 	var soxCmd *exec.Cmd
 
 	if useFFmpeg {
 		ffmpegArgs := []string{"-hide_banner", "-i", absAudioClipPath, "-f", "sox", "-"}
-		soxArgs := append([]string{"-t", "sox", "-"}, getSoxSpectrogramArgs(widthStr, heightStr, absSpectrogramPath)...)
+		soxArgs := append([]string{"-t", "sox", "-"}, getSoxSpectrogramArgs(params, absSpectrogramPath)...)
 
 		if runtime.GOOS == "windows" {
 			// #nosec G204 - ffmpegBinary and soxBinary are validated by ValidateToolPath/exec.LookPath
@@ -678,7 +678,7 @@ func createSpectrogramWithSoX(ctx context.Context, absAudioClipPath, absSpectrog
 		}
 		runtime.Gosched()
 	} else {
-		soxArgs := append([]string{absAudioClipPath}, getSoxSpectrogramArgs(widthStr, heightStr, absSpectrogramPath)...)
+		soxArgs := append([]string{absAudioClipPath}, getSoxSpectrogramArgs(params, absSpectrogramPath)...)
 
 		if runtime.GOOS == "windows" {
 			// #nosec G204 - soxBinary is validated by exec.LookPath during config initialization
@@ -702,12 +702,13 @@ func createSpectrogramWithSoX(ctx context.Context, absAudioClipPath, absSpectrog
 }
 
 // getSoxSpectrogramArgs returns the common SoX arguments.
-func getSoxSpectrogramArgs(widthStr, heightStr, absSpectrogramPath string) []string {
+func getSoxSpectrogramArgs(params conf.SpectrogramParams, absSpectrogramPath string) []string {
 	const audioLength = "15"
 	const dynamicRange = "100"
-	args := []string{"-n", "rate", "24k", "spectrogram", "-x", widthStr, "-y", heightStr, "-d", audioLength, "-z", dynamicRange, "-o", absSpectrogramPath}
-	width, _ := strconv.Atoi(widthStr)
-	if width < 800 {
+	args := []string{"-n", "rate", "24k", "spectrogram", "-x", strconv.Itoa(params.Width), "-y", strconv.Itoa(params.Height), "-d", audioLength, "-z", dynamicRange}
+	args = append(args, params.SoxColorArgs()...)
+	args = append(args, "-o", absSpectrogramPath)
+	if params.Raw {
 		args = append(args, "-r")
 	}
 	return args
@@ -715,7 +716,7 @@ func getSoxSpectrogramArgs(widthStr, heightStr, absSpectrogramPath string) []str
 
 // createSpectrogramWithFFmpeg generates a spectrogram using only ffmpeg.
 // Accepts a context for timeout and cancellation.
-func createSpectrogramWithFFmpeg(ctx context.Context, absAudioClipPath, absSpectrogramPath string, width int, settings *conf.Settings) error {
+func createSpectrogramWithFFmpeg(ctx context.Context, absAudioClipPath, absSpectrogramPath string, params conf.SpectrogramParams, settings *conf.Settings) error {
 	ffmpegBinary := settings.Realtime.Audio.FfmpegPath
 	if ffmpegBinary == "" {
 		return ErrFFmpegNotConfigured
@@ -728,15 +729,16 @@ func createSpectrogramWithFFmpeg(ctx context.Context, absAudioClipPath, absSpect
 	spectrogramSemaphore <- struct{}{}
 	defer func() { <-spectrogramSemaphore }()
 
-	height := width / 2
-	heightStr := strconv.Itoa(height)
-	widthStr := strconv.Itoa(width)
+	filter := fmt.Sprintf("showspectrumpic=s=%dx%d:legend=0:gain=3:drange=100", params.Width, params.Height)
+	if color := params.FFmpegColor(); color != "" {
+		filter += ":color=" + color
+	}
 
 	ffmpegArgs := []string{
 		"-hide_banner",
 		"-y",
 		"-i", absAudioClipPath,
-		"-lavfi", fmt.Sprintf("showspectrumpic=s=%sx%s:legend=0:gain=3:drange=100", widthStr, heightStr),
+		"-lavfi", filter,
 		"-frames:v", "1",
 		absSpectrogramPath,
 	}
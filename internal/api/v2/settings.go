@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -35,6 +36,14 @@ func (c *Controller) initSettingsRoutes() {
 	// Routes for settings
 	// GET /api/v2/settings - Retrieves all application settings
 	settingsGroup.GET("", c.GetAllSettings)
+	// GET /api/v2/settings/schema - Retrieves the JSON schema of the settings for rendering settings forms
+	settingsGroup.GET("/schema", c.GetSettingsSchema)
+	// GET /api/v2/settings/fingerprint - Reports whether the config file changed since it was loaded
+	settingsGroup.GET("/fingerprint", c.GetSettingsFingerprint)
+	// GET /api/v2/settings/health - Retrieves advisory warnings about likely configuration mistakes
+	settingsGroup.GET("/health", c.GetSettingsHealth)
+	// GET /api/v2/settings/sources - Reports whether each setting came from the config file, environment, defaults or an override
+	settingsGroup.GET("/sources", c.GetSettingsSources)
 	// GET /api/v2/settings/:section - Retrieves settings for a specific section (e.g., birdnet, webserver)
 	settingsGroup.GET("/:section", c.GetSectionSettings)
 	// PUT /api/v2/settings - Updates multiple settings sections with complete replacement
@@ -82,6 +91,89 @@ func (c *Controller) GetAllSettings(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, settings)
 }
 
+// GetSettingsSchema handles GET /api/v2/settings/schema
+func (c *Controller) GetSettingsSchema(ctx echo.Context) error {
+	c.logAPIRequest(ctx, slog.LevelInfo, "Getting settings schema")
+
+	schema, err := conf.GenerateJSONSchema()
+	if err != nil {
+		c.logAPIRequest(ctx, slog.LevelError, "Failed to generate settings schema", "error", err.Error())
+		return c.HandleError(ctx, err, "Failed to generate settings schema", http.StatusInternalServerError)
+	}
+
+	return ctx.Blob(http.StatusOK, "application/schema+json", schema)
+}
+
+// SettingsFingerprintResponse reports the fingerprints of the config file
+type SettingsFingerprintResponse struct {
+	Fingerprint       string `json:"fingerprint"`        // fingerprint of the config file on disk
+	LoadedFingerprint string `json:"loaded_fingerprint"` // fingerprint of the config file when the settings were loaded
+	ChangedExternally bool   `json:"changed_externally"` // true when another process changed the config file
+}
+
+// GetSettingsFingerprint handles GET /api/v2/settings/fingerprint
+func (c *Controller) GetSettingsFingerprint(ctx echo.Context) error {
+	c.logAPIRequest(ctx, slog.LevelInfo, "Getting settings fingerprint")
+
+	settings := conf.Setting()
+	if settings == nil {
+		return c.HandleError(ctx, fmt.Errorf("settings not initialized"), "Failed to get settings", http.StatusInternalServerError)
+	}
+
+	fingerprint, err := conf.ConfigFingerprint()
+	if err != nil {
+		c.logAPIRequest(ctx, slog.LevelError, "Failed to fingerprint config file", "error", err.Error())
+		return c.HandleError(ctx, err, "Failed to read config file", http.StatusInternalServerError)
+	}
+	changed, err := conf.ConfigChangedExternally()
+	if err != nil {
+		return c.HandleError(ctx, err, "Failed to read config file", http.StatusInternalServerError)
+	}
+
+	return ctx.JSON(http.StatusOK, SettingsFingerprintResponse{
+		Fingerprint:       fingerprint,
+		LoadedFingerprint: settings.ConfigFingerprint,
+		ChangedExternally: changed,
+	})
+}
+
+// SettingsHealthResponse lists advisory warnings about the settings
+type SettingsHealthResponse struct {
+	Issues []conf.ValidationIssue `json:"issues"`
+}
+
+// GetSettingsHealth handles GET /api/v2/settings/health
+func (c *Controller) GetSettingsHealth(ctx echo.Context) error {
+	c.logAPIRequest(ctx, slog.LevelInfo, "Getting settings health")
+
+	settings := conf.Setting()
+	if settings == nil {
+		return c.HandleError(ctx, fmt.Errorf("settings not initialized"), "Failed to get settings", http.StatusInternalServerError)
+	}
+
+	issues := settings.Lint()
+	if issues == nil {
+		issues = []conf.ValidationIssue{}
+	}
+	return ctx.JSON(http.StatusOK, SettingsHealthResponse{Issues: issues})
+}
+
+// SettingsSourcesResponse maps config keys to the source of their values
+type SettingsSourcesResponse struct {
+	Sources map[string]string `json:"sources"` // config key to file, env, default or override
+}
+
+// GetSettingsSources handles GET /api/v2/settings/sources
+func (c *Controller) GetSettingsSources(ctx echo.Context) error {
+	c.logAPIRequest(ctx, slog.LevelInfo, "Getting settings sources")
+
+	sources := conf.SettingSources()
+	if sources == nil {
+		return c.HandleError(ctx, fmt.Errorf("settings not initialized"), "Failed to get settings", http.StatusInternalServerError)
+	}
+	return ctx.JSON(http.StatusOK, SettingsSourcesResponse{Sources: sources})
+}
+
 // GetSectionSettings handles GET /api/v2/settings/:section
 func (c *Controller) GetSectionSettings(ctx echo.Context) error {
 	section := ctx.Param("section")
@@ -198,28 +290,9 @@ func validateSettingsData(settings *conf.Settings) error {
 		return fmt.Errorf("longitude must be between -180 and 180")
 	}
 
-	// Validate WebServer settings - fix for port type
-	// Check if we can convert the port to an integer
-	var (
-		portInt int
-		err     error
-	)
-
-	// If the port is a string (as indicated by the linter error), convert it to int
-	switch v := interface{}(settings.WebServer.Port).(type) {
-	case int:
-		portInt = v
-	case string:
-		portInt, err = strconv.Atoi(v)
-		if err != nil {
-			return fmt.Errorf("invalid port number: %v", v)
-		}
-	default:
-		return fmt.Errorf("port has an unsupported type: %T", v)
-	}
-
-	if portInt < 1 || portInt > 65535 {
-		return fmt.Errorf("port must be between 1 and 65535")
+	// Validate WebServer settings, the port is a port number or host:port
+	if _, err := conf.NormalizeWebServerPort(settings.WebServer.Port); err != nil {
+		return fmt.Errorf("invalid webserver port: %w", err)
 	}
 
 	// Add additional validation for other fields as needed
@@ -637,7 +710,7 @@ func handleMQTTSection(settings *conf.Settings, data json.RawMessage, skippedFie
 
 	// Perform any additional validation on MQTT settings
 	// For example, checking broker URL format, etc.
-	if mqttSettings.Enabled && mqttSettings.Broker == "" {
+	if mqttSettings.Enabled && len(mqttSettings.BrokerList()) == 0 {
 		return fmt.Errorf("broker is required when MQTT is enabled")
 	}
 
@@ -1006,7 +1079,7 @@ func mqttSettingsChanged(oldSettings, currentSettings *conf.Settings) bool {
 
 	// Check for changes in MQTT settings
 	return oldMQTT.Enabled != newMQTT.Enabled ||
-		oldMQTT.Broker != newMQTT.Broker ||
+		!slices.Equal(oldMQTT.BrokerList(), newMQTT.BrokerList()) ||
 		oldMQTT.Topic != newMQTT.Topic ||
 		oldMQTT.Username != newMQTT.Username ||
 		oldMQTT.Password != newMQTT.Password
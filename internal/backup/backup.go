@@ -158,6 +158,17 @@ func sanitizeConfig(config *conf.Settings) *conf.Settings {
 	sanitized.Output.MySQL.Password = ""
 	sanitized.Realtime.MQTT.Password = ""
 	sanitized.Realtime.Weather.OpenWeather.APIKey = ""
+	sanitized.SystemID = ""
+	for _, target := range sanitized.Backup.Targets {
+		for _, key := range conf.BackupTargetSecretKeys() {
+			delete(target.Settings, key)
+		}
+	}
+	for _, channel := range sanitized.Notifications {
+		for _, key := range conf.NotificationChannelSecretKeys() {
+			delete(channel.Settings, key)
+		}
+	}
 
 	return &sanitized
 }
@@ -394,11 +405,7 @@ func (m *Manager) processBackupSource(ctx context.Context, sourceName string, so
 	}
 
 	// 4. Create the archive file path
-	archiveFileName := metadata.ID + ".tar"
-	// Compression logic removed as it's not in BackupConfig
-	// if m.config.Compression {
-	//  archiveFileName += ".gz"
-	// }
+	archiveFileName := metadata.ID + m.config.ArchiveExtension()
 	// Note: Encryption happens *after* archiving/compression, file extension doesn't change yet.
 	archivePath := filepath.Join(tempDir, archiveFileName)
 	m.logger.Debug("Prepared archive details", "source_name", sourceName, "archive_path", archivePath)
@@ -477,7 +484,8 @@ func (m *Manager) hashConfig() (string, error) {
 	return hex.EncodeToString(hash[:]), nil
 }
 
-// addConfigToArchive adds the sanitized configuration file to the tar archive
+// addConfigToArchive adds the sanitized configuration file to the tar archive,
+// and the effective configuration when IncludeEffectiveConfig is set
 func (m *Manager) addConfigToArchive(tw *tar.Writer, metadata *Metadata) error {
 	m.logger.Debug("Adding sanitized config to archive", "backup_id", metadata.ID)
 	start := time.Now()
@@ -494,10 +502,49 @@ func (m *Manager) addConfigToArchive(tw *tar.Writer, metadata *Metadata) error {
 			Build()
 	}
 
+	// Standard name within the archive
+	if err := addFileToArchive(tw, "config.yml", yamlBytes, metadata); err != nil {
+		return err
+	}
+
+	if m.config.IncludeEffectiveConfig {
+		if err := m.addEffectiveConfigToArchive(tw, metadata); err != nil {
+			return err
+		}
+	}
+	m.logger.Debug("Finished adding sanitized config to archive", "backup_id", metadata.ID, "duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// addEffectiveConfigToArchive adds the settings with all defaults filled in to
+// the tar archive so that a restore does not depend on the defaults of the
+// restoring version. Secrets are removed when SanitizeConfig is set.
+func (m *Manager) addEffectiveConfigToArchive(tw *tar.Writer, metadata *Metadata) error {
+	m.logger.Debug("Adding effective config to archive", "backup_id", metadata.ID)
+
+	settings := m.fullConfig
+	if m.config.SanitizeConfig {
+		settings = sanitizeConfig(settings)
+	}
+
+	yamlBytes, err := settings.EffectiveYAML()
+	if err != nil {
+		return errors.New(err).
+			Component("backup").
+			Category(errors.CategoryConfiguration).
+			Context("operation", "marshal_effective_config").
+			Build()
+	}
+
+	return addFileToArchive(tw, "config.effective.yml", yamlBytes, metadata)
+}
+
+// addFileToArchive writes a read-only file with the given content to the tar archive
+func addFileToArchive(tw *tar.Writer, name string, data []byte, metadata *Metadata) error {
 	// Create TAR header
 	hdr := &tar.Header{
-		Name:    "config.yml", // Standard name within the archive
-		Size:    int64(len(yamlBytes)),
+		Name:    name,
+		Size:    int64(len(data)),
 		Mode:    0o644, // Read-only permissions
 		ModTime: metadata.Timestamp,
 	}
@@ -508,18 +555,19 @@ func (m *Manager) addConfigToArchive(tw *tar.Writer, metadata *Metadata) error {
 			Component("backup").
 			Category(errors.CategoryFileIO).
 			Context("operation", "write_config_tar_header").
+			Context("file", name).
 			Build()
 	}
 
-	// Write config data
-	if _, err := tw.Write(yamlBytes); err != nil {
+	// Write file data
+	if _, err := tw.Write(data); err != nil {
 		return errors.New(err).
 			Component("backup").
 			Category(errors.CategoryFileIO).
 			Context("operation", "write_config_to_tar").
+			Context("file", name).
 			Build()
 	}
-	m.logger.Debug("Finished adding sanitized config to archive", "backup_id", metadata.ID, "duration_ms", time.Since(start).Milliseconds())
 	return nil
 }
 
@@ -539,16 +587,37 @@ func (m *Manager) storeBackupInTargets(ctx context.Context, archivePath string,
 
 	var wg sync.WaitGroup
 	errChan := make(chan error, len(targetsToStore))
-	storeCtx, cancel := context.WithTimeout(ctx, m.getStoreTimeout()) // Apply specific timeout for storing
-	defer cancel()
 
-	m.logger.Info("Storing backup archive in targets", "backup_id", metadata.ID, "targets_count", len(targetsToStore))
+	// Uploads share the bandwidth limit, at most MaxConcurrentUploads run at once
+	uploadCtx := WithBandwidthLimit(ctx, m.config.BandwidthLimitKBps)
+	var uploadSlots chan struct{}
+	if m.config.MaxConcurrentUploads > 0 {
+		uploadSlots = make(chan struct{}, m.config.MaxConcurrentUploads)
+	}
+
+	m.logger.Info("Storing backup archive in targets", "backup_id", metadata.ID, "targets_count", len(targetsToStore),
+		"max_concurrent_uploads", m.config.MaxConcurrentUploads, "bandwidth_limit_kbps", m.config.BandwidthLimitKBps)
 
 	for _, target := range targetsToStore {
 		wg.Add(1)
 		go func(t Target) {
 			defer wg.Done()
 			targetName := t.Name()
+
+			if uploadSlots != nil {
+				select {
+				case uploadSlots <- struct{}{}:
+					defer func() { <-uploadSlots }()
+				case <-ctx.Done():
+					errChan <- fmt.Errorf("target %s: %w", targetName, ctx.Err())
+					return
+				}
+			}
+
+			// The store timeout starts when the upload starts, not while it waits for a slot
+			storeCtx, cancel := context.WithTimeout(uploadCtx, m.getStoreTimeout())
+			defer cancel()
+
 			startTargetTime := time.Now()
 			m.logger.Info("Storing backup in target", "backup_id", metadata.ID, "target_name", targetName)
 
@@ -642,16 +711,17 @@ func (m *Manager) createArchive(ctx context.Context, archivePath string, reader
 		}
 	}()
 
-	// Determine writer: plain tar or gzipped tar
-	var fileWriter io.WriteCloser = archiveFile
-	// Compression logic removed
-	// if m.config.Compression {
-	//  gzWriter := gzip.NewWriter(archiveFile)
-	//  defer gzWriter.Close()
-	//  fileWriter = gzWriter
-	//  metadata.Compressed = true // Update metadata
-	//  m.logger.Debug("Using Gzip compression for archive", "backup_id", metadata.ID)
-	// }
+	// Determine writer: plain tar or compressed tar
+	fileWriter, err := m.config.Compressor(archiveFile)
+	if err != nil {
+		return errors.New(err).
+			Component("backup").
+			Category(errors.CategoryConfiguration).
+			Context("operation", "create_compressor").
+			Build()
+	}
+	metadata.Compressed = m.config.CompressionAlgorithm() != conf.BackupCompressionNone
+	m.logger.Debug("Using archive compression", "backup_id", metadata.ID, "compression", m.config.CompressionAlgorithm(), "level", m.config.CompressionLevel)
 
 	tarWriter := tar.NewWriter(fileWriter)
 	defer func() {
@@ -689,14 +759,13 @@ func (m *Manager) createArchive(ctx context.Context, archivePath string, reader
 			Context("operation", "close_tar_writer").
 			Build()
 	}
-	if closer, ok := fileWriter.(io.Closer); ok && closer != archiveFile { // Don't double-close archiveFile
-		if err := closer.Close(); err != nil {
-			return errors.New(err).
-				Component("backup").
-				Category(errors.CategoryFileIO).
-				Context("operation", "close_intermediate_writer").
-				Build()
-		}
+	// Flush the compressed stream, this does not close archiveFile
+	if err := fileWriter.Close(); err != nil {
+		return errors.New(err).
+			Component("backup").
+			Category(errors.CategoryFileIO).
+			Context("operation", "close_intermediate_writer").
+			Build()
 	}
 	if err := archiveFile.Close(); err != nil {
 		return errors.New(err).
@@ -861,16 +930,11 @@ func (m *Manager) encryptArchive(ctx context.Context, sourcePath, destPath strin
 	return nil
 }
 
-// parseRetentionAge parses a duration string (e.g., "30d", "4w", "1y") into time.Duration
+// parseRetentionAge parses a duration string (e.g., "30d", "4w", "6m", "1y") into time.Duration,
+// see conf.BackupRetention.MaxAgeDuration
 func (m *Manager) parseRetentionAge(age string) (time.Duration, error) {
-	if age == "" {
-		return 0, nil
-	}
-
-	// Parse the number and unit
-	var num int
-	var unit string
-	if _, err := fmt.Sscanf(age, "%d%s", &num, &unit); err != nil {
+	duration, err := conf.BackupRetention{MaxAge: age}.MaxAgeDuration()
+	if err != nil {
 		return 0, errors.Newf("invalid retention age format: %s - %v", age, err).
 			Component("backup").
 			Category(errors.CategoryValidation).
@@ -878,26 +942,18 @@ func (m *Manager) parseRetentionAge(age string) (time.Duration, error) {
 			Context("age", age).
 			Build()
 	}
+	return duration, nil
+}
 
-	// Convert to duration
-	switch unit {
-	case "d":
-		hours := num * 24
-		return time.Duration(hours) * time.Hour, nil
-	case "m":
-		hours := num * 30 * 24 // approximate
-		return time.Duration(hours) * time.Hour, nil
-	case "y":
-		hours := num * 365 * 24 // approximate
-		return time.Duration(hours) * time.Hour, nil
-	default:
-		return 0, errors.Newf("invalid retention age unit: %s", unit).
-			Component("backup").
-			Category(errors.CategoryValidation).
-			Context("operation", "parse_retention_age").
-			Context("unit", unit).
-			Build()
+// retentionForTarget returns the retention policy of a registered target,
+// matching it to a configured target by type
+func (m *Manager) retentionForTarget(targetName string) conf.BackupRetention {
+	for _, target := range m.config.Targets {
+		if target.Enabled && target.Type == targetName {
+			return m.config.RetentionFor(target)
+		}
 	}
+	return m.config.Retention
 }
 
 // groupBackupsByTargetAndType groups backups first by target name, then by source type
@@ -1148,9 +1204,8 @@ func (m *Manager) cleanupOldBackups(ctx context.Context) error {
 			continue
 		}
 
-		// Determine retention policy (should be per source type if config allows, otherwise global)
-		// Assuming global retention from m.config.Retention for now
-		retentionPolicy := m.config.Retention
+		// Targets may override the global retention policy
+		retentionPolicy := m.retentionForTarget(targetName)
 
 		for sourceType, backups := range sourceMap {
 			wg.Add(1)
@@ -1432,7 +1487,6 @@ func (m *Manager) ValidateBackupCounts(ctx context.Context) error {
 	}
 
 	groupedBackups := m.groupBackupsByTargetAndType(allBackups)
-	retention := m.config.Retention
 	var validationErrors []error
 
 	m.mu.RLock()
@@ -1449,7 +1503,7 @@ func (m *Manager) ValidateBackupCounts(ctx context.Context) error {
 		// Check counts for each source type found in the target
 		for sourceType, backups := range targetGroups {
 			backupCount := len(backups)
-			minRequired := retention.MinBackups
+			minRequired := m.retentionForTarget(targetName).MinBackups
 
 			// Check minimum backups
 			if minRequired > 0 && backupCount < minRequired {
@@ -1480,7 +1534,7 @@ func (m *Manager) ValidateBackupCounts(ctx context.Context) error {
 func isWeeklyBackup(t time.Time, schedules []conf.BackupScheduleConfig) bool {
 	for _, s := range schedules {
 		if s.Enabled && s.IsWeekly {
-			configuredDay, err := parseWeekday(s.Weekday) // Assume parseWeekday exists
+			configuredDay, err := conf.ParseWeekday(s.Weekday)
 			if err != nil {
 				slog.Warn("Could not parse configured weekly backup day in schedule, skipping schedule check", "configured_day", s.Weekday, "error", err)
 				continue // Check next schedule
@@ -1524,5 +1578,3 @@ func isWeeklyBackup(t time.Time, schedules []conf.BackupScheduleConfig) bool {
 //  return nil
 // }
 
-// Note: parseWeekday moved to scheduler.go as it's primarily used there.
-// If needed here, it should be imported or duplicated.
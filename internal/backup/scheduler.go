@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"strings"
 	"sync"
 	"time"
 
@@ -284,43 +283,15 @@ func (s *Scheduler) runBackup(schedule *BackupSchedule) {
 
 // calculateNextRun determines the next run time for a schedule
 func (s *Scheduler) calculateNextRun(now time.Time, hour, minute int, weekday time.Weekday, isWeekly bool) time.Time {
-	var next time.Time
-
+	schedule := conf.BackupScheduleConfig{Hour: hour, Minute: minute, IsWeekly: isWeekly}
 	if isWeekly {
-		// For weekly backups
-		next = time.Date(
-			now.Year(), now.Month(), now.Day(),
-			hour, minute, 0, 0, now.Location(),
-		)
-
-		// Adjust to next occurrence of weekday
-		daysUntilWeekday := int(weekday - next.Weekday())
-		if daysUntilWeekday < 0 {
-			// We're past the target weekday, so add days to get to next week
-			daysUntilWeekday += 7
-		} else if daysUntilWeekday == 0 {
-			// We're on the target weekday
-			if now.After(next) {
-				// The scheduled time has passed today, so schedule for next week
-				daysUntilWeekday = 7
-			}
-			// Otherwise, daysUntilWeekday remains 0 (today)
-		}
-		next = next.AddDate(0, 0, daysUntilWeekday)
-	} else {
-		// For daily backups
-		next = time.Date(
-			now.Year(), now.Month(), now.Day(),
-			hour, minute, 0, 0, now.Location(),
-		)
-
-		// If we're past the time today, schedule for tomorrow
-		if now.After(next) {
-			next = next.AddDate(0, 0, 1)
-		}
+		schedule.Weekday = weekday.String()
 	}
-
-	return next
+	loc := now.Location()
+	if settings := conf.GetSettings(); settings != nil {
+		loc = settings.Location()
+	}
+	return schedule.NextRun(now, loc)
 }
 
 // UpdateSchedules updates all schedules based on new configuration
@@ -345,28 +316,6 @@ func (s *Scheduler) UpdateSchedules(schedules []BackupSchedule) {
 	}
 }
 
-// parseWeekday converts a weekday string to time.Weekday
-func parseWeekday(weekday string) (time.Weekday, error) {
-	switch strings.ToLower(weekday) {
-	case "sunday":
-		return time.Sunday, nil
-	case "monday":
-		return time.Monday, nil
-	case "tuesday":
-		return time.Tuesday, nil
-	case "wednesday":
-		return time.Wednesday, nil
-	case "thursday":
-		return time.Thursday, nil
-	case "friday":
-		return time.Friday, nil
-	case "saturday":
-		return time.Saturday, nil
-	default:
-		return -1, fmt.Errorf("invalid weekday: %s", weekday)
-	}
-}
-
 // LoadFromConfig loads schedules from the backup configuration
 func (s *Scheduler) LoadFromConfig(config *conf.BackupConfig) error {
 	s.mu.Lock()
@@ -416,7 +365,7 @@ func (s *Scheduler) LoadFromConfig(config *conf.BackupConfig) error {
 				weekday = time.Sunday
 			} else {
 				// Parse the specified weekday
-				parsedDay, err := parseWeekday(scheduleConf.Weekday)
+				parsedDay, err := conf.ParseWeekday(scheduleConf.Weekday)
 				if err != nil {
 					errMsg := fmt.Sprintf("invalid weekday '%s' in schedule config", scheduleConf.Weekday)
 					s.logger.Error(errMsg, "config", scheduleConf, "error", err)
@@ -432,7 +381,7 @@ func (s *Scheduler) LoadFromConfig(config *conf.BackupConfig) error {
 		case scheduleConf.Weekday != "":
 			// If weekday is specified but not explicitly marked as weekly, still make it weekly
 			isWeekly = true
-			parsedDay, err := parseWeekday(scheduleConf.Weekday)
+			parsedDay, err := conf.ParseWeekday(scheduleConf.Weekday)
 			if err != nil {
 				errMsg := fmt.Sprintf("invalid weekday '%s' in schedule config", scheduleConf.Weekday)
 				s.logger.Error(errMsg, "config", scheduleConf, "error", err)
@@ -0,0 +1,461 @@
+package targets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/backup"
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+const (
+	defaultAzureTimeout   = 30 * time.Second
+	azureAPIVersion       = "2021-08-06"
+	azureMaxBlobSize      = 5000 * 1024 * 1024 // Largest blob stored with a single Put Blob request
+	azureMetadataFileExt  = ".meta"
+	azureMaxMetadataBytes = 64 * 1024
+)
+
+// AzureBlobTargetConfig holds configuration for the Azure Blob Storage target
+type AzureBlobTargetConfig struct {
+	AccountName string
+	AccountKey  string // Base64 encoded account key for shared key authentication
+	SASToken    string // Shared access signature, used instead of the account key
+	Container   string
+	Prefix      string
+	Endpoint    string        // Blob service endpoint, defaults to https://<account>.blob.core.windows.net
+	Timeout     time.Duration // Timeout for server responses
+	Debug       bool
+}
+
+// AzureBlobTarget implements the backup.Target interface for Azure Blob Storage
+// using the Blob service REST API
+type AzureBlobTarget struct {
+	config AzureBlobTargetConfig
+	key    []byte
+	client *http.Client
+	logger backup.Logger
+}
+
+// azureBlobList is the List Blobs response of the Blob service
+type azureBlobList struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			ContentLength int64  `xml:"Content-Length"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// NewAzureBlobTarget creates a new Azure Blob Storage target with the given configuration
+func NewAzureBlobTarget(config *AzureBlobTargetConfig, logger backup.Logger) (*AzureBlobTarget, error) {
+	settings := conf.AzureBlobBackupSettings{
+		AccountName: config.AccountName,
+		AccountKey:  config.AccountKey,
+		SASToken:    config.SASToken,
+		Container:   config.Container,
+		Prefix:      config.Prefix,
+		Endpoint:    config.Endpoint,
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, backup.NewError(backup.ErrConfig, "azure: invalid configuration", err)
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.AccountName)
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	config.SASToken = strings.TrimPrefix(config.SASToken, "?")
+	if config.Timeout == 0 {
+		config.Timeout = defaultAzureTimeout
+	}
+	if logger == nil {
+		logger = backup.DefaultLogger()
+	}
+
+	var key []byte
+	if config.AccountKey != "" {
+		key, _ = base64.StdEncoding.DecodeString(config.AccountKey) // validated above
+	}
+
+	// Uploads of large backups may take long, the timeout applies to waiting
+	// for responses once a request was sent
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = config.Timeout
+
+	return &AzureBlobTarget{
+		config: *config,
+		key:    key,
+		client: &http.Client{Transport: transport},
+		logger: logger,
+	}, nil
+}
+
+// NewAzureBlobTargetFromMap creates a new Azure Blob Storage target from a map configuration
+func NewAzureBlobTargetFromMap(settings map[string]any) (*AzureBlobTarget, error) {
+	decoded, err := conf.BackupTarget{Type: "azure", Settings: settings}.Decode()
+	if err != nil {
+		return nil, backup.NewError(backup.ErrConfig, "azure: invalid settings", err)
+	}
+	azure := decoded.(*conf.AzureBlobBackupSettings)
+
+	config := AzureBlobTargetConfig{
+		AccountName: azure.AccountName,
+		AccountKey:  azure.AccountKey,
+		SASToken:    azure.SASToken,
+		Container:   azure.Container,
+		Prefix:      azure.Prefix,
+		Endpoint:    azure.Endpoint,
+	}
+	if timeout, ok := settings["timeout"].(string); ok {
+		duration, err := conf.ParseHumanDuration(timeout)
+		if err != nil {
+			return nil, backup.NewError(backup.ErrValidation, "azure: invalid timeout format", err)
+		}
+		config.Timeout = duration
+	}
+	if debug, ok := settings["debug"].(bool); ok {
+		config.Debug = debug
+	}
+
+	var logger backup.Logger
+	if l, ok := settings["logger"].(backup.Logger); ok {
+		logger = l
+	}
+
+	return NewAzureBlobTarget(&config, logger)
+}
+
+// Name returns the name of this target
+func (t *AzureBlobTarget) Name() string {
+	return "azure"
+}
+
+// blobName returns the blob name of a backup file
+func (t *AzureBlobTarget) blobName(name string) string {
+	if t.config.Prefix == "" {
+		return name
+	}
+	return t.config.Prefix + "/" + name
+}
+
+// requestURL returns the URL of a blob, or of the container for an empty
+// blob name, with the query parameters and the SAS token
+func (t *AzureBlobTarget) requestURL(blob string, query url.Values) string {
+	target := t.config.Endpoint + "/" + url.PathEscape(t.config.Container)
+	if blob != "" {
+		segments := strings.Split(blob, "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		target += "/" + strings.Join(segments, "/")
+	}
+
+	rawQuery := query.Encode()
+	if t.config.SASToken != "" {
+		if rawQuery != "" {
+			rawQuery += "&"
+		}
+		rawQuery += t.config.SASToken
+	}
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+	return target
+}
+
+// do sends a request to the Blob service and returns the response, statuses
+// outside of 2xx and the accepted statuses are errors
+func (t *AzureBlobTarget) do(ctx context.Context, method, target string, body io.Reader, contentLength int64, headers map[string]string, accepted ...int) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, backup.NewError(backup.ErrIO, "azure: failed to create request", err)
+	}
+	if body != nil {
+		req.ContentLength = contentLength
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if t.key != nil {
+		req.Header.Set("Authorization", "SharedKey "+t.config.AccountName+":"+t.signature(req))
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, backup.NewError(backup.ErrCanceled, fmt.Sprintf("azure: %s canceled", method), ctx.Err())
+		}
+		return nil, backup.NewError(backup.ErrIO, fmt.Sprintf("azure: %s request failed", method), err)
+	}
+	if resp.StatusCode/100 == 2 || slices.Contains(accepted, resp.StatusCode) {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	code := backup.ErrIO
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		code = backup.ErrSecurity
+	case http.StatusNotFound:
+		code = backup.ErrNotFound
+	}
+	// Do not log the URL, it may contain the SAS token
+	return nil, backup.NewError(code, fmt.Sprintf("azure: %s %s failed with status %s (%s)",
+		method, req.URL.Path, resp.Status, resp.Header.Get("x-ms-error-code")), nil)
+}
+
+// request sends a request and closes the response
+func (t *AzureBlobTarget) request(ctx context.Context, method, target string, body io.Reader, contentLength int64, headers map[string]string, accepted ...int) error {
+	resp, err := t.do(ctx, method, target, body, contentLength, headers, accepted...)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// signature returns the shared key signature of a request, see
+// https://learn.microsoft.com/rest/api/storageservices/authorize-with-shared-key
+func (t *AzureBlobTarget) signature(req *http.Request) string {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(azureStringToSign(req, t.config.AccountName)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// azureStringToSign returns the canonical form of a request that is signed
+// for shared key authentication
+func azureStringToSign(req *http.Request, account string) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+	lines := []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date, x-ms-date is used instead
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	}
+
+	// Canonicalized headers are the x-ms- headers sorted by name
+	var msHeaders []string
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-ms-") {
+			msHeaders = append(msHeaders, name+":"+strings.TrimSpace(strings.Join(values, ",")))
+		}
+	}
+	slices.Sort(msHeaders)
+	lines = append(lines, msHeaders...)
+
+	// Canonicalized resource is the account, the path and the sorted query parameters
+	resource := "/" + account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		values := slices.Clone(query[name])
+		slices.Sort(values)
+		resource += "\n" + strings.ToLower(name) + ":" + strings.Join(values, ",")
+	}
+	lines = append(lines, resource)
+
+	return strings.Join(lines, "\n")
+}
+
+// putBlob uploads data as a block blob
+func (t *AzureBlobTarget) putBlob(ctx context.Context, blob string, body io.Reader, size int64, contentType string) error {
+	headers := map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+		"Content-Type":   contentType,
+	}
+	if size == 0 {
+		body = http.NoBody
+	}
+	return t.request(ctx, http.MethodPut, t.requestURL(blob, nil), body, size, headers)
+}
+
+// Store implements the backup.Target interface. The backup and its metadata
+// are uploaded as separate blobs, each upload replaces a blob atomically.
+func (t *AzureBlobTarget) Store(ctx context.Context, sourcePath string, metadata *backup.Metadata) error {
+	name := filepath.Base(sourcePath)
+	if t.config.Debug {
+		t.logger.Printf("🔄 Azure: Storing backup %s to container %s", name, t.config.Container)
+	}
+
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return backup.NewError(backup.ErrIO, "azure: failed to marshal metadata", err)
+	}
+
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return backup.NewError(backup.ErrIO, "azure: failed to open backup file", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			t.logger.Printf("azure: failed to close backup file %s: %v", sourcePath, err)
+		}
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return backup.NewError(backup.ErrIO, "azure: failed to stat backup file", err)
+	}
+	if info.Size() > azureMaxBlobSize {
+		return backup.NewError(backup.ErrValidation, fmt.Sprintf("azure: backup file too large: %d bytes (max %d bytes)", info.Size(), azureMaxBlobSize), nil)
+	}
+
+	if err := t.putBlob(ctx, t.blobName(name), backup.ThrottledReader(ctx, file), info.Size(), "application/octet-stream"); err != nil {
+		return err
+	}
+	if err := t.putBlob(ctx, t.blobName(name+azureMetadataFileExt), bytes.NewReader(metadataBytes), int64(len(metadataBytes)), "application/json"); err != nil {
+		return backup.NewError(backup.ErrIO, "azure: failed to store metadata", err)
+	}
+
+	if t.config.Debug {
+		t.logger.Printf("✅ Azure: Successfully stored backup %s with metadata", name)
+	}
+	return nil
+}
+
+// List implements the backup.Target interface
+func (t *AzureBlobTarget) List(ctx context.Context) ([]backup.BackupInfo, error) {
+	if t.config.Debug {
+		t.logger.Printf("🔄 Azure: Listing backups in container %s", t.config.Container)
+	}
+
+	prefix := t.blobName("")
+	var backups []backup.BackupInfo
+	marker := ""
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		resp, err := t.do(ctx, http.MethodGet, t.requestURL("", query), nil, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list azureBlobList
+		err = xml.NewDecoder(resp.Body).Decode(&list)
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			t.logger.Printf("azure: failed to close response body: %v", closeErr)
+		}
+		if err != nil {
+			return nil, backup.NewError(backup.ErrIO, "azure: invalid List Blobs response", err)
+		}
+
+		for i := range list.Blobs {
+			blob := &list.Blobs[i]
+			name := strings.TrimPrefix(blob.Name, prefix)
+			if name == "" || strings.Contains(name, "/") || strings.HasSuffix(name, azureMetadataFileExt) {
+				continue
+			}
+
+			info := backup.BackupInfo{
+				Target: name,
+				Metadata: backup.Metadata{
+					ID:   name,
+					Size: blob.Properties.ContentLength,
+				},
+			}
+			if modified, err := http.ParseTime(blob.Properties.LastModified); err == nil {
+				info.Timestamp = modified
+			}
+			// Prefer the stored metadata, the listing only has size and modification time
+			if metadata, err := t.readMetadata(ctx, name); err == nil {
+				info.Metadata = *metadata
+				info.ID = name
+			}
+			backups = append(backups, info)
+		}
+
+		if list.NextMarker == "" {
+			return backups, nil
+		}
+		marker = list.NextMarker
+	}
+}
+
+// readMetadata reads the metadata blob of a backup
+func (t *AzureBlobTarget) readMetadata(ctx context.Context, name string) (*backup.Metadata, error) {
+	resp, err := t.do(ctx, http.MethodGet, t.requestURL(t.blobName(name+azureMetadataFileExt), nil), nil, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var metadata backup.Metadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, azureMaxMetadataBytes)).Decode(&metadata); err != nil {
+		return nil, backup.NewError(backup.ErrCorruption, "azure: invalid metadata", err)
+	}
+	return &metadata, nil
+}
+
+// Delete implements the backup.Target interface
+func (t *AzureBlobTarget) Delete(ctx context.Context, target string) error {
+	if t.config.Debug {
+		t.logger.Printf("🔄 Azure: Deleting backup %s from container %s", target, t.config.Container)
+	}
+
+	if target == "" || strings.Contains(target, "/") || target == "." || target == ".." {
+		return backup.NewError(backup.ErrValidation, fmt.Sprintf("azure: invalid backup name %q", target), nil)
+	}
+	if err := t.request(ctx, http.MethodDelete, t.requestURL(t.blobName(target), nil), nil, 0, nil); err != nil {
+		return err
+	}
+	// The metadata blob may not exist for backups stored by other tools
+	if err := t.request(ctx, http.MethodDelete, t.requestURL(t.blobName(target+azureMetadataFileExt), nil), nil, 0, nil, http.StatusNotFound); err != nil {
+		t.logger.Printf("⚠️ Azure: Warning: failed to delete metadata of %s: %v", target, err)
+	}
+
+	if t.config.Debug {
+		t.logger.Printf("✅ Azure: Successfully deleted backup %s", target)
+	}
+	return nil
+}
+
+// Validate checks that the container can be listed with the credentials
+func (t *AzureBlobTarget) Validate() error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.config.Timeout)
+	defer cancel()
+
+	query := url.Values{"restype": {"container"}, "comp": {"list"}, "maxresults": {"1"}}
+	return t.request(ctx, http.MethodGet, t.requestURL("", query), nil, 0, nil)
+}
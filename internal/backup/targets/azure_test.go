@@ -0,0 +1,204 @@
+package targets
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/backup"
+)
+
+// Azurite development storage account
+const (
+	azuriteAccount = "devstoreaccount1"
+	azuriteKey     = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+// newAzureServer starts an in-memory Blob service for one container that
+// checks shared key signatures or a SAS token
+func newAzureServer(t *testing.T, sasToken string) *httptest.Server {
+	t.Helper()
+	key, _ := base64.StdEncoding.DecodeString(azuriteKey)
+	var mu sync.Mutex
+	blobs := make(map[string][]byte)
+	containerPath := "/" + azuriteAccount + "/backups"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sasToken != "" {
+			if r.URL.Query().Get("sig") != "signature" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		} else {
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte(azureStringToSign(r, azuriteAccount)))
+			want := "SharedKey " + azuriteAccount + ":" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+			if r.Header.Get("Authorization") != want {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		blob := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, containerPath), "/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("comp") == "list":
+			prefix := r.URL.Query().Get("prefix")
+			var names []string
+			for name := range blobs {
+				if strings.HasPrefix(name, prefix) {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+			for _, name := range names {
+				fmt.Fprintf(w, "<Blob><Name>%s</Name><Properties><Last-Modified>%s</Last-Modified><Content-Length>%d</Content-Length></Properties></Blob>",
+					xmlEscape(name), time.Now().UTC().Format(http.TimeFormat), len(blobs[name]))
+			}
+			fmt.Fprint(w, `</Blobs><NextMarker/></EnumerationResults>`)
+		case r.Method == http.MethodPut && r.Header.Get("x-ms-blob-type") == "BlockBlob":
+			data, _ := io.ReadAll(r.Body)
+			blobs[blob] = data
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet:
+			data, ok := blobs[blob]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case r.Method == http.MethodDelete:
+			if _, ok := blobs[blob]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(blobs, blob)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func TestAzureBlobTargetStoreListDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings map[string]any
+		sasToken string
+	}{
+		{"shared key", map[string]any{"accountkey": azuriteKey}, ""},
+		{"SAS token", map[string]any{"sastoken": "?sv=2021-08-06&sp=racwdl&sig=signature"}, "signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := newAzureServer(t, tt.sasToken)
+			settings := map[string]any{
+				"accountname": azuriteAccount,
+				"container":   "backups",
+				"prefix":      "birdnet",
+				"endpoint":    server.URL + "/" + azuriteAccount,
+			}
+			for key, value := range tt.settings {
+				settings[key] = value
+			}
+			target, err := NewAzureBlobTargetFromMap(settings)
+			if err != nil {
+				t.Fatalf("NewAzureBlobTargetFromMap() error = %v", err)
+			}
+			ctx := context.Background()
+
+			if err := target.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			sourcePath := filepath.Join(t.TempDir(), "birdnet-backup.tar.gz")
+			if err := os.WriteFile(sourcePath, []byte("backup data"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			timestamp := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+			if err := target.Store(ctx, sourcePath, &backup.Metadata{ID: "backup-1", Timestamp: timestamp, Size: 11}); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+
+			backups, err := target.List(ctx)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(backups) != 1 {
+				t.Fatalf("List() returned %d backups, want 1: %+v", len(backups), backups)
+			}
+			if got := backups[0]; got.ID != "birdnet-backup.tar.gz" || !got.Timestamp.Equal(timestamp) {
+				t.Errorf("List()[0] = %+v, want the stored backup and its metadata", got)
+			}
+
+			if err := target.Delete(ctx, backups[0].ID); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if backups, err := target.List(ctx); err != nil || len(backups) != 0 {
+				t.Errorf("List() after Delete() = %v, %v, want no backups", backups, err)
+			}
+		})
+	}
+}
+
+func TestAzureBlobTargetWrongKey(t *testing.T) {
+	t.Parallel()
+
+	server := newAzureServer(t, "")
+	target, err := NewAzureBlobTargetFromMap(map[string]any{
+		"accountname": azuriteAccount,
+		"accountkey":  base64.StdEncoding.EncodeToString([]byte("wrong key")),
+		"container":   "backups",
+		"endpoint":    server.URL + "/" + azuriteAccount,
+	})
+	if err != nil {
+		t.Fatalf("NewAzureBlobTargetFromMap() error = %v", err)
+	}
+	if err := target.Validate(); !backup.IsSecurityError(err) {
+		t.Errorf("Validate() error = %v, want a security error", err)
+	}
+}
+
+func TestAzureStringToSign(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest(http.MethodGet, "https://account.blob.core.windows.net/backups?restype=container&comp=list&prefix=birdnet%2F", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-ms-version", "2021-08-06")
+	req.Header.Set("x-ms-date", "Thu, 01 May 2025 12:00:00 GMT")
+
+	want := "GET\n\n\n\n\n\n\n\n\n\n\n\n" +
+		"x-ms-date:Thu, 01 May 2025 12:00:00 GMT\nx-ms-version:2021-08-06\n" +
+		"/account/backups\ncomp:list\nprefix:birdnet/\nrestype:container"
+	if got := azureStringToSign(req, "account"); got != want {
+		t.Errorf("azureStringToSign() =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package targets
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,10 +16,12 @@ import (
 
 	"github.com/jlaffaye/ftp"
 	"github.com/tphakala/birdnet-go/internal/backup"
+	"github.com/tphakala/birdnet-go/internal/conf"
 )
 
 const (
 	defaultFTPPort        = 21
+	defaultFTPSPort       = 990 // Implicit FTPS
 	defaultTimeout        = 30 * time.Second
 	defaultMaxConnections = 5
 	defaultMaxRetries     = 3
@@ -52,6 +55,7 @@ type FTPTargetConfig struct {
 	RetryBackoff time.Duration
 	Features     []string // Required server features
 	MinSpace     int64    // Minimum required space in bytes
+	TLSMode      string   // conf.FTPTLSModeExplicit or conf.FTPTLSModeImplicit for FTPS, empty for plain FTP
 }
 
 // NewFTPTarget creates a new FTP target with the given configuration
@@ -64,9 +68,19 @@ func NewFTPTarget(config *FTPTargetConfig, logger backup.Logger) (*FTPTarget, er
 		return nil, backup.NewError(backup.ErrConfig, "ftp: base path is required", nil)
 	}
 
+	switch config.TLSMode {
+	case "", conf.FTPTLSModeExplicit, conf.FTPTLSModeImplicit:
+	default:
+		return nil, backup.NewError(backup.ErrConfig, fmt.Sprintf("ftp: invalid TLS mode %q, use %s or %s",
+			config.TLSMode, conf.FTPTLSModeExplicit, conf.FTPTLSModeImplicit), nil)
+	}
+
 	// Set defaults for optional fields
 	if config.Port == 0 {
 		config.Port = defaultFTPPort
+		if config.TLSMode == conf.FTPTLSModeImplicit {
+			config.Port = defaultFTPSPort
+		}
 	}
 	if config.Timeout == 0 {
 		config.Timeout = defaultTimeout
@@ -121,16 +135,37 @@ func NewFTPTargetFromMap(settings map[string]interface{}) (*FTPTarget, error) {
 		config.BasePath = basePath
 	}
 	if timeout, ok := settings["timeout"].(string); ok {
-		duration, err := time.ParseDuration(timeout)
+		duration, err := conf.ParseHumanDuration(timeout)
 		if err != nil {
 			return nil, backup.NewError(backup.ErrValidation, "ftp: invalid timeout format", err)
 		}
 		config.Timeout = duration
+	} else if seconds, ok := settings["timeoutseconds"].(int); ok {
+		if seconds < 0 {
+			return nil, backup.NewError(backup.ErrValidation, "ftp: timeoutseconds must not be negative", nil)
+		}
+		config.Timeout = time.Duration(seconds) * time.Second
 	}
 	if debug, ok := settings["debug"].(bool); ok {
 		config.Debug = debug
 	}
 
+	// The FTP client only opens passive mode data connections
+	if passive, ok := settings["passive"].(bool); ok && !passive {
+		return nil, backup.NewError(backup.ErrConfig, "ftp: active mode is not supported, set passive to true", nil)
+	}
+
+	// UseTLS implies explicit FTPS unless a TLS mode is set
+	useTLS, _ := settings["usetls"].(bool)
+	tlsMode, _ := settings["tlsmode"].(string)
+	switch {
+	case !useTLS && tlsMode != "":
+		return nil, backup.NewError(backup.ErrConfig, fmt.Sprintf("ftp: TLS mode %q requires usetls to be enabled", tlsMode), nil)
+	case useTLS && tlsMode == "":
+		tlsMode = conf.FTPTLSModeExplicit
+	}
+	config.TLSMode = tlsMode
+
 	var logger backup.Logger
 	if l, ok := settings["logger"].(backup.Logger); ok {
 		logger = l
@@ -251,7 +286,15 @@ func (t *FTPTarget) connect(ctx context.Context) (*ftp.ServerConn, error) {
 
 	go func() {
 		addr := fmt.Sprintf("%s:%d", t.config.Host, t.config.Port)
-		conn, err := ftp.Dial(addr, ftp.DialWithTimeout(t.config.Timeout))
+		options := []ftp.DialOption{ftp.DialWithTimeout(t.config.Timeout)}
+		tlsConfig := &tls.Config{ServerName: t.config.Host, MinVersion: tls.VersionTLS12}
+		switch t.config.TLSMode {
+		case conf.FTPTLSModeExplicit:
+			options = append(options, ftp.DialWithExplicitTLS(tlsConfig))
+		case conf.FTPTLSModeImplicit:
+			options = append(options, ftp.DialWithTLS(tlsConfig))
+		}
+		conn, err := ftp.Dial(addr, options...)
 		if err != nil {
 			errChan <- backup.NewError(backup.ErrIO, "ftp: connection failed", err)
 			return
@@ -344,7 +387,7 @@ func (t *FTPTarget) uploadFile(ctx context.Context, conn *ftp.ServerConn, localP
 				t.logger.Printf("ftp: failed to close pipe writer: %v", err)
 			}
 		}()
-		_, err := io.Copy(pw, file)
+		_, err := io.Copy(pw, backup.ThrottledReader(ctx, file))
 		if err != nil {
 			errChan <- backup.NewError(backup.ErrIO, "ftp: failed to copy file data", err)
 			return
@@ -20,6 +20,7 @@ import (
 	"google.golang.org/api/option"
 
 	"github.com/tphakala/birdnet-go/internal/backup"
+	"github.com/tphakala/birdnet-go/internal/conf"
 )
 
 const (
@@ -556,7 +557,7 @@ func (t *GDriveTarget) Store(ctx context.Context, sourcePath string, metadata *b
 			}
 		}()
 
-		if _, err = t.service.Files.Create(backupFile).Media(file).Context(ctx).Do(); err != nil {
+		if _, err = t.service.Files.Create(backupFile).Media(backup.ThrottledReader(ctx, file)).Context(ctx).Do(); err != nil {
 			return backup.NewError(backup.ErrIO, "gdrive: failed to upload backup file", err)
 		}
 
@@ -818,7 +819,7 @@ func NewGDriveTargetFromMap(settings map[string]interface{}) (*GDriveTarget, err
 		config.BasePath = basePath
 	}
 	if timeout, ok := settings["timeout"].(string); ok {
-		duration, err := time.ParseDuration(timeout)
+		duration, err := conf.ParseHumanDuration(timeout)
 		if err != nil {
 			return nil, backup.NewError(backup.ErrValidation, "gdrive: invalid timeout format", err)
 		}
@@ -831,7 +832,7 @@ func NewGDriveTargetFromMap(settings map[string]interface{}) (*GDriveTarget, err
 		config.MaxRetries = maxRetries
 	}
 	if retryBackoff, ok := settings["retry_backoff"].(string); ok {
-		duration, err := time.ParseDuration(retryBackoff)
+		duration, err := conf.ParseHumanDuration(retryBackoff)
 		if err != nil {
 			return nil, backup.NewError(backup.ErrValidation, "gdrive: invalid retry_backoff format", err)
 		}
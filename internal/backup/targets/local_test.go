@@ -0,0 +1,51 @@
+package targets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/backup"
+)
+
+func TestLocalTargetListsEveryArchiveExtension(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewLocalTarget(LocalTargetConfig{Path: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatalf("NewLocalTarget() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// Archives of compression none, gzip and zstd
+	sourceDir := t.TempDir()
+	timestamp := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	want := map[string]bool{}
+	for i, extension := range []string{".tar", ".tar.gz", ".tar.zst"} {
+		id := "backup-" + extension[1:]
+		sourcePath := filepath.Join(sourceDir, id+extension)
+		if err := os.WriteFile(sourcePath, []byte("backup data"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		metadata := &backup.Metadata{ID: id, Timestamp: timestamp.Add(time.Duration(i) * time.Hour), Size: 11}
+		if err := target.Store(ctx, sourcePath, metadata); err != nil {
+			t.Fatalf("Store() of a %s archive error = %v", extension, err)
+		}
+		want[id] = true
+	}
+
+	backups, err := target.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(backups) != len(want) {
+		t.Fatalf("List() returned %d backups, want %d: %+v", len(backups), len(want), backups)
+	}
+	for _, b := range backups {
+		if !want[b.ID] {
+			t.Errorf("List() returned unexpected backup %q", b.ID)
+		}
+	}
+}
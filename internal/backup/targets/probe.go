@@ -0,0 +1,161 @@
+// probe.go connection tests of backup targets for conf.BackupTarget.TestConnection
+package targets
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/backup"
+	"github.com/tphakala/birdnet-go/internal/conf"
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// probeSource is the metadata source of marker backups stored by connection tests
+const probeSource = "connection-test"
+
+func init() {
+	conf.RegisterBackupTargetTester("local", testLocalTarget)
+	conf.RegisterBackupTargetTester("ftp", func(ctx context.Context, t conf.BackupTarget) error {
+		target, err := NewFTPTargetFromMap(t.Settings)
+		if err != nil {
+			return err
+		}
+		return probeTarget(ctx, target)
+	})
+	conf.RegisterBackupTargetTester("sftp", func(ctx context.Context, t conf.BackupTarget) error {
+		target, err := NewSFTPTarget(t.Settings, slog.Default())
+		if err != nil {
+			return err
+		}
+		return probeTarget(ctx, target)
+	})
+	conf.RegisterBackupTargetTester("rsync", func(ctx context.Context, t conf.BackupTarget) error {
+		target, err := NewRsyncTarget(t.Settings)
+		if err != nil {
+			return err
+		}
+		return probeTarget(ctx, target)
+	})
+	conf.RegisterBackupTargetTester("gdrive", func(ctx context.Context, t conf.BackupTarget) error {
+		target, err := NewGDriveTargetFromMap(t.Settings)
+		if err != nil {
+			return err
+		}
+		return probeTarget(ctx, target)
+	})
+	conf.RegisterBackupTargetTester("webdav", func(ctx context.Context, t conf.BackupTarget) error {
+		target, err := NewWebDAVTargetFromMap(t.Settings)
+		if err != nil {
+			return err
+		}
+		return probeTarget(ctx, target)
+	})
+	conf.RegisterBackupTargetTester("azure", func(ctx context.Context, t conf.BackupTarget) error {
+		target, err := NewAzureBlobTargetFromMap(t.Settings)
+		if err != nil {
+			return err
+		}
+		return probeTarget(ctx, target)
+	})
+}
+
+// testLocalTarget checks that the backup directory of a local target exists
+// or can be created, is writable and has free space
+func testLocalTarget(ctx context.Context, t conf.BackupTarget) error {
+	path, _ := t.Settings["path"].(string)
+	target, err := NewLocalTarget(LocalTargetConfig{Path: path}, nil)
+	if err != nil {
+		return err
+	}
+	return target.Validate()
+}
+
+// probeTarget stores a small marker backup on a target, checks that the
+// target lists it and deletes it again. The target is closed afterwards.
+func probeTarget(ctx context.Context, target backup.Target) (err error) {
+	if closer, ok := target.(io.Closer); ok {
+		defer func() {
+			if closeErr := closer.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
+	tempDir, err := os.MkdirTemp("", "birdnet-go-probe-")
+	if err != nil {
+		return errors.New(err).
+			Component("backup").
+			Category(errors.CategoryFileIO).
+			Context("operation", "create_probe_file").
+			Build()
+	}
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	// Remote targets expect backup archives, name the marker like one
+	timestamp := time.Now().UTC().Truncate(time.Second)
+	name := fmt.Sprintf("birdnet-go-connection-test-%d.tar.gz", timestamp.Unix())
+	content := []byte("BirdNET-Go backup target connection test\n")
+	markerPath := filepath.Join(tempDir, name)
+	if err := os.WriteFile(markerPath, content, 0o600); err != nil {
+		return errors.New(err).
+			Component("backup").
+			Category(errors.CategoryFileIO).
+			Context("operation", "create_probe_file").
+			Build()
+	}
+
+	metadata := &backup.Metadata{
+		Version:   1,
+		ID:        name,
+		Timestamp: timestamp,
+		Size:      int64(len(content)),
+		Type:      "probe",
+		Source:    probeSource,
+	}
+	if err := target.Store(ctx, markerPath, metadata); err != nil {
+		return fmt.Errorf("%s: failed to store marker backup: %w", target.Name(), err)
+	}
+
+	// The marker is deleted by name unless the target lists it with its own ID
+	deleteID := name
+	backups, listErr := target.List(ctx)
+	found := false
+	for i := range backups {
+		if isProbeBackup(&backups[i], name, timestamp) {
+			found = true
+			if backups[i].ID != "" {
+				deleteID = backups[i].ID
+			}
+			break
+		}
+	}
+
+	deleteErr := target.Delete(ctx, deleteID)
+	switch {
+	case listErr != nil:
+		return fmt.Errorf("%s: failed to list backups: %w", target.Name(), listErr)
+	case !found:
+		return errors.Newf("%s: stored marker backup %s is not listed", target.Name(), name).
+			Component("backup").
+			Category(errors.CategoryValidation).
+			Context("operation", "verify_probe_backup").
+			Build()
+	case deleteErr != nil:
+		return fmt.Errorf("%s: failed to delete marker backup %s: %w", target.Name(), name, deleteErr)
+	}
+	return nil
+}
+
+// isProbeBackup reports whether a listed backup is the marker backup, targets
+// list backups by file name, by metadata ID or only with their metadata
+func isProbeBackup(info *backup.BackupInfo, name string, timestamp time.Time) bool {
+	return info.ID == name || info.Target == name ||
+		(info.Source == probeSource && info.Timestamp.Equal(timestamp))
+}
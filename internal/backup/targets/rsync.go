@@ -3,6 +3,7 @@ package targets
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"os"
@@ -15,10 +16,11 @@ import (
 	"time"
 
 	"github.com/tphakala/birdnet-go/internal/backup"
+	"github.com/tphakala/birdnet-go/internal/conf"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
-	defaultRsyncPort     = 22
 	defaultRsyncTimeout  = 30 * time.Second
 	rsyncMaxRetries      = 3 // Renamed from defaultMaxRetries
 	rsyncRetryBackoff    = time.Second
@@ -62,6 +64,8 @@ type RsyncTarget struct {
 	mu          sync.Mutex // Protects operations
 	tempFiles   map[string]bool
 	tempFilesMu sync.Mutex // Protects tempFiles map
+	// decryptedKeyFile is the temporary copy of an encrypted key file, removed on Close
+	decryptedKeyFile string
 }
 
 // ProgressReader wraps an io.Reader to track progress
@@ -80,48 +84,31 @@ func (r *RsyncProgressReader) Read(p []byte) (n int, err error) {
 
 // NewRsyncTarget creates a new rsync target with the given configuration
 func NewRsyncTarget(settings map[string]interface{}) (*RsyncTarget, error) {
-	config := RsyncTargetConfig{}
-
-	// Required settings
-	host, ok := settings["host"].(string)
-	if !ok {
-		return nil, backup.NewError(backup.ErrConfig, "rsync: host is required", nil)
-	}
-	config.Host = host
-
-	// Optional settings with defaults
-	if port, ok := settings["port"].(int); ok {
-		config.Port = port
-	} else {
-		config.Port = defaultRsyncPort
-	}
-
-	if username, ok := settings["username"].(string); ok {
-		config.Username = username
+	decoded, err := conf.BackupTarget{Type: "rsync", Settings: settings}.Decode()
+	if err == nil {
+		err = decoded.Validate()
 	}
-
-	if keyFile, ok := settings["key_file"].(string); ok {
-		config.KeyFile = keyFile
+	if err != nil {
+		return nil, backup.NewError(backup.ErrConfig, "rsync: invalid settings", err)
 	}
+	rsyncSettings := decoded.(*conf.RsyncBackupSettings)
 
-	if knownHostFile, ok := settings["known_hosts_file"].(string); ok {
-		config.KnownHostFile = knownHostFile
-	} else {
-		// Default to user's known_hosts file
-		homeDir, err := os.UserHomeDir()
-		if err == nil {
-			config.KnownHostFile = filepath.Join(homeDir, ".ssh", "known_hosts")
-		}
+	// Required settings
+	if rsyncSettings.Host == "" {
+		return nil, backup.NewError(backup.ErrConfig, "rsync: host is required", nil)
 	}
 
-	if basePath, ok := settings["path"].(string); ok {
-		config.BasePath = strings.TrimRight(basePath, "/")
-	} else {
-		return nil, backup.NewError(backup.ErrConfig, "rsync: path is required", nil)
+	config := RsyncTargetConfig{
+		Host:          rsyncSettings.Host,
+		Port:          rsyncSettings.Port,
+		Username:      rsyncSettings.Username,
+		KeyFile:       rsyncSettings.SSHKeyPath,
+		KnownHostFile: rsyncSettings.KnownHostsPath,
+		BasePath:      strings.TrimRight(rsyncSettings.Path, "/"),
 	}
 
 	if timeout, ok := settings["timeout"].(string); ok {
-		duration, err := time.ParseDuration(timeout)
+		duration, err := conf.ParseHumanDuration(timeout)
 		if err != nil {
 			return nil, backup.NewError(backup.ErrValidation, "rsync: invalid timeout format", err)
 		}
@@ -142,15 +129,27 @@ func NewRsyncTarget(settings map[string]interface{}) (*RsyncTarget, error) {
 		tempFiles: make(map[string]bool),
 	}
 
+	// ssh cannot read a key passphrase non-interactively, use a decrypted copy of the key
+	if passphrase := rsyncSettings.PrivateKeyPassphrase; passphrase != "" && config.KeyFile != "" {
+		keyFile, err := decryptKeyFile(config.KeyFile, passphrase)
+		if err != nil {
+			return nil, backup.NewError(backup.ErrConfig, "rsync: failed to decrypt private key", err)
+		}
+		target.decryptedKeyFile = keyFile
+		target.config.KeyFile = keyFile
+	}
+
 	// Find rsync and ssh executables
 	rsyncPath, err := exec.LookPath("rsync")
 	if err != nil {
+		target.removeDecryptedKeyFile()
 		return nil, backup.NewError(backup.ErrConfig, "rsync: command not found in PATH", err)
 	}
 	target.rsyncPath = rsyncPath
 
 	sshPath, err := exec.LookPath("ssh")
 	if err != nil {
+		target.removeDecryptedKeyFile()
 		return nil, backup.NewError(backup.ErrConfig, "ssh: command not found in PATH", err)
 	}
 	target.sshPath = sshPath
@@ -158,6 +157,49 @@ func NewRsyncTarget(settings map[string]interface{}) (*RsyncTarget, error) {
 	return target, nil
 }
 
+// decryptKeyFile writes the decrypted private key of an encrypted key file to
+// a temporary file that only the owner can read and returns its path
+func decryptKeyFile(keyFile, passphrase string) (string, error) {
+	pemBytes, err := os.ReadFile(keyFile)
+	if err != nil {
+		return "", err
+	}
+	rawKey, err := conf.ParseSSHPrivateKey(pemBytes, passphrase)
+	if err != nil {
+		return "", err
+	}
+	block, err := ssh.MarshalPrivateKey(rawKey, "")
+	if err != nil {
+		return "", err
+	}
+
+	tempFile, err := os.CreateTemp("", "rsync-key-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = tempFile.Write(pem.EncodeToMemory(block))
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return "", err
+	}
+	return tempFile.Name(), nil
+}
+
+// removeDecryptedKeyFile removes the decrypted copy of the private key
+func (t *RsyncTarget) removeDecryptedKeyFile() error {
+	if t.decryptedKeyFile == "" {
+		return nil
+	}
+	if err := os.Remove(t.decryptedKeyFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	t.decryptedKeyFile = ""
+	return nil
+}
+
 // isTransientError checks if an error is likely temporary
 func (t *RsyncTarget) isTransientError(err error) bool {
 	if err == nil {
@@ -437,6 +479,9 @@ func (t *RsyncTarget) atomicUpload(ctx context.Context, sourcePath string) error
 		if t.config.Debug {
 			args = append(args, "--progress")
 		}
+		if kbps := backup.BandwidthLimitKBps(ctx); kbps > 0 {
+			args = append(args, fmt.Sprintf("--bwlimit=%d", kbps))
+		}
 
 		// Add source and temporary destination
 		// Use --rsh option to handle the SSH connection securely
@@ -595,6 +640,11 @@ func (t *RsyncTarget) Close() error {
 		errs = append(errs, err)
 	}
 
+	// Remove the decrypted private key
+	if err := t.removeDecryptedKeyFile(); err != nil {
+		errs = append(errs, err)
+	}
+
 	// Combine all errors
 	if len(errs) > 0 {
 		var errMsg strings.Builder
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"os"
 	"path"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/pkg/sftp"
 	"github.com/tphakala/birdnet-go/internal/backup"
+	"github.com/tphakala/birdnet-go/internal/conf"
 	"github.com/tphakala/birdnet-go/internal/errors"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/knownhosts"
@@ -25,7 +27,6 @@ const (
 	defaultSFTPRetryBackoff = time.Second
 	defaultSFTPMaxConns     = 5
 	defaultSFTPTimeout      = 30 * time.Second
-	defaultSFTPPort         = 22
 	sftpTempFilePrefix      = "tmp-"
 	sftpMetadataFileExt     = ".meta"
 	sftpMetadataVersion     = 1
@@ -33,18 +34,21 @@ const (
 
 // SFTPTargetConfig holds configuration for the SFTP target
 type SFTPTargetConfig struct {
-	Host          string
-	Port          int
-	Username      string
-	Password      string
-	KeyFile       string
-	KnownHostFile string
-	BasePath      string
-	Timeout       time.Duration
-	Debug         bool
-	MaxConns      int
-	MaxRetries    int
-	RetryBackoff  time.Duration
+	Host                  string
+	Port                  int
+	Username              string
+	Password              string
+	KeyFile               string
+	KeyPassphrase         string
+	KnownHostFile         string
+	HostKeyFingerprint    string
+	InsecureIgnoreHostKey bool
+	BasePath              string
+	Timeout               time.Duration
+	Debug                 bool
+	MaxConns              int
+	MaxRetries            int
+	RetryBackoff          time.Duration
 }
 
 // SFTPTarget implements the backup.Target interface for SFTP storage
@@ -73,65 +77,47 @@ func (r *SFTPProgressReader) Read(p []byte) (n int, err error) {
 
 // NewSFTPTarget creates a new SFTP target with the given configuration
 func NewSFTPTarget(settings map[string]any, logger *slog.Logger) (*SFTPTarget, error) {
-	config := SFTPTargetConfig{}
-
-	// Required settings
-	host, ok := settings["host"].(string)
-	if !ok {
-		return nil, errors.Newf("sftp: host is required").
+	decoded, err := conf.BackupTarget{Type: "sftp", Settings: settings}.Decode()
+	if err == nil {
+		err = decoded.Validate()
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Errorf("sftp: %w", err)).
 			Component("backup").
 			Category(errors.CategoryConfiguration).
 			Context("operation", "create_sftp_target").
 			Build()
 	}
-	config.Host = host
+	sftpSettings := decoded.(*conf.SFTPBackupSettings)
 
-	basePath, ok := settings["path"].(string)
-	if !ok {
+	if sftpSettings.Path == "" {
 		return nil, errors.Newf("sftp: path is required").
 			Component("backup").
 			Category(errors.CategoryConfiguration).
 			Context("operation", "create_sftp_target").
 			Build()
 	}
-	// Preserve root path "/" while trimming trailing slashes from other paths
-	if basePath == "/" {
-		config.BasePath = "/"
-	} else {
-		config.BasePath = strings.TrimRight(basePath, "/")
-	}
 
-	// Optional settings with defaults
-	if port, ok := settings["port"].(int); ok {
-		config.Port = port
-	} else {
-		config.Port = defaultSFTPPort
+	config := SFTPTargetConfig{
+		Host:                  sftpSettings.Host,
+		Port:                  sftpSettings.Port,
+		Username:              sftpSettings.Username,
+		Password:              sftpSettings.Password,
+		KeyFile:               sftpSettings.PrivateKeyPath,
+		KeyPassphrase:         sftpSettings.PrivateKeyPassphrase,
+		KnownHostFile:         sftpSettings.KnownHostsPath,
+		HostKeyFingerprint:    sftpSettings.HostKeyFingerprint,
+		InsecureIgnoreHostKey: sftpSettings.InsecureIgnoreHostKey,
 	}
-
-	if username, ok := settings["username"].(string); ok {
-		config.Username = username
-	}
-
-	if password, ok := settings["password"].(string); ok {
-		config.Password = password
-	}
-
-	if keyFile, ok := settings["key_file"].(string); ok {
-		config.KeyFile = keyFile
-	}
-
-	if knownHostFile, ok := settings["known_hosts_file"].(string); ok {
-		config.KnownHostFile = knownHostFile
+	// Preserve root path "/" while trimming trailing slashes from other paths
+	if sftpSettings.Path == "/" {
+		config.BasePath = "/"
 	} else {
-		// Default to user's known_hosts file
-		homeDir, err := os.UserHomeDir()
-		if err == nil {
-			config.KnownHostFile = filepath.Join(homeDir, ".ssh", "known_hosts")
-		}
+		config.BasePath = strings.TrimRight(sftpSettings.Path, "/")
 	}
 
 	if timeout, ok := settings["timeout"].(string); ok {
-		duration, err := time.ParseDuration(timeout)
+		duration, err := conf.ParseHumanDuration(timeout)
 		if err != nil {
 			return nil, errors.New(err).
 				Component("backup").
@@ -309,26 +295,13 @@ func (t *SFTPTarget) connect(ctx context.Context) (*sftp.Client, error) {
 			Timeout: t.config.Timeout,
 		}
 
-		// Set host key callback based on known_hosts file
-		if t.config.KnownHostFile != "" {
-			callback, err := knownHostsCallback(t.config.KnownHostFile)
-			if err != nil {
-				resultChan <- connResult{nil, errors.New(err).
-					Component("backup").
-					Category(errors.CategoryValidation).
-					Context("operation", "setup_known_hosts").
-					Build()}
-				return
-			}
-			config.HostKeyCallback = callback
-		} else {
-			resultChan <- connResult{nil, errors.Newf("sftp: known_hosts file is required for secure host key verification").
-				Component("backup").
-				Category(errors.CategoryValidation).
-				Context("operation", "verify_known_hosts").
-				Build()}
+		// Set host key callback based on the pinned fingerprint and known_hosts file
+		callback, err := t.hostKeyCallback()
+		if err != nil {
+			resultChan <- connResult{nil, err}
 			return
 		}
+		config.HostKeyCallback = callback
 
 		// Set authentication method
 		switch {
@@ -343,7 +316,16 @@ func (t *SFTPTarget) connect(ctx context.Context) (*sftp.Client, error) {
 				return
 			}
 
-			signer, err := ssh.ParsePrivateKey(key)
+			rawKey, err := conf.ParseSSHPrivateKey(key, t.config.KeyPassphrase)
+			if err != nil {
+				resultChan <- connResult{nil, errors.New(err).
+					Component("backup").
+					Category(errors.CategoryValidation).
+					Context("operation", "parse_private_key").
+					Build()}
+				return
+			}
+			signer, err := ssh.NewSignerFromKey(rawKey)
 			if err != nil {
 				resultChan <- connResult{nil, errors.New(err).
 					Component("backup").
@@ -700,7 +682,7 @@ func (t *SFTPTarget) uploadFile(ctx context.Context, client *sftp.Client, localP
 				}
 			}
 		}()
-		_, err := io.Copy(pw, file)
+		_, err := io.Copy(pw, backup.ThrottledReader(ctx, file))
 		errChan <- err
 	}()
 
@@ -997,6 +979,65 @@ func (t *SFTPTarget) cleanupTempFiles(client *sftp.Client) {
 	}
 }
 
+// hostKeyCallback returns the host key verification of the target. A pinned
+// fingerprint and a known_hosts file must both match when both are set,
+// without either verification is only skipped when InsecureIgnoreHostKey is set.
+func (t *SFTPTarget) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	var callbacks []ssh.HostKeyCallback
+	if t.config.HostKeyFingerprint != "" {
+		callbacks = append(callbacks, fingerprintCallback(t.config.HostKeyFingerprint))
+	}
+	if t.config.KnownHostFile != "" {
+		callback, err := knownHostsCallback(t.config.KnownHostFile)
+		if err != nil {
+			return nil, errors.New(err).
+				Component("backup").
+				Category(errors.CategoryValidation).
+				Context("operation", "setup_known_hosts").
+				Build()
+		}
+		callbacks = append(callbacks, callback)
+	}
+
+	switch {
+	case len(callbacks) > 0:
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			for _, callback := range callbacks {
+				if err := callback(hostname, remote, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	case t.config.InsecureIgnoreHostKey:
+		t.logger.Warn("SFTP: Host key verification is disabled, the connection is vulnerable to man-in-the-middle attacks",
+			"host", t.config.Host)
+		return ssh.InsecureIgnoreHostKey(), nil // #nosec G106 -- explicitly enabled by the insecure_ignore_host_key setting
+	default:
+		return nil, errors.Newf("sftp: known_hosts file or host key fingerprint is required for secure host key verification").
+			Component("backup").
+			Category(errors.CategoryValidation).
+			Context("operation", "verify_known_hosts").
+			Build()
+	}
+}
+
+// fingerprintCallback creates a host key callback function that accepts only
+// the host key with a SHA256 fingerprint
+func fingerprintCallback(fingerprint string) ssh.HostKeyCallback {
+	want := strings.TrimRight(fingerprint, "=")
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if got := ssh.FingerprintSHA256(key); got != want {
+			return errors.Newf("sftp: host key fingerprint of %s is %s, expected %s", hostname, got, want).
+				Component("backup").
+				Category(errors.CategoryValidation).
+				Context("operation", "verify_host_key_fingerprint").
+				Build()
+		}
+		return nil
+	}
+}
+
 // knownHostsCallback creates a host key callback function from a known_hosts file
 func knownHostsCallback(knownHostsFile string) (ssh.HostKeyCallback, error) {
 	// Check if the known_hosts file exists
@@ -0,0 +1,411 @@
+package targets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/backup"
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+const (
+	defaultWebDAVTimeout   = 30 * time.Second
+	webdavMetadataFileExt  = ".meta"
+	webdavTempFilePrefix   = "tmp-"
+	webdavPropfindRequest  = `<?xml version="1.0" encoding="utf-8"?><d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/><d:getcontentlength/><d:getlastmodified/></d:prop></d:propfind>`
+	webdavMaxMetadataBytes = 64 * 1024
+)
+
+// WebDAVTargetConfig holds configuration for the WebDAV target
+type WebDAVTargetConfig struct {
+	URL      string        // Server URL, e.g. https://cloud.example.com/remote.php/dav/files/user
+	Username string        // Username for basic authentication
+	Password string        // Password for basic authentication
+	Token    string        // Bearer token, used instead of basic authentication
+	BasePath string        // Collection below URL storing the backups
+	Timeout  time.Duration // Timeout for server responses
+	Debug    bool
+}
+
+// WebDAVTarget implements the backup.Target interface for WebDAV servers such
+// as Nextcloud and ownCloud
+type WebDAVTarget struct {
+	config WebDAVTargetConfig
+	client *http.Client
+	logger backup.Logger
+}
+
+// webdavMultistatus is the PROPFIND response of a WebDAV server
+type webdavMultistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+				ContentLength int64  `xml:"getcontentlength"`
+				LastModified  string `xml:"getlastmodified"`
+			} `xml:"prop"`
+			Status string `xml:"status"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+// NewWebDAVTarget creates a new WebDAV target with the given configuration
+func NewWebDAVTarget(config *WebDAVTargetConfig, logger backup.Logger) (*WebDAVTarget, error) {
+	settings := conf.WebDAVBackupSettings{
+		URL:      config.URL,
+		Username: config.Username,
+		Password: config.Password,
+		Token:    config.Token,
+		Path:     config.BasePath,
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, backup.NewError(backup.ErrConfig, "webdav: invalid configuration", err)
+	}
+
+	config.URL = strings.TrimRight(config.URL, "/")
+	config.BasePath = strings.Trim(config.BasePath, "/")
+	if config.Timeout == 0 {
+		config.Timeout = defaultWebDAVTimeout
+	}
+	if logger == nil {
+		logger = backup.DefaultLogger()
+	}
+
+	// Uploads of large backups may take long, the timeout applies to waiting
+	// for responses once a request was sent
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = config.Timeout
+
+	return &WebDAVTarget{
+		config: *config,
+		client: &http.Client{Transport: transport},
+		logger: logger,
+	}, nil
+}
+
+// NewWebDAVTargetFromMap creates a new WebDAV target from a map configuration
+func NewWebDAVTargetFromMap(settings map[string]any) (*WebDAVTarget, error) {
+	decoded, err := conf.BackupTarget{Type: "webdav", Settings: settings}.Decode()
+	if err != nil {
+		return nil, backup.NewError(backup.ErrConfig, "webdav: invalid settings", err)
+	}
+	webdav := decoded.(*conf.WebDAVBackupSettings)
+
+	config := WebDAVTargetConfig{
+		URL:      webdav.URL,
+		Username: webdav.Username,
+		Password: webdav.Password,
+		Token:    webdav.Token,
+		BasePath: webdav.Path,
+	}
+	if timeout, ok := settings["timeout"].(string); ok {
+		duration, err := conf.ParseHumanDuration(timeout)
+		if err != nil {
+			return nil, backup.NewError(backup.ErrValidation, "webdav: invalid timeout format", err)
+		}
+		config.Timeout = duration
+	}
+	if debug, ok := settings["debug"].(bool); ok {
+		config.Debug = debug
+	}
+
+	var logger backup.Logger
+	if l, ok := settings["logger"].(backup.Logger); ok {
+		logger = l
+	}
+
+	return NewWebDAVTarget(&config, logger)
+}
+
+// Name returns the name of this target
+func (t *WebDAVTarget) Name() string {
+	return "webdav"
+}
+
+// fileURL returns the URL of a file in the backup collection, or of the
+// collection itself for an empty name
+func (t *WebDAVTarget) fileURL(name string) string {
+	return t.pathURL(path.Join(t.config.BasePath, name))
+}
+
+// pathURL returns the URL of a slash separated path below the server URL
+func (t *WebDAVTarget) pathURL(p string) string {
+	segments := []string{t.config.URL}
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" {
+			segments = append(segments, url.PathEscape(segment))
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// do sends an authenticated request and returns the response, statuses
+// outside of 2xx and the accepted statuses are errors
+func (t *WebDAVTarget) do(ctx context.Context, method, target string, body io.Reader, headers map[string]string, accepted ...int) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, backup.NewError(backup.ErrIO, "webdav: failed to create request", err)
+	}
+	if sized, ok := body.(sizedBody); ok {
+		req.ContentLength = sized.size
+		if sized.size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	switch {
+	case t.config.Token != "":
+		req.Header.Set("Authorization", "Bearer "+t.config.Token)
+	case t.config.Username != "":
+		req.SetBasicAuth(t.config.Username, t.config.Password)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, backup.NewError(backup.ErrCanceled, fmt.Sprintf("webdav: %s canceled", method), ctx.Err())
+		}
+		return nil, backup.NewError(backup.ErrIO, fmt.Sprintf("webdav: %s request failed", method), err)
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	for _, status := range accepted {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+	_ = resp.Body.Close()
+
+	code := backup.ErrIO
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		code = backup.ErrSecurity
+	case http.StatusNotFound:
+		code = backup.ErrNotFound
+	case http.StatusInsufficientStorage:
+		code = backup.ErrInsufficientSpace
+	}
+	return nil, backup.NewError(code, fmt.Sprintf("webdav: %s %s failed with status %s", method, target, resp.Status), nil)
+}
+
+// request sends a request and closes the response
+func (t *WebDAVTarget) request(ctx context.Context, method, target string, body io.Reader, headers map[string]string, accepted ...int) error {
+	resp, err := t.do(ctx, method, target, body, headers, accepted...)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// ensureCollection creates the backup collection and its parents
+func (t *WebDAVTarget) ensureCollection(ctx context.Context) error {
+	current := ""
+	for _, segment := range strings.Split(t.config.BasePath, "/") {
+		if segment == "" {
+			continue
+		}
+		current = path.Join(current, segment)
+		// 405 Method Not Allowed is returned for existing collections
+		if err := t.request(ctx, "MKCOL", t.pathURL(current), nil, nil, http.StatusMethodNotAllowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upload stores a local file at a name in the backup collection
+func (t *WebDAVTarget) upload(ctx context.Context, localPath, name string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return backup.NewError(backup.ErrIO, "webdav: failed to open file", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			t.logger.Printf("webdav: failed to close file %s: %v", localPath, err)
+		}
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return backup.NewError(backup.ErrIO, "webdav: failed to stat file", err)
+	}
+	body := sizedBody{Reader: backup.ThrottledReader(ctx, file), size: info.Size()}
+	return t.request(ctx, http.MethodPut, t.fileURL(name), body, map[string]string{"Content-Type": "application/octet-stream"})
+}
+
+// sizedBody is a request body of a known size, so that uploads through a
+// throttled reader are not sent with chunked encoding that some servers reject
+type sizedBody struct {
+	io.Reader
+	size int64
+}
+
+// Store implements the backup.Target interface. The backup is uploaded under
+// a temporary name and moved into place, followed by its metadata file.
+func (t *WebDAVTarget) Store(ctx context.Context, sourcePath string, metadata *backup.Metadata) error {
+	name := filepath.Base(sourcePath)
+	if t.config.Debug {
+		t.logger.Printf("🔄 WebDAV: Storing backup %s to %s", name, t.config.URL)
+	}
+
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return backup.NewError(backup.ErrIO, "webdav: failed to marshal metadata", err)
+	}
+
+	if err := t.ensureCollection(ctx); err != nil {
+		return err
+	}
+
+	tempName := webdavTempFilePrefix + name
+	if err := t.upload(ctx, sourcePath, tempName); err != nil {
+		return err
+	}
+	headers := map[string]string{"Destination": t.fileURL(name), "Overwrite": "T"}
+	if err := t.request(ctx, "MOVE", t.fileURL(tempName), nil, headers); err != nil {
+		if deleteErr := t.request(ctx, http.MethodDelete, t.fileURL(tempName), nil, nil, http.StatusNotFound); deleteErr != nil {
+			t.logger.Printf("webdav: failed to remove temporary file %s: %v", tempName, deleteErr)
+		}
+		return err
+	}
+
+	if err := t.request(ctx, http.MethodPut, t.fileURL(name+webdavMetadataFileExt), bytes.NewReader(metadataBytes),
+		map[string]string{"Content-Type": "application/json"}); err != nil {
+		return backup.NewError(backup.ErrIO, "webdav: failed to store metadata", err)
+	}
+
+	if t.config.Debug {
+		t.logger.Printf("✅ WebDAV: Successfully stored backup %s with metadata", name)
+	}
+	return nil
+}
+
+// List implements the backup.Target interface
+func (t *WebDAVTarget) List(ctx context.Context) ([]backup.BackupInfo, error) {
+	if t.config.Debug {
+		t.logger.Printf("🔄 WebDAV: Listing backups from %s", t.config.URL)
+	}
+
+	resp, err := t.do(ctx, "PROPFIND", t.fileURL("")+"/", strings.NewReader(webdavPropfindRequest),
+		map[string]string{"Depth": "1", "Content-Type": "application/xml"})
+	if err != nil {
+		if backup.IsErrorCode(err, backup.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var status webdavMultistatus
+	err = xml.NewDecoder(resp.Body).Decode(&status)
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		t.logger.Printf("webdav: failed to close response body: %v", closeErr)
+	}
+	if err != nil {
+		return nil, backup.NewError(backup.ErrIO, "webdav: invalid PROPFIND response", err)
+	}
+
+	var backups []backup.BackupInfo
+	for i := range status.Responses {
+		response := &status.Responses[i]
+		href, err := url.PathUnescape(response.Href)
+		if err != nil {
+			href = response.Href
+		}
+		name := path.Base(strings.TrimRight(href, "/"))
+		if len(response.Propstat) == 0 || strings.HasSuffix(name, webdavMetadataFileExt) || strings.HasPrefix(name, webdavTempFilePrefix) {
+			continue
+		}
+		prop := response.Propstat[0].Prop
+		if prop.ResourceType.Collection != nil {
+			continue
+		}
+
+		info := backup.BackupInfo{
+			Target: name,
+			Metadata: backup.Metadata{
+				ID:   name,
+				Size: prop.ContentLength,
+			},
+		}
+		if modified, err := http.ParseTime(prop.LastModified); err == nil {
+			info.Timestamp = modified
+		}
+		// Prefer the stored metadata, the listing only has size and modification time
+		if metadata, err := t.readMetadata(ctx, name); err == nil {
+			info.Metadata = *metadata
+			info.ID = name
+		}
+		backups = append(backups, info)
+	}
+	return backups, nil
+}
+
+// readMetadata reads the metadata file of a backup
+func (t *WebDAVTarget) readMetadata(ctx context.Context, name string) (*backup.Metadata, error) {
+	resp, err := t.do(ctx, http.MethodGet, t.fileURL(name+webdavMetadataFileExt), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var metadata backup.Metadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, webdavMaxMetadataBytes)).Decode(&metadata); err != nil {
+		return nil, backup.NewError(backup.ErrCorruption, "webdav: invalid metadata", err)
+	}
+	return &metadata, nil
+}
+
+// Delete implements the backup.Target interface
+func (t *WebDAVTarget) Delete(ctx context.Context, target string) error {
+	if t.config.Debug {
+		t.logger.Printf("🔄 WebDAV: Deleting backup %s from %s", target, t.config.URL)
+	}
+
+	name := path.Base(target)
+	if name != target || name == "." || name == ".." {
+		return backup.NewError(backup.ErrValidation, fmt.Sprintf("webdav: invalid backup name %q", target), nil)
+	}
+	if err := t.request(ctx, http.MethodDelete, t.fileURL(name), nil, nil); err != nil {
+		return err
+	}
+	// The metadata file may not exist for backups stored by other tools
+	if err := t.request(ctx, http.MethodDelete, t.fileURL(name+webdavMetadataFileExt), nil, nil, http.StatusNotFound); err != nil {
+		t.logger.Printf("⚠️ WebDAV: Warning: failed to delete metadata of %s: %v", name, err)
+	}
+
+	if t.config.Debug {
+		t.logger.Printf("✅ WebDAV: Successfully deleted backup %s", name)
+	}
+	return nil
+}
+
+// Validate checks that the server is reachable with the credentials and that
+// the backup collection exists or can be created
+func (t *WebDAVTarget) Validate() error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.config.Timeout)
+	defer cancel()
+
+	if err := t.ensureCollection(ctx); err != nil {
+		return err
+	}
+	return t.request(ctx, "PROPFIND", t.fileURL("")+"/", strings.NewReader(webdavPropfindRequest),
+		map[string]string{"Depth": "0", "Content-Type": "application/xml"})
+}
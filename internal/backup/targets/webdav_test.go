@@ -0,0 +1,84 @@
+package targets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/backup"
+	"golang.org/x/net/webdav"
+)
+
+// newWebDAVServer starts an in-memory WebDAV server that requires a bearer token
+func newWebDAVServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	handler := &webdav.Handler{FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWebDAVTargetStoreListDelete(t *testing.T) {
+	t.Parallel()
+
+	server := newWebDAVServer(t, "secret")
+	target, err := NewWebDAVTargetFromMap(map[string]any{
+		"url":   server.URL + "/",
+		"token": "secret",
+		"path":  "birdnet/backups",
+	})
+	if err != nil {
+		t.Fatalf("NewWebDAVTargetFromMap() error = %v", err)
+	}
+	ctx := context.Background()
+
+	sourcePath := filepath.Join(t.TempDir(), "birdnet-backup.tar.gz")
+	if err := os.WriteFile(sourcePath, []byte("backup data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	timestamp := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := target.Store(ctx, sourcePath, &backup.Metadata{ID: "backup-1", Timestamp: timestamp, Size: 11}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	backups, err := target.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("List() returned %d backups, want 1: %+v", len(backups), backups)
+	}
+	if got := backups[0]; got.ID != "birdnet-backup.tar.gz" || !got.Timestamp.Equal(timestamp) || got.Size != 11 {
+		t.Errorf("List()[0] = %+v, want the stored backup and its metadata", got)
+	}
+
+	if err := target.Delete(ctx, backups[0].ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if backups, err := target.List(ctx); err != nil || len(backups) != 0 {
+		t.Errorf("List() after Delete() = %v, %v, want no backups", backups, err)
+	}
+}
+
+func TestWebDAVTargetUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := newWebDAVServer(t, "secret")
+	target, err := NewWebDAVTargetFromMap(map[string]any{"url": server.URL, "token": "wrong", "path": "backups"})
+	if err != nil {
+		t.Fatalf("NewWebDAVTargetFromMap() error = %v", err)
+	}
+	if err := target.Validate(); !backup.IsSecurityError(err) {
+		t.Errorf("Validate() error = %v, want a security error", err)
+	}
+}
@@ -0,0 +1,79 @@
+package backup
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// bytesPerKB is the size of the kilobytes of bandwidth limits, as in the
+// --bwlimit option of rsync
+const bytesPerKB = 1024
+
+// bandwidthLimitKey is the context key of the upload bandwidth limiter
+type bandwidthLimitKey struct{}
+
+// bandwidthLimit is the limiter shared by the uploads of one backup
+type bandwidthLimit struct {
+	limiter *rate.Limiter
+	kbps    int
+}
+
+// WithBandwidthLimit returns a context that limits the uploads of targets
+// reading through ThrottledReader to kbps kilobytes per second in total. The
+// limit is shared by all uploads using the context, zero or less is
+// unlimited.
+func WithBandwidthLimit(ctx context.Context, kbps int) context.Context {
+	if kbps <= 0 {
+		return ctx
+	}
+	bytesPerSecond := kbps * bytesPerKB
+	return context.WithValue(ctx, bandwidthLimitKey{}, &bandwidthLimit{
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond),
+		kbps:    kbps,
+	})
+}
+
+// BandwidthLimitKBps returns the bandwidth limit of a context in kilobytes
+// per second, zero when uploads are unlimited. Targets uploading with
+// external tools pass it to the tool instead of using ThrottledReader.
+func BandwidthLimitKBps(ctx context.Context) int {
+	if limit, ok := ctx.Value(bandwidthLimitKey{}).(*bandwidthLimit); ok {
+		return limit.kbps
+	}
+	return 0
+}
+
+// ThrottledReader returns a reader that reads from r no faster than the
+// bandwidth limit of ctx, or r itself when uploads are unlimited. Reads wait
+// for the limit and fail when ctx is done.
+func ThrottledReader(ctx context.Context, r io.Reader) io.Reader {
+	limit, ok := ctx.Value(bandwidthLimitKey{}).(*bandwidthLimit)
+	if !ok {
+		return r
+	}
+	return &throttledReader{ctx: ctx, reader: r, limiter: limit.limiter}
+}
+
+// throttledReader is an io.Reader limited by a token bucket of bytes
+type throttledReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+// Read reads at most one burst of the limiter and waits until the limiter
+// allows the bytes read
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if burst := r.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
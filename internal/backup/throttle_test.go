@@ -0,0 +1,66 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThrottledReaderUnlimited(t *testing.T) {
+	t.Parallel()
+
+	r := strings.NewReader("backup")
+	if got := ThrottledReader(context.Background(), r); got != io.Reader(r) {
+		t.Error("ThrottledReader() without a limit did not return the reader itself")
+	}
+	if got := ThrottledReader(WithBandwidthLimit(context.Background(), 0), r); got != io.Reader(r) {
+		t.Error("ThrottledReader() with a zero limit did not return the reader itself")
+	}
+	if got := BandwidthLimitKBps(context.Background()); got != 0 {
+		t.Errorf("BandwidthLimitKBps() without a limit = %d, want 0", got)
+	}
+}
+
+func TestThrottledReaderLimit(t *testing.T) {
+	t.Parallel()
+
+	const kbps = 100
+	ctx := WithBandwidthLimit(context.Background(), kbps)
+	if got := BandwidthLimitKBps(ctx); got != kbps {
+		t.Errorf("BandwidthLimitKBps() = %d, want %d", got, kbps)
+	}
+
+	// The first second of data passes at once, the second one waits for the limit
+	data := bytes.Repeat([]byte("x"), 2*kbps*bytesPerKB)
+	start := time.Now()
+	got, err := io.ReadAll(ThrottledReader(ctx, bytes.NewReader(data)))
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("ReadAll() returned %d bytes, want %d", len(got), len(data))
+	}
+	if elapsed < 800*time.Millisecond {
+		t.Errorf("reading 2 seconds of data took %v, want about 1s", elapsed)
+	}
+}
+
+func TestThrottledReaderCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(WithBandwidthLimit(context.Background(), 1))
+	r := ThrottledReader(ctx, bytes.NewReader(make([]byte, 4*bytesPerKB)))
+
+	// Drain the initial burst, then cancel while waiting for the limit
+	if _, err := io.ReadFull(r, make([]byte, bytesPerKB)); err != nil {
+		t.Fatalf("ReadFull() of the first burst error = %v", err)
+	}
+	cancel()
+	if _, err := io.ReadAll(r); err == nil {
+		t.Error("ReadAll() after cancel error = nil, want the context error")
+	}
+}
@@ -55,6 +55,8 @@ type BirdNET struct {
 	mu                  sync.Mutex
 	resultsBuffer       []datastore.Results // Pre-allocated buffer for results to reduce allocations
 	confidenceBuffer    []float32           // Pre-allocated buffer for confidence values to reduce allocations
+	secondaryNames      map[string]string   // Scientific name to common name in the secondary locale
+	namesMu             sync.RWMutex        // Guards secondaryNames
 }
 
 // NewBirdNET initializes a new BirdNET instance with given settings.
@@ -68,7 +70,7 @@ func NewBirdNET(settings *conf.Settings) (*BirdNET, error) {
 	var modelIdentifier string
 	if settings.BirdNET.ModelPath != "" {
 		// Use custom model path
-		modelIdentifier = settings.BirdNET.ModelPath
+		modelIdentifier = bn.modelPath()
 	} else {
 		// Use default embedded model
 		modelIdentifier = DefaultModelVersion
@@ -122,10 +124,15 @@ func NewBirdNET(settings *conf.Settings) (*BirdNET, error) {
 	}
 
 	// Normalize and validate locale setting.
-	inputLocale := strings.ToLower(settings.BirdNET.Locale)
-	normalizedLocale, err := conf.NormalizeLocale(inputLocale)
-	if err != nil {
-		return nil, err
+	normalizedLocale, matched := conf.NormalizeLocale(settings.BirdNET.Locale)
+	if !matched {
+		return nil, errors.New(fmt.Errorf("BirdNET: locale '%s' not supported, supported locales are %s",
+			settings.BirdNET.Locale, strings.Join(conf.SupportedLocales(), ", "))).
+			Component("birdnet").
+			Category(errors.CategoryValidation).
+			Context("validation_type", "locale-normalization").
+			Context("input_locale", settings.BirdNET.Locale).
+			Build()
 	}
 	settings.BirdNET.Locale = normalizedLocale
 
@@ -173,7 +180,7 @@ func (bn *BirdNET) initializeModel() error {
 	}
 
 	// Determine the number of threads for the interpreter based on settings and system capacity.
-	threads := bn.determineThreadCount(bn.Settings.BirdNET.Threads)
+	threads := bn.determineThreadCount()
 
 	// Configure interpreter options.
 	options := tflite.NewInterpreterOptions()
@@ -242,7 +249,7 @@ func (bn *BirdNET) initializeModel() error {
 
 // getMetaModelData returns the appropriate meta model data based on the settings.
 func (bn *BirdNET) getMetaModelData() []byte {
-	if bn.Settings.BirdNET.RangeFilter.Model == "legacy" {
+	if bn.Settings.BirdNET.RangeFilter.Model == conf.RangeFilterModelLegacy {
 		fmt.Printf("⚠️ Using legacy range filter model")
 		return metaModelDataV1
 	}
@@ -295,40 +302,39 @@ func (bn *BirdNET) initializeMetaModel() error {
 }
 
 // determineThreadCount calculates the appropriate number of threads to use based on settings and system capabilities.
-func (bn *BirdNET) determineThreadCount(configuredThreads int) int {
-	systemCpuCount := runtime.NumCPU()
-
+func (bn *BirdNET) determineThreadCount() int {
 	// If threads are configured to 0, try to get optimal count from cpuspec
-	if configuredThreads == 0 {
+	if bn.Settings.BirdNET.Threads == 0 {
 		spec := cpuspec.GetCPUSpec()
 		optimalThreads := spec.GetOptimalThreadCount()
 		if optimalThreads > 0 {
-			return min(optimalThreads, systemCpuCount)
+			return min(optimalThreads, runtime.NumCPU())
 		}
-
-		// If cpuspec doesn't know the CPU, use all available cores
-		return systemCpuCount
-	}
-
-	// If threads are configured but exceed system CPU count, limit to system CPU count
-	if configuredThreads > systemCpuCount {
-		return systemCpuCount
 	}
 
-	return configuredThreads
+	// Use all available cores when cpuspec doesn't know the CPU, and limit
+	// configured threads to the system CPU count
+	return bn.Settings.BirdNET.EffectiveThreads()
 }
 
 // loadLabels extracts and loads labels from either the embedded files or an external file
 func (bn *BirdNET) loadLabels() error {
 	bn.Settings.BirdNET.Labels = []string{} // Reset labels.
 
-	// Use embedded labels if no external label path is set
+	// Use embedded labels if no external label path is set, otherwise use external labels
+	var err error
 	if bn.Settings.BirdNET.LabelPath == "" {
-		return bn.loadEmbeddedLabels()
+		err = bn.loadEmbeddedLabels()
+	} else {
+		err = bn.loadExternalLabels()
+	}
+	if err != nil {
+		return err
 	}
 
-	// Otherwise use external labels
-	return bn.loadExternalLabels()
+	// Common names in the optional secondary locale
+	bn.loadSecondaryNames()
+	return nil
 }
 
 // loadEmbeddedLabels loads labels from the embedded label files
@@ -410,11 +416,12 @@ func (bn *BirdNET) loadExternalLabels() error {
 		"birdnet-label-loading",
 	)
 
-	file, err := os.Open(bn.Settings.BirdNET.LabelPath)
+	labelPath := bn.labelPath()
+	file, err := os.Open(labelPath)
 	if err != nil {
 		return errors.New(err).
 			Category(errors.CategoryFileIO).
-			Context("label_path", bn.Settings.BirdNET.LabelPath).
+			Context("label_path", labelPath).
 			Context("operation", "open").
 			Timing("label-file-open", time.Since(start)).
 			Build()
@@ -430,7 +437,7 @@ func (bn *BirdNET) loadExternalLabels() error {
 	if err != nil {
 		return errors.New(err).
 			Category(errors.CategoryLabelLoad).
-			Context("label_path", bn.Settings.BirdNET.LabelPath).
+			Context("label_path", labelPath).
 			Context("operation", "parse").
 			Timing("label-file-load", time.Since(start)).
 			Build()
@@ -489,6 +496,18 @@ func (bn *BirdNET) Delete() {
 	}
 }
 
+// modelPath returns the path of the external model file with a relative
+// ModelPath resolved against the config directory, empty for the embedded model
+func (bn *BirdNET) modelPath() string {
+	return bn.Settings.BirdNET.ResolveModelPath(conf.ConfigDir())
+}
+
+// labelPath returns the path of the external label file with a relative
+// LabelPath resolved against the config directory, empty for the embedded labels
+func (bn *BirdNET) labelPath() string {
+	return bn.Settings.BirdNET.ResolveLabelPath(conf.ConfigDir())
+}
+
 // loadModel loads either the embedded model or an external model file
 func (bn *BirdNET) loadModel() ([]byte, error) {
 	start := time.Now()
@@ -497,7 +516,7 @@ func (bn *BirdNET) loadModel() ([]byte, error) {
 		return modelData, nil
 	}
 
-	modelPath := bn.Settings.BirdNET.ModelPath
+	modelPath := bn.modelPath()
 	data, err := os.ReadFile(modelPath)
 	if err != nil {
 		return nil, errors.New(err).
@@ -574,7 +593,7 @@ func (bn *BirdNET) ReloadModel() error {
 	// Re-determine model info if using a custom model path
 	if bn.Settings.BirdNET.ModelPath != "" {
 		var err error
-		bn.ModelInfo, err = DetermineModelInfo(bn.Settings.BirdNET.ModelPath)
+		bn.ModelInfo, err = DetermineModelInfo(bn.modelPath())
 		if err != nil {
 			return fmt.Errorf("\033[31m❌ failed to determine model information: %w\033[0m", err)
 		}
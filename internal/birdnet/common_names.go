@@ -0,0 +1,80 @@
+// common_names.go resolves species common names in the primary and secondary locale
+package birdnet
+
+import (
+	"bufio"
+	"bytes"
+	"log"
+	"strings"
+)
+
+// loadSecondaryNames loads the common names of BirdNET.SecondaryLocale from
+// the embedded label files. Species keep only their primary name when no
+// different secondary locale is set or its labels cannot be loaded.
+func (bn *BirdNET) loadSecondaryNames() {
+	bn.namesMu.Lock()
+	defer bn.namesMu.Unlock()
+	bn.secondaryNames = nil
+
+	secondary := bn.Settings.BirdNET.SecondaryLocale
+	if secondary == "" || secondary == bn.Settings.BirdNET.Locale {
+		return
+	}
+	if bn.Settings.BirdNET.LabelPath != "" {
+		log.Printf("[birdnet] Secondary locale '%s' is ignored with an external label file", secondary)
+		return
+	}
+
+	result := GetLabelFileDataWithResult(bn.ModelInfo.ID, secondary, bn)
+	if result.Error != nil {
+		log.Printf("[birdnet] Failed to load labels of secondary locale '%s': %v", secondary, result.Error)
+		return
+	}
+	if result.FallbackOccurred {
+		// Fallback labels are in a different language than the one asked for
+		log.Printf("[birdnet] Labels of secondary locale '%s' are not available, showing primary names only", secondary)
+		return
+	}
+
+	bn.secondaryNames = parseCommonNames(result.Data)
+	bn.Debug("Loaded %d common names of secondary locale '%s'", len(bn.secondaryNames), secondary)
+}
+
+// parseCommonNames maps the scientific names of label file lines to their common names
+func parseCommonNames(data []byte) map[string]string {
+	names := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		scientific, common := SplitSpeciesName(strings.TrimSpace(scanner.Text()))
+		if scientific != "" && common != "" {
+			names[scientific] = common
+		}
+	}
+	return names
+}
+
+// CommonNames returns the common name of a species label such as
+// "Parus major_Great Tit" in the primary locale and in BirdNET.SecondaryLocale.
+// secondary is empty when no secondary locale is set, the species has no name
+// in it or the name equals the primary one.
+func (bn *BirdNET) CommonNames(label string) (primary, secondary string) {
+	scientific, primary := SplitSpeciesName(label)
+
+	bn.namesMu.RLock()
+	secondary = bn.secondaryNames[scientific]
+	bn.namesMu.RUnlock()
+
+	if strings.EqualFold(secondary, primary) {
+		secondary = ""
+	}
+	return primary, secondary
+}
+
+// FormatCommonNames joins a primary and secondary common name for display,
+// e.g. "Great Tit / talitiainen", or returns the primary name alone
+func FormatCommonNames(primary, secondary string) string {
+	if secondary == "" {
+		return primary
+	}
+	return primary + " / " + secondary
+}
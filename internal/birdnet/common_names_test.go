@@ -0,0 +1,54 @@
+package birdnet
+
+import (
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+func TestCommonNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		secondary     string
+		labelPath     string
+		label         string
+		wantPrimary   string
+		wantSecondary string
+	}{
+		{"secondary locale", "fi", "", "Parus major_Great Tit", "Great Tit", "talitiainen"},
+		{"no secondary locale", "", "", "Parus major_Great Tit", "Great Tit", ""},
+		{"secondary equals primary", "en-uk", "", "Parus major_Great Tit", "Great Tit", ""},
+		{"unknown species", "fi", "", "Avis unknown_Unknown Bird", "Unknown Bird", ""},
+		{"external labels", "fi", "/labels.txt", "Parus major_Great Tit", "Great Tit", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &conf.Settings{}
+			settings.BirdNET.Locale = "en-uk"
+			settings.BirdNET.SecondaryLocale = tt.secondary
+			settings.BirdNET.LabelPath = tt.labelPath
+			bn := &BirdNET{Settings: settings, ModelInfo: ModelInfo{ID: "BirdNET_GLOBAL_6K_V2.4"}}
+			bn.loadSecondaryNames()
+
+			primary, secondary := bn.CommonNames(tt.label)
+			if primary != tt.wantPrimary || secondary != tt.wantSecondary {
+				t.Errorf("CommonNames(%q) = %q, %q, want %q, %q", tt.label, primary, secondary, tt.wantPrimary, tt.wantSecondary)
+			}
+		})
+	}
+}
+
+func TestFormatCommonNames(t *testing.T) {
+	t.Parallel()
+
+	if got := FormatCommonNames("Great Tit", "talitiainen"); got != "Great Tit / talitiainen" {
+		t.Errorf("FormatCommonNames() = %q, want Great Tit / talitiainen", got)
+	}
+	if got := FormatCommonNames("Great Tit", ""); got != "Great Tit" {
+		t.Errorf("FormatCommonNames() = %q, want Great Tit", got)
+	}
+}
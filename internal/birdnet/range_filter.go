@@ -120,7 +120,7 @@ func (bn *BirdNET) GetProbableSpecies(date time.Time, week float32) ([]SpeciesSc
 	for _, filter := range filters {
 		if filter.Score >= bn.Settings.BirdNET.RangeFilter.Threshold {
 			// Check if species is in exclude list before adding
-			if !isSpeciesExcluded(filter.Label, bn.Settings.Realtime.Species.Exclude) {
+			if !isSpeciesExcluded(filter.Label, &bn.Settings.Realtime.Species) {
 				speciesScores = append(speciesScores, SpeciesScore{Score: float64(filter.Score), Label: filter.Label})
 			} else {
 				bn.Debug("Excluding species from range filter: %s", filter.Label)
@@ -158,7 +158,7 @@ func addSpeciesWithMaxScore(bn *BirdNET, speciesScores *[]SpeciesScore, speciesN
 
 	matchFound := false
 	for _, label := range bn.Settings.BirdNET.Labels {
-		if matchesSpecies(label, speciesName) {
+		if matchesSpecies(label, speciesName, &bn.Settings.Realtime.Species) {
 			bn.Debug("Adding species with max score: %s (matched with: %s)", label, speciesName)
 			*speciesScores = append(*speciesScores, SpeciesScore{Score: 1.0, Label: label})
 			matchFound = true
@@ -171,19 +171,15 @@ func addSpeciesWithMaxScore(bn *BirdNET, speciesScores *[]SpeciesScore, speciesN
 }
 
 // isSpeciesExcluded checks if a species should be excluded based on its label
-func isSpeciesExcluded(label string, excludeList []string) bool {
-	for _, excludedSpecies := range excludeList {
-		if matchesSpecies(label, excludedSpecies) {
-			return true
-		}
-	}
-	return false
+func isSpeciesExcluded(label string, species *conf.SpeciesSettings) bool {
+	scientificName, commonName, _ := observation.ParseSpeciesString(label)
+	return species.IsExcluded(commonName, scientificName)
 }
 
 // matchesSpecies checks if a label matches a species name (either common or scientific)
-func matchesSpecies(label, speciesName string) bool {
+func matchesSpecies(label, speciesName string, species *conf.SpeciesSettings) bool {
 	scientificName, commonName, _ := observation.ParseSpeciesString(label)
-	return strings.EqualFold(scientificName, speciesName) || strings.EqualFold(commonName, speciesName)
+	return species.Matches([]string{speciesName}, commonName, scientificName)
 }
 
 // predictFilter applies a TensorFlow Lite model to predict species based on the context.
@@ -83,6 +83,7 @@ type BwClient struct {
 	Latitude      float64
 	Longitude     float64
 	HTTPClient    *http.Client
+	rateLimiter   *TokenBucket // upload rate limiter, nil when unlimited
 }
 
 // maskURL masks sensitive BirdWeatherID tokens in URLs for safe logging
@@ -114,6 +115,7 @@ func New(settings *conf.Settings) (*BwClient, error) {
 		Latitude:      settings.BirdNET.Latitude,
 		Longitude:     settings.BirdNET.Longitude,
 		HTTPClient:    &http.Client{Timeout: 45 * time.Second},
+		rateLimiter:   NewTokenBucket(settings.Realtime.Birdweather.RateLimit),
 	}
 	return client, nil
 }
@@ -587,6 +589,9 @@ func (b *BwClient) UploadSoundscape(timestamp string, pcmData []byte) (soundscap
 		b.BirdweatherID, neturl.QueryEscape(timestamp), audioExt)
 	maskedURL := strings.ReplaceAll(soundscapeURL, b.BirdweatherID, "***")
 	serviceLogger.Debug("Creating soundscape upload request", "url", maskedURL)
+	if err := b.checkRateLimit("soundscape upload"); err != nil {
+		return "", err
+	}
 	req, err := http.NewRequest("POST", soundscapeURL, &gzipAudioData)
 	if err != nil {
 		serviceLogger.Error("Failed to create soundscape POST request", "url", maskedURL, "error", err)
@@ -752,6 +757,9 @@ func (b *BwClient) PostDetection(soundscapeID, timestamp, commonName, scientific
 	}
 
 	// Execute POST request
+	if err := b.checkRateLimit("detection post"); err != nil {
+		return err
+	}
 	serviceLogger.Info("Posting detection", "url", maskedDetectionURL, "soundscape_id", soundscapeID, "scientific_name", scientificName)
 	resp, err := b.HTTPClient.Post(detectionURL, "application/json", bytes.NewBuffer(postDataBytes))
 	if err != nil {
@@ -0,0 +1,124 @@
+// ratelimit.go implements a token bucket used to limit BirdWeather upload requests.
+package birdweather
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// TokenBucket is a simple token bucket rate limiter. Tokens refill continuously
+// at a rate of MaxPerMinute per minute up to the configured burst size.
+// A nil *TokenBucket allows every request.
+type TokenBucket struct {
+	mu         sync.Mutex
+	capacity   float64          // maximum number of tokens in the bucket
+	tokens     float64          // currently available tokens
+	refillRate float64          // tokens added per second
+	lastRefill time.Time        // last time tokens were refilled
+	now        func() time.Time // clock, replaceable for testing
+}
+
+// NewTokenBucket creates a token bucket from rate limit settings. It returns nil
+// when MaxPerMinute is 0, which means uploads are not rate limited.
+func NewTokenBucket(settings conf.RateLimitSettings) *TokenBucket {
+	return newTokenBucket(settings, time.Now)
+}
+
+// newTokenBucket creates a token bucket using the given clock
+func newTokenBucket(settings conf.RateLimitSettings, now func() time.Time) *TokenBucket {
+	if settings.MaxPerMinute <= 0 {
+		return nil
+	}
+
+	burst := settings.BurstSize
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		capacity:   float64(burst),
+		tokens:     float64(burst),
+		refillRate: float64(settings.MaxPerMinute) / 60.0,
+		lastRefill: now(),
+		now:        now,
+	}
+}
+
+// refill adds tokens accumulated since the last refill, caller must hold the lock
+func (tb *TokenBucket) refill() {
+	now := tb.now()
+	elapsed := now.Sub(tb.lastRefill).Seconds()
+	if elapsed > 0 {
+		tb.tokens = min(tb.capacity, tb.tokens+elapsed*tb.refillRate)
+		tb.lastRefill = now
+	}
+}
+
+// Allow consumes a token and reports whether the request may proceed.
+func (tb *TokenBucket) Allow() bool {
+	if tb == nil {
+		return true
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill()
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return true
+	}
+	return false
+}
+
+// Available returns the number of tokens currently available.
+func (tb *TokenBucket) Available() float64 {
+	if tb == nil {
+		return 0
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill()
+	return tb.tokens
+}
+
+// NextTokenIn returns how long until the next token becomes available.
+func (tb *TokenBucket) NextTokenIn() time.Duration {
+	if tb == nil {
+		return 0
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill()
+	if tb.tokens >= 1 {
+		return 0
+	}
+	missing := 1 - tb.tokens
+	return time.Duration(math.Ceil(missing / tb.refillRate * float64(time.Second)))
+}
+
+// checkRateLimit consults the client's rate limiter before a POST request and
+// returns an error when the request would exceed the configured upload rate.
+func (b *BwClient) checkRateLimit(operation string) error {
+	if b.rateLimiter.Allow() {
+		return nil
+	}
+
+	retryIn := b.rateLimiter.NextTokenIn()
+	serviceLogger.Warn("BirdWeather upload rate limit reached", "operation", operation, "retry_in", retryIn)
+	return errors.New(fmt.Errorf("birdweather upload rate limit reached, next request allowed in %s", retryIn.Round(time.Second))).
+		Component("birdweather").
+		Category(errors.CategoryLimit).
+		Context("operation", operation).
+		Context("retry_in_ms", retryIn.Milliseconds()).
+		Build()
+}
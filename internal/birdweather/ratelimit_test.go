@@ -0,0 +1,133 @@
+package birdweather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// fakeClock is a manually advanced clock for deterministic token bucket tests
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestNewTokenBucketUnlimited(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTokenBucket(conf.RateLimitSettings{MaxPerMinute: 0, BurstSize: 5})
+	if tb != nil {
+		t.Fatalf("expected nil token bucket for MaxPerMinute 0, got %+v", tb)
+	}
+
+	// A nil bucket must allow every request
+	for range 100 {
+		if !tb.Allow() {
+			t.Fatal("nil token bucket should always allow requests")
+		}
+	}
+	if d := tb.NextTokenIn(); d != 0 {
+		t.Errorf("nil token bucket NextTokenIn() = %v, want 0", d)
+	}
+}
+
+func TestTokenBucketBurst(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	tb := newTokenBucket(conf.RateLimitSettings{MaxPerMinute: 6, BurstSize: 3}, clock.Now)
+
+	for i := range 3 {
+		if !tb.Allow() {
+			t.Fatalf("request %d within burst should be allowed", i+1)
+		}
+	}
+	if tb.Allow() {
+		t.Fatal("request beyond burst should be denied")
+	}
+}
+
+func TestTokenBucketDefaultBurst(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	tb := newTokenBucket(conf.RateLimitSettings{MaxPerMinute: 60}, clock.Now)
+
+	if !tb.Allow() {
+		t.Fatal("first request should be allowed")
+	}
+	if tb.Allow() {
+		t.Fatal("second immediate request should be denied with default burst of 1")
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		maxPerMinute  int
+		burst         int
+		consume       int
+		advance       time.Duration
+		wantAvailable float64
+	}{
+		{"one token per 10 seconds after 10s", 6, 3, 3, 10 * time.Second, 1},
+		{"half token after 5s", 6, 3, 3, 5 * time.Second, 0.5},
+		{"refill capped at burst", 6, 3, 3, 10 * time.Minute, 3},
+		{"one token per second", 60, 10, 10, 4 * time.Second, 4},
+		{"partial consumption refills to capacity", 120, 5, 2, 30 * time.Second, 5},
+		{"no time elapsed", 30, 2, 1, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clock := &fakeClock{t: time.Unix(0, 0)}
+			tb := newTokenBucket(conf.RateLimitSettings{MaxPerMinute: tt.maxPerMinute, BurstSize: tt.burst}, clock.Now)
+
+			for range tt.consume {
+				if !tb.Allow() {
+					t.Fatal("unexpected denial while consuming initial tokens")
+				}
+			}
+
+			clock.Advance(tt.advance)
+			if got := tb.Available(); math.Abs(got-tt.wantAvailable) > 1e-9 {
+				t.Errorf("Available() = %v, want %v", got, tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestTokenBucketNextTokenIn(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	tb := newTokenBucket(conf.RateLimitSettings{MaxPerMinute: 6, BurstSize: 1}, clock.Now)
+
+	if d := tb.NextTokenIn(); d != 0 {
+		t.Errorf("NextTokenIn() with full bucket = %v, want 0", d)
+	}
+
+	tb.Allow()
+	if d := tb.NextTokenIn(); d != 10*time.Second {
+		t.Errorf("NextTokenIn() after consuming = %v, want 10s", d)
+	}
+
+	clock.Advance(4 * time.Second)
+	if d := tb.NextTokenIn(); d != 6*time.Second {
+		t.Errorf("NextTokenIn() after 4s = %v, want 6s", d)
+	}
+
+	clock.Advance(6 * time.Second)
+	if !tb.Allow() {
+		t.Error("request should be allowed once the token has refilled")
+	}
+}
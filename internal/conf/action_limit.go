@@ -0,0 +1,46 @@
+// conf/action_limit.go concurrency limit of command actions
+package conf
+
+import (
+	"fmt"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Policies for command actions started while MaxConcurrentActions are running
+const (
+	ActionOverflowQueue = "queue" // wait for a running command to finish
+	ActionOverflowDrop  = "drop"  // skip the command
+)
+
+// ActionLimit returns the maximum number of command actions running at once,
+// 0 for unlimited, and the policy for commands over the limit, queue when
+// none is configured
+func (s *Settings) ActionLimit() (maxConcurrent int, overflow string) {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	overflow = s.Realtime.OnActionOverflow
+	if overflow == "" {
+		overflow = ActionOverflowQueue
+	}
+	return max(s.Realtime.MaxConcurrentActions, 0), overflow
+}
+
+// validateActionLimit checks the command action concurrency limit and
+// overflow policy, an empty policy queues
+func validateActionLimit(settings *RealtimeSettings) error {
+	if c := constraintFor("realtime.maxconcurrentactions"); !c.inRange(float64(settings.MaxConcurrentActions)) {
+		return errors.New(fmt.Errorf("realtime maxconcurrentactions must be %s, got %d", c, settings.MaxConcurrentActions)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeMaxConcurrentActions).
+			Build()
+	}
+	if c := constraintFor("realtime.onactionoverflow"); settings.OnActionOverflow != "" && !c.allows(settings.OnActionOverflow) {
+		return errors.New(fmt.Errorf("realtime onactionoverflow must be %s, got %q", c, settings.OnActionOverflow)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeActionOverflow).
+			Build()
+	}
+	return nil
+}
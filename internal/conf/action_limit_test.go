@@ -0,0 +1,47 @@
+package conf
+
+import "testing"
+
+func TestActionLimit(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	if limit, overflow := settings.ActionLimit(); limit != 0 || overflow != ActionOverflowQueue {
+		t.Errorf("ActionLimit() = %d, %q, want 0, %q", limit, overflow, ActionOverflowQueue)
+	}
+
+	settings.Realtime.MaxConcurrentActions = 3
+	settings.Realtime.OnActionOverflow = ActionOverflowDrop
+	if limit, overflow := settings.ActionLimit(); limit != 3 || overflow != ActionOverflowDrop {
+		t.Errorf("ActionLimit() = %d, %q, want 3, %q", limit, overflow, ActionOverflowDrop)
+	}
+}
+
+func TestValidateActionLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		limit    int
+		overflow string
+		wantCode string
+	}{
+		{"unlimited", 0, "", ""},
+		{"queue", 4, ActionOverflowQueue, ""},
+		{"drop", 1, ActionOverflowDrop, ""},
+		{"negative limit", -1, ActionOverflowQueue, ErrCodeMaxConcurrentActions},
+		{"unknown overflow", 2, "block", ErrCodeActionOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateActionLimit(&RealtimeSettings{MaxConcurrentActions: tt.limit, OnActionOverflow: tt.overflow})
+			if (err != nil) != (tt.wantCode != "") {
+				t.Fatalf("validateActionLimit() error = %v, want code %q", err, tt.wantCode)
+			}
+			if err != nil && validationCode(err) != tt.wantCode {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// conf/atomic_write.go atomic file writes for config and sidecar files
+package conf
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// renameFile renames a file, replaced in tests to simulate rename failures
+var renameFile = os.Rename
+
+// AtomicWriteFile writes data to path through a temporary file in the same
+// directory that replaces path once it is completely written, so that a crash
+// never leaves a truncated file behind. The file gets the permissions perm,
+// e.g. 0o600 for files containing secrets. When the rename fails, e.g. with a
+// cross-device link, the temporary file is copied instead.
+func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tempFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "create-temp-file").
+			Context("dir", dir).
+			Build()
+	}
+	tempFileName := tempFile.Name()
+	// Ensure the temporary file is removed in case of any failure
+	defer func() {
+		if err := os.Remove(tempFileName); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to remove temporary file: %v", err)
+		}
+	}()
+
+	// Write and flush the data before the file replaces the original
+	_, err = tempFile.Write(data)
+	if err == nil {
+		err = tempFile.Chmod(perm)
+	}
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if err != nil {
+		// Best effort close on error path
+		_ = tempFile.Close()
+		return errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "write-temp-file").
+			Context("path", tempFileName).
+			Build()
+	}
+	if err := tempFile.Close(); err != nil {
+		return errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "close-temp-file").
+			Context("path", tempFileName).
+			Build()
+	}
+
+	// Renaming is atomic on most filesystems
+	if err := renameFile(tempFileName, path); err != nil {
+		// If rename fails (e.g., cross-device link), fall back to copy & delete
+		if err := moveFile(tempFileName, path); err != nil {
+			return errors.New(err).
+				Category(errors.CategoryFileIO).
+				Context("operation", "move-temp-file").
+				Context("src", tempFileName).
+				Context("dst", path).
+				Build()
+		}
+		// The copy keeps the permissions of an existing destination file
+		if err := os.Chmod(path, perm); err != nil {
+			return errors.New(err).
+				Category(errors.CategoryFileIO).
+				Context("operation", "chmod-file").
+				Context("path", path).
+				Build()
+		}
+	}
+
+	return nil
+}
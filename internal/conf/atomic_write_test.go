@@ -0,0 +1,86 @@
+package conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// checkAtomicWrite verifies the content and permissions of a written file and
+// that no temporary files are left in its directory
+func checkAtomicWrite(t *testing.T, path, want string, perm os.FileMode) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != want {
+		t.Errorf("content = %q, want %q", data, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if got := info.Mode().Perm(); got != perm {
+		t.Errorf("permissions = %o, want %o", got, perm)
+	}
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), ".*.tmp"))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(matches) > 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+}
+
+func TestAtomicWriteFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AtomicWriteFile(path, []byte("new"), 0o600); err != nil {
+		t.Fatalf("AtomicWriteFile() error = %v", err)
+	}
+	checkAtomicWrite(t, path, "new", 0o600)
+}
+
+func TestAtomicWriteFileRenameFallback(t *testing.T) {
+	// Not parallel, the test replaces the package level renameFile
+	original := renameFile
+	t.Cleanup(func() { renameFile = original })
+	renames := 0
+	renameFile = func(oldpath, newpath string) error {
+		renames++
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: errors.New("invalid cross-device link")}
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AtomicWriteFile(path, []byte("secret: value"), 0o600); err != nil {
+		t.Fatalf("AtomicWriteFile() error = %v", err)
+	}
+	if renames == 0 {
+		t.Error("rename was not attempted")
+	}
+	checkAtomicWrite(t, path, "secret: value", 0o600)
+}
+
+func TestAtomicWriteFileMissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing", "config.yaml")
+	if err := AtomicWriteFile(path, []byte("data"), 0o600); err == nil {
+		t.Fatal("AtomicWriteFile() error = nil, want error for missing directory")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file exists after failed write, Stat() error = %v", err)
+	}
+}
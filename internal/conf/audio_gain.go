@@ -0,0 +1,23 @@
+// conf/audio_gain.go sound card input gain
+package conf
+
+import "math"
+
+// Limits of the sound card input gain in dB. Amplifying the input raises the
+// noise floor along with bird calls, so excessive gain increases false
+// positives and clips loud sounds.
+const (
+	MinAudioGain = -20.0
+	MaxAudioGain = 40.0
+)
+
+// DBToLinear converts a gain in dB to a linear amplitude factor
+func DBToLinear(db float64) float64 {
+	return math.Pow(10, db/20)
+}
+
+// GainFactor returns the linear amplitude factor of the configured input
+// gain, 1 when no gain is set
+func (a *AudioSettings) GainFactor() float64 {
+	return DBToLinear(a.Gain)
+}
@@ -0,0 +1,56 @@
+package conf
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDBToLinear(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		db   float64
+		want float64
+	}{
+		{0, 1},
+		{20, 10},
+		{40, 100},
+		{-20, 0.1},
+		{6, 1.9953},
+		{-6, 0.5012},
+	}
+
+	for _, tt := range tests {
+		if got := DBToLinear(tt.db); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("DBToLinear(%v) = %v, want %v", tt.db, got, tt.want)
+		}
+	}
+}
+
+func TestGainFactor(t *testing.T) {
+	t.Parallel()
+
+	audio := &AudioSettings{}
+	if got := audio.GainFactor(); got != 1 {
+		t.Errorf("GainFactor() without gain = %v, want 1", got)
+	}
+	audio.Gain = 20
+	if got := audio.GainFactor(); math.Abs(got-10) > 1e-9 {
+		t.Errorf("GainFactor() with 20 dB = %v, want 10", got)
+	}
+}
+
+func TestValidateAudioGain(t *testing.T) {
+	t.Parallel()
+
+	for _, gain := range []float64{MinAudioGain, 0, 12.5, MaxAudioGain} {
+		if err := validateAudioSettings(&AudioSettings{Gain: gain}); err != nil {
+			t.Errorf("validateAudioSettings() with gain %v error = %v", gain, err)
+		}
+	}
+	for _, gain := range []float64{MinAudioGain - 1, MaxAudioGain + 0.5} {
+		if err := validateAudioSettings(&AudioSettings{Gain: gain}); err == nil {
+			t.Errorf("validateAudioSettings() with gain %v error = nil, want error", gain)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// conf/audio_tools.go detection of ffmpeg and sox and the audio formats they support
+package conf
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// exportEncoders maps audio export types to the ffmpeg encoders used to
+// create them, matching the encoders of the myaudio package
+var exportEncoders = map[string]string{
+	"flac": "flac",
+	"opus": "libopus",
+	"aac":  "aac",
+	"mp3":  "libmp3lame",
+}
+
+var (
+	// runToolCommand runs an audio tool and returns its combined output,
+	// replaced in tests
+	runToolCommand = func(path string, args ...string) ([]byte, error) {
+		return exec.Command(path, args...).CombinedOutput()
+	}
+
+	// audioToolProbes caches the probe results of tool paths so that
+	// repeated validation does not execute the tools again
+	audioToolProbes      = make(map[string][]string)
+	audioToolProbesMutex sync.Mutex
+)
+
+// ProbeAudioTools detects ffmpeg and sox, at the configured paths or in the
+// system PATH, and stores their paths, the audio types sox reads and the
+// export types ffmpeg can encode in the audio settings. A missing tool is not
+// an error, its path is cleared. The results are cached per tool path, use
+// RefreshAudioTools to probe the tools again.
+func ProbeAudioTools(settings *Settings) error {
+	return probeAudioTools(&settings.Realtime.Audio)
+}
+
+// RefreshAudioTools clears the cached probe results and probes the audio
+// tools again, e.g. after ffmpeg or sox was installed
+func RefreshAudioTools(settings *Settings) error {
+	audioToolProbesMutex.Lock()
+	clear(audioToolProbes)
+	audioToolProbesMutex.Unlock()
+
+	return probeAudioTools(&settings.Realtime.Audio)
+}
+
+// probeAudioTools detects the audio tools and their formats for the audio settings
+func probeAudioTools(settings *AudioSettings) error {
+	var probeErrs []error
+
+	// Validate and determine the effective FFmpeg path, WAV is exported without
+	// ffmpeg and export types stay unknown when the encoders cannot be listed
+	settings.ExportTypes = []string{"wav"}
+	validatedFfmpegPath, ffmpegErr := ValidateToolPath(settings.FfmpegPath, GetFfmpegBinaryName())
+	if ffmpegErr != nil {
+		log.Printf("FFmpeg validation failed: %v. Audio export/conversion requiring FFmpeg might be disabled or use defaults.", ffmpegErr)
+		// Log validation warning for telemetry
+		logValidationWarning(ffmpegErr, ErrCodeFFmpeg, "ffmpeg-not-available")
+		settings.FfmpegPath = "" // Ensure path is empty if validation failed
+	} else {
+		settings.FfmpegPath = validatedFfmpegPath // Store the validated path (explicit or from PATH)
+		encoders, err := cachedToolProbe(validatedFfmpegPath, probeFfmpegEncoders)
+		if err != nil {
+			probeErrs = append(probeErrs, err)
+			settings.ExportTypes = nil
+		}
+		for _, exportType := range slices.Sorted(maps.Keys(exportEncoders)) {
+			if slices.Contains(encoders, exportEncoders[exportType]) {
+				settings.ExportTypes = append(settings.ExportTypes, exportType)
+			}
+		}
+	}
+
+	// Validate and determine the effective SoX path
+	soxPath, soxErr := ValidateToolPath(settings.SoxPath, GetSoxBinaryName())
+	if soxErr != nil {
+		settings.SoxPath = ""
+		settings.SoxAudioTypes = nil
+		log.Println("SoX not found in system PATH. Audio source processing requiring SoX might be disabled.")
+	} else {
+		settings.SoxPath = soxPath
+		formats, err := cachedToolProbe(soxPath, probeSoxFormats)
+		if err != nil {
+			probeErrs = append(probeErrs, err)
+		}
+		settings.SoxAudioTypes = formats
+	}
+
+	if len(probeErrs) > 0 {
+		return errors.New(errors.Join(probeErrs...)).
+			Category(errors.CategorySystem).
+			Context("operation", "probe-audio-tools").
+			Build()
+	}
+	return nil
+}
+
+// cachedToolProbe returns the cached probe result of a tool path, probing the
+// tool when it has not been probed yet. Failed probes are not cached.
+func cachedToolProbe(path string, probe func(path string) ([]string, error)) ([]string, error) {
+	audioToolProbesMutex.Lock()
+	defer audioToolProbesMutex.Unlock()
+
+	if result, ok := audioToolProbes[path]; ok {
+		return result, nil
+	}
+	result, err := probe(path)
+	if err != nil {
+		return nil, err
+	}
+	audioToolProbes[path] = result
+	return result, nil
+}
+
+// probeFfmpegEncoders returns the names of the audio encoders of ffmpeg
+func probeFfmpegEncoders(ffmpegPath string) ([]string, error) {
+	output, err := runToolCommand(ffmpegPath, "-hide_banner", "-encoders")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list encoders of ffmpeg at %s: %w", ffmpegPath, err)
+	}
+
+	// Encoder lines look like " A....D libmp3lame  libmp3lame MP3 (MPEG audio layer 3)",
+	// the first flag is A for audio encoders
+	var encoders []string
+	for line := range strings.Lines(string(output)) {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && len(fields[0]) == 6 && fields[0][0] == 'A' {
+			encoders = append(encoders, fields[1])
+		}
+	}
+	return encoders, nil
+}
+
+// probeSoxFormats returns the audio file formats sox reads
+func probeSoxFormats(soxPath string) ([]string, error) {
+	// Execute SoX with the help flag to get its output
+	output, err := runToolCommand(soxPath, "-h")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list formats of sox at %s: %w", soxPath, err)
+	}
+
+	// Iterate through the lines to find the supported audio formats
+	for line := range strings.Lines(string(output)) {
+		if formats, found := strings.CutPrefix(line, "AUDIO FILE FORMATS:"); found {
+			return strings.Fields(formats), nil
+		}
+	}
+	return nil, nil
+}
+
+// validateExportTools returns a warning when audio export is enabled with an
+// export type that the detected ffmpeg cannot encode
+func validateExportTools(audio *AudioSettings, settings *Settings) string {
+	export := audio.Export
+	if !export.Enabled || audio.FfmpegPath == "" || audio.ExportTypes == nil || slices.Contains(audio.ExportTypes, export.Type) {
+		return ""
+	}
+
+	message := fmt.Sprintf("audio export type %s is not supported by ffmpeg at %s, supported types are %s",
+		export.Type, audio.FfmpegPath, strings.Join(audio.ExportTypes, ", "))
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeExportType, "export-type-unsupported")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-audio-validation: %s", message))
+	return message
+}
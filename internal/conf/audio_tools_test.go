@@ -0,0 +1,156 @@
+package conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const fakeFfmpegEncoders = `Encoders:
+ V..... = Video
+ A..... = Audio
+ ------
+ V....D libx264              libx264 H.264 / AVC / MPEG-4 AVC
+ A....D aac                  AAC (Advanced Audio Coding)
+ A....D flac                 FLAC (Free Lossless Audio Codec)
+ A....D libmp3lame           libmp3lame MP3 (MPEG audio layer 3)
+`
+
+const fakeSoxHelp = `SoX v14.4.2
+AUDIO FILE FORMATS: aiff flac mp3 wav
+PLAYLIST FORMATS: m3u pls
+`
+
+// fakeAudioTools replaces the audio tool commands with fake output, creates
+// fake tool binaries and returns their paths and a count of tool executions
+func fakeAudioTools(t *testing.T, ffmpegFails bool) (ffmpegPath, soxPath string, runs *int) {
+	t.Helper()
+
+	original := runToolCommand
+	t.Cleanup(func() {
+		runToolCommand = original
+		audioToolProbesMutex.Lock()
+		clear(audioToolProbes)
+		audioToolProbesMutex.Unlock()
+	})
+
+	dir := t.TempDir()
+	ffmpegPath = filepath.Join(dir, "ffmpeg")
+	soxPath = filepath.Join(dir, "sox")
+	for _, path := range []string{ffmpegPath, soxPath} {
+		if err := os.WriteFile(path, nil, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs = new(int)
+	runToolCommand = func(path string, args ...string) ([]byte, error) {
+		*runs++
+		switch {
+		case path == ffmpegPath && ffmpegFails:
+			return nil, errors.New("exit status 1")
+		case path == ffmpegPath:
+			return []byte(fakeFfmpegEncoders), nil
+		case path == soxPath:
+			return []byte(fakeSoxHelp), nil
+		}
+		t.Fatalf("unexpected tool command %s %s", path, strings.Join(args, " "))
+		return nil, nil
+	}
+	return ffmpegPath, soxPath, runs
+}
+
+// TestProbeAudioTools replaces package level tool commands and cannot run in parallel
+func TestProbeAudioTools(t *testing.T) {
+	ffmpegPath, soxPath, runs := fakeAudioTools(t, false)
+
+	settings := &Settings{}
+	settings.Realtime.Audio.FfmpegPath = ffmpegPath
+	settings.Realtime.Audio.SoxPath = soxPath
+	if err := ProbeAudioTools(settings); err != nil {
+		t.Fatalf("ProbeAudioTools() error = %v", err)
+	}
+
+	audio := settings.Realtime.Audio
+	if audio.FfmpegPath != ffmpegPath || audio.SoxPath != soxPath {
+		t.Errorf("tool paths = %q, %q, want %q, %q", audio.FfmpegPath, audio.SoxPath, ffmpegPath, soxPath)
+	}
+	if want := []string{"wav", "aac", "flac", "mp3"}; !slices.Equal(audio.ExportTypes, want) {
+		t.Errorf("ExportTypes = %v, want %v", audio.ExportTypes, want)
+	}
+	if want := []string{"aiff", "flac", "mp3", "wav"}; !slices.Equal(audio.SoxAudioTypes, want) {
+		t.Errorf("SoxAudioTypes = %v, want %v", audio.SoxAudioTypes, want)
+	}
+
+	// Probe results are cached
+	if err := ProbeAudioTools(settings); err != nil {
+		t.Fatalf("ProbeAudioTools() error = %v", err)
+	}
+	if *runs != 2 {
+		t.Errorf("tools were run %d times, want 2 with cached probes", *runs)
+	}
+	if err := RefreshAudioTools(settings); err != nil {
+		t.Fatalf("RefreshAudioTools() error = %v", err)
+	}
+	if *runs != 4 {
+		t.Errorf("tools were run %d times, want 4 after refresh", *runs)
+	}
+}
+
+// TestProbeAudioToolsFfmpegFailure replaces package level tool commands and cannot run in parallel
+func TestProbeAudioToolsFfmpegFailure(t *testing.T) {
+	ffmpegPath, soxPath, _ := fakeAudioTools(t, true)
+
+	settings := &Settings{}
+	settings.Realtime.Audio.FfmpegPath = ffmpegPath
+	settings.Realtime.Audio.SoxPath = soxPath
+	if err := ProbeAudioTools(settings); err == nil {
+		t.Error("ProbeAudioTools() error = nil, want error for failing ffmpeg")
+	}
+	if settings.Realtime.Audio.ExportTypes != nil {
+		t.Errorf("ExportTypes = %v, want nil for unknown encoders", settings.Realtime.Audio.ExportTypes)
+	}
+	if len(settings.Realtime.Audio.SoxAudioTypes) == 0 {
+		t.Error("SoxAudioTypes is empty, want sox formats despite the ffmpeg failure")
+	}
+}
+
+func TestValidateExportTools(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		exportType  string
+		exportTypes []string
+		ffmpegPath  string
+		wantWarning bool
+	}{
+		{"supported type", "mp3", []string{"wav", "mp3"}, "/usr/bin/ffmpeg", false},
+		{"unsupported type", "opus", []string{"wav", "mp3"}, "/usr/bin/ffmpeg", true},
+		{"unknown encoders", "opus", nil, "/usr/bin/ffmpeg", false},
+		{"no ffmpeg", "opus", []string{"wav"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			audio := &settings.Realtime.Audio
+			audio.FfmpegPath = tt.ffmpegPath
+			audio.ExportTypes = tt.exportTypes
+			audio.Export.Enabled = true
+			audio.Export.Type = tt.exportType
+
+			warning := validateExportTools(audio, settings)
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("validateExportTools() = %q, want warning %v", warning, tt.wantWarning)
+			}
+			if tt.wantWarning && (len(settings.ValidationWarnings) != 1 || !strings.HasPrefix(settings.ValidationWarnings[0], "config-audio-validation: ")) {
+				t.Errorf("ValidationWarnings = %v, want one config-audio-validation warning", settings.ValidationWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// conf/auth_durations.go bounds and effective values of authentication durations
+package conf
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Defaults and upper bounds of the authentication durations
+const (
+	DefaultAuthCodeExp     = 10 * time.Minute
+	MaxAuthCodeExp         = 10 * time.Minute
+	DefaultAccessTokenExp  = time.Hour
+	MaxAccessTokenExp      = 24 * time.Hour
+	DefaultSessionDuration = 7 * Day
+	MaxSessionDuration     = 30 * Day
+)
+
+// EffectiveAuthCodeExp returns the authorization code lifetime used by the
+// OAuth2 server, the default when unset and at most MaxAuthCodeExp
+func (b *BasicAuth) EffectiveAuthCodeExp() time.Duration {
+	return effectiveDuration(b.AuthCodeExp, DefaultAuthCodeExp, MaxAuthCodeExp)
+}
+
+// EffectiveAccessTokenExp returns the access token lifetime used by the
+// OAuth2 server, the default when unset and at most MaxAccessTokenExp
+func (b *BasicAuth) EffectiveAccessTokenExp() time.Duration {
+	return effectiveDuration(b.AccessTokenExp, DefaultAccessTokenExp, MaxAccessTokenExp)
+}
+
+// EffectiveSessionDuration returns the login session lifetime used by the
+// session stores, the default when unset and at most MaxSessionDuration
+func (s *Security) EffectiveSessionDuration() time.Duration {
+	return effectiveDuration(s.SessionDuration, DefaultSessionDuration, MaxSessionDuration)
+}
+
+// effectiveDuration returns defaultValue for a zero or negative d and limits d to maxValue
+func effectiveDuration(d, defaultValue, maxValue time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultValue
+	}
+	return min(d, maxValue)
+}
+
+// validateAuthDurations checks the authentication durations when authentication
+// is enabled. Unset token lifetimes use their defaults, negative ones are
+// errors and durations above their maximum are clamped with a warning.
+func validateAuthDurations(settings *Settings) error {
+	security := &settings.Security
+	if !security.BasicAuth.Enabled && !security.GoogleAuth.Enabled && !security.GithubAuth.Enabled {
+		return nil
+	}
+
+	type authDuration struct {
+		key          string
+		value        *time.Duration
+		defaultValue time.Duration
+		maxValue     time.Duration
+	}
+	durations := []authDuration{
+		{"security.sessionduration", &security.SessionDuration, DefaultSessionDuration, MaxSessionDuration},
+	}
+	if security.BasicAuth.Enabled {
+		durations = append(durations,
+			authDuration{"security.basicauth.authcodeexp", &security.BasicAuth.AuthCodeExp, DefaultAuthCodeExp, MaxAuthCodeExp},
+			authDuration{"security.basicauth.accesstokenexp", &security.BasicAuth.AccessTokenExp, DefaultAccessTokenExp, MaxAccessTokenExp},
+		)
+	}
+
+	for _, d := range durations {
+		switch {
+		case *d.value < 0:
+			return errors.New(fmt.Errorf("%s must be a positive duration, got %s", d.key, *d.value)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeAuthDuration).
+				Context("setting", d.key).
+				Build()
+		case *d.value == 0:
+			*d.value = d.defaultValue
+		case *d.value > d.maxValue:
+			message := fmt.Sprintf("%s %s exceeds the maximum, using %s", d.key, *d.value, d.maxValue)
+			log.Printf("Configuration warning: %s", message)
+			logValidationWarning(fmt.Errorf("%s", message), ErrCodeAuthDuration, "duration-clamped")
+			settings.ValidationWarnings = append(settings.ValidationWarnings,
+				fmt.Sprintf("config-security-validation: %s", message))
+			*d.value = d.maxValue
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEffectiveAuthDurations(t *testing.T) {
+	t.Parallel()
+
+	security := Security{
+		SessionDuration: 90 * Day,
+		BasicAuth:       BasicAuth{AccessTokenExp: 2 * time.Hour},
+	}
+	if got := security.EffectiveSessionDuration(); got != MaxSessionDuration {
+		t.Errorf("EffectiveSessionDuration() = %v, want %v", got, MaxSessionDuration)
+	}
+	if got := security.BasicAuth.EffectiveAuthCodeExp(); got != DefaultAuthCodeExp {
+		t.Errorf("EffectiveAuthCodeExp() = %v, want %v", got, DefaultAuthCodeExp)
+	}
+	if got := security.BasicAuth.EffectiveAccessTokenExp(); got != 2*time.Hour {
+		t.Errorf("EffectiveAccessTokenExp() = %v, want 2h", got)
+	}
+}
+
+func TestValidateAuthDurations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		security     Security
+		wantErr      bool
+		wantWarnings int
+		want         Security
+	}{
+		{
+			name:     "auth disabled is not checked",
+			security: Security{SessionDuration: 90 * Day, BasicAuth: BasicAuth{AuthCodeExp: -time.Minute}},
+			want:     Security{SessionDuration: 90 * Day, BasicAuth: BasicAuth{AuthCodeExp: -time.Minute}},
+		},
+		{
+			name:     "unset token lifetimes use defaults",
+			security: Security{SessionDuration: Day, BasicAuth: BasicAuth{Enabled: true}},
+			want:     Security{SessionDuration: Day, BasicAuth: BasicAuth{Enabled: true, AuthCodeExp: DefaultAuthCodeExp, AccessTokenExp: DefaultAccessTokenExp}},
+		},
+		{
+			name:         "too long durations are clamped",
+			security:     Security{SessionDuration: 90 * Day, BasicAuth: BasicAuth{Enabled: true, AuthCodeExp: time.Hour, AccessTokenExp: 48 * time.Hour}},
+			wantWarnings: 3,
+			want:         Security{SessionDuration: MaxSessionDuration, BasicAuth: BasicAuth{Enabled: true, AuthCodeExp: MaxAuthCodeExp, AccessTokenExp: MaxAccessTokenExp}},
+		},
+		{
+			name:         "oauth provider only clamps the session",
+			security:     Security{SessionDuration: 90 * Day, GoogleAuth: SocialProvider{Enabled: true}, BasicAuth: BasicAuth{AuthCodeExp: time.Hour}},
+			wantWarnings: 1,
+			want:         Security{SessionDuration: MaxSessionDuration, GoogleAuth: SocialProvider{Enabled: true}, BasicAuth: BasicAuth{AuthCodeExp: time.Hour}},
+		},
+		{
+			name:     "negative token lifetime",
+			security: Security{SessionDuration: Day, BasicAuth: BasicAuth{Enabled: true, AccessTokenExp: -time.Hour}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{Security: tt.security}
+			err := validateAuthDurations(settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateAuthDurations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(settings.ValidationWarnings) != tt.wantWarnings {
+				t.Errorf("got %d warnings %v, want %d", len(settings.ValidationWarnings), settings.ValidationWarnings, tt.wantWarnings)
+			}
+			got := settings.Security
+			if got.SessionDuration != tt.want.SessionDuration ||
+				got.BasicAuth.AuthCodeExp != tt.want.BasicAuth.AuthCodeExp ||
+				got.BasicAuth.AccessTokenExp != tt.want.BasicAuth.AccessTokenExp {
+				t.Errorf("durations = %v, %v, %v, want %v, %v, %v",
+					got.SessionDuration, got.BasicAuth.AuthCodeExp, got.BasicAuth.AccessTokenExp,
+					tt.want.SessionDuration, tt.want.BasicAuth.AuthCodeExp, tt.want.BasicAuth.AccessTokenExp)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// conf/autocreate.go creation of a default config file when none exists
+package conf
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// NoAutoCreateEnv is the environment variable that disables creating a
+// default config file when none is found, e.g. on read-only container
+// filesystems. It accepts the values of strconv.ParseBool.
+const NoAutoCreateEnv = "BIRDNET_GO_NO_AUTOCREATE"
+
+// autoCreateDisabled is set by SetAutoCreate(false)
+var autoCreateDisabled atomic.Bool
+
+// SetAutoCreate sets whether Load creates a default config file when no
+// config file is found, which is the default. When disabled, a missing
+// config file is an error. NoAutoCreateEnv disables creation as well.
+func SetAutoCreate(enabled bool) {
+	autoCreateDisabled.Store(!enabled)
+}
+
+// autoCreateEnabled reports whether a default config file may be created
+func autoCreateEnabled() bool {
+	if autoCreateDisabled.Load() {
+		return false
+	}
+	disabled, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(NoAutoCreateEnv)))
+	return err != nil || !disabled
+}
+
+// missingConfigError returns the error of a missing config file when
+// creating a default one is disabled
+func missingConfigError(configPaths []string) error {
+	return errors.New(fmt.Errorf("no config.yaml found in %s and creating a default config is disabled, mount a config file into one of these directories or set %s to its directory",
+		strings.Join(configPaths, ", "), ConfigDirEnv)).
+		Category(errors.CategoryConfiguration).
+		Context("operation", "find-config-file").
+		Build()
+}
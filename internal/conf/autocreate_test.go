@@ -0,0 +1,74 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// Not parallel, the tests set environment variables and load settings through
+// the global viper instance
+
+// isolateConfigSearch points the config search at an empty directory and
+// returns it
+func isolateConfigSearch(t *testing.T) string {
+	t.Helper()
+
+	configDir := t.TempDir()
+	t.Setenv(ConfigDirEnv, configDir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(NoAutoCreateEnv, "")
+	t.Cleanup(viper.Reset)
+	return configDir
+}
+
+func TestSetAutoCreateDisabled(t *testing.T) {
+	configDir := isolateConfigSearch(t)
+	SetAutoCreate(false)
+	t.Cleanup(func() { SetAutoCreate(true) })
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("Load() without a config file error = nil, want an error")
+	}
+	if !strings.Contains(err.Error(), configDir) || !strings.Contains(err.Error(), "disabled") {
+		t.Errorf("Load() error = %v, want the searched directories and that creation is disabled", err)
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "config.yaml")); !os.IsNotExist(err) {
+		t.Errorf("Load() created a config file, stat error = %v", err)
+	}
+}
+
+func TestNoAutoCreateEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", true},
+		{"false", true},
+		{"0", true},
+		{"invalid", true},
+		{"true", false},
+		{"1", false},
+		{" TRUE ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(NoAutoCreateEnv, tt.value)
+			if got := autoCreateEnabled(); got != tt.want {
+				t.Errorf("autoCreateEnabled() with %s=%q = %v, want %v", NoAutoCreateEnv, tt.value, got, tt.want)
+			}
+		})
+	}
+
+	SetAutoCreate(false)
+	t.Cleanup(func() { SetAutoCreate(true) })
+	t.Setenv(NoAutoCreateEnv, "false")
+	if autoCreateEnabled() {
+		t.Error("autoCreateEnabled() after SetAutoCreate(false) = true, want false")
+	}
+}
@@ -0,0 +1,122 @@
+// conf/backup_compression.go compression algorithm and level of backup archives
+package conf
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Backup archive compression algorithms
+const (
+	BackupCompressionGzip = "gzip"
+	BackupCompressionZstd = "zstd"
+	BackupCompressionNone = "none"
+)
+
+// Compression levels of zstd, as accepted by the zstd command line tool
+const (
+	zstdMinLevel = 1
+	zstdMaxLevel = 22
+	// zstdSlowLevel is the lowest level selecting the best and slowest
+	// compression, see zstd.EncoderLevelFromZstd
+	zstdSlowLevel = 10
+)
+
+// CompressionAlgorithm returns the configured compression algorithm in lower
+// case, gzip when it is unset
+func (c BackupConfig) CompressionAlgorithm() string {
+	algorithm := strings.ToLower(strings.TrimSpace(c.Compression))
+	if algorithm == "" {
+		return BackupCompressionGzip
+	}
+	return algorithm
+}
+
+// ValidateCompression validates the compression algorithm and the level for
+// the algorithm. Level 0 selects the default level of the algorithm.
+func (c BackupConfig) ValidateCompression() error {
+	switch c.CompressionAlgorithm() {
+	case BackupCompressionGzip:
+		if c.CompressionLevel < 0 || c.CompressionLevel > gzip.BestCompression {
+			return fmt.Errorf("gzip compression level must be between %d and %d, got %d",
+				gzip.BestSpeed, gzip.BestCompression, c.CompressionLevel)
+		}
+	case BackupCompressionNone:
+		if c.CompressionLevel != 0 {
+			return fmt.Errorf("compression level must be 0 when compression is none, got %d", c.CompressionLevel)
+		}
+	case BackupCompressionZstd:
+		if c.CompressionLevel < 0 || c.CompressionLevel > zstdMaxLevel {
+			return fmt.Errorf("zstd compression level must be between %d and %d, got %d",
+				zstdMinLevel, zstdMaxLevel, c.CompressionLevel)
+		}
+	default:
+		return fmt.Errorf("compression %q is invalid, use gzip, zstd or none", c.Compression)
+	}
+	return nil
+}
+
+// CompressionWarning returns a warning for valid but extreme compression
+// levels, an empty string when there is nothing to warn about
+func (c BackupConfig) CompressionWarning() string {
+	switch algorithm := c.CompressionAlgorithm(); {
+	case algorithm == BackupCompressionZstd && c.CompressionLevel >= zstdSlowLevel && c.CompressionLevel <= zstdMaxLevel:
+		return fmt.Sprintf("zstd compression level %d selects the best compression, which is slow on low-power devices, levels 1-9 are much faster", c.CompressionLevel)
+	case algorithm != BackupCompressionGzip:
+		return ""
+	case c.CompressionLevel == gzip.BestSpeed:
+		return "gzip compression level 1 is the fastest but produces the largest backup archives"
+	case c.CompressionLevel == gzip.BestCompression:
+		return "gzip compression level 9 is slow on low-power devices and saves little space over the default level"
+	}
+	return ""
+}
+
+// ArchiveExtension returns the file extension of backup archives written with
+// the configured compression: ".tar.gz" for gzip, ".tar.zst" for zstd and
+// ".tar" for none
+func (c BackupConfig) ArchiveExtension() string {
+	switch c.CompressionAlgorithm() {
+	case BackupCompressionGzip:
+		return ".tar.gz"
+	case BackupCompressionZstd:
+		return ".tar.zst"
+	default:
+		return ".tar"
+	}
+}
+
+// Compressor returns a writer that compresses the archive written to it into
+// w with the configured algorithm and level. Closing the writer flushes the
+// compressed stream but does not close w.
+func (c BackupConfig) Compressor(w io.Writer) (io.WriteCloser, error) {
+	if err := c.ValidateCompression(); err != nil {
+		return nil, err
+	}
+	switch c.CompressionAlgorithm() {
+	case BackupCompressionNone:
+		return nopWriteCloser{w}, nil
+	case BackupCompressionZstd:
+		level := zstd.SpeedDefault
+		if c.CompressionLevel != 0 {
+			level = zstd.EncoderLevelFromZstd(c.CompressionLevel)
+		}
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(level))
+	}
+	level := c.CompressionLevel
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	return gzip.NewWriterLevel(w, level)
+}
+
+// nopWriteCloser adds a no-op Close to an io.Writer
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,122 @@
+package conf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestBackupConfigValidateCompression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		config      BackupConfig
+		wantErr     bool
+		wantWarning bool
+	}{
+		{"defaults", BackupConfig{}, false, false},
+		{"gzip level 6", BackupConfig{Compression: "gzip", CompressionLevel: 6}, false, false},
+		{"upper case", BackupConfig{Compression: "GZIP"}, false, false},
+		{"gzip level 1", BackupConfig{Compression: "gzip", CompressionLevel: 1}, false, true},
+		{"gzip level 9", BackupConfig{CompressionLevel: 9}, false, true},
+		{"gzip level 10", BackupConfig{Compression: "gzip", CompressionLevel: 10}, true, false},
+		{"negative level", BackupConfig{CompressionLevel: -1}, true, false},
+		{"none", BackupConfig{Compression: "none"}, false, false},
+		{"none with level", BackupConfig{Compression: "none", CompressionLevel: 3}, true, false},
+		{"zstd", BackupConfig{Compression: "zstd"}, false, false},
+		{"zstd level 3", BackupConfig{Compression: "zstd", CompressionLevel: 3}, false, false},
+		{"zstd level 19", BackupConfig{Compression: "zstd", CompressionLevel: 19}, false, true},
+		{"zstd level 23", BackupConfig{Compression: "zstd", CompressionLevel: 23}, true, false},
+		{"unknown", BackupConfig{Compression: "bzip2"}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.config.ValidateCompression()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCompression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if warning := tt.config.CompressionWarning(); (warning != "") != tt.wantWarning {
+				t.Errorf("CompressionWarning() = %q, wantWarning %v", warning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestBackupConfigCompressor(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("BirdNET-Go backup archive content")
+
+	var compressed bytes.Buffer
+	w, err := BackupConfig{CompressionLevel: 9}.Compressor(&compressed)
+	if err != nil {
+		t.Fatalf("Compressor() error = %v", err)
+	}
+	if _, err := w.Write(content); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	r, err := gzip.NewReader(&compressed)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("decompressed archive = %q, %v, want %q", got, err, content)
+	}
+
+	var plain bytes.Buffer
+	w, err = BackupConfig{Compression: "none"}.Compressor(&plain)
+	if err != nil {
+		t.Fatalf("Compressor() with none error = %v", err)
+	}
+	if _, err := w.Write(content); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !bytes.Equal(plain.Bytes(), content) {
+		t.Errorf("uncompressed archive = %q, want %q", plain.Bytes(), content)
+	}
+
+	var zstdCompressed bytes.Buffer
+	w, err = BackupConfig{Compression: "zstd", CompressionLevel: 3}.Compressor(&zstdCompressed)
+	if err != nil {
+		t.Fatalf("Compressor() with zstd error = %v", err)
+	}
+	if _, err := w.Write(content); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	zr, err := zstd.NewReader(&zstdCompressed)
+	if err != nil {
+		t.Fatalf("zstd.NewReader() error = %v", err)
+	}
+	defer zr.Close()
+	if got, err := io.ReadAll(zr); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("decompressed zstd archive = %q, %v, want %q", got, err, content)
+	}
+}
+
+func TestBackupConfigArchiveExtension(t *testing.T) {
+	t.Parallel()
+
+	if got := (BackupConfig{}).ArchiveExtension(); got != ".tar.gz" {
+		t.Errorf("ArchiveExtension() = %q, want .tar.gz", got)
+	}
+	if got := (BackupConfig{Compression: "zstd"}).ArchiveExtension(); got != ".tar.zst" {
+		t.Errorf("ArchiveExtension() with zstd = %q, want .tar.zst", got)
+	}
+	if got := (BackupConfig{Compression: "none"}).ArchiveExtension(); got != ".tar" {
+		t.Errorf("ArchiveExtension() with none = %q, want .tar", got)
+	}
+}
@@ -0,0 +1,57 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestBackupConfigLoadsSnakeCaseKeys(t *testing.T) {
+	t.Parallel()
+
+	settings, err := LoadFromReader(strings.NewReader(`
+backup:
+  include_effective_config: true
+  sanitize_config: true
+  encryption_key: c2VjcmV0
+  compression_level: 6
+  max_concurrent_uploads: 2
+  bandwidth_limit_kbps: 512
+`))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	if !settings.Backup.SanitizeConfig || settings.Backup.EncryptionKey != "c2VjcmV0" {
+		t.Errorf("SanitizeConfig = %v, EncryptionKey = %q, want sanitize_config and encryption_key of the config",
+			settings.Backup.SanitizeConfig, settings.Backup.EncryptionKey)
+	}
+	if !settings.Backup.IncludeEffectiveConfig {
+		t.Error("IncludeEffectiveConfig = false, want include_effective_config of the config")
+	}
+	if settings.Backup.CompressionLevel != 6 {
+		t.Errorf("CompressionLevel = %d, want compression_level of the config", settings.Backup.CompressionLevel)
+	}
+	if settings.Backup.MaxConcurrentUploads != 2 || settings.Backup.BandwidthLimitKBps != 512 {
+		t.Errorf("MaxConcurrentUploads = %d, BandwidthLimitKBps = %d, want the upload limits of the config",
+			settings.Backup.MaxConcurrentUploads, settings.Backup.BandwidthLimitKBps)
+	}
+
+	// Settings must survive a save and reload
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("LoadFromReader(saved) error = %v", err)
+	}
+	if reloaded.Backup.SanitizeConfig != settings.Backup.SanitizeConfig ||
+		reloaded.Backup.EncryptionKey != settings.Backup.EncryptionKey ||
+		reloaded.Backup.IncludeEffectiveConfig != settings.Backup.IncludeEffectiveConfig ||
+		reloaded.Backup.CompressionLevel != settings.Backup.CompressionLevel ||
+		reloaded.Backup.MaxConcurrentUploads != settings.Backup.MaxConcurrentUploads ||
+		reloaded.Backup.BandwidthLimitKBps != settings.Backup.BandwidthLimitKBps {
+		t.Errorf("reloaded backup settings = %+v, want %+v", reloaded.Backup, settings.Backup)
+	}
+}
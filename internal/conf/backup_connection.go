@@ -0,0 +1,72 @@
+// conf/backup_connection.go connection tests of configured backup targets
+package conf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// BackupTargetTester tests that a backup target of one type can be reached
+// and written with its settings
+type BackupTargetTester func(ctx context.Context, target BackupTarget) error
+
+var (
+	backupTargetTesters      = make(map[string]BackupTargetTester)
+	backupTargetTestersMutex sync.RWMutex
+)
+
+// RegisterBackupTargetTester sets the connection test of a backup target type
+// such as "ftp", so that the backup targets package can implement the tests
+// without the conf package importing it. Registering a type again replaces
+// its tester, a nil tester removes it.
+func RegisterBackupTargetTester(targetType string, fn BackupTargetTester) {
+	backupTargetTestersMutex.Lock()
+	defer backupTargetTestersMutex.Unlock()
+
+	targetType = strings.ToLower(targetType)
+	if fn == nil {
+		delete(backupTargetTesters, targetType)
+		return
+	}
+	backupTargetTesters[targetType] = fn
+}
+
+// TestConnection checks that backups can be stored on the target with its
+// current settings. Remote targets store, list and delete a small marker
+// backup, local targets check that the backup directory is writable. Types
+// without a registered tester return an error.
+func (t BackupTarget) TestConnection(ctx context.Context) error {
+	backupTargetTestersMutex.RLock()
+	tester, ok := backupTargetTesters[strings.ToLower(t.Type)]
+	backupTargetTestersMutex.RUnlock()
+
+	if !ok {
+		return errors.New(fmt.Errorf("connection test is not supported for backup target type %q", t.Type)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "test-backup-target").
+			Context("target_type", t.Type).
+			Build()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return tester(ctx, t)
+}
+
+// TestAll tests the connection of every enabled backup target and returns the
+// result by target index, nil for targets that passed. Disabled targets are
+// skipped.
+func (c BackupConfig) TestAll(ctx context.Context) map[int]error {
+	results := make(map[int]error)
+	for i, target := range c.Targets {
+		if !target.Enabled {
+			continue
+		}
+		results[i] = target.TestConnection(ctx)
+	}
+	return results
+}
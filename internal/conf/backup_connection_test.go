@@ -0,0 +1,67 @@
+package conf
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// Not parallel, the tests change the registered backup target testers
+
+func TestBackupTargetTestConnection(t *testing.T) {
+	var tested []string
+	RegisterBackupTargetTester("Probe", func(ctx context.Context, target BackupTarget) error {
+		tested = append(tested, target.Settings["path"].(string))
+		if target.Settings["path"] == "bad" {
+			return fmt.Errorf("permission denied")
+		}
+		return nil
+	})
+	t.Cleanup(func() { RegisterBackupTargetTester("probe", nil) })
+
+	if err := (BackupTarget{Type: "PROBE", Settings: map[string]any{"path": "good"}}).TestConnection(context.Background()); err != nil {
+		t.Errorf("TestConnection() error = %v, want nil", err)
+	}
+	if err := (BackupTarget{Type: "unknown"}).TestConnection(context.Background()); err == nil {
+		t.Error("TestConnection() of an unknown type error = nil, want an error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := (BackupTarget{Type: "probe", Settings: map[string]any{"path": "good"}}).TestConnection(ctx); err == nil {
+		t.Error("TestConnection() with a cancelled context error = nil, want an error")
+	}
+	if len(tested) != 1 {
+		t.Errorf("tester ran %d times, want 1", len(tested))
+	}
+}
+
+func TestBackupConfigTestAll(t *testing.T) {
+	RegisterBackupTargetTester("probe", func(ctx context.Context, target BackupTarget) error {
+		if target.Settings["path"] == "bad" {
+			return fmt.Errorf("permission denied")
+		}
+		return nil
+	})
+	t.Cleanup(func() { RegisterBackupTargetTester("probe", nil) })
+
+	config := BackupConfig{Targets: []BackupTarget{
+		{Type: "probe", Enabled: true, Settings: map[string]any{"path": "good"}},
+		{Type: "probe", Enabled: false, Settings: map[string]any{"path": "bad"}},
+		{Type: "probe", Enabled: true, Settings: map[string]any{"path": "bad"}},
+	}}
+	results := config.TestAll(context.Background())
+
+	if len(results) != 2 {
+		t.Fatalf("TestAll() returned %d results, want 2 for the enabled targets", len(results))
+	}
+	if err := results[0]; err != nil {
+		t.Errorf("TestAll()[0] = %v, want nil", err)
+	}
+	if _, ok := results[1]; ok {
+		t.Error("TestAll() tested the disabled target 1")
+	}
+	if results[2] == nil {
+		t.Error("TestAll()[2] = nil, want the tester error")
+	}
+}
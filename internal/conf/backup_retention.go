@@ -0,0 +1,75 @@
+// conf/backup_retention.go backup retention policies and per-target overrides
+package conf
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// RetentionFor returns the retention policy of a backup target, its own
+// policy when set, otherwise the global policy. A target policy replaces the
+// global policy as a whole, unset fields of it are not inherited.
+func (c BackupConfig) RetentionFor(t BackupTarget) BackupRetention {
+	if t.Retention != nil {
+		return *t.Retention
+	}
+	return c.Retention
+}
+
+// MaxAgeDuration returns MaxAge as a duration, zero when MaxAge is empty.
+// Months and years are approximated as 30 and 365 days, other values are
+// parsed with ParseHumanDuration, e.g. "7d" or "4w".
+func (r BackupRetention) MaxAgeDuration() (time.Duration, error) {
+	age := r.MaxAge
+	if age == "" {
+		return 0, nil
+	}
+
+	// Months and years are retention specific, "m" means minutes in other durations
+	if unit := age[len(age)-1]; unit == 'm' || unit == 'y' {
+		if num, err := strconv.Atoi(age[:len(age)-1]); err == nil {
+			days := num * 30
+			if unit == 'y' {
+				days = num * 365
+			}
+			return time.Duration(days) * Day, nil
+		}
+	}
+
+	return ParseHumanDuration(age)
+}
+
+// Validate validates the backup retention policy
+func (r BackupRetention) Validate() error {
+	maxAge, err := r.MaxAgeDuration()
+	if err != nil {
+		return errors.New(fmt.Errorf("invalid retention maxage %q, use a value such as 7d, 4w, 6m or 1y: %w", r.MaxAge, err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupRetentionMaxAge).
+			Build()
+	}
+	if maxAge < 0 {
+		return errors.New(fmt.Errorf("retention maxage must not be negative, got %q", r.MaxAge)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupRetentionMaxAge).
+			Build()
+	}
+
+	if r.MaxBackups < 0 || r.MinBackups < 0 {
+		return errors.New(fmt.Errorf("retention maxbackups and minbackups must not be negative, got %d and %d", r.MaxBackups, r.MinBackups)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupRetentionCount).
+			Build()
+	}
+	if r.MaxBackups > 0 && r.MinBackups > r.MaxBackups {
+		return errors.New(fmt.Errorf("retention minbackups (%d) must not exceed maxbackups (%d)", r.MinBackups, r.MaxBackups)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupRetentionCount).
+			Build()
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package conf
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestRetentionFor(t *testing.T) {
+	t.Parallel()
+
+	config := BackupConfig{Retention: BackupRetention{MaxAge: "30d", MaxBackups: 30, MinBackups: 7}}
+	local := BackupRetention{MaxAge: "7d", MinBackups: 3}
+
+	tests := []struct {
+		name   string
+		target BackupTarget
+		want   BackupRetention
+	}{
+		{"global policy", BackupTarget{Type: "s3"}, config.Retention},
+		{"target override", BackupTarget{Type: "local", Retention: &local}, local},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := config.RetentionFor(tt.target); got != tt.want {
+				t.Errorf("RetentionFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetentionForFromConfig(t *testing.T) {
+	t.Parallel()
+
+	settings, err := LoadFromReader(strings.NewReader(`
+backup:
+  enabled: true
+  retention:
+    maxage: 90d
+    minbackups: 5
+  targets:
+    - type: s3
+      enabled: true
+      settings:
+        bucket: birdnet-backups
+        region: eu-north-1
+    - type: local
+      enabled: true
+      settings:
+        path: /backups
+      retention:
+        maxage: 7d
+        maxbackups: 10
+`))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+
+	if got := settings.Backup.RetentionFor(settings.Backup.Targets[0]); got.MaxAge != "90d" || got.MinBackups != 5 {
+		t.Errorf("s3 retention = %+v, want the global policy", got)
+	}
+	want := BackupRetention{MaxAge: "7d", MaxBackups: 10}
+	if got := settings.Backup.RetentionFor(settings.Backup.Targets[1]); got != want {
+		t.Errorf("local retention = %+v, want %+v", got, want)
+	}
+}
+
+func TestBackupRetentionMaxAgeDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		maxAge  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"7d", 7 * Day, false},
+		{"4w", 4 * Week, false},
+		{"6m", 180 * Day, false},
+		{"1y", 365 * Day, false},
+		{"36h", 36 * time.Hour, false},
+		{"bogus", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.maxAge, func(t *testing.T) {
+			t.Parallel()
+			got, err := BackupRetention{MaxAge: tt.maxAge}.MaxAgeDuration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaxAgeDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MaxAgeDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackupRetentionValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		retention BackupRetention
+		errType   string
+	}{
+		{"empty policy", BackupRetention{}, ""},
+		{"valid policy", BackupRetention{MaxAge: "30d", MaxBackups: 10, MinBackups: 3}, ""},
+		{"invalid max age", BackupRetention{MaxAge: "thirty days"}, "backup-retention-max-age"},
+		{"negative max age", BackupRetention{MaxAge: "-1d"}, "backup-retention-max-age"},
+		{"negative count", BackupRetention{MaxBackups: -1}, "backup-retention-count"},
+		{"min exceeds max", BackupRetention{MaxBackups: 2, MinBackups: 5}, "backup-retention-count"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.retention.Validate()
+			if (err != nil) != (tt.errType != "") {
+				t.Fatalf("Validate() error = %v, want error %v", err, tt.errType != "")
+			}
+			if err == nil {
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError type, got %T", err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("expected validation_type = %s, got %v", tt.errType, ctx)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsTargetRetention(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadFromReader(strings.NewReader(`
+backup:
+  enabled: true
+  targets:
+    - type: local
+      enabled: true
+      retention:
+        maxbackups: 1
+        minbackups: 2
+`))
+	if err == nil || !strings.Contains(err.Error(), "backup target 0 (local) retention") {
+		t.Errorf("LoadFromReader() error = %v, want target retention error", err)
+	}
+}
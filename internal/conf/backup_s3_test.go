@@ -0,0 +1,52 @@
+package conf
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestS3BackupSettingsValidateUploadOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings S3BackupSettings
+		wantErr  bool
+	}{
+		{"defaults", S3BackupSettings{}, false},
+		{"AES256", S3BackupSettings{ServerSideEncryption: "AES256"}, false},
+		{"KMS with key", S3BackupSettings{ServerSideEncryption: "aws:kms", KMSKeyID: "alias/backups"}, false},
+		{"glacier", S3BackupSettings{StorageClass: "GLACIER"}, false},
+		{"unknown encryption", S3BackupSettings{ServerSideEncryption: "aes256"}, true},
+		{"KMS key without KMS encryption", S3BackupSettings{ServerSideEncryption: "AES256", KMSKeyID: "alias/backups"}, true},
+		{"unknown storage class", S3BackupSettings{StorageClass: "COLD"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := tt.settings
+			settings.Bucket, settings.Region = "birdnet", "eu-north-1"
+			if err := settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestS3BackupSettingsUploadHeaders(t *testing.T) {
+	t.Parallel()
+
+	if headers := (&S3BackupSettings{}).UploadHeaders(); len(headers) != 0 {
+		t.Errorf("UploadHeaders() without options = %v, want none", headers)
+	}
+
+	settings := S3BackupSettings{ServerSideEncryption: "aws:kms", KMSKeyID: "alias/backups", StorageClass: "DEEP_ARCHIVE"}
+	want := map[string]string{
+		"x-amz-server-side-encryption":                "aws:kms",
+		"x-amz-server-side-encryption-aws-kms-key-id": "alias/backups",
+		"x-amz-storage-class":                         "DEEP_ARCHIVE",
+	}
+	if got := settings.UploadHeaders(); !maps.Equal(got, want) {
+		t.Errorf("UploadHeaders() = %v, want %v", got, want)
+	}
+}
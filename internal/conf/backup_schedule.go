@@ -0,0 +1,72 @@
+// conf/backup_schedule.go backup schedule validation and next run calculation
+package conf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Validate checks the schedule time and, for weekly schedules, the weekday.
+// Weekly schedules without a weekday run on Sundays like the backup scheduler
+// does.
+func (s BackupScheduleConfig) Validate() error {
+	if s.Hour < 0 || s.Hour > 23 {
+		return errors.New(fmt.Errorf("backup schedule hour must be between 0 and 23, got %d", s.Hour)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupScheduleHour).
+			Context("hour", s.Hour).
+			Build()
+	}
+	if s.Minute < 0 || s.Minute > 59 {
+		return errors.New(fmt.Errorf("backup schedule minute must be between 0 and 59, got %d", s.Minute)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupScheduleMinute).
+			Context("minute", s.Minute).
+			Build()
+	}
+	if s.IsWeekly && s.Weekday != "" {
+		if _, err := ParseWeekday(s.Weekday); err != nil {
+			return errors.New(fmt.Errorf("weekly backup schedule has an invalid weekday: %w", err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeBackupScheduleWeekday).
+				Context("weekday", s.Weekday).
+				Build()
+		}
+	}
+	return nil
+}
+
+// NextRun returns the first scheduled instant at or after from, evaluated in
+// loc or in the location of from when loc is nil. Run times are wall clock
+// times, so a schedule keeps its hour across DST changes. A wall clock time
+// skipped by a DST change runs at the corresponding time after the change.
+// Weekly schedules with an unparseable weekday run on Sundays, like the
+// backup scheduler does for weekly schedules without a weekday.
+func (s BackupScheduleConfig) NextRun(from time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = from.Location()
+	}
+	local := from.In(loc)
+
+	days, step := 0, 1
+	if s.IsWeekly {
+		weekday, err := ParseWeekday(s.Weekday)
+		if err != nil {
+			weekday = time.Sunday
+		}
+		days = (int(weekday) - int(local.Weekday()) + 7) % 7
+		step = 7
+	}
+
+	at := func(days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, s.Hour, s.Minute, 0, 0, loc)
+	}
+
+	next := at(days)
+	if from.After(next) {
+		next = at(days + step)
+	}
+	return next
+}
@@ -0,0 +1,122 @@
+package conf
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestParseWeekday(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{"Monday", time.Monday, false},
+		{"saturday", time.Saturday, false},
+		{"0", time.Sunday, false},
+		{"6", time.Saturday, false},
+		{"7", time.Sunday, true},
+		{"-1", time.Sunday, true},
+		{"Mon", time.Sunday, true},
+		{"", time.Sunday, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseWeekday(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWeekday(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseWeekday(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackupScheduleConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule BackupScheduleConfig
+		errType  string
+	}{
+		{"daily", BackupScheduleConfig{Hour: 23, Minute: 59}, ""},
+		{"weekly", BackupScheduleConfig{Hour: 0, Minute: 0, Weekday: "Sunday", IsWeekly: true}, ""},
+		{"daily ignores weekday", BackupScheduleConfig{Hour: 3, Weekday: "someday"}, ""},
+		{"hour too large", BackupScheduleConfig{Hour: 24}, "backup-schedule-hour"},
+		{"negative hour", BackupScheduleConfig{Hour: -1}, "backup-schedule-hour"},
+		{"minute too large", BackupScheduleConfig{Minute: 60}, "backup-schedule-minute"},
+		{"weekly invalid weekday", BackupScheduleConfig{Weekday: "Funday", IsWeekly: true}, "backup-schedule-weekday"},
+		{"weekly without weekday runs on Sunday", BackupScheduleConfig{IsWeekly: true}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.schedule.Validate()
+			if (err != nil) != (tt.errType != "") {
+				t.Fatalf("Validate() error = %v, want error type %q", err, tt.errType)
+			}
+			if err == nil {
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError type, got %T", err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("expected validation_type = %s, got %v", tt.errType, ctx)
+			}
+		})
+	}
+}
+
+func TestBackupScheduleConfigNextRun(t *testing.T) {
+	t.Parallel()
+
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	// Wednesday 2024-03-27 10:00 UTC
+	from := time.Date(2024, 3, 27, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule BackupScheduleConfig
+		from     time.Time
+		loc      *time.Location
+		want     time.Time
+	}{
+		{"daily later today", BackupScheduleConfig{Hour: 12}, from, nil, time.Date(2024, 3, 27, 12, 0, 0, 0, time.UTC)},
+		{"daily passed today", BackupScheduleConfig{Hour: 9, Minute: 30}, from, nil, time.Date(2024, 3, 28, 9, 30, 0, 0, time.UTC)},
+		{"daily at from", BackupScheduleConfig{Hour: 10}, from, nil, from},
+		{"weekly later this week", BackupScheduleConfig{Hour: 1, Weekday: "Friday", IsWeekly: true}, from, nil, time.Date(2024, 3, 29, 1, 0, 0, 0, time.UTC)},
+		{"weekly wraps to next week", BackupScheduleConfig{Hour: 8, Weekday: "Monday", IsWeekly: true}, from, nil, time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)},
+		{"weekly passed today", BackupScheduleConfig{Hour: 9, Weekday: "Wednesday", IsWeekly: true}, from, nil, time.Date(2024, 4, 3, 9, 0, 0, 0, time.UTC)},
+		{"weekly without weekday", BackupScheduleConfig{Hour: 4, IsWeekly: true}, from, nil, time.Date(2024, 3, 31, 4, 0, 0, 0, time.UTC)},
+		{"weekly across month and year", BackupScheduleConfig{Hour: 2, Weekday: "3", IsWeekly: true}, time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), nil, time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)},
+		{"evaluated in location", BackupScheduleConfig{Hour: 11}, from, helsinki, time.Date(2024, 3, 28, 11, 0, 0, 0, helsinki)},
+		// Clocks in Helsinki moved from 03:00 to 04:00 on 2024-03-31
+		{"keeps wall clock across DST", BackupScheduleConfig{Hour: 2, Weekday: "Monday", IsWeekly: true}, time.Date(2024, 3, 30, 12, 0, 0, 0, helsinki), nil, time.Date(2024, 4, 1, 2, 0, 0, 0, helsinki)},
+		{"skipped wall clock time", BackupScheduleConfig{Hour: 3, Minute: 30}, time.Date(2024, 3, 31, 1, 0, 0, 0, helsinki), nil, time.Date(2024, 3, 31, 4, 30, 0, 0, helsinki)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.schedule.NextRun(tt.from, tt.loc); !got.Equal(tt.want) {
+				t.Errorf("NextRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
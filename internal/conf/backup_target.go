@@ -0,0 +1,77 @@
+// conf/backup_target.go typed settings of backup targets
+package conf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// azureAccountNamePattern matches Azure storage account names
+	azureAccountNamePattern = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+	// azureContainerPattern matches Azure blob container names
+	azureContainerPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,61}[a-z0-9]$`)
+)
+
+// Decode returns the settings map of the backup target as the typed settings
+// of its type, e.g. *FTPBackupSettings for "ftp". Settings that are not in the
+// config keep their defaults. The settings are not validated, call Validate
+// on the result.
+func (t BackupTarget) Decode() (BackupTargetSettings, error) {
+	var settings BackupTargetSettings
+	switch strings.ToLower(t.Type) {
+	case "local":
+		settings = &LocalBackupSettings{}
+	case "ftp":
+		settings = &FTPBackupSettings{Passive: true}
+	case "sftp":
+		settings = &SFTPBackupSettings{}
+	case "s3":
+		settings = &S3BackupSettings{UseSSL: true}
+	case "rsync":
+		settings = &RsyncBackupSettings{}
+	case "gdrive":
+		settings = &GoogleDriveBackupSettings{}
+	case "webdav":
+		settings = &WebDAVBackupSettings{}
+	case "azure":
+		settings = &AzureBlobBackupSettings{}
+	default:
+		return nil, errors.New(fmt.Errorf("unknown backup target type %q", t.Type)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupTargetType).
+			Build()
+	}
+
+	data, err := yaml.Marshal(t.Settings)
+	if err == nil {
+		err = yaml.Unmarshal(data, settings)
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Errorf("invalid settings of backup target type %s: %w", t.Type, err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupTargetSettings).
+			Build()
+	}
+	return settings, nil
+}
+
+// validateBackupTarget checks the settings of a backup target with the
+// Validate method of its typed settings
+func validateBackupTarget(target BackupTarget) error {
+	settings, err := target.Decode()
+	if err != nil {
+		return err
+	}
+	if err := settings.Validate(); err != nil {
+		return errors.New(err).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBackupTargetSettings).
+			Build()
+	}
+	return nil
+}
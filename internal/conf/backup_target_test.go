@@ -0,0 +1,163 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBackupTargetDecode(t *testing.T) {
+	t.Parallel()
+
+	settings, err := BackupTarget{Type: "FTP", Settings: map[string]any{
+		"host":   "nas.local",
+		"port":   2121,
+		"usetls": true,
+	}}.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	ftp, ok := settings.(*FTPBackupSettings)
+	if !ok {
+		t.Fatalf("Decode() = %T, want *FTPBackupSettings", settings)
+	}
+	if ftp.Host != "nas.local" || ftp.Port != 2121 || !ftp.UseTLS || !ftp.Passive {
+		t.Errorf("Decode() = %+v, want the map values and passive mode by default", ftp)
+	}
+
+	settings, err = BackupTarget{Type: "webdav", Settings: map[string]any{"url": "https://cloud.example.com/remote.php/dav/files/birdnet"}}.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Validate() of decoded WebDAV settings error = %v", err)
+	}
+
+	if _, err := (BackupTarget{Type: "tape"}).Decode(); err == nil {
+		t.Error("Decode() of an unknown type error = nil, want an error")
+	}
+	if _, err := (BackupTarget{Type: "ftp", Settings: map[string]any{"port": "twenty-one"}}).Decode(); err == nil {
+		t.Error("Decode() of an invalid port error = nil, want an error")
+	}
+}
+
+func TestWebDAVBackupSettingsValidate(t *testing.T) {
+	t.Parallel()
+
+	const nextcloud = "https://cloud.example.com/remote.php/dav/files/birdnet"
+	tests := []struct {
+		name     string
+		settings WebDAVBackupSettings
+		wantErr  bool
+	}{
+		{"basic auth", WebDAVBackupSettings{URL: nextcloud, Username: "birdnet", Password: "app-password"}, false},
+		{"bearer token", WebDAVBackupSettings{URL: nextcloud, Token: "token"}, false},
+		{"anonymous", WebDAVBackupSettings{URL: "http://nas.local/webdav"}, false},
+		{"missing URL", WebDAVBackupSettings{}, true},
+		{"not http", WebDAVBackupSettings{URL: "ftp://nas.local"}, true},
+		{"token and username", WebDAVBackupSettings{URL: nextcloud, Username: "birdnet", Token: "token"}, true},
+		{"password without username", WebDAVBackupSettings{URL: nextcloud, Password: "app-password"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAzureBlobBackupSettingsValidate(t *testing.T) {
+	t.Parallel()
+
+	const key = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+	tests := []struct {
+		name     string
+		settings AzureBlobBackupSettings
+		wantErr  bool
+	}{
+		{"shared key", AzureBlobBackupSettings{AccountName: "birdnet", AccountKey: key, Container: "backups"}, false},
+		{"SAS token", AzureBlobBackupSettings{AccountName: "birdnet", SASToken: "sv=2021-08-06&sig=abc", Container: "backups"}, false},
+		{"Azurite", AzureBlobBackupSettings{AccountName: "devstoreaccount1", AccountKey: key, Container: "backups", Endpoint: "http://127.0.0.1:10000/devstoreaccount1"}, false},
+		{"no credentials", AzureBlobBackupSettings{AccountName: "birdnet", Container: "backups"}, true},
+		{"key and SAS token", AzureBlobBackupSettings{AccountName: "birdnet", AccountKey: key, SASToken: "sig=abc", Container: "backups"}, true},
+		{"key not base64", AzureBlobBackupSettings{AccountName: "birdnet", AccountKey: "not base64!", Container: "backups"}, true},
+		{"uppercase account", AzureBlobBackupSettings{AccountName: "BirdNET", AccountKey: key, Container: "backups"}, true},
+		{"invalid container", AzureBlobBackupSettings{AccountName: "birdnet", AccountKey: key, Container: "my--backups"}, true},
+		{"invalid endpoint", AzureBlobBackupSettings{AccountName: "birdnet", AccountKey: key, Container: "backups", Endpoint: "127.0.0.1:10000"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackupTargetDecodeSFTP(t *testing.T) {
+	t.Parallel()
+
+	const fingerprint = "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"
+	settings, err := BackupTarget{Type: "sftp", Settings: map[string]any{
+		"host":                 "nas.local",
+		"username":             "birdnet",
+		"key_file":             "/etc/birdnet/id_ed25519",
+		"known_hosts_file":     "/etc/ssh/ssh_known_hosts",
+		"host_key_fingerprint": fingerprint,
+	}}.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	sftp := settings.(*SFTPBackupSettings)
+	if sftp.PrivateKeyPath != "/etc/birdnet/id_ed25519" || sftp.KnownHostsPath != "/etc/ssh/ssh_known_hosts" || sftp.HostKeyFingerprint != fingerprint {
+		t.Errorf("Decode() = %+v, want the key file, known hosts file and fingerprint of the map", sftp)
+	}
+}
+
+func TestValidateSettingsBackupTargets(t *testing.T) {
+	t.Parallel()
+
+	load := func(target string) error {
+		_, err := LoadFromReader(strings.NewReader("backup:\n  enabled: true\n  targets:\n" + target))
+		return err
+	}
+	if err := load("    - type: sftp\n      enabled: true\n      settings:\n        host: nas.local\n        username: birdnet\n        host_key_fingerprint: SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8\n"); err != nil {
+		t.Errorf("LoadFromReader() of a valid SFTP target error = %v", err)
+	}
+	if err := load("    - type: sftp\n      enabled: true\n      settings:\n        host: nas.local\n        username: birdnet\n        host_key_fingerprint: nThbg6kX\n"); err == nil {
+		t.Error("LoadFromReader() of an SFTP target with an invalid fingerprint error = nil, want an error")
+	}
+	if err := load("    - type: local\n      enabled: true\n"); err == nil {
+		t.Error("LoadFromReader() of a local target without a path error = nil, want an error")
+	}
+	if err := load("    - type: sftp\n      enabled: false\n      settings:\n        host: nas.local\n"); err != nil {
+		t.Errorf("LoadFromReader() of a disabled, incomplete SFTP target error = %v, want nil", err)
+	}
+}
+
+func TestValidateSettingsDefaultKnownHostsWarning(t *testing.T) {
+	t.Parallel()
+
+	warnings := func(target string) []string {
+		t.Helper()
+		settings, err := LoadFromReader(strings.NewReader("backup:\n  enabled: true\n  targets:\n" + target))
+		if err != nil {
+			t.Fatalf("LoadFromReader() error = %v", err)
+		}
+		var found []string
+		for _, warning := range settings.ValidationWarnings {
+			if strings.Contains(warning, "known_hosts_file") {
+				found = append(found, warning)
+			}
+		}
+		return found
+	}
+	if got := warnings("    - type: sftp\n      enabled: true\n      settings:\n        host: nas.local\n        username: birdnet\n"); len(got) != 1 {
+		t.Errorf("warnings of an SFTP target using the default known hosts file = %v, want one warning", got)
+	}
+	if got := warnings("    - type: sftp\n      enabled: true\n      settings:\n        host: nas.local\n        username: birdnet\n        known_hosts_file: /etc/ssh/ssh_known_hosts\n"); len(got) != 0 {
+		t.Errorf("warnings of an SFTP target with a known hosts file = %v, want none", got)
+	}
+}
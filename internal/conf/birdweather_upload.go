@@ -0,0 +1,73 @@
+// conf/birdweather_upload.go BirdWeather detection and soundscape uploads
+package conf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// BirdWeather upload modes
+const (
+	BirdweatherUploadDetections  = "detections"  // a clip with every detection
+	BirdweatherUploadSoundscapes = "soundscapes" // periodic soundscapes without detections
+	BirdweatherUploadBoth        = "both"        // detections and periodic soundscapes
+)
+
+// BirdWeather soundscape durations
+const (
+	DefaultSoundscapeInterval = 15 * time.Minute // used when SoundscapeInterval is not set
+	MinSoundscapeInterval     = time.Minute
+	SoundscapeLength          = 30 * time.Second // length of periodic soundscapes, within the capture buffer
+)
+
+// BirdweatherUploadMode is the resolved upload mode of BirdWeather
+type BirdweatherUploadMode struct {
+	Detections         bool          // true to upload a clip with every detection
+	Soundscapes        bool          // true to upload periodic soundscapes
+	SoundscapeInterval time.Duration // time between periodic soundscapes
+}
+
+// ResolvedUploadMode returns what is uploaded to BirdWeather, detections when
+// no mode is configured and DefaultSoundscapeInterval when no interval is
+// configured
+func (b *BirdweatherSettings) ResolvedUploadMode() BirdweatherUploadMode {
+	mode := BirdweatherUploadMode{SoundscapeInterval: b.SoundscapeInterval}
+	switch b.UploadMode {
+	case BirdweatherUploadSoundscapes:
+		mode.Soundscapes = true
+	case BirdweatherUploadBoth:
+		mode.Detections, mode.Soundscapes = true, true
+	default:
+		mode.Detections = true
+	}
+	if mode.SoundscapeInterval <= 0 {
+		mode.SoundscapeInterval = DefaultSoundscapeInterval
+	}
+	return mode
+}
+
+// validateBirdweatherUpload checks the upload mode and, for soundscape
+// uploads, the soundscape interval. An empty mode uploads detections.
+func validateBirdweatherUpload(settings *BirdweatherSettings) error {
+	if c := constraintFor("realtime.birdweather.uploadmode"); settings.UploadMode != "" && !c.allows(settings.UploadMode) {
+		return errors.New(fmt.Errorf("birdweather upload mode must be %s, got %q", c, settings.UploadMode)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBirdweatherUploadMode).
+			Build()
+	}
+	if settings.SoundscapeInterval < 0 {
+		return errors.New(fmt.Errorf("birdweather soundscape interval must be non-negative, got %s", settings.SoundscapeInterval)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBirdweatherSoundscape).
+			Build()
+	}
+	if mode := settings.ResolvedUploadMode(); mode.Soundscapes && mode.SoundscapeInterval < MinSoundscapeInterval {
+		return errors.New(fmt.Errorf("birdweather soundscape interval must be at least %s, got %s", MinSoundscapeInterval, mode.SoundscapeInterval)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeBirdweatherSoundscape).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolvedUploadMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings BirdweatherSettings
+		want     BirdweatherUploadMode
+	}{
+		{"default", BirdweatherSettings{}, BirdweatherUploadMode{Detections: true, SoundscapeInterval: DefaultSoundscapeInterval}},
+		{"detections", BirdweatherSettings{UploadMode: BirdweatherUploadDetections}, BirdweatherUploadMode{Detections: true, SoundscapeInterval: DefaultSoundscapeInterval}},
+		{"soundscapes", BirdweatherSettings{UploadMode: BirdweatherUploadSoundscapes, SoundscapeInterval: 5 * time.Minute}, BirdweatherUploadMode{Soundscapes: true, SoundscapeInterval: 5 * time.Minute}},
+		{"both", BirdweatherSettings{UploadMode: BirdweatherUploadBoth}, BirdweatherUploadMode{Detections: true, Soundscapes: true, SoundscapeInterval: DefaultSoundscapeInterval}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.settings.ResolvedUploadMode(); got != tt.want {
+				t.Errorf("ResolvedUploadMode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBirdweatherUpload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings BirdweatherSettings
+		wantCode string
+	}{
+		{"default", BirdweatherSettings{}, ""},
+		{"soundscapes with default interval", BirdweatherSettings{UploadMode: BirdweatherUploadSoundscapes}, ""},
+		{"both with interval", BirdweatherSettings{UploadMode: BirdweatherUploadBoth, SoundscapeInterval: time.Minute}, ""},
+		{"short interval without soundscapes", BirdweatherSettings{UploadMode: BirdweatherUploadDetections, SoundscapeInterval: time.Second}, ""},
+		{"unknown mode", BirdweatherSettings{UploadMode: "everything"}, ErrCodeBirdweatherUploadMode},
+		{"negative interval", BirdweatherSettings{SoundscapeInterval: -time.Minute}, ErrCodeBirdweatherSoundscape},
+		{"interval too short", BirdweatherSettings{UploadMode: BirdweatherUploadSoundscapes, SoundscapeInterval: 30 * time.Second}, ErrCodeBirdweatherSoundscape},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := tt.settings
+			err := validateBirdweatherUpload(&settings)
+			if (err != nil) != (tt.wantCode != "") {
+				t.Fatalf("validateBirdweatherUpload() error = %v, want code %q", err, tt.wantCode)
+			}
+			if err != nil && validationCode(err) != tt.wantCode {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// conf/changes.go in-memory log of recent settings changes
+package conf
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultChangeLogSize is the number of changes RecentChanges returns unless
+// SetChangeLogSize changes it
+const DefaultChangeLogSize = 20
+
+// Sources of ConfigChangeEvent
+const (
+	ChangeSourceSave  = "save"  // settings written to the config file
+	ChangeSourcePatch = "patch" // settings changed in memory by ApplyPatch
+	ChangeSourceMerge = "merge" // settings merged from a partial config file by MergeConfigFile
+)
+
+// ConfigChangeEvent records one change of the settings. Only the config keys
+// of the changed settings are recorded, values are left out so that secrets
+// are never kept in the log.
+type ConfigChangeEvent struct {
+	Timestamp    time.Time `json:"timestamp"`
+	ChangedPaths []string  `json:"changedPaths"`
+	Source       string    `json:"source"`
+}
+
+// changeLog is a ring buffer of the most recent settings changes
+type changeLog struct {
+	mu     sync.Mutex
+	events []ConfigChangeEvent
+	next   int // index of the slot the next event is written to
+	full   bool
+	// saved is a copy of the settings last read from or written to the
+	// config file, saves are diffed against it
+	saved *Settings
+}
+
+var configChanges = &changeLog{events: make([]ConfigChangeEvent, DefaultChangeLogSize)}
+
+// SetChangeLogSize sets how many recent changes are kept, the most recent
+// ones are kept when the log shrinks. Sizes below 1 are treated as 1.
+func SetChangeLogSize(size int) {
+	size = max(size, 1)
+	configChanges.mu.Lock()
+	defer configChanges.mu.Unlock()
+
+	recent := configChanges.recentLocked()
+	if len(recent) > size {
+		recent = recent[len(recent)-size:]
+	}
+	configChanges.events = make([]ConfigChangeEvent, size)
+	copy(configChanges.events, recent)
+	configChanges.next = len(recent) % size
+	configChanges.full = len(recent) == size
+}
+
+// RecentChanges returns the recorded settings changes, oldest first
+func RecentChanges() []ConfigChangeEvent {
+	configChanges.mu.Lock()
+	defer configChanges.mu.Unlock()
+	return configChanges.recentLocked()
+}
+
+// recentLocked returns copies of the events in order, the caller must hold mu
+func (l *changeLog) recentLocked() []ConfigChangeEvent {
+	var ordered []ConfigChangeEvent
+	if l.full {
+		ordered = append(ordered, l.events[l.next:]...)
+	}
+	ordered = append(ordered, l.events[:l.next]...)
+	for i := range ordered {
+		ordered[i].ChangedPaths = slices.Clone(ordered[i].ChangedPaths)
+	}
+	return ordered
+}
+
+// record adds an event for changed config keys, no event is added when
+// nothing changed
+func (l *changeLog) record(source string, changed []string) {
+	if len(changed) == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events[l.next] = ConfigChangeEvent{
+		Timestamp:    time.Now(),
+		ChangedPaths: slices.Clone(changed),
+		Source:       source,
+	}
+	l.next = (l.next + 1) % len(l.events)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// setSaved remembers settings as the content of the config file, the caller
+// must hold the locks guarding settings
+func (l *changeLog) setSaved(settings *Settings) {
+	var saved *Settings
+	if settings != nil {
+		saved = cloneSettings(settings)
+	}
+	l.mu.Lock()
+	l.saved = saved
+	l.mu.Unlock()
+}
+
+// recordSave adds an event for the settings changed since the config file
+// was last read or written and remembers settings as its new content. The
+// caller must hold the locks guarding settings.
+func (l *changeLog) recordSave(settings *Settings) {
+	l.mu.Lock()
+	previous := l.saved
+	l.mu.Unlock()
+
+	if previous != nil {
+		l.record(ChangeSourceSave, Diff(previous, settings))
+	}
+	l.setSaved(settings)
+}
+
+// Diff returns the sorted config keys of the settings that differ between
+// before and after, such as "birdnet.threshold". Lists and maps are compared
+// as a whole, runtime values that are not stored in the config file are
+// ignored.
+func Diff(before, after *Settings) []string {
+	var changed []string
+	diffStruct(reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem(), "", &changed)
+	slices.Sort(changed)
+	return changed
+}
+
+// Equal reports whether s and other have the same values for all settings
+// stored in the config file, compared like Diff. Runtime values that are not
+// stored in the config file are ignored, two nil settings are equal.
+func (s *Settings) Equal(other *Settings) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	return len(Diff(s, other)) == 0
+}
+
+// diffStruct appends the config keys of the fields that differ between two
+// struct values, key is the config key of the structs
+func diffStruct(before, after reflect.Value, key string, changed *[]string) {
+	t := before.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		configName := strings.ToLower(field.Name)
+		yamlTag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if yamlTag == "-" {
+			continue
+		} else if yamlTag != "" {
+			configName = yamlTag
+		}
+		fieldKey := configName
+		if key != "" {
+			fieldKey = key + "." + configName
+		}
+
+		beforeValue, afterValue := before.Field(i), after.Field(i)
+		if beforeValue.Kind() == reflect.Struct && beforeValue.Type() != timeType {
+			diffStruct(beforeValue, afterValue, fieldKey, changed)
+			continue
+		}
+		if !reflect.DeepEqual(beforeValue.Interface(), afterValue.Interface()) {
+			*changed = append(*changed, fieldKey)
+		}
+	}
+}
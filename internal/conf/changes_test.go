@@ -0,0 +1,162 @@
+package conf
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	before := &Settings{}
+	before.BirdNET.Threshold = 0.8
+	before.Realtime.Species.Include = []string{"Eurasian Blackbird"}
+	before.Backup.SanitizeConfig = true
+
+	after := cloneSettings(before)
+	if changed := Diff(before, after); len(changed) != 0 {
+		t.Errorf("Diff() of equal settings = %v, want none", changed)
+	}
+
+	after.BirdNET.Threshold = 0.7
+	after.Realtime.Species.Include = append(after.Realtime.Species.Include, "Great Tit")
+	after.Backup.SanitizeConfig = false
+	after.Security.BasicAuth.Password = "secret"
+	after.Version = "1.2.3" // runtime value, not stored in the config file
+	want := []string{"backup.sanitize_config", "birdnet.threshold", "realtime.species.include", "security.basicauth.password"}
+	if changed := Diff(before, after); !slices.Equal(changed, want) {
+		t.Errorf("Diff() = %v, want %v", changed, want)
+	}
+}
+
+func TestSettingsEqual(t *testing.T) {
+	t.Parallel()
+
+	base := &Settings{}
+	base.BirdNET.Threshold = 0.8
+	base.Realtime.Audio.Export.Path = "clips/"
+	base.Realtime.Species.Include = []string{"Eurasian Blackbird"}
+	base.Realtime.Species.Config = map[string]SpeciesConfig{
+		"great tit": {Threshold: 0.5, Actions: []SpeciesAction{{Type: "ExecuteCommand", Command: "/bin/true"}}},
+	}
+	base.Realtime.Telemetry.Labels = map[string]string{"node": "garden"}
+
+	tests := []struct {
+		name   string
+		modify func(s *Settings)
+		want   bool
+	}{
+		{"copy", func(*Settings) {}, true},
+		{"top level value", func(s *Settings) { s.BirdNET.Threshold = 0.7 }, false},
+		{"nested struct value", func(s *Settings) { s.Realtime.Audio.Export.Path = "other/" }, false},
+		{"slice element", func(s *Settings) { s.Realtime.Species.Include[0] = "Great Tit" }, false},
+		{"slice length", func(s *Settings) {
+			s.Realtime.Species.Include = append(s.Realtime.Species.Include, "Great Tit")
+		}, false},
+		{"map value", func(s *Settings) { s.Realtime.Telemetry.Labels["node"] = "roof" }, false},
+		{"map key", func(s *Settings) { s.Realtime.Telemetry.Labels["site"] = "garden" }, false},
+		{"map of structs with slices", func(s *Settings) {
+			config := s.Realtime.Species.Config["great tit"]
+			config.Actions[0].Command = "/bin/false"
+		}, false},
+		{"runtime value", func(s *Settings) {
+			s.Version = "1.2.3"
+			s.ValidationWarnings = []string{"warning"}
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			other := cloneSettings(base)
+			tt.modify(other)
+			if got := base.Equal(other); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+			if got := other.Equal(base); got != tt.want {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var none *Settings
+		if !none.Equal(nil) {
+			t.Error("nil settings are not equal to nil")
+		}
+		if none.Equal(base) || base.Equal(nil) {
+			t.Error("nil settings are equal to loaded settings")
+		}
+	})
+}
+
+// withChangeLog replaces the global change log for a test, tests using it
+// cannot run in parallel
+func withChangeLog(t *testing.T, size int) {
+	t.Helper()
+	previous := configChanges
+	configChanges = &changeLog{events: make([]ConfigChangeEvent, size)}
+	t.Cleanup(func() { configChanges = previous })
+}
+
+func TestRecentChanges(t *testing.T) {
+	withChangeLog(t, 3)
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		configChanges.record(ChangeSourcePatch, []string{key})
+	}
+	configChanges.record(ChangeSourcePatch, nil)
+
+	paths := func() []string {
+		var got []string
+		for _, event := range RecentChanges() {
+			got = append(got, event.ChangedPaths...)
+		}
+		return got
+	}
+	if got := paths(); !slices.Equal(got, []string{"b", "c", "d"}) {
+		t.Errorf("RecentChanges() paths = %v, want the 3 most recent", got)
+	}
+
+	SetChangeLogSize(2)
+	if got := paths(); !slices.Equal(got, []string{"c", "d"}) {
+		t.Errorf("RecentChanges() paths after shrinking = %v, want [c d]", got)
+	}
+	SetChangeLogSize(4)
+	configChanges.record(ChangeSourceSave, []string{"e"})
+	if got := paths(); !slices.Equal(got, []string{"c", "d", "e"}) {
+		t.Errorf("RecentChanges() paths after growing = %v, want [c d e]", got)
+	}
+
+	events := RecentChanges()
+	events[0].ChangedPaths[0] = "edited"
+	if got := paths(); got[0] != "c" {
+		t.Error("RecentChanges() returned events sharing paths with the log")
+	}
+}
+
+func TestChangeLogRecordsPatchesAndSaves(t *testing.T) {
+	withChangeLog(t, DefaultChangeLogSize)
+
+	settings := newPatchTestSettings(t)
+	configChanges.setSaved(settings)
+
+	if _, err := settings.ApplyPatch(map[string]any{"birdnet.threshold": 0.6}); err != nil {
+		t.Fatalf("ApplyPatch() error = %v", err)
+	}
+	settings.Realtime.MQTT.Password = "mqtt-password"
+	configChanges.recordSave(settings)
+	// A save without changes since the last one is not recorded
+	configChanges.recordSave(settings)
+
+	events := RecentChanges()
+	if len(events) != 2 {
+		t.Fatalf("RecentChanges() = %+v, want a patch and a save", events)
+	}
+	if events[0].Source != ChangeSourcePatch || !slices.Equal(events[0].ChangedPaths, []string{"birdnet.threshold"}) {
+		t.Errorf("patch event = %+v, want birdnet.threshold", events[0])
+	}
+	if events[1].Source != ChangeSourceSave || !slices.Equal(events[1].ChangedPaths, []string{"birdnet.threshold", "realtime.mqtt.password"}) {
+		t.Errorf("save event = %+v, want the changes since the settings were loaded", events[1])
+	}
+}
@@ -0,0 +1,155 @@
+// conf/clip_filename.go audio clip filename templates for audio export
+package conf
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// DefaultClipFilenameTemplate reproduces the built-in clip naming scheme,
+// e.g. 2024/05/parus_major_85p_20240512T061502Z.wav
+const DefaultClipFilenameTemplate = "{{.Year}}/{{.Month}}/{{.Species}}_{{.Confidence}}p_{{.Timestamp}}.{{.Ext}}"
+
+// ClipMeta describes an audio clip for filename rendering
+type ClipMeta struct {
+	ScientificName string    // scientific name of the detected species
+	CommonName     string    // common name of the detected species
+	Confidence     float64   // detection confidence between 0 and 1
+	Time           time.Time // time of the detection
+	Extension      string    // file extension without leading dot, e.g. "mp3"
+}
+
+// clipTemplateData holds the fields available to filename templates
+type clipTemplateData struct {
+	Species    string // lowercase scientific name with underscores, e.g. parus_major
+	CommonName string // common name as reported by the model
+	Confidence string // confidence as a whole percentage, e.g. 85
+	Date       string // detection date, 2006-01-02
+	Year       string // detection year, 2006
+	Month      string // detection month, 01
+	Day        string // detection day of month, 02
+	Time       string // detection time of day, 150405
+	Timestamp  string // detection timestamp, 20060102T150405Z
+	Ext        string // file extension without leading dot
+}
+
+// unsafeFilenameChars are replaced in values inserted into clip filenames
+var unsafeFilenameChars = strings.NewReplacer(
+	"/", "_", "\\", "_", ":", "_", "*", "_", "?", "_",
+	"\"", "_", "<", "_", ">", "_", "|", "_", "\x00", "",
+)
+
+// newClipTemplateData converts clip metadata into template fields. Values are
+// sanitized so that they can never introduce additional path segments.
+func newClipTemplateData(meta ClipMeta) clipTemplateData {
+	sanitize := func(value string) string {
+		return unsafeFilenameChars.Replace(strings.TrimSpace(value))
+	}
+
+	return clipTemplateData{
+		Species:    sanitize(strings.ToLower(strings.ReplaceAll(meta.ScientificName, " ", "_"))),
+		CommonName: sanitize(meta.CommonName),
+		Confidence: fmt.Sprintf("%.0f", meta.Confidence*100),
+		Date:       meta.Time.Format("2006-01-02"),
+		Year:       meta.Time.Format("2006"),
+		Month:      meta.Time.Format("01"),
+		Day:        meta.Time.Format("02"),
+		Time:       meta.Time.Format("150405"),
+		Timestamp:  meta.Time.Format("20060102T150405Z"),
+		Ext:        sanitize(strings.TrimPrefix(meta.Extension, ".")),
+	}
+}
+
+// parseClipFilenameTemplate parses a filename template and verifies that it
+// only references known fields by executing it against sample data.
+func parseClipFilenameTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("filename").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := newClipTemplateData(ClipMeta{
+		ScientificName: "Parus major",
+		CommonName:     "Great Tit",
+		Confidence:     0.85,
+		Time:           time.Now(),
+		Extension:      "wav",
+	})
+	if err := tmpl.Execute(&strings.Builder{}, sample); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// ValidateFilenameTemplate checks that a clip filename template compiles and
+// only references known fields. An empty template is valid.
+func ValidateFilenameTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+
+	if _, err := parseClipFilenameTemplate(text); err != nil {
+		return errors.New(fmt.Errorf("invalid audio export filename template %q: %w", text, err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeExportFilenameTemplate).
+			Build()
+	}
+	return nil
+}
+
+// RenderFilename renders the clip filename for meta using FilenameTemplate,
+// falling back to DefaultClipFilenameTemplate when no template is configured.
+// The result is a relative slash separated path. Empty, "." and ".." segments
+// are dropped to prevent path traversal, and the file extension is appended
+// when the template does not end with it.
+func (e ExportSettings) RenderFilename(meta ClipMeta) (string, error) {
+	text := e.FilenameTemplate
+	if text == "" {
+		text = DefaultClipFilenameTemplate
+	}
+
+	tmpl, err := parseClipFilenameTemplate(text)
+	if err != nil {
+		return "", errors.New(fmt.Errorf("invalid audio export filename template: %w", err)).
+			Category(errors.CategoryConfiguration).
+			Context("filename_template", text).
+			Build()
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, newClipTemplateData(meta)); err != nil {
+		return "", errors.New(fmt.Errorf("failed to render audio clip filename: %w", err)).
+			Category(errors.CategoryConfiguration).
+			Context("filename_template", text).
+			Build()
+	}
+
+	// Rebuild the path from safe segments only
+	var segments []string
+	for _, segment := range strings.Split(strings.ReplaceAll(rendered.String(), "\\", "/"), "/") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" || segment == "." || segment == ".." {
+			continue
+		}
+		segments = append(segments, unsafeFilenameChars.Replace(segment))
+	}
+	if len(segments) == 0 {
+		return "", errors.New(fmt.Errorf("audio clip filename template %q rendered an empty filename", text)).
+			Category(errors.CategoryConfiguration).
+			Context("filename_template", text).
+			Build()
+	}
+
+	filename := path.Join(segments...)
+	if ext := strings.TrimPrefix(meta.Extension, "."); ext != "" && !strings.HasSuffix(filename, "."+ext) {
+		filename += "." + ext
+	}
+
+	return filename, nil
+}
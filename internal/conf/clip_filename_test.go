@@ -0,0 +1,83 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExportSettingsRenderFilename(t *testing.T) {
+	t.Parallel()
+
+	meta := ClipMeta{
+		ScientificName: "Parus major",
+		CommonName:     "Great Tit",
+		Confidence:     0.854,
+		Time:           time.Date(2024, 5, 12, 6, 15, 2, 0, time.Local),
+		Extension:      "mp3",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		meta     ClipMeta
+		want     string
+		wantErr  bool
+	}{
+		{"default naming", "", meta, "2024/05/parus_major_85p_20240512T061502Z.mp3", false},
+		{"date directory", "{{.Date}}/{{.Species}}_{{.Confidence}}.mp3", meta, "2024-05-12/parus_major_85.mp3", false},
+		{"extension appended", "{{.Species}}", meta, "parus_major.mp3", false},
+		{"common name", "{{.CommonName}}_{{.Time}}", meta, "Great Tit_061502.mp3", false},
+		{"parent segments dropped", "../../{{.Species}}", meta, "parus_major.mp3", false},
+		{"absolute path made relative", "/etc/{{.Species}}", meta, "etc/parus_major.mp3", false},
+		{"backslash separators", "{{.Year}}\\..\\{{.Species}}", meta, "2024/parus_major.mp3", false},
+		{
+			name:     "separators in values do not create directories",
+			template: "{{.CommonName}}",
+			meta:     ClipMeta{CommonName: "../../etc/passwd", Time: meta.Time, Extension: "wav"},
+			want:     ".._.._etc_passwd.wav",
+		},
+		{"empty result", "{{if false}}x{{end}}", ClipMeta{Time: meta.Time}, "", true},
+		{"unknown field", "{{.Station}}", meta, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			export := ExportSettings{FilenameTemplate: tt.template}
+			got, err := export.RenderFilename(tt.meta)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderFilename() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderFilename() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFilenameTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{"empty", "", false},
+		{"default", DefaultClipFilenameTemplate, false},
+		{"all fields", "{{.Date}}/{{.Year}}{{.Month}}{{.Day}}/{{.Species}}_{{.CommonName}}_{{.Confidence}}_{{.Time}}_{{.Timestamp}}.{{.Ext}}", false},
+		{"template functions", `{{printf "%s-%s" .Year .Species}}`, false},
+		{"syntax error", "{{.Species", true},
+		{"unknown field", "{{.Date}}/{{.Location}}", true},
+		{"unknown function", "{{lower .Species}}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ValidateFilenameTemplate(tt.template); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFilenameTemplate(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+			}
+		})
+	}
+}
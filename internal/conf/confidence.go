@@ -0,0 +1,49 @@
+// conf/confidence.go format of detection confidence in integrations and outputs
+package conf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Formats of RealtimeSettings.ConfidenceFormat
+const (
+	ConfidenceFormatFraction = "fraction" // confidence between 0 and 1, the default
+	ConfidenceFormatPercent  = "percent"  // confidence between 0 and 100
+)
+
+// ConfidenceValue returns a confidence between 0 and 1 in the configured
+// format, e.g. 85.23 for 0.8523 with the percent format. Numeric outputs such
+// as MQTT messages use it.
+func (s *Settings) ConfidenceValue(c float64) float64 {
+	if strings.EqualFold(s.Realtime.ConfidenceFormat, ConfidenceFormatPercent) {
+		return c * 100
+	}
+	return c
+}
+
+// FormatConfidence returns a confidence between 0 and 1 as text in the
+// configured format, "0.8523" as a fraction or "85.23" as a percentage.
+// Text outputs such as CSV files use it.
+func (s *Settings) FormatConfidence(c float64) string {
+	if strings.EqualFold(s.Realtime.ConfidenceFormat, ConfidenceFormatPercent) {
+		return strconv.FormatFloat(c*100, 'f', 2, 64)
+	}
+	return strconv.FormatFloat(c, 'f', 4, 64)
+}
+
+// validateConfidenceFormat checks the confidence format and lowercases it
+func validateConfidenceFormat(r *RealtimeSettings) error {
+	c := constraintFor("realtime.confidenceformat")
+	r.ConfidenceFormat = strings.ToLower(strings.TrimSpace(r.ConfidenceFormat))
+	if r.ConfidenceFormat != "" && !c.allows(r.ConfidenceFormat) {
+		return errors.New(fmt.Errorf("unknown confidence format %q, must be %s", r.ConfidenceFormat, c)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeConfidenceFormat).
+			Build()
+	}
+	return nil
+}
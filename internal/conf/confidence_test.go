@@ -0,0 +1,58 @@
+package conf
+
+import "testing"
+
+func TestFormatConfidence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format    string
+		wantValue float64
+		wantText  string
+	}{
+		{"", 0.8523, "0.8523"},
+		{ConfidenceFormatFraction, 0.8523, "0.8523"},
+		{ConfidenceFormatPercent, 85.23, "85.23"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.Realtime.ConfidenceFormat = tt.format
+			if got := settings.ConfidenceValue(0.8523); got < tt.wantValue-1e-9 || got > tt.wantValue+1e-9 {
+				t.Errorf("ConfidenceValue(0.8523) = %v, want %v", got, tt.wantValue)
+			}
+			if got := settings.FormatConfidence(0.8523); got != tt.wantText {
+				t.Errorf("FormatConfidence(0.8523) = %q, want %q", got, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestValidateConfidenceFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"fraction", ConfidenceFormatFraction, false},
+		{" Percent ", ConfidenceFormatPercent, false},
+		{"ratio", "ratio", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			r := &RealtimeSettings{ConfidenceFormat: tt.format}
+			err := validateConfidenceFormat(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfidenceFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if r.ConfidenceFormat != tt.want {
+				t.Errorf("ConfidenceFormat = %q, want %q", r.ConfidenceFormat, tt.want)
+			}
+		})
+	}
+}
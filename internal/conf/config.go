@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/fs"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -39,23 +40,88 @@ type EqualizerSettings struct {
 }
 
 type ExportSettings struct {
-	Debug     bool              // true to enable audio export debug
-	Enabled   bool              // export audio clips containing indentified bird calls
-	Path      string            // path to audio clip export directory
-	Type      string            // audio file type, wav, mp3 or flac
-	Bitrate   string            // bitrate for audio export
-	Retention RetentionSettings // retention settings
+	Debug            bool                // true to enable audio export debug
+	Enabled          bool                // export audio clips containing indentified bird calls
+	Path             string              // path to audio clip export directory
+	Type             string              // audio file type, wav, mp3 or flac
+	Bitrate          string              // bitrate for audio export
+	FilenameTemplate string              // text/template for clip filenames relative to Path, empty uses the default naming
+	Retention        RetentionSettings   // retention settings
+	Spectrogram      SpectrogramSettings // spectrogram image settings
+}
+
+// SpectrogramSettings contains settings for spectrogram images of audio clips
+type SpectrogramSettings struct {
+	Width       int    // image width in pixels when a request does not set one
+	Height      int    // image height in pixels at Width, other widths keep the aspect ratio
+	ColorScheme string // default, monochrome, light or highcolor
+	Raw         bool   // true to leave out axes and legend, images narrower than 800 pixels are always raw
 }
 
 type RetentionSettings struct {
 	Debug            bool   // true to enable retention debug
 	Policy           string // retention policy, "none", "age" or "usage"
 	MaxAge           string // maximum age of audio clips to keep
-	MaxUsage         string // maximum disk usage percentage before cleanup
+	MaxUsage         string // maximum disk usage before cleanup, percentage (80%) or absolute size (50GB)
 	MinClips         int    // minimum number of clips per species to keep
 	KeepSpectrograms bool   // true to keep spectrograms
 }
 
+// validRetentionPolicies lists the supported audio clip retention policies
+var validRetentionPolicies = []string{"none", "age", "usage"}
+
+// Validate validates retention settings, ensuring the setting required by the
+// selected policy is present and parseable
+func (r *RetentionSettings) Validate() error {
+	if r.MinClips < 0 {
+		return errors.New(fmt.Errorf("retention minclips must be non-negative, got %d", r.MinClips)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRetentionMinClips).
+			Context("min_clips", r.MinClips).
+			Build()
+	}
+
+	switch r.Policy {
+	case "none":
+		return nil
+	case "age":
+		hours, err := ParseRetentionPeriod(r.MaxAge)
+		if err != nil || hours <= 0 {
+			return errors.New(fmt.Errorf("retention policy \"age\" requires a valid maxage such as \"30d\", got %q", r.MaxAge)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeRetentionMaxAge).
+				Context("max_age", r.MaxAge).
+				Build()
+		}
+	case "usage":
+		if _, isPercent, err := ParseDiskLimit(r.MaxUsage, 0); err != nil {
+			return errors.New(fmt.Errorf("retention policy \"usage\" requires maxusage as a percentage like \"80%%\" or a size like \"50GB\", got %q", r.MaxUsage)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeRetentionMaxUsage).
+				Context("max_usage", r.MaxUsage).
+				Build()
+		} else if isPercent {
+			// ParseDiskLimit clamps percentages, reject out of range values explicitly
+			usage, _ := ParsePercentage(r.MaxUsage)
+			if err := ValidatePercentRange(usage, "maxusage"); err != nil || usage < 1 {
+				return errors.New(fmt.Errorf("retention policy \"usage\" requires maxusage between 1%% and 100%%, got %q", r.MaxUsage)).
+					Category(errors.CategoryValidation).
+					Context("validation_type", ErrCodeRetentionMaxUsage).
+					Context("max_usage", r.MaxUsage).
+					Build()
+			}
+		}
+	default:
+		return errors.New(fmt.Errorf("unknown retention policy %q, valid options are: %s", r.Policy, strings.Join(validRetentionPolicies, ", "))).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRetentionPolicy).
+			Context("policy", r.Policy).
+			Build()
+	}
+
+	return nil
+}
+
 // AudioSettings contains settings for audio processing and export.
 // SoundLevelSettings contains settings for sound level monitoring
 type SoundLevelSettings struct {
@@ -67,9 +133,12 @@ type SoundLevelSettings struct {
 
 type AudioSettings struct {
 	Source          string             // audio source to use for analysis
+	Gain            float64            // sound card input gain in dB applied before analysis, see GainFactor
+	Normalize       bool               // true to normalize the sound card input peak level, a positive Gain caps the amplification
 	FfmpegPath      string             // path to ffmpeg, runtime value
 	SoxPath         string             // path to sox, runtime value
 	SoxAudioTypes   []string           `yaml:"-"` // supported audio types of sox, runtime value
+	ExportTypes     []string           `yaml:"-"` // export types the detected ffmpeg can encode, nil when unknown, runtime value
 	StreamTransport string             // preferred transport for audio streaming: "auto", "sse", or "ws"
 	Export          ExportSettings     // export settings
 	SoundLevel      SoundLevelSettings // sound level monitoring settings
@@ -78,11 +147,12 @@ type AudioSettings struct {
 	Equalizer EqualizerSettings // equalizer settings
 }
 type Thumbnails struct {
-	Debug          bool   // true to enable debug mode
-	Summary        bool   // show thumbnails on summary table
-	Recent         bool   // show thumbnails on recent table
-	ImageProvider  string // preferred image provider: "auto", "wikimedia", "avicommons"
-	FallbackPolicy string // fallback policy: "none", "all" - try all available providers if preferred fails
+	Debug          bool     // true to enable debug mode
+	Summary        bool     // show thumbnails on summary table
+	Recent         bool     // show thumbnails on recent table
+	ImageProvider  string   // preferred image provider: "auto", "wikimedia", "avicommons"
+	FallbackPolicy string   // fallback policy: "none", "all" - try all available providers if preferred fails
+	Providers      []string // image providers to try in order, overrides ImageProvider and FallbackPolicy when set
 }
 
 // Dashboard contains settings for the web dashboard.
@@ -111,36 +181,56 @@ type RetrySettings struct {
 
 // BirdweatherSettings contains settings for BirdWeather API integration.
 type BirdweatherSettings struct {
-	Enabled          bool          // true to enable birdweather uploads
-	Debug            bool          // true to enable debug mode
-	ID               string        // birdweather ID
-	Threshold        float64       // threshold for prediction confidence for uploads
-	LocationAccuracy float64       // accuracy of location in meters
-	RetrySettings    RetrySettings // settings for retry mechanism
+	Enabled            bool              // true to enable birdweather uploads
+	Debug              bool              // true to enable debug mode
+	ID                 string            // birdweather ID
+	Threshold          float64           // threshold for prediction confidence for uploads
+	LocationAccuracy   float64           // accuracy of location in meters
+	RetrySettings      RetrySettings     // settings for retry mechanism
+	RateLimit          RateLimitSettings // settings for upload rate limiting
+	UploadMode         string            // what to upload: "detections", "soundscapes" or "both"
+	SoundscapeInterval time.Duration     // time between periodic soundscape uploads, 0 for 15 minutes
+}
+
+// RateLimitSettings contains settings for client side request rate limiting
+type RateLimitSettings struct {
+	MaxPerMinute int // maximum number of requests per minute, 0 for unlimited
+	BurstSize    int // maximum number of requests allowed in a burst, 0 defaults to 1
 }
 
 // WeatherSettings contains all weather-related settings
 type WeatherSettings struct {
 	Provider     string              // "none", "yrno" or "openweather"
 	PollInterval int                 // weather data polling interval in minutes
+	PollJitter   int                 // random extra delay of up to this many minutes per poll, 0 disables
 	Debug        bool                // true to enable debug mode
 	OpenWeather  OpenWeatherSettings // OpenWeather integration settings
 }
 
 // OpenWeatherSettings contains settings for OpenWeather integration.
 type OpenWeatherSettings struct {
-	Enabled  bool   // true to enable OpenWeather integration, for legacy support
-	APIKey   string // OpenWeather API key
-	Endpoint string // OpenWeather API endpoint
-	Units    string // units of measurement: standard, metric, or imperial
-	Language string // language code for the response
+	Enabled    bool   // true to enable OpenWeather integration, for legacy support
+	APIKey     string // OpenWeather API key
+	APIKeyFile string `yaml:",omitempty"` // file to read the OpenWeather API key from
+	Endpoint   string // OpenWeather API endpoint
+	Units      string // units of measurement: standard, metric, or imperial
+	Language   string // language code for the response
 }
 
 // PrivacyFilterSettings contains settings for the privacy filter.
 type PrivacyFilterSettings struct {
-	Debug      bool    // true to enable debug mode
-	Enabled    bool    // true to enable privacy filter
-	Confidence float32 // confidence threshold for human detection
+	Debug      bool                     // true to enable debug mode
+	Enabled    bool                     // true to enable privacy filter
+	Confidence float32                  // confidence threshold for human detection
+	Privacy    DetectionPrivacySettings // anonymization of detections stored in the database
+}
+
+// DetectionPrivacySettings contains settings for anonymizing detections
+// before they are stored, e.g. for public dashboards. The defaults store
+// detections with full precision.
+type DetectionPrivacySettings struct {
+	RoundTimestampTo time.Duration // round detection timestamps down to this interval, 0 stores precise timestamps
+	StoreLocation    bool          // true to store the station latitude and longitude with detections
 }
 
 // DogBarkFilterSettings contains settings for the dog bark filter.
@@ -160,10 +250,11 @@ type RTSPHealthSettings struct {
 
 // RTSPSettings contains settings for RTSP streaming.
 type RTSPSettings struct {
-	Transport        string              // RTSP Transport Protocol
-	URLs             []string            // RTSP stream URL
-	Health           RTSPHealthSettings  // health monitoring settings
-	FFmpegParameters []string            // optional custom FFmpeg parameters
+	Transport        string             // RTSP Transport Protocol
+	URLs             []string           // RTSP stream URL
+	Health           RTSPHealthSettings // health monitoring settings
+	ReconnectBackoff RetrySettings      // backoff between reconnection attempts, MaxRetries is not used
+	FFmpegParameters []string           // optional custom FFmpeg parameters
 }
 
 // MQTTSettings contains settings for MQTT integration.
@@ -171,9 +262,11 @@ type MQTTSettings struct {
 	Enabled       bool            // true to enable MQTT
 	Debug         bool            // true to enable MQTT debug
 	Broker        string          // MQTT broker URL
+	Brokers       []string        `yaml:",omitempty"` // MQTT broker URLs tried in order for failover, overrides Broker when set
 	Topic         string          // MQTT topic
 	Username      string          // MQTT username
 	Password      string          // MQTT password
+	PasswordFile  string          `yaml:",omitempty"` // file to read the MQTT password from
 	Retain        bool            // true to retain messages
 	RetrySettings RetrySettings   // settings for retry mechanism
 	TLS           MQTTTLSSettings // TLS/SSL configuration
@@ -181,7 +274,7 @@ type MQTTSettings struct {
 
 // MQTTTLSSettings contains TLS/SSL configuration for secure MQTT connections
 type MQTTTLSSettings struct {
-	Enabled            bool   // true to enable TLS (auto-detected from broker URL)
+	Enabled            bool   // true to enable TLS (auto-detected per broker from its URL)
 	InsecureSkipVerify bool   // true to skip certificate verification (for self-signed certs)
 	CACert             string `yaml:"cacert,omitempty"`     // path to CA certificate file (managed internally)
 	ClientCert         string `yaml:"clientcert,omitempty"` // path to client certificate file (managed internally)
@@ -190,26 +283,30 @@ type MQTTTLSSettings struct {
 
 // TelemetrySettings contains settings for telemetry.
 type TelemetrySettings struct {
-	Enabled bool   // true to enable Prometheus compatible telemetry endpoint
-	Listen  string // IP address and port to listen on
+	Enabled   bool              // true to enable Prometheus compatible telemetry endpoint
+	Listen    string            // IP address and port to listen on
+	Namespace string            // optional prefix prepended to all exported metric names
+	Labels    map[string]string // constant labels attached to all exported metrics, e.g. node identity
 }
 
 // MonitoringSettings contains settings for system resource monitoring
 type MonitoringSettings struct {
-	Enabled              bool                  // true to enable system resource monitoring
-	CheckInterval        int                   // interval in seconds between resource checks
-	CriticalResendInterval int                 // interval in minutes between critical alert resends (default: 30)
-	HysteresisPercent    float64               // hysteresis percentage for state transitions (default: 5.0)
-	CPU                  ThresholdSettings     // CPU usage thresholds
-	Memory               ThresholdSettings     // Memory usage thresholds
-	Disk                 DiskThresholdSettings // Disk usage thresholds
+	Enabled                bool                     // true to enable system resource monitoring
+	CheckInterval          int                      // interval in seconds between resource checks
+	CriticalResendInterval int                      // interval in minutes between critical alert resends (default: 30)
+	HysteresisPercent      float64                  // hysteresis percentage for state transitions (default: 5.0)
+	CPU                    ThresholdSettings        // CPU usage thresholds
+	Memory                 ThresholdSettings        // Memory usage thresholds
+	Disk                   DiskThresholdSettings    // Disk usage thresholds
+	Temperature            ThresholdSettings        // CPU temperature thresholds in degrees Celsius, read from thermal zones
+	Network                NetworkThresholdSettings // Network interface error and throughput thresholds
 }
 
 // ThresholdSettings contains warning and critical thresholds
 type ThresholdSettings struct {
 	Enabled  bool    // true to enable monitoring for this resource
-	Warning  float64 // warning threshold percentage
-	Critical float64 // critical threshold percentage
+	Warning  float64 // warning threshold, in percent unless the resource specifies another unit
+	Critical float64 // critical threshold, in percent unless the resource specifies another unit
 }
 
 // DiskThresholdSettings contains disk monitoring configuration for multiple paths
@@ -220,6 +317,14 @@ type DiskThresholdSettings struct {
 	Paths    []string // filesystem paths to monitor
 }
 
+// NetworkThresholdSettings contains network interface monitoring configuration
+type NetworkThresholdSettings struct {
+	Enabled    bool              // true to enable network interface monitoring
+	Interfaces []string          // interfaces to monitor, empty monitors all non-loopback interfaces
+	Errors     ThresholdSettings // packet error rate thresholds in percent of transferred packets
+	Throughput ThresholdSettings // combined receive and transmit throughput thresholds in Mbit/s
+}
+
 // SentrySettings contains settings for Sentry error tracking
 type SentrySettings struct {
 	Enabled bool // true to enable Sentry error tracking (opt-in)
@@ -228,12 +333,17 @@ type SentrySettings struct {
 
 // RealtimeSettings contains all settings related to realtime processing.
 type RealtimeSettings struct {
-	Interval         int                      // minimum interval between log messages in seconds
-	ProcessingTime   bool                     // true to report processing time for each prediction
-	Audio            AudioSettings            // Audio processing settings
-	Dashboard        Dashboard                // Dashboard settings
-	DynamicThreshold DynamicThresholdSettings // Dynamic threshold settings
-	Log              struct {
+	Interval             int                      // minimum interval between log messages in seconds
+	ProcessingTime       bool                     // true to report processing time for each prediction
+	DedupWindow          time.Duration            // detections of a species within this window are collapsed into one, 0 disables
+	DedupStrategy        string                   // detection kept of collapsed duplicates: "highest-confidence" or "first"
+	ConfidenceFormat     string                   // confidence in MQTT messages and output files: "fraction" (0-1) or "percent" (0-100)
+	MaxConcurrentActions int                      // maximum number of command actions running at once, 0 for unlimited
+	OnActionOverflow     string                   // command actions over the limit: "queue" to wait or "drop" to skip
+	Audio                AudioSettings            // Audio processing settings
+	Dashboard            Dashboard                // Dashboard settings
+	DynamicThreshold     DynamicThresholdSettings // Dynamic threshold settings
+	Log                  struct {
 		Enabled bool   // true to enable OBS chat log
 		Path    string // path to OBS chat log
 	}
@@ -247,21 +357,36 @@ type RealtimeSettings struct {
 	Monitoring    MonitoringSettings    // System resource monitoring settings
 	Species       SpeciesSettings       // Custom thresholds and actions for species
 	Weather       WeatherSettings       // Weather provider related settings
+	QuietHours    QuietHoursSettings    // Daily schedule suppressing detection side effects
+}
+
+// QuietHoursSettings suppresses detection side effects during a daily time
+// window, see InQuietHours
+type QuietHoursSettings struct {
+	Enabled  bool     // true to enable quiet hours
+	Start    string   // start time in HH:MM format, in the Main.TimeZone time zone
+	End      string   // end time in HH:MM format, before Start for windows crossing midnight
+	Weekdays []string // weekdays the window starts on, empty for every day
+	Suppress []string // side effects to suppress: "actions", "notifications" and "mqtt", empty for all
 }
 
 // SpeciesAction represents a single action configuration
 type SpeciesAction struct {
-	Type            string   `yaml:"type"`            // Type of action (ExecuteCommand, etc)
-	Command         string   `yaml:"command"`         // Path to the command to execute
-	Parameters      []string `yaml:"parameters"`      // Action parameters
-	ExecuteDefaults bool     `yaml:"executeDefaults"` // Whether to also execute default actions
+	Type            string            `yaml:"type"`                     // Type of action (ExecuteCommand, etc)
+	Command         string            `yaml:"command"`                  // Path to the command to execute
+	Parameters      []string          `yaml:"parameters"`               // Action parameters
+	ExecuteDefaults bool              `yaml:"executeDefaults"`          // Whether to also execute default actions
+	TimeoutSeconds  int               `yaml:"timeoutseconds,omitempty"` // Seconds the command may run before it is killed, 0 for the 30 second default
+	Env             map[string]string `yaml:"env,omitempty"`            // Environment variables set for the command, names are uppercased
 }
 
 // SpeciesConfig represents configuration for a specific species
 type SpeciesConfig struct {
-	Threshold float64         `yaml:"threshold"`          // Confidence threshold
-	Interval  int             `yaml:"interval,omitempty"` // New field: Custom interval in seconds
-	Actions   []SpeciesAction `yaml:"actions"`            // List of actions to execute
+	Threshold        float64         `yaml:"threshold"`                  // Confidence threshold
+	Interval         int             `yaml:"interval,omitempty"`         // New field: Custom interval in seconds
+	Actions          []SpeciesAction `yaml:"actions"`                    // List of actions to execute
+	KeepSpectrograms *bool           `yaml:"keepspectrograms,omitempty"` // Overrides the retention keepspectrograms setting for the species when set
+	MinOccurrences   int             `yaml:"minoccurrences,omitempty"`   // Detections needed within the interval before the species is reported, 0 or 1 reports every detection
 }
 
 // RealtimeSpeciesSettings contains all species-specific settings
@@ -279,54 +404,63 @@ type ActionConfig struct {
 
 // InputConfig holds settings for file or directory analysis
 type InputConfig struct {
-	Path      string `yaml:"-"` // path to input file or directory
-	Recursive bool   `yaml:"-"` // true for recursive directory analysis
-	Watch     bool   `yaml:"-"` // true to watch directory for new files
+	Path         string `yaml:"-"` // path to input file or directory
+	Recursive    bool   `yaml:"-"` // true for recursive directory analysis
+	Watch        bool   `yaml:"-"` // true to watch directory for new files
+	Workers      int    `yaml:"-"` // number of files analyzed in parallel, 0 analyzes one file at a time
+	FilePattern  string `yaml:"-"` // glob matched against file names, e.g. "*_2024*.wav", empty analyzes all audio files
+	SkipExisting bool   `yaml:"-"` // true to skip files with results in the output directory
 }
 
 type BirdNETConfig struct {
-	Debug       bool                // true to enable debug mode
-	Sensitivity float64             // birdnet analysis sigmoid sensitivity
-	Threshold   float64             // threshold for prediction confidence to report
-	Overlap     float64             // birdnet analysis overlap between chunks
-	Longitude   float64             // longitude of recording location for prediction filtering
-	Latitude    float64             // latitude of recording location for prediction filtering
-	Threads     int                 // number of CPU threads to use for analysis
-	Locale      string              // language to use for labels
-	RangeFilter RangeFilterSettings // range filter settings
-	ModelPath   string              // path to external model file (empty for embedded)
-	LabelPath   string              // path to external label file (empty for embedded)
-	Labels      []string            `yaml:"-"` // list of available species labels, runtime value
-	UseXNNPACK  bool                // true to use XNNPACK delegate for inference acceleration
+	Debug           bool                // true to enable debug mode
+	Sensitivity     float64             // birdnet analysis sigmoid sensitivity
+	Threshold       float64             // threshold for prediction confidence to report
+	Overlap         float64             // birdnet analysis overlap between chunks
+	Longitude       float64             // longitude of recording location for prediction filtering
+	Latitude        float64             // latitude of recording location for prediction filtering
+	Threads         int                 // number of CPU threads to use for analysis
+	Locale          string              // language to use for labels
+	SecondaryLocale string              // optional second language of species common names shown next to Locale, empty to disable, embedded labels only
+	RangeFilter     RangeFilterSettings // range filter settings
+	ModelPath       string              // path to external model file, relative to the config directory (empty for embedded)
+	LabelPath       string              // path to external label file, relative to the config directory (empty for embedded)
+	Labels          []string            `yaml:"-"` // list of available species labels, runtime value
+	UseXNNPACK      bool                // true to use XNNPACK delegate for inference acceleration
 }
 
 // RangeFilterSettings contains settings for the range filter
 type RangeFilterSettings struct {
-	Debug       bool      // true to enable debug mode
-	Model       string    // range filter model model
-	Threshold   float32   // rangefilter species occurrence threshold
-	Species     []string  `yaml:"-"` // list of included species, runtime value
-	LastUpdated time.Time `yaml:"-"` // last time the species list was updated, runtime value
+	Debug          bool          // true to enable debug mode
+	Model          string        // range filter model model
+	Threshold      float32       // rangefilter species occurrence threshold
+	AutoUpdate     bool          // true to recompute the species list every UpdateInterval
+	UpdateInterval time.Duration // how often the species list is recomputed, 0 uses DefaultRangeFilterUpdateInterval
+	Species        []string      `yaml:"-"` // list of included species, runtime value
+	LastUpdated    time.Time     `yaml:"-"` // last time the species list was updated, runtime value
 }
 
 // BasicAuth holds settings for the password authentication
 type BasicAuth struct {
-	Enabled        bool          // true to enable password authentication
-	Password       string        // password for admin interface
-	ClientID       string        // client id for OAuth2
-	ClientSecret   string        // client secret for OAuth2
-	RedirectURI    string        // redirect uri for OAuth2
-	AuthCodeExp    time.Duration // duration for authorization code
-	AccessTokenExp time.Duration // duration for access token
+	Enabled          bool          // true to enable password authentication
+	Password         string        // password for admin interface
+	PasswordFile     string        `yaml:",omitempty"` // file to read the admin password from
+	ClientID         string        // client id for OAuth2
+	ClientSecret     string        // client secret for OAuth2
+	ClientSecretFile string        `yaml:",omitempty"` // file to read the OAuth2 client secret from
+	RedirectURI      string        // redirect uri for OAuth2
+	AuthCodeExp      time.Duration // duration for authorization code
+	AccessTokenExp   time.Duration // duration for access token
 }
 
 // SocialProvider holds settings for an OAuth2 identity provider
 type SocialProvider struct {
-	Enabled      bool   // true to enable social provider
-	ClientID     string // client id for OAuth2
-	ClientSecret string // client secret for OAuth2
-	RedirectURI  string // redirect uri for OAuth2
-	UserId       string // valid user id for OAuth2
+	Enabled          bool   // true to enable social provider
+	ClientID         string // client id for OAuth2
+	ClientSecret     string // client secret for OAuth2
+	ClientSecretFile string `yaml:",omitempty"` // file to read the OAuth2 client secret from
+	RedirectURI      string // redirect uri for OAuth2
+	UserId           string // valid user id for OAuth2
 }
 
 type AllowSubnetBypass struct {
@@ -355,6 +489,9 @@ type Security struct {
 	GithubAuth        SocialProvider    // Github OAuth2 configuration
 	SessionSecret     string            // secret for session cookie
 	SessionDuration   time.Duration     // duration for browser session cookies
+	ProtectedRoutes   []string          // path globs requiring authentication, empty protects the built-in protected routes
+	PublicRoutes      []string          // path globs never requiring authentication, take precedence over ProtectedRoutes
+	TrustedProxies    []string          // CIDRs of reverse proxies whose forwarded client address headers are honored
 }
 
 type WebServerSettings struct {
@@ -363,14 +500,23 @@ type WebServerSettings struct {
 	Port       string             // port for web server
 	Log        LogConfig          // logging configuration for web server
 	LiveStream LiveStreamSettings // live stream configuration
+	CORS       CORSSettings       // cross-origin access to the API
+}
+
+// CORSSettings configures cross-origin requests to the API, see Policy
+type CORSSettings struct {
+	AllowedOrigins   []string // origins allowed to call the API such as https://dashboard.example.com, or *, empty for same-origin only
+	AllowedMethods   []string // methods allowed in cross-origin requests, empty for all
+	AllowCredentials bool     // true to allow cookies and authorization headers from the allowed origins
 }
 
 type LiveStreamSettings struct {
 	Debug          bool   // true to enable debug mode
-	BitRate        int    // bitrate for live stream in kbps
-	SampleRate     int    // sample rate for live stream in Hz
+	BitRate        int    // bitrate for live stream in kbps, 0 to use the quality preset
+	SampleRate     int    // sample rate for live stream in Hz, 0 to use the quality preset
 	SegmentLength  int    // length of each segment in seconds
 	FfmpegLogLevel string // log level for ffmpeg
+	Quality        string // quality preset for unset bitrate and sample rate: "low", "medium" or "high"
 }
 
 // BackupRetention defines backup retention policy
@@ -398,14 +544,23 @@ func (s *LocalBackupSettings) Validate() error {
 	return nil
 }
 
+// FTPS modes of FTPBackupSettings.TLSMode
+const (
+	FTPTLSModeExplicit = "explicit" // Upgrade the connection with AUTH TLS, usually on port 21
+	FTPTLSModeImplicit = "implicit" // Connect with TLS from the start, usually on port 990
+)
+
 // FTPBackupSettings defines settings for FTP backup target
 type FTPBackupSettings struct {
-	Host     string `yaml:"host"`     // FTP server hostname or IP address
-	Port     int    `yaml:"port"`     // FTP server port (default: 21)
-	Username string `yaml:"username"` // FTP username
-	Password string `yaml:"password"` // FTP password
-	Path     string `yaml:"path"`     // Remote path on FTP server
-	UseTLS   bool   `yaml:"usetls"`   // Use FTPS (FTP over TLS)
+	Host           string `yaml:"host"`           // FTP server hostname or IP address
+	Port           int    `yaml:"port"`           // FTP server port (default: 21, 990 for implicit TLS)
+	Username       string `yaml:"username"`       // FTP username
+	Password       string `yaml:"password"`       // FTP password
+	Path           string `yaml:"path"`           // Remote path on FTP server
+	UseTLS         bool   `yaml:"usetls"`         // Use FTPS (FTP over TLS)
+	TLSMode        string `yaml:"tlsmode"`        // FTPS mode when UseTLS is set: explicit or implicit (default: explicit)
+	Passive        bool   `yaml:"passive"`        // Use passive mode data connections (default: true), the backup FTP client does not support active mode
+	TimeoutSeconds int    `yaml:"timeoutseconds"` // Timeout for opening control and data connections in seconds, 0 uses the default of 30 seconds
 }
 
 // Validate validates FTP backup settings
@@ -413,20 +568,38 @@ func (s *FTPBackupSettings) Validate() error {
 	if s.Host == "" {
 		return fmt.Errorf("FTP host cannot be empty")
 	}
+	if s.TimeoutSeconds < 0 {
+		return fmt.Errorf("FTP timeout must not be negative, got %d seconds", s.TimeoutSeconds)
+	}
+	switch {
+	case !s.UseTLS && s.TLSMode != "":
+		return fmt.Errorf("FTP TLS mode %q requires usetls to be enabled", s.TLSMode)
+	case s.UseTLS && s.TLSMode == "":
+		s.TLSMode = FTPTLSModeExplicit // UseTLS implies explicit FTPS by default
+	case s.UseTLS && s.TLSMode != FTPTLSModeExplicit && s.TLSMode != FTPTLSModeImplicit:
+		return fmt.Errorf("FTP TLS mode %q is invalid, use %s or %s", s.TLSMode, FTPTLSModeExplicit, FTPTLSModeImplicit)
+	}
 	if s.Port == 0 {
 		s.Port = 21 // Set default port
+		if s.TLSMode == FTPTLSModeImplicit {
+			s.Port = 990
+		}
 	}
 	return nil
 }
 
 // SFTPBackupSettings defines settings for SFTP backup target
 type SFTPBackupSettings struct {
-	Host           string `yaml:"host"`           // SFTP server hostname or IP address
-	Port           int    `yaml:"port"`           // SFTP server port (default: 22)
-	Username       string `yaml:"username"`       // SFTP username
-	Password       string `yaml:"password"`       // SFTP password (optional if using key)
-	PrivateKeyPath string `yaml:"privatekeypath"` // Path to private key file (optional)
-	Path           string `yaml:"path"`           // Remote path on SFTP server
+	Host                  string `yaml:"host"`                     // SFTP server hostname or IP address
+	Port                  int    `yaml:"port"`                     // SFTP server port (default: 22)
+	Username              string `yaml:"username"`                 // SFTP username
+	Password              string `yaml:"password"`                 // SFTP password (optional if using key)
+	PrivateKeyPath        string `yaml:"key_file"`                 // Path to private key file (optional)
+	PrivateKeyPassphrase  string `yaml:"privatekeypassphrase"`     // Passphrase of an encrypted private key (sensitive)
+	Path                  string `yaml:"path"`                     // Remote path on SFTP server
+	KnownHostsPath        string `yaml:"known_hosts_file"`         // Path to a known_hosts file with the server host key (default: ~/.ssh/known_hosts unless a fingerprint is pinned or verification is skipped)
+	HostKeyFingerprint    string `yaml:"host_key_fingerprint"`     // Pinned SHA256 fingerprint of the server host key, e.g. "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8" as printed by ssh-keygen -l
+	InsecureIgnoreHostKey bool   `yaml:"insecure_ignore_host_key"` // Skip host key verification, vulnerable to man-in-the-middle attacks, only for testing
 }
 
 // Validate validates SFTP backup settings
@@ -440,18 +613,33 @@ func (s *SFTPBackupSettings) Validate() error {
 	if s.Username == "" {
 		return fmt.Errorf("SFTP username cannot be empty")
 	}
+	if s.HostKeyFingerprint != "" && !ValidHostKeyFingerprint(s.HostKeyFingerprint) {
+		return fmt.Errorf("SFTP host key fingerprint %q is invalid, use the SHA256 fingerprint printed by ssh-keygen -l, e.g. SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", s.HostKeyFingerprint)
+	}
+	if s.KnownHostsPath == "" && s.HostKeyFingerprint == "" && !s.InsecureIgnoreHostKey {
+		s.KnownHostsPath = defaultKnownHostsPath() // Host keys are verified by default
+	}
+	if s.KnownHostsPath == "" && s.HostKeyFingerprint == "" && !s.InsecureIgnoreHostKey {
+		return fmt.Errorf("SFTP host key verification requires known_hosts_file or host_key_fingerprint, set insecure_ignore_host_key to skip verification")
+	}
+	if err := validateSSHKeyPassphrase(s.PrivateKeyPath, s.PrivateKeyPassphrase); err != nil {
+		return fmt.Errorf("SFTP %w", err)
+	}
 	return nil
 }
 
 // S3BackupSettings defines settings for S3-compatible backup target
 type S3BackupSettings struct {
-	Endpoint        string `yaml:"endpoint"`        // S3 endpoint URL
-	Region          string `yaml:"region"`          // AWS region
-	Bucket          string `yaml:"bucket"`          // S3 bucket name
-	AccessKeyID     string `yaml:"accesskeyid"`     // AWS access key ID
-	SecretAccessKey string `yaml:"secretaccesskey"` // AWS secret access key
-	Prefix          string `yaml:"prefix"`          // Object key prefix
-	UseSSL          bool   `yaml:"usessl"`          // Use SSL/TLS (default: true)
+	Endpoint             string `yaml:"endpoint"`             // S3 endpoint URL
+	Region               string `yaml:"region"`               // AWS region
+	Bucket               string `yaml:"bucket"`               // S3 bucket name
+	AccessKeyID          string `yaml:"accesskeyid"`          // AWS access key ID
+	SecretAccessKey      string `yaml:"secretaccesskey"`      // AWS secret access key
+	Prefix               string `yaml:"prefix"`               // Object key prefix
+	UseSSL               bool   `yaml:"usessl"`               // Use SSL/TLS (default: true)
+	ServerSideEncryption string `yaml:"serversideencryption"` // Server-side encryption of uploaded objects: AES256, aws:kms or aws:kms:dsse, empty uses the bucket default
+	KMSKeyID             string `yaml:"kmskeyid"`             // KMS key ID or ARN for aws:kms encryption, empty uses the AWS managed key
+	StorageClass         string `yaml:"storageclass"`         // Storage class of uploaded objects, e.g. STANDARD_IA or GLACIER, empty uses the bucket default
 }
 
 // Validate validates S3 backup settings
@@ -462,17 +650,19 @@ func (s *S3BackupSettings) Validate() error {
 	if s.Region == "" {
 		return fmt.Errorf("S3 region cannot be empty")
 	}
-	return nil
+	return s.validateUploadOptions()
 }
 
 // RsyncBackupSettings defines settings for rsync backup target
 type RsyncBackupSettings struct {
-	Host       string   `yaml:"host"`       // Remote host (optional for local rsync)
-	Port       int      `yaml:"port"`       // SSH port for remote rsync (default: 22)
-	Username   string   `yaml:"username"`   // SSH username for remote rsync
-	Path       string   `yaml:"path"`       // Destination path
-	SSHKeyPath string   `yaml:"sshkeypath"` // Path to SSH private key
-	Options    []string `yaml:"options"`    // Additional rsync options
+	Host                 string   `yaml:"host"`                 // Remote host (optional for local rsync)
+	Port                 int      `yaml:"port"`                 // SSH port for remote rsync (default: 22)
+	Username             string   `yaml:"username"`             // SSH username for remote rsync
+	Path                 string   `yaml:"path"`                 // Destination path
+	SSHKeyPath           string   `yaml:"key_file"`             // Path to SSH private key
+	PrivateKeyPassphrase string   `yaml:"privatekeypassphrase"` // Passphrase of an encrypted SSH private key (sensitive)
+	KnownHostsPath       string   `yaml:"known_hosts_file"`     // Path to a known_hosts file with the host key of a remote host (default: ~/.ssh/known_hosts)
+	Options              []string `yaml:"options"`              // Additional rsync options
 }
 
 // Validate validates rsync backup settings
@@ -483,13 +673,19 @@ func (s *RsyncBackupSettings) Validate() error {
 	if s.Host != "" && s.Port == 0 {
 		s.Port = 22 // Set default SSH port for remote rsync
 	}
+	if s.Host != "" && s.KnownHostsPath == "" {
+		s.KnownHostsPath = defaultKnownHostsPath()
+	}
+	if err := validateSSHKeyPassphrase(s.SSHKeyPath, s.PrivateKeyPassphrase); err != nil {
+		return fmt.Errorf("rsync SSH %w", err)
+	}
 	return nil
 }
 
 // GoogleDriveBackupSettings defines settings for Google Drive backup target
 type GoogleDriveBackupSettings struct {
-	CredentialsPath string `yaml:"credentialspath"` // Path to Google service account credentials JSON
-	FolderID        string `yaml:"folderid"`        // Google Drive folder ID where backups will be stored
+	CredentialsPath string `yaml:"credentials_file"` // Path to Google service account credentials JSON
+	FolderID        string `yaml:"folderid"`         // Google Drive folder ID where backups will be stored
 }
 
 // Validate validates Google Drive backup settings
@@ -500,11 +696,76 @@ func (s *GoogleDriveBackupSettings) Validate() error {
 	return nil
 }
 
+// WebDAVBackupSettings defines settings for WebDAV backup target, e.g. Nextcloud or ownCloud
+type WebDAVBackupSettings struct {
+	URL      string `yaml:"url"`      // WebDAV server URL, e.g. https://cloud.example.com/remote.php/dav/files/user
+	Username string `yaml:"username"` // Username for basic authentication
+	Password string `yaml:"password"` // Password or app password for basic authentication
+	Token    string `yaml:"token"`    // Bearer token, used instead of basic authentication
+	Path     string `yaml:"path"`     // Remote path below the URL where backups will be stored
+}
+
+// Validate validates WebDAV backup settings
+func (s *WebDAVBackupSettings) Validate() error {
+	if s.URL == "" {
+		return fmt.Errorf("WebDAV URL cannot be empty")
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("WebDAV URL %q is invalid, use an http or https URL", s.URL)
+	}
+	if s.Token != "" && s.Username != "" {
+		return fmt.Errorf("WebDAV bearer token and username cannot both be set, use either token or basic authentication")
+	}
+	if s.Password != "" && s.Username == "" {
+		return fmt.Errorf("WebDAV password requires a username")
+	}
+	return nil
+}
+
+// AzureBlobBackupSettings defines settings for Azure Blob Storage backup target
+type AzureBlobBackupSettings struct {
+	AccountName string `yaml:"accountname"` // Storage account name
+	AccountKey  string `yaml:"accountkey"`  // Storage account key for shared key authentication
+	SASToken    string `yaml:"sastoken"`    // Shared access signature token, used instead of the account key
+	Container   string `yaml:"container"`   // Blob container storing the backups
+	Prefix      string `yaml:"prefix"`      // Blob name prefix
+	Endpoint    string `yaml:"endpoint"`    // Blob service endpoint, e.g. http://127.0.0.1:10000/devstoreaccount1 for Azurite (default: https://<accountname>.blob.core.windows.net)
+}
+
+// Validate validates Azure Blob Storage backup settings
+func (s *AzureBlobBackupSettings) Validate() error {
+	if !azureAccountNamePattern.MatchString(s.AccountName) {
+		return fmt.Errorf("azure storage account name %q is invalid, use 3 to 24 lowercase letters and numbers", s.AccountName)
+	}
+	if !azureContainerPattern.MatchString(s.Container) || strings.Contains(s.Container, "--") {
+		return fmt.Errorf("azure container name %q is invalid, use 3 to 63 lowercase letters, numbers and single hyphens", s.Container)
+	}
+	switch {
+	case s.AccountKey == "" && s.SASToken == "":
+		return fmt.Errorf("azure blob storage requires an account key or a SAS token")
+	case s.AccountKey != "" && s.SASToken != "":
+		return fmt.Errorf("azure account key and SAS token cannot both be set")
+	case s.AccountKey != "":
+		if _, err := base64.StdEncoding.DecodeString(s.AccountKey); err != nil {
+			return fmt.Errorf("azure account key is not valid base64")
+		}
+	}
+	if s.Endpoint != "" {
+		u, err := url.Parse(s.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("azure blob endpoint %q is invalid, use an http or https URL", s.Endpoint)
+		}
+	}
+	return nil
+}
+
 // BackupTarget defines settings for a backup target
 type BackupTarget struct {
-	Type     string         `yaml:"type"`     // Specifies the type of the backup target (e.g., "local", "s3", "ftp", "sftp"). This determines the storage mechanism.
-	Enabled  bool           `yaml:"enabled"`  // If true, this backup target will be used for storing backups. At least one target should be enabled for backups to be stored.
-	Settings map[string]any `yaml:"settings"` // A map of key-value pairs for target-specific settings. TODO: Consider using BackupTargetSettings interface for type safety after implementing custom YAML unmarshaling.
+	Type      string           `yaml:"type"`                // Specifies the type of the backup target (e.g., "local", "s3", "ftp", "sftp", "rsync", "gdrive", "webdav", "azure"). This determines the storage mechanism.
+	Enabled   bool             `yaml:"enabled"`             // If true, this backup target will be used for storing backups. At least one target should be enabled for backups to be stored.
+	Settings  map[string]any   `yaml:"settings"`            // A map of key-value pairs for target-specific settings. Use Decode to read them as the BackupTargetSettings of the target type.
+	Retention *BackupRetention `yaml:"retention,omitempty"` // Optional retention policy for this target, replacing the global Retention policy when set. See BackupConfig.RetentionFor.
 }
 
 // BackupScheduleConfig defines a single backup schedule
@@ -518,14 +779,19 @@ type BackupScheduleConfig struct {
 
 // BackupConfig contains backup-related configuration
 type BackupConfig struct {
-	Enabled        bool                   `yaml:"enabled"`         // Global flag to enable or disable the entire backup system. If false, no backups (manual or scheduled) will occur.
-	Debug          bool                   `yaml:"debug"`           // If true, enables detailed debug logging for backup operations.
-	Encryption     bool                   `yaml:"encryption"`      // If true, enables encryption for backup archives. Requires EncryptionKey to be set.
-	EncryptionKey  string                 `yaml:"encryption_key"`  // Base64-encoded encryption key used for AES-256-GCM encryption of backup archives. Must be kept secret and safe.
-	SanitizeConfig bool                   `yaml:"sanitize_config"` // If true, sensitive information (like passwords, API keys) will be removed from the configuration file copy that is included in the backup archive.
-	Retention      BackupRetention        `yaml:"retention"`       // Defines policies for how long and how many backups are kept.
-	Targets        []BackupTarget         `yaml:"targets"`         // A list of configured backup targets (destinations) where backup archives will be stored.
-	Schedules      []BackupScheduleConfig `yaml:"schedules"`       // A list of schedules (e.g., daily, weekly) that define when automatic backups should run.
+	Enabled                bool                   `yaml:"enabled"`                                                          // Global flag to enable or disable the entire backup system. If false, no backups (manual or scheduled) will occur.
+	Debug                  bool                   `yaml:"debug"`                                                            // If true, enables detailed debug logging for backup operations.
+	Encryption             bool                   `yaml:"encryption"`                                                       // If true, enables encryption for backup archives. Requires EncryptionKey to be set.
+	EncryptionKey          string                 `yaml:"encryption_key" mapstructure:"encryption_key"`                     // Base64-encoded encryption key used for AES-256-GCM encryption of backup archives. Must be kept secret and safe.
+	SanitizeConfig         bool                   `yaml:"sanitize_config" mapstructure:"sanitize_config"`                   // If true, sensitive information (like passwords, API keys) will be removed from the configuration file copy that is included in the backup archive.
+	IncludeEffectiveConfig bool                   `yaml:"include_effective_config" mapstructure:"include_effective_config"` // If true, the archive also contains config.effective.yml, a snapshot of the settings with all defaults filled in so that restores do not depend on the defaults of the restoring version. Secrets are removed when SanitizeConfig is set.
+	Compression            string                 `yaml:"compression"`                                                      // Compression algorithm of backup archives: gzip (default when empty, .tar.gz), zstd (.tar.zst) or none (.tar). Archives were uncompressed .tar files before this setting, set none to keep them.
+	CompressionLevel       int                    `yaml:"compression_level" mapstructure:"compression_level"`               // Compression level of the algorithm, 1-9 for gzip and 1-22 for zstd. 0 selects the default level of the algorithm.
+	MaxConcurrentUploads   int                    `yaml:"max_concurrent_uploads" mapstructure:"max_concurrent_uploads"`     // Maximum number of targets a backup archive is stored to at the same time. 0 stores to all targets at once.
+	BandwidthLimitKBps     int                    `yaml:"bandwidth_limit_kbps" mapstructure:"bandwidth_limit_kbps"`         // Total upload bandwidth of backup targets in kilobytes (1024 bytes) per second, shared by concurrent uploads. 0 is unlimited. Local targets are not limited.
+	Retention              BackupRetention        `yaml:"retention"`                                                        // Defines policies for how long and how many backups are kept.
+	Targets                []BackupTarget         `yaml:"targets"`                                                          // A list of configured backup targets (destinations) where backup archives will be stored.
+	Schedules              []BackupScheduleConfig `yaml:"schedules"`                                                        // A list of schedules (e.g., daily, weekly) that define when automatic backups should run.
 
 	// OperationTimeouts defines timeouts for various backup operations
 	OperationTimeouts struct {
@@ -538,17 +804,21 @@ type BackupConfig struct {
 
 // Settings contains all configuration options for the BirdNET-Go application.
 type Settings struct {
-	Debug bool // true to enable debug mode
+	Debug         bool // true to enable debug mode
+	ConfigVersion int  // config file format version, files without it are version 1 and migrated on load
 
 	// Runtime values, not stored in config file
-	Version            string   `yaml:"-"` // Version from build
-	BuildDate          string   `yaml:"-"` // Build date from build
-	SystemID           string   `yaml:"-"` // Unique system identifier for telemetry
-	ValidationWarnings []string `yaml:"-"` // Configuration validation warnings for telemetry
+	Version            string            `yaml:"-"` // Version from build
+	BuildDate          string            `yaml:"-"` // Build date from build
+	SystemID           string            `yaml:"-"` // Unique system identifier for telemetry
+	ValidationWarnings []string          `yaml:"-"` // Configuration validation warnings for telemetry
+	ConfigFingerprint  string            `yaml:"-"` // ConfigFingerprint of the config file when the settings were loaded
+	inlineSecrets      map[string]string // inline config values of secrets loaded from files, restored on save
 
 	Main struct {
 		Name      string    // name of BirdNET-Go node, can be used to identify source of notes
 		TimeAs24h bool      // true 24-hour time format, false 12-hour time format
+		TimeZone  string    // IANA time zone name, e.g. Europe/Helsinki, empty uses the system time zone
 		Log       LogConfig // logging configuration
 	}
 
@@ -565,7 +835,7 @@ type Settings struct {
 		File struct {
 			Enabled bool   `yaml:"-"` // true to enable file output
 			Path    string `yaml:"-"` // directory to output results
-			Type    string `yaml:"-"` // table, csv
+			Type    string `yaml:"-"` // table, csv or json
 		}
 
 		SQLite struct {
@@ -574,22 +844,30 @@ type Settings struct {
 		}
 
 		MySQL struct {
-			Enabled  bool   // true to enable mysql output
-			Username string // username for mysql database
-			Password string // password for mysql database
-			Database string // database name for mysql database
-			Host     string // host for mysql database
-			Port     string // port for mysql database
+			Enabled         bool          // true to enable mysql output
+			Username        string        // username for mysql database
+			Password        string        // password for mysql database
+			PasswordFile    string        `yaml:",omitempty"` // file to read the mysql password from
+			Database        string        // database name for mysql database
+			Host            string        // host for mysql database
+			Port            string        // port for mysql database
+			MaxOpenConns    int           // maximum number of open connections, 0 is unlimited
+			MaxIdleConns    int           // maximum number of idle connections kept open, 0 keeps none
+			ConnMaxLifetime time.Duration // maximum time a connection is reused, 0 reuses connections forever
 		}
 	}
 
 	Backup BackupConfig // Backup configuration
+
+	Notifications []NotificationChannel // Detection notification channels such as webhooks or Telegram
 }
 
 // LogConfig defines the configuration for a log file
 type LogConfig struct {
 	Enabled     bool         // true to enable this log
-	Path        string       // Path to the log file
+	Output      string       `yaml:",omitempty"` // file, stdout, stderr, syslog or journald, main.log only, default file
+	Format      string       `yaml:",omitempty"` // text or json, main.log only, default json
+	Path        string       // Path to the log file, required for file output
 	Rotation    RotationType // Type of log rotation
 	MaxSize     int64        // Max size in bytes for RotationSize
 	RotationDay string       // Day of the week for RotationWeekly (as a string: "Sunday", "Monday", etc.)
@@ -608,6 +886,7 @@ const (
 var (
 	settingsInstance *Settings
 	once             sync.Once
+	settingsLoadErr  error // error of the load by loadSettingsOnce
 	settingsMutex    sync.RWMutex
 )
 
@@ -627,45 +906,28 @@ func Load() (*Settings, error) {
 			Build()
 	}
 
-	// Unmarshal the config into settings
-	if err := viper.Unmarshal(settings); err != nil {
-		return nil, errors.New(err).
-			Category(errors.CategoryConfiguration).
-			Context("operation", "unmarshal-config").
-			Build()
+	// Unmarshal the config into settings, the cleaned config of migrated
+	// legacy settings is written back below
+	migrated, err := decodeSettings(viper.GetViper(), settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read secrets from secret files, e.g. Docker or Kubernetes secrets
+	if err := resolveSecretFiles(settings); err != nil {
+		return nil, err
 	}
 
 	// Validate settings
 	if err := ValidateSettings(settings); err != nil {
-		// Check if it's just a validation warning (contains fallback info)
-		var validationErr ValidationError
-		if errors.As(err, &validationErr) {
-			// Report configuration issues to telemetry for debugging
-			for _, errMsg := range validationErr.Errors {
-				if strings.Contains(errMsg, "fallback") || strings.Contains(errMsg, "not supported") {
-					// This is a warning about locale fallback - report to telemetry but don't fail
-					log.Printf("Configuration warning: %s", errMsg)
-					// Store the warning for later telemetry reporting
-					settings.ValidationWarnings = append(settings.ValidationWarnings, errMsg)
-					// Note: Telemetry reporting will happen later in birdnet package when Sentry is initialized
-				} else {
-					// This is a real validation error - fail the config load
-					return nil, errors.New(err).
-						Category(errors.CategoryValidation).
-						Context("component", "settings").
-						Context("error_msg", errMsg).
-						Build()
-				}
-			}
-		} else {
-			// Other validation errors should fail the config load
-			return nil, errors.New(err).
-				Category(errors.CategoryValidation).
-				Context("component", "settings").
-				Build()
+		if err := checkValidationResult(err); err != nil {
+			return nil, err
 		}
 	}
 
+	// Warn about keys of the config file no setting consumes
+	warnUnknownKeys(viper.ConfigFileUsed(), settings)
+
 	// Log the loaded species settings for debugging
 	/*
 		log.Printf("Loaded Species Settings: Include: %v, Exclude: %v, Threshold: %v",
@@ -676,9 +938,97 @@ func Load() (*Settings, error) {
 
 	// Save settings instance
 	settingsInstance = settings
+
+	// Write migrated settings back so that the migration only runs once
+	if migrated {
+		if err := saveSettingsFile(settings); err != nil {
+			log.Printf("Failed to write migrated settings to config file: %v", err)
+		}
+	}
+
+	// Remember the loaded config file content to detect external changes
+	if data, err := readConfigFile(viper.ConfigFileUsed()); err == nil {
+		recordChecksum(data)
+		settings.ConfigFingerprint = contentFingerprint(data)
+	}
+
+	// Later saves are recorded as changes against the loaded settings
+	speciesListMutex.RLock()
+	configChanges.setSaved(settings)
+	speciesListMutex.RUnlock()
+
 	return settingsInstance, nil
 }
 
+// decodeSettings unmarshals the config read by v into settings and migrates
+// it from its config version to CurrentConfigVersion, it reports whether
+// settings were migrated
+func decodeSettings(v *viper.Viper, settings *Settings) (bool, error) {
+	if err := v.Unmarshal(settings, viper.DecodeHook(settingsDecodeHook())); err != nil {
+		return false, errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "unmarshal-config").
+			Build()
+	}
+
+	// The weather section of the config file takes precedence over legacy
+	// OpenWeather settings
+	migrated := false
+	if v.InConfig("realtime.weather") {
+		migrated = migrateLegacyOpenWeather(settings, true)
+	}
+
+	from := max(settings.ConfigVersion, 1)
+	if from > CurrentConfigVersion {
+		log.Printf("Config file version %d is newer than version %d supported by this build, settings not known to this build are ignored",
+			from, CurrentConfigVersion)
+		return migrated, nil
+	}
+	if from == CurrentConfigVersion {
+		return migrated, nil
+	}
+
+	applied, err := runMigrations(settings, from, CurrentConfigVersion)
+	if err != nil {
+		return false, err
+	}
+	for _, description := range applied {
+		log.Printf("Config migration: %s", description)
+	}
+	return true, nil
+}
+
+// checkValidationResult separates validation warnings, such as an unsupported
+// locale falling back to a default, from validation errors that must fail.
+// Warnings are logged, validators store their telemetry entries in
+// settings.ValidationWarnings themselves.
+func checkValidationResult(err error) error {
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) {
+		// Other validation errors should fail the config load
+		return errors.New(err).
+			Category(errors.CategoryValidation).
+			Context("component", "settings").
+			Build()
+	}
+
+	for _, issue := range validationErr.Issues {
+		if issue.Severity != SeverityError {
+			log.Printf("Configuration warning: %s", issue.Message)
+			continue
+		}
+		// This is a real validation error - fail the config load
+		return errors.New(err).
+			Category(errors.CategoryValidation).
+			Context("component", "settings").
+			Context("field", issue.Field).
+			Context("error_msg", issue.Message).
+			Build()
+	}
+
+	return nil
+}
+
 // initViper initializes viper with default values and reads the configuration file.
 func initViper() error {
 	viper.SetConfigName("config")
@@ -702,11 +1052,15 @@ func initViper() error {
 	// function defined in defaults.go
 	setDefaultConfig()
 
-	// Read configuration file
-	err = viper.ReadInConfig()
+	// Read configuration file, decrypting it when it is encrypted
+	err = readInConfig(configPaths)
 	if err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
 		if errors.As(err, &configFileNotFoundError) {
+			// Immutable deployments must provide a config file
+			if !autoCreateEnabled() {
+				return missingConfigError(configPaths)
+			}
 			// Config file not found, create config with defaults
 			return createDefaultConfig()
 		}
@@ -730,7 +1084,10 @@ func createDefaultConfig() error {
 			Build()
 	}
 	configPath := filepath.Join(configPaths[0], "config.yaml")
-	defaultConfig := getDefaultConfig()
+	defaultConfig, err := generateDefaultConfig()
+	if err != nil {
+		return err
+	}
 
 	// If the basicauth secret is not set, generate a random one
 	if viper.GetString("security.basicauth.clientsecret") == "" {
@@ -746,9 +1103,10 @@ func createDefaultConfig() error {
 			Build()
 	}
 
-	// Write default config file with secure permissions (0600)
-	// Only the owner should be able to read/write the config file for security
-	if err := os.WriteFile(configPath, []byte(defaultConfig), 0o600); err != nil {
+	// Write default config file with secure permissions (0600), encrypted when
+	// a config key is set. Only the owner should be able to read/write the
+	// config file for security
+	if err := writeConfigFile(configPath, []byte(defaultConfig)); err != nil {
 		return errors.New(err).
 			Category(errors.CategoryFileIO).
 			Context("operation", "write-default-config").
@@ -757,7 +1115,7 @@ func createDefaultConfig() error {
 	}
 
 	fmt.Println("Created default config file at:", configPath)
-	return viper.ReadInConfig()
+	return readInConfig(configPaths)
 }
 
 // getDefaultConfig reads the default configuration from the embedded config.yaml file.
@@ -777,20 +1135,63 @@ func GetSettings() *Settings {
 }
 
 // SaveSettings saves the current settings to the configuration file.
-// It uses UpdateYAMLConfig to handle the atomic write process.
+// It uses UpdateYAMLConfig to handle the atomic write process. Calls within
+// the save debounce window are coalesced into a single write, see
+// SetSaveDebounce. Nothing is written when the settings equal the content
+// the config file was last read or written with.
 func SaveSettings() error {
+	return settingsWriter.Do()
+}
+
+// SaveSettingsAsync saves the settings like SaveSettings without waiting for
+// the save debounce window, the returned channel receives the result of the
+// write
+func SaveSettingsAsync() <-chan error {
+	return settingsWriter.Request()
+}
+
+// writeSettings writes the current settings to the configuration file
+func writeSettings() error {
 	settingsMutex.RLock()
 	defer settingsMutex.RUnlock()
 
+	// Skip the write when the config file already has the settings
+	configChanges.mu.Lock()
+	saved := configChanges.saved
+	configChanges.mu.Unlock()
+	speciesListMutex.RLock()
+	unchanged := saved.Equal(settingsInstance)
+	speciesListMutex.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	if err := saveSettingsFile(settingsInstance); err != nil {
+		return err
+	}
+
+	// Record the settings changed since the config file was last written
+	speciesListMutex.RLock()
+	configChanges.recordSave(settingsInstance)
+	speciesListMutex.RUnlock()
+	return nil
+}
+
+// saveSettingsFile writes settings to the configuration file, the caller must
+// hold settingsMutex
+func saveSettingsFile(settings *Settings) error {
 	// Create a deep copy of the settings
-	settingsCopy := *settingsInstance
+	settingsCopy := *settings
 
 	// Create a separate copy of the species list
 	speciesListMutex.RLock()
-	settingsCopy.BirdNET.RangeFilter.Species = make([]string, len(settingsInstance.BirdNET.RangeFilter.Species))
-	copy(settingsCopy.BirdNET.RangeFilter.Species, settingsInstance.BirdNET.RangeFilter.Species)
+	settingsCopy.BirdNET.RangeFilter.Species = make([]string, len(settings.BirdNET.RangeFilter.Species))
+	copy(settingsCopy.BirdNET.RangeFilter.Species, settings.BirdNET.RangeFilter.Species)
 	speciesListMutex.RUnlock()
 
+	// Never write secrets read from secret files back to the config file
+	restoreInlineSecrets(&settingsCopy)
+
 	// Find the path of the current config file
 	configPath, err := FindConfigFile()
 	if err != nil {
@@ -800,8 +1201,8 @@ func SaveSettings() error {
 			Build()
 	}
 
-	// Save the settings to the config file
-	if err := SaveYAMLConfig(configPath, &settingsCopy); err != nil {
+	// Save the settings to the config file, keeping its comments
+	if err := SaveYAMLConfigPreservingComments(configPath, &settingsCopy); err != nil {
 		return errors.New(err).
 			Category(errors.CategoryFileIO).
 			Context("operation", "save-yaml-config").
@@ -813,26 +1214,53 @@ func SaveSettings() error {
 	return nil
 }
 
-// Setting returns the current settings instance, initializing it if necessary
+// Setting returns the current settings instance, initializing it if necessary.
+// It exits the process when the settings cannot be loaded, like MustSetting.
 func Setting() *Settings {
+	return MustSetting()
+}
+
+// MustSetting returns the current settings instance, initializing it if
+// necessary, and exits the process when the settings cannot be loaded
+func MustSetting() *Settings {
+	settings, err := SettingOK()
+	if err != nil {
+		// Fatal error loading settings - application cannot continue
+		log.Fatalf("Error loading settings: %v", err)
+	}
+	return settings
+}
+
+// SettingOK returns the current settings instance, initializing it if
+// necessary, and returns the load error instead of exiting the process. The
+// settings are loaded only once, later calls return the error of the first
+// load until SetTestSettings resets the global settings.
+func SettingOK() (*Settings, error) {
+	if err := loadSettingsOnce(); err != nil {
+		return nil, err
+	}
+	return GetSettings(), nil
+}
+
+// loadSettingsOnce loads the settings on first use unless they were already
+// loaded, and returns the error of that load
+func loadSettingsOnce() error {
 	once.Do(func() {
 		if settingsInstance == nil {
-			_, err := Load()
-			if err != nil {
-				// Fatal error loading settings - application cannot continue
-				enhancedErr := errors.New(err).
+			if _, err := Load(); err != nil {
+				settingsLoadErr = errors.New(err).
 					Category(errors.CategoryConfiguration).
 					Context("operation", "load-settings-init").
 					Build()
-				log.Fatalf("Error loading settings: %v", enhancedErr)
 			}
 		}
 	})
-	return GetSettings()
+	return settingsLoadErr
 }
 
 // SaveYAMLConfig updates the YAML configuration file with new settings.
-// It overwrites the existing file, not preserving comments or structure.
+// It overwrites the existing file, not preserving comments or structure,
+// see SaveYAMLConfigPreservingComments.
 func SaveYAMLConfig(configPath string, settings *Settings) error {
 	// Marshal the settings struct to YAML
 	yamlData, err := yaml.Marshal(settings)
@@ -843,55 +1271,13 @@ func SaveYAMLConfig(configPath string, settings *Settings) error {
 			Build()
 	}
 
-	// Write the YAML data to a temporary file
-	// This is done to ensure atomic write operation
-	tempFile, err := os.CreateTemp(filepath.Dir(configPath), "config-*.yaml")
-	if err != nil {
-		return errors.New(err).
-			Category(errors.CategoryFileIO).
-			Context("operation", "create-temp-file").
-			Context("dir", filepath.Dir(configPath)).
-			Build()
+	// Write the config atomically, encrypted when a config key is set
+	if err := writeConfigFile(configPath, yamlData); err != nil {
+		return err
 	}
-	tempFileName := tempFile.Name()
-	// Ensure the temporary file is removed in case of any failure
-	defer func() {
-		if err := os.Remove(tempFileName); err != nil && !os.IsNotExist(err) {
-			log.Printf("Failed to remove temporary file: %v", err)
-		}
-	}()
 
-	// Write the YAML data to the temporary file
-	if _, err := tempFile.Write(yamlData); err != nil {
-		// Best effort close on error path
-		_ = tempFile.Close()
-		return errors.New(err).
-			Category(errors.CategoryFileIO).
-			Context("operation", "write-temp-file").
-			Build()
-	}
-	// Close the temporary file after writing
-	if err := tempFile.Close(); err != nil {
-		return errors.New(err).
-			Category(errors.CategoryFileIO).
-			Context("operation", "close-temp-file").
-			Build()
-	}
-
-	// Try to rename the temporary file to replace the original config file
-	// This is typically an atomic operation on most filesystems
-	if err := os.Rename(tempFileName, configPath); err != nil {
-		// If rename fails (e.g., cross-device link), fall back to copy & delete
-		// This might happen when the temp directory is on a different filesystem
-		if err := moveFile(tempFileName, configPath); err != nil {
-			return errors.New(err).
-				Category(errors.CategoryFileIO).
-				Context("operation", "move-config-file").
-				Context("src", tempFileName).
-				Context("dst", configPath).
-				Build()
-		}
-	}
+	// Remember the written content so that the config watcher ignores our own write
+	recordChecksum(yamlData)
 
 	// If we've reached this point, the operation was successful
 	return nil
@@ -921,6 +1307,8 @@ func (s *Settings) GetWeatherSettings() (provider string, openweather OpenWeathe
 		return s.Realtime.Weather.Provider, s.Realtime.Weather.OpenWeather
 	}
 
+	// Legacy format, Load migrates it so this is only reached for settings
+	// that were not loaded from a config file
 	if s.Realtime.OpenWeather.Enabled {
 		return "openweather", s.Realtime.OpenWeather
 	}
@@ -0,0 +1,145 @@
+// conf/constraints.go enum and range constraints shared by validation and the JSON schema
+package conf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// settingConstraint describes the values accepted by a setting. Nil bounds
+// are unbounded and an empty Enum accepts any value.
+type settingConstraint struct {
+	Enum    []any
+	Minimum *float64
+	Maximum *float64
+}
+
+// between returns a constraint for values from minimum to maximum inclusive
+func between(minimum, maximum float64) settingConstraint {
+	return settingConstraint{Minimum: &minimum, Maximum: &maximum}
+}
+
+// atLeast returns a constraint for values of at least minimum
+func atLeast(minimum float64) settingConstraint {
+	return settingConstraint{Minimum: &minimum}
+}
+
+// oneOf returns a constraint for a fixed set of string values
+func oneOf(values ...string) settingConstraint {
+	enum := make([]any, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return settingConstraint{Enum: enum}
+}
+
+// oneOfInts returns a constraint for a fixed set of integer values
+func oneOfInts(values ...int) settingConstraint {
+	enum := make([]any, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return settingConstraint{Enum: enum}
+}
+
+// settingConstraints holds the constraints of settings keyed by their lowercase
+// config key. ValidateSettings and GenerateJSONSchema both read them so that
+// validation and the published schema cannot diverge.
+var settingConstraints = map[string]settingConstraint{
+	"main.log.output":                                  oneOf(LogOutputFile, LogOutputStdout, LogOutputStderr, LogOutputSyslog, LogOutputJournald),
+	"main.log.format":                                  oneOf(LogFormatText, LogFormatJSON),
+	"birdnet.sensitivity":                              between(0, 1.5),
+	"birdnet.threshold":                                between(0, 1),
+	"birdnet.overlap":                                  between(0, 2.99),
+	"birdnet.longitude":                                between(-180, 180),
+	"birdnet.latitude":                                 between(-90, 90),
+	"birdnet.threads":                                  atLeast(0),
+	"birdnet.rangefilter.model":                        oneOf(RangeFilterModelLatest, RangeFilterModelLegacy),
+	"birdnet.rangefilter.threshold":                    between(0, 1),
+	"webserver.livestream.bitrate":                     between(16, 320),
+	"webserver.livestream.segmentlength":               between(2, 30),
+	"webserver.livestream.samplerate":                  oneOfInts(16000, 22050, 44100, 48000),
+	"webserver.livestream.ffmpegloglevel":              oneOf("quiet", "panic", "fatal", "error", "warning", "info", "verbose", "debug", "trace"),
+	"webserver.livestream.quality":                     oneOf(LiveStreamQualityLow, LiveStreamQualityMedium, LiveStreamQualityHigh),
+	"realtime.interval":                                atLeast(0),
+	"realtime.mqtt.retrysettings.maxretries":           atLeast(0),
+	"realtime.rtsp.health.healthydatathreshold":        between(1, MaxRTSPHealthyDataThreshold.Seconds()),
+	"realtime.rtsp.health.monitoringinterval":          atLeast(1),
+	"realtime.rtsp.reconnectbackoff.initialdelay":      atLeast(1),
+	"realtime.rtsp.reconnectbackoff.backoffmultiplier": atLeast(1),
+	"realtime.privacyfilter.confidence":                between(0, 1),
+	"realtime.dogbarkfilter.confidence":                between(0, 1),
+	"realtime.dogbarkfilter.remember":                  atLeast(0),
+	"realtime.audio.gain":                              between(MinAudioGain, MaxAudioGain),
+	"realtime.audio.soundlevel.interval":               atLeast(MinSoundLevelInterval),
+	"realtime.audio.streamtransport":                   oneOf(StreamTransportAuto, StreamTransportSSE, StreamTransportWS),
+	"realtime.dedupstrategy":                           oneOf(DedupStrategyHighestConfidence, DedupStrategyFirst),
+	"realtime.confidenceformat":                        oneOf(ConfidenceFormatFraction, ConfidenceFormatPercent),
+	"realtime.quiethours.suppress":                     oneOf(QuietHoursActions, QuietHoursNotifications, QuietHoursMQTT),
+	"realtime.maxconcurrentactions":                    atLeast(0),
+	"realtime.onactionoverflow":                        oneOf(ActionOverflowQueue, ActionOverflowDrop),
+	"realtime.audio.export.type":                       oneOf("wav", "flac", "aac", "opus", "mp3"),
+	"realtime.audio.export.retention.policy":           oneOf(validRetentionPolicies...),
+	"realtime.audio.export.spectrogram.width":          between(MinSpectrogramSize, MaxSpectrogramSize),
+	"realtime.audio.export.spectrogram.height":         between(MinSpectrogramSize, MaxSpectrogramSize),
+	"realtime.audio.export.spectrogram.colorscheme":    oneOf(SpectrogramColorDefault, SpectrogramColorMonochrome, SpectrogramColorLight, SpectrogramColorHigh),
+	"realtime.birdweather.threshold":                   between(0, 1),
+	"realtime.birdweather.locationaccuracy":            atLeast(0),
+	"realtime.birdweather.uploadmode":                  oneOf(BirdweatherUploadDetections, BirdweatherUploadSoundscapes, BirdweatherUploadBoth),
+	"realtime.dashboard.summarylimit":                  between(1, MaxSummaryLimit),
+	"realtime.dashboard.thumbnails.imageprovider":      oneOf(append([]string{ImageProviderAuto}, imageProviders...)...),
+	"realtime.dashboard.thumbnails.fallbackpolicy":     oneOf(FallbackPolicyNone, FallbackPolicyAll),
+	"realtime.dashboard.thumbnails.providers":          oneOf(imageProviders...),
+	"realtime.weather.provider":                        oneOf("none", "yrno", "openweather"),
+	"realtime.weather.pollinterval":                    atLeast(15),
+	"realtime.weather.polljitter":                      atLeast(0),
+	"realtime.monitoring.checkinterval":                atLeast(MinMonitoringCheckInterval),
+}
+
+// constraintFor returns the constraint of a config key. Unknown keys are a
+// programming error, panicking ensures a typo cannot silently disable validation.
+func constraintFor(key string) settingConstraint {
+	constraint, ok := settingConstraints[key]
+	if !ok {
+		panic(fmt.Sprintf("conf: no constraint defined for %q", key))
+	}
+	return constraint
+}
+
+// inRange reports whether value is within the bounds of the constraint
+func (c settingConstraint) inRange(value float64) bool {
+	if c.Minimum != nil && value < *c.Minimum {
+		return false
+	}
+	if c.Maximum != nil && value > *c.Maximum {
+		return false
+	}
+	return true
+}
+
+// allows reports whether value is one of the enum values of the constraint
+func (c settingConstraint) allows(value any) bool {
+	return len(c.Enum) == 0 || slices.Contains(c.Enum, value)
+}
+
+// String describes the accepted values for use in validation messages,
+// e.g. "between 0 and 1.5"
+func (c settingConstraint) String() string {
+	switch {
+	case len(c.Enum) > 0:
+		values := make([]string, len(c.Enum))
+		for i, value := range c.Enum {
+			values[i] = fmt.Sprint(value)
+		}
+		return "one of " + strings.Join(values, ", ")
+	case c.Minimum != nil && c.Maximum != nil:
+		return fmt.Sprintf("between %g and %g", *c.Minimum, *c.Maximum)
+	case c.Minimum != nil:
+		return fmt.Sprintf("at least %g", *c.Minimum)
+	case c.Maximum != nil:
+		return fmt.Sprintf("at most %g", *c.Maximum)
+	default:
+		return "any value"
+	}
+}
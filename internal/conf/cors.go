@@ -0,0 +1,110 @@
+// conf/cors.go cross-origin access to the web API
+package conf
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// CORSAnyOrigin allows requests from every origin
+const CORSAnyOrigin = "*"
+
+// DefaultCORSMethods are the methods accepted in AllowedMethods, all allowed
+// when none are configured. OPTIONS is answered by the preflight handling.
+var DefaultCORSMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// CORSPolicy is the resolved cross-origin policy applied by the web server.
+// With no AllowedOrigins only same-origin requests are allowed and no CORS
+// headers are sent.
+type CORSPolicy struct {
+	AllowedOrigins   []string // normalized origins such as https://dashboard.example.com, or *
+	AllowedMethods   []string // uppercase methods allowed in cross-origin requests
+	AllowCredentials bool     // true to allow cookies and authorization headers
+}
+
+// Enabled reports whether cross-origin requests are allowed from any origin
+// at all
+func (p CORSPolicy) Enabled() bool {
+	return len(p.AllowedOrigins) > 0
+}
+
+// Policy returns the policy for the settings, origins normalized to
+// scheme://host[:port] and methods uppercased, DefaultCORSMethods when none
+// are configured
+func (c *CORSSettings) Policy() CORSPolicy {
+	policy := CORSPolicy{AllowCredentials: c.AllowCredentials}
+	for _, origin := range c.AllowedOrigins {
+		if normalized, err := normalizeOrigin(origin); err == nil && !slices.Contains(policy.AllowedOrigins, normalized) {
+			policy.AllowedOrigins = append(policy.AllowedOrigins, normalized)
+		}
+	}
+	for _, method := range c.AllowedMethods {
+		if method = strings.ToUpper(strings.TrimSpace(method)); !slices.Contains(policy.AllowedMethods, method) {
+			policy.AllowedMethods = append(policy.AllowedMethods, method)
+		}
+	}
+	if len(policy.AllowedMethods) == 0 {
+		policy.AllowedMethods = slices.Clone(DefaultCORSMethods)
+	}
+	return policy
+}
+
+// normalizeOrigin returns an origin as scheme://host[:port] in lowercase, an
+// error unless it is * or an http or https URL without path, query or
+// credentials
+func normalizeOrigin(origin string) (string, error) {
+	origin = strings.TrimSpace(origin)
+	if origin == CORSAnyOrigin {
+		return origin, nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("host is missing")
+	}
+	if u.User != nil || strings.TrimSuffix(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("origin must not have credentials, a path, query or fragment")
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
+
+// validateCORSSettings checks that the allowed origins are valid URLs or *
+// and the allowed methods are HTTP methods. Credentials cannot be allowed for
+// every origin, browsers reject that combination.
+func validateCORSSettings(settings *CORSSettings) error {
+	for _, origin := range settings.AllowedOrigins {
+		if _, err := normalizeOrigin(origin); err != nil {
+			return errors.New(fmt.Errorf("invalid CORS origin %q, must be a URL such as https://example.com or *: %w", origin, err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeCORSOrigin).
+				Build()
+		}
+	}
+	if settings.AllowCredentials && slices.ContainsFunc(settings.AllowedOrigins, func(origin string) bool {
+		return strings.TrimSpace(origin) == CORSAnyOrigin
+	}) {
+		return errors.New(fmt.Errorf("CORS credentials cannot be allowed for origin *, list the allowed origins")).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeCORSOrigin).
+			Build()
+	}
+	for _, method := range settings.AllowedMethods {
+		if !slices.Contains(DefaultCORSMethods, strings.ToUpper(strings.TrimSpace(method))) {
+			return errors.New(fmt.Errorf("invalid CORS method %q, must be one of %s", method, strings.Join(DefaultCORSMethods, ", "))).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeCORSMethod).
+				Build()
+		}
+	}
+	return nil
+}
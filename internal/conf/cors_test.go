@@ -0,0 +1,77 @@
+package conf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCORSPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings CORSSettings
+		want     CORSPolicy
+	}{
+		{"same origin only", CORSSettings{}, CORSPolicy{AllowedMethods: DefaultCORSMethods}},
+		{"normalized origins", CORSSettings{
+			AllowedOrigins:   []string{"HTTPS://Dashboard.example.com/", "https://dashboard.example.com", "http://localhost:3000"},
+			AllowCredentials: true,
+		}, CORSPolicy{
+			AllowedOrigins:   []string{"https://dashboard.example.com", "http://localhost:3000"},
+			AllowedMethods:   DefaultCORSMethods,
+			AllowCredentials: true,
+		}},
+		{"configured methods", CORSSettings{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"get", " HEAD ", "GET"},
+		}, CORSPolicy{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "HEAD"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.settings.Policy()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Policy() = %+v, want %+v", got, tt.want)
+			}
+			if got.Enabled() != (len(tt.want.AllowedOrigins) > 0) {
+				t.Errorf("Enabled() = %v with origins %v", got.Enabled(), got.AllowedOrigins)
+			}
+		})
+	}
+}
+
+func TestValidateCORSSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings CORSSettings
+		wantCode string
+	}{
+		{"empty", CORSSettings{}, ""},
+		{"valid", CORSSettings{AllowedOrigins: []string{"https://example.com", "http://192.168.1.10:8080"}, AllowedMethods: []string{"get", "POST"}, AllowCredentials: true}, ""},
+		{"any origin", CORSSettings{AllowedOrigins: []string{"*"}}, ""},
+		{"missing scheme", CORSSettings{AllowedOrigins: []string{"example.com"}}, ErrCodeCORSOrigin},
+		{"unsupported scheme", CORSSettings{AllowedOrigins: []string{"ftp://example.com"}}, ErrCodeCORSOrigin},
+		{"origin with path", CORSSettings{AllowedOrigins: []string{"https://example.com/app"}}, ErrCodeCORSOrigin},
+		{"credentials with any origin", CORSSettings{AllowedOrigins: []string{"*"}, AllowCredentials: true}, ErrCodeCORSOrigin},
+		{"unknown method", CORSSettings{AllowedMethods: []string{"TRACE"}}, ErrCodeCORSMethod},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := tt.settings
+			err := validateCORSSettings(&settings)
+			if (err != nil) != (tt.wantCode != "") {
+				t.Fatalf("validateCORSSettings() error = %v, want code %q", err, tt.wantCode)
+			}
+			if err != nil && validationCode(err) != tt.wantCode {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// conf/dashboard.go bounds of the dashboard summary table
+package conf
+
+import (
+	"fmt"
+	"log"
+)
+
+// Summary table limits of Dashboard.SummaryLimit
+const (
+	DefaultSummaryLimit = 30
+	MaxSummaryLimit     = 1000
+)
+
+// EffectiveSummaryLimit returns the number of species the summary table
+// shows, the default for limits that are not positive and at most
+// MaxSummaryLimit, so that a misconfigured limit never empties the table
+func (d Dashboard) EffectiveSummaryLimit() int {
+	switch {
+	case d.SummaryLimit <= 0:
+		return DefaultSummaryLimit
+	case d.SummaryLimit > MaxSummaryLimit:
+		return MaxSummaryLimit
+	default:
+		return d.SummaryLimit
+	}
+}
+
+// capSummaryLimit caps a summary limit above MaxSummaryLimit and returns a
+// warning, an empty string when the limit is within bounds
+func capSummaryLimit(dashboard *Dashboard, settings *Settings) string {
+	if dashboard.SummaryLimit <= MaxSummaryLimit {
+		return ""
+	}
+
+	message := fmt.Sprintf("dashboard summary limit %d is above the maximum of %d, using %d",
+		dashboard.SummaryLimit, MaxSummaryLimit, MaxSummaryLimit)
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeDashboardSummaryLimit, "summary-limit-capped")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-dashboard-validation: %s", message))
+	dashboard.SummaryLimit = MaxSummaryLimit
+	return message
+}
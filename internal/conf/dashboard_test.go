@@ -0,0 +1,51 @@
+package conf
+
+import (
+	"testing"
+)
+
+func TestEffectiveSummaryLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{-5, DefaultSummaryLimit},
+		{0, DefaultSummaryLimit},
+		{1, 1},
+		{30, 30},
+		{MaxSummaryLimit, MaxSummaryLimit},
+		{MaxSummaryLimit + 1, MaxSummaryLimit},
+	}
+	for _, tt := range tests {
+		if got := (Dashboard{SummaryLimit: tt.limit}).EffectiveSummaryLimit(); got != tt.want {
+			t.Errorf("EffectiveSummaryLimit() with %d = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSummaryLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, limit := range []int{0, -1} {
+		if err := validateDashboardSettings(&Dashboard{SummaryLimit: limit}); err == nil {
+			t.Errorf("validateDashboardSettings() with limit %d error = nil, want an error", limit)
+		}
+	}
+	if err := validateDashboardSettings(&Dashboard{SummaryLimit: 5000}); err != nil {
+		t.Errorf("validateDashboardSettings() with limit 5000 error = %v, want nil as it is capped", err)
+	}
+
+	settings := &Settings{}
+	dashboard := &Dashboard{SummaryLimit: 5000}
+	if warning := capSummaryLimit(dashboard, settings); warning == "" || dashboard.SummaryLimit != MaxSummaryLimit {
+		t.Errorf("capSummaryLimit() = %q with limit %d, want a warning and %d", warning, dashboard.SummaryLimit, MaxSummaryLimit)
+	}
+	if len(settings.ValidationWarnings) != 1 {
+		t.Errorf("ValidationWarnings = %v, want one warning", settings.ValidationWarnings)
+	}
+	if warning := capSummaryLimit(&Dashboard{SummaryLimit: 30}, settings); warning != "" {
+		t.Errorf("capSummaryLimit() with limit 30 = %q, want no warning", warning)
+	}
+}
@@ -0,0 +1,129 @@
+// conf/database.go connection strings of the detection database
+package conf
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Defaults of the MySQL connection pool
+const (
+	DefaultMySQLMaxOpenConns    = 25
+	DefaultMySQLMaxIdleConns    = 10
+	DefaultMySQLConnMaxLifetime = 5 * time.Minute
+)
+
+// Drivers returned by DatabaseDSN
+const (
+	DatabaseDriverSQLite = "sqlite"
+	DatabaseDriverMySQL  = "mysql"
+)
+
+// DatabaseDSN returns the driver and connection string of the enabled
+// database, the file path for SQLite and user:pass@tcp(host:port)/db with
+// connection parameters for MySQL. SQLite wins when both databases are
+// enabled, like the datastore does. It fails when no database is enabled.
+func (s *Settings) DatabaseDSN() (driver, dsn string, err error) {
+	switch {
+	case s.Output.SQLite.Enabled:
+		dsn, err = s.SQLiteDSN()
+		return DatabaseDriverSQLite, dsn, err
+	case s.Output.MySQL.Enabled:
+		dsn, err = s.MySQLDSN()
+		return DatabaseDriverMySQL, dsn, err
+	default:
+		return "", "", errors.New(fmt.Errorf("no database is enabled, enable the SQLite or MySQL output")).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "database-dsn").
+			Build()
+	}
+}
+
+// SQLiteDSN returns the file path of the SQLite database
+func (s *Settings) SQLiteDSN() (string, error) {
+	if s.Output.SQLite.Path == "" {
+		return "", errors.New(fmt.Errorf("SQLite database path is not set")).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "database-dsn").
+			Build()
+	}
+	return s.Output.SQLite.Path, nil
+}
+
+// MySQLDSN returns the connection string of the MySQL database. The DSN is
+// built by the MySQL driver so that credentials with special characters parse
+// back unchanged.
+func (s *Settings) MySQLDSN() (string, error) {
+	m := s.Output.MySQL
+	// The MySQL DSN parser splits the user from the password at the first
+	// colon, a colon in the username cannot be represented
+	if strings.Contains(m.Username, ":") {
+		return "", errors.New(fmt.Errorf("MySQL username must not contain a colon")).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "database-dsn").
+			Build()
+	}
+	cfg := mysql.NewConfig()
+	cfg.User = m.Username
+	cfg.Passwd = m.Password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(m.Host, m.Port)
+	cfg.DBName = m.Database
+	cfg.Params = map[string]string{"charset": "utf8mb4"}
+	cfg.ParseTime = true
+	cfg.Loc = time.Local
+	return cfg.FormatDSN(), nil
+}
+
+// databaseConflictWarning returns a warning when both databases are enabled,
+// or an empty string. SQLite is enabled by default, so enabling MySQL without
+// disabling SQLite keeps the detections in SQLite.
+func databaseConflictWarning(settings *Settings) string {
+	if settings.Output.SQLite.Enabled && settings.Output.MySQL.Enabled {
+		return "both the SQLite and MySQL databases are enabled, only SQLite is used, disable one of them"
+	}
+	return ""
+}
+
+// DatabasePoolConfig holds the connection pool settings passed to
+// database/sql, zero values have the database/sql meaning
+type DatabasePoolConfig struct {
+	MaxOpenConns    int           // maximum number of open connections, 0 is unlimited
+	MaxIdleConns    int           // maximum number of idle connections, 0 keeps none
+	ConnMaxLifetime time.Duration // maximum time a connection is reused, 0 is forever
+}
+
+// MySQLPoolConfig returns the connection pool settings of the MySQL database.
+// Idle connections are capped at the open connection limit like database/sql
+// does.
+func (s *Settings) MySQLPoolConfig() DatabasePoolConfig {
+	m := s.Output.MySQL
+	pool := DatabasePoolConfig{
+		MaxOpenConns:    m.MaxOpenConns,
+		MaxIdleConns:    m.MaxIdleConns,
+		ConnMaxLifetime: m.ConnMaxLifetime,
+	}
+	if pool.MaxOpenConns > 0 {
+		pool.MaxIdleConns = min(pool.MaxIdleConns, pool.MaxOpenConns)
+	}
+	return pool
+}
+
+// validateMySQLPool checks that the MySQL connection pool settings are not
+// negative
+func validateMySQLPool(settings *Settings) error {
+	m := settings.Output.MySQL
+	if m.MaxOpenConns < 0 || m.MaxIdleConns < 0 || m.ConnMaxLifetime < 0 {
+		return errors.New(fmt.Errorf("MySQL connection pool settings must not be negative, got maxopenconns %d, maxidleconns %d and connmaxlifetime %s",
+			m.MaxOpenConns, m.MaxIdleConns, m.ConnMaxLifetime)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeMySQLPool).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package conf
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestDatabaseDSNSQLite(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Output.SQLite.Enabled = true
+	settings.Output.SQLite.Path = "data/birdnet.db"
+
+	driver, dsn, err := settings.DatabaseDSN()
+	if err != nil {
+		t.Fatalf("DatabaseDSN() error = %v", err)
+	}
+	if driver != DatabaseDriverSQLite || dsn != "data/birdnet.db" {
+		t.Errorf("DatabaseDSN() = %q, %q, want %q, %q", driver, dsn, DatabaseDriverSQLite, "data/birdnet.db")
+	}
+}
+
+func TestDatabaseDSNMySQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     string
+	}{
+		{"plain", "birdnet", "secret", "birdnet:secret@tcp(db:3306)/birdnet?loc=Local&parseTime=true&charset=utf8mb4"},
+		{"special characters", "bird@net", "p@ss:w/rd?&=", "bird@net:p@ss:w/rd?&=@tcp(db:3306)/birdnet?loc=Local&parseTime=true&charset=utf8mb4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.Output.MySQL.Enabled = true
+			settings.Output.MySQL.Username = tt.username
+			settings.Output.MySQL.Password = tt.password
+			settings.Output.MySQL.Host = "db"
+			settings.Output.MySQL.Port = "3306"
+			settings.Output.MySQL.Database = "birdnet"
+
+			driver, dsn, err := settings.DatabaseDSN()
+			if err != nil {
+				t.Fatalf("DatabaseDSN() error = %v", err)
+			}
+			if driver != DatabaseDriverMySQL || dsn != tt.want {
+				t.Errorf("DatabaseDSN() = %q, %q, want %q, %q", driver, dsn, DatabaseDriverMySQL, tt.want)
+			}
+
+			cfg, err := mysql.ParseDSN(dsn)
+			if err != nil {
+				t.Fatalf("ParseDSN(%q) error = %v", dsn, err)
+			}
+			if cfg.User != tt.username || cfg.Passwd != tt.password || cfg.Addr != "db:3306" || cfg.DBName != "birdnet" || !cfg.ParseTime {
+				t.Errorf("ParseDSN() = user %q, password %q, addr %q, db %q, parseTime %v",
+					cfg.User, cfg.Passwd, cfg.Addr, cfg.DBName, cfg.ParseTime)
+			}
+		})
+	}
+}
+
+func TestDatabaseDSNBothEnabled(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Output.SQLite.Enabled = true
+	settings.Output.SQLite.Path = "data/birdnet.db"
+	settings.Output.MySQL.Enabled = true
+	settings.Output.MySQL.Username = "birdnet"
+	settings.Output.MySQL.Host = "db"
+	settings.Output.MySQL.Port = "3306"
+
+	driver, dsn, err := settings.DatabaseDSN()
+	if err != nil {
+		t.Fatalf("DatabaseDSN() error = %v", err)
+	}
+	if driver != DatabaseDriverSQLite || dsn != "data/birdnet.db" {
+		t.Errorf("DatabaseDSN() = %q, %q, want %q, %q", driver, dsn, DatabaseDriverSQLite, "data/birdnet.db")
+	}
+
+	// Each datastore opens its own database regardless of the other
+	if dsn, err := settings.MySQLDSN(); err != nil || dsn == "" {
+		t.Errorf("MySQLDSN() = %q, %v, want a DSN", dsn, err)
+	}
+	if warning := databaseConflictWarning(settings); warning == "" {
+		t.Error("databaseConflictWarning() = \"\", want a warning")
+	}
+}
+
+func TestValidateSettingsDatabaseConflict(t *testing.T) {
+	t.Parallel()
+
+	settings, err := loadDefaultSettings()
+	if err != nil {
+		t.Fatalf("loadDefaultSettings() error = %v", err)
+	}
+	settings.Output.SQLite.Enabled = true
+	settings.Output.MySQL.Enabled = true
+
+	var ve ValidationError
+	if err := ValidateSettings(settings); err != nil && !stderrors.As(err, &ve) {
+		t.Fatalf("ValidateSettings() error = %v", err)
+	}
+	for _, issue := range ve.Issues {
+		if issue.Code == ErrCodeDatabaseConflict {
+			if issue.Severity != SeverityWarning {
+				t.Errorf("issue severity = %v, want warning", issue.Severity)
+			}
+			return
+		}
+	}
+	t.Errorf("ValidateSettings() issues = %v, want a %s warning", ve.Issues, ErrCodeDatabaseConflict)
+}
+
+func TestDatabaseDSNErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*Settings)
+	}{
+		{"none enabled", func(s *Settings) {}},
+		{"sqlite without path", func(s *Settings) { s.Output.SQLite.Enabled = true }},
+		{"colon in mysql username", func(s *Settings) {
+			s.Output.MySQL.Enabled = true
+			s.Output.MySQL.Username = "bird:net"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			tt.modify(settings)
+			if driver, dsn, err := settings.DatabaseDSN(); err == nil {
+				t.Errorf("DatabaseDSN() = %q, %q, want error", driver, dsn)
+			}
+		})
+	}
+}
+
+func TestMySQLPoolConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		open, idle int
+		lifetime   time.Duration
+		want       DatabasePoolConfig
+	}{
+		{"configured", 25, 10, 5 * time.Minute, DatabasePoolConfig{25, 10, 5 * time.Minute}},
+		{"idle capped at open", 4, 10, 0, DatabasePoolConfig{4, 4, 0}},
+		{"unlimited open", 0, 10, time.Hour, DatabasePoolConfig{0, 10, time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.Output.MySQL.MaxOpenConns = tt.open
+			settings.Output.MySQL.MaxIdleConns = tt.idle
+			settings.Output.MySQL.ConnMaxLifetime = tt.lifetime
+			if got := settings.MySQLPoolConfig(); got != tt.want {
+				t.Errorf("MySQLPoolConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMySQLPool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		modify  func(*Settings)
+		wantErr bool
+	}{
+		{"zero values", func(s *Settings) {}, false},
+		{"defaults", func(s *Settings) {
+			s.Output.MySQL.MaxOpenConns = DefaultMySQLMaxOpenConns
+			s.Output.MySQL.MaxIdleConns = DefaultMySQLMaxIdleConns
+			s.Output.MySQL.ConnMaxLifetime = DefaultMySQLConnMaxLifetime
+		}, false},
+		{"negative open", func(s *Settings) { s.Output.MySQL.MaxOpenConns = -1 }, true},
+		{"negative idle", func(s *Settings) { s.Output.MySQL.MaxIdleConns = -1 }, true},
+		{"negative lifetime", func(s *Settings) { s.Output.MySQL.ConnMaxLifetime = -time.Second }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			tt.modify(settings)
+			err := validateMySQLPool(settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateMySQLPool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && validationCode(err) != ErrCodeMySQLPool {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), ErrCodeMySQLPool)
+			}
+		})
+	}
+}
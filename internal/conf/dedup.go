@@ -0,0 +1,40 @@
+// conf/dedup.go collapsing of duplicate detections of a species
+package conf
+
+import (
+	"fmt"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Values of RealtimeSettings.DedupStrategy
+const (
+	DedupStrategyHighestConfidence = "highest-confidence" // keep the most confident detection
+	DedupStrategyFirst             = "first"              // keep the first detection
+)
+
+// DedupStrategyOrDefault returns the dedup strategy, highest-confidence when
+// it is not set
+func (r *RealtimeSettings) DedupStrategyOrDefault() string {
+	if r.DedupStrategy == "" {
+		return DedupStrategyHighestConfidence
+	}
+	return r.DedupStrategy
+}
+
+// validateDedupSettings checks the detection deduplication window and strategy
+func validateDedupSettings(r *RealtimeSettings) error {
+	if r.DedupWindow < 0 {
+		return errors.New(fmt.Errorf("detection dedup window must not be negative, got %s", r.DedupWindow)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeDedupWindow).
+			Build()
+	}
+	if c := constraintFor("realtime.dedupstrategy"); r.DedupStrategy != "" && !c.allows(r.DedupStrategy) {
+		return errors.New(fmt.Errorf("unknown detection dedup strategy %q, must be %s", r.DedupStrategy, c)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeDedupStrategy).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateDedupSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		window   time.Duration
+		strategy string
+		wantErr  bool
+	}{
+		{"disabled", 0, "", false},
+		{"highest confidence", 30 * time.Second, DedupStrategyHighestConfidence, false},
+		{"first", time.Minute, DedupStrategyFirst, false},
+		{"negative window", -time.Second, DedupStrategyFirst, true},
+		{"unknown strategy", time.Minute, "last", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := RealtimeSettings{DedupWindow: tt.window, DedupStrategy: tt.strategy}
+			if err := validateDedupSettings(&settings); (err != nil) != tt.wantErr {
+				t.Errorf("validateDedupSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := (&RealtimeSettings{}).DedupStrategyOrDefault(); got != DedupStrategyHighestConfidence {
+		t.Errorf("DedupStrategyOrDefault() = %q, want %q", got, DedupStrategyHighestConfidence)
+	}
+}
@@ -9,58 +9,88 @@ import (
 
 // Sets default values for the configuration.
 func setDefaultConfig() {
-	viper.SetDefault("debug", false)
+	setDefaults(viper.GetViper())
+}
+
+// setDefaults sets default values for the configuration on the given viper instance.
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("debug", false)
 
 	// Main configuration
-	viper.SetDefault("main.name", "BirdNET-Go")
-	viper.SetDefault("main.timeas24h", true)
-	viper.SetDefault("main.log.enabled", true)
-	viper.SetDefault("main.log.path", "birdnet.log")
-	viper.SetDefault("main.log.rotation", RotationDaily)
-	viper.SetDefault("main.log.maxsize", 1048576)
-	viper.SetDefault("main.log.rotationday", "Sunday")
+	v.SetDefault("main.name", "BirdNET-Go")
+	v.SetDefault("main.timeas24h", true)
+	v.SetDefault("main.timezone", "")
+	v.SetDefault("main.log.enabled", true)
+	v.SetDefault("main.log.output", LogOutputFile)
+	v.SetDefault("main.log.format", LogFormatJSON)
+	v.SetDefault("main.log.path", "birdnet.log")
+	v.SetDefault("main.log.rotation", RotationDaily)
+	v.SetDefault("main.log.maxsize", 1048576)
+	v.SetDefault("main.log.rotationday", "Sunday")
 
 	// BirdNET configuration
-	viper.SetDefault("birdnet.debug", false)
-	viper.SetDefault("birdnet.sensitivity", 1.0)
-	viper.SetDefault("birdnet.threshold", 0.8)
-	viper.SetDefault("birdnet.overlap", 0.0)
-	viper.SetDefault("birdnet.threads", 0)
-	viper.SetDefault("birdnet.locale", DefaultFallbackLocale)
-	viper.SetDefault("birdnet.latitude", 0.000)
-	viper.SetDefault("birdnet.longitude", 0.000)
-	viper.SetDefault("birdnet.modelpath", "")
-	viper.SetDefault("birdnet.labelpath", "")
-	viper.SetDefault("birdnet.usexnnpack", true)
+	v.SetDefault("birdnet.debug", false)
+	v.SetDefault("birdnet.sensitivity", 1.0)
+	v.SetDefault("birdnet.threshold", 0.8)
+	v.SetDefault("birdnet.overlap", 0.0)
+	v.SetDefault("birdnet.threads", 0)
+	v.SetDefault("birdnet.locale", DefaultFallbackLocale)
+	v.SetDefault("birdnet.secondarylocale", "")
+	v.SetDefault("birdnet.latitude", 0.000)
+	v.SetDefault("birdnet.longitude", 0.000)
+	v.SetDefault("birdnet.modelpath", "")
+	v.SetDefault("birdnet.labelpath", "")
+	v.SetDefault("birdnet.usexnnpack", true)
 
 	// Range filter configuration
-	viper.SetDefault("birdnet.rangefilter.debug", false)
-	viper.SetDefault("birdnet.rangefilter.model", "latest")
-	viper.SetDefault("birdnet.rangefilter.threshold", 0.01)
+	v.SetDefault("birdnet.rangefilter.debug", false)
+	v.SetDefault("birdnet.rangefilter.model", "latest")
+	v.SetDefault("birdnet.rangefilter.threshold", 0.01)
+	v.SetDefault("birdnet.rangefilter.autoupdate", true)
+	v.SetDefault("birdnet.rangefilter.updateinterval", "24h")
 
 	// Realtime configuration
-	viper.SetDefault("realtime.interval", 15)
-	viper.SetDefault("realtime.processingtime", false)
+	v.SetDefault("realtime.interval", 15)
+	v.SetDefault("realtime.processingtime", false)
+	v.SetDefault("realtime.dedupwindow", "0s")
+	v.SetDefault("realtime.dedupstrategy", DedupStrategyHighestConfidence)
+	v.SetDefault("realtime.confidenceformat", ConfidenceFormatFraction)
+	v.SetDefault("realtime.maxconcurrentactions", 0)
+	v.SetDefault("realtime.onactionoverflow", ActionOverflowQueue)
+
+	// Quiet hours configuration
+	v.SetDefault("realtime.quiethours.enabled", false)
+	v.SetDefault("realtime.quiethours.start", "22:00")
+	v.SetDefault("realtime.quiethours.end", "06:00")
+	v.SetDefault("realtime.quiethours.weekdays", []string{})
+	v.SetDefault("realtime.quiethours.suppress", []string{})
 
 	// Audio source configuration
-	viper.SetDefault("realtime.audio.useaudiocore", false) // true to use new audiocore package instead of myaudio
-	viper.SetDefault("realtime.audio.source", "sysdefault")
-	viper.SetDefault("realtime.audio.streamtransport", "sse")
+	v.SetDefault("realtime.audio.useaudiocore", false) // true to use new audiocore package instead of myaudio
+	v.SetDefault("realtime.audio.source", "sysdefault")
+	v.SetDefault("realtime.audio.streamtransport", "sse")
 
 	// Sound level monitoring configuration
-	viper.SetDefault("realtime.audio.soundlevel.enabled", false)
-	viper.SetDefault("realtime.audio.soundlevel.interval", 10)
+	v.SetDefault("realtime.audio.gain", 0.0)
+	v.SetDefault("realtime.audio.normalize", false)
+	v.SetDefault("realtime.audio.soundlevel.enabled", false)
+	v.SetDefault("realtime.audio.soundlevel.interval", 10)
 
 	// Audio export configuration
-	viper.SetDefault("realtime.audio.export.debug", false)
-	viper.SetDefault("realtime.audio.export.enabled", true)
-	viper.SetDefault("realtime.audio.export.path", "clips/")
-	viper.SetDefault("realtime.audio.export.type", "wav")
-	viper.SetDefault("realtime.audio.export.bitrate", "128k")
+	v.SetDefault("realtime.audio.export.debug", false)
+	v.SetDefault("realtime.audio.export.enabled", true)
+	v.SetDefault("realtime.audio.export.path", "clips/")
+	v.SetDefault("realtime.audio.export.type", "wav")
+	v.SetDefault("realtime.audio.export.bitrate", "128k")
+	v.SetDefault("realtime.audio.export.filenametemplate", "")
+	v.SetDefault("realtime.audio.export.spectrogram.width", DefaultSpectrogramWidth)
+	v.SetDefault("realtime.audio.export.spectrogram.height", DefaultSpectrogramHeight)
+	v.SetDefault("realtime.audio.export.spectrogram.colorscheme", SpectrogramColorDefault)
+	v.SetDefault("realtime.audio.export.spectrogram.raw", false)
 
 	// Audio equalizer configuration
-	viper.SetDefault("realtime.audio.equalizer.enabled", false)
-	viper.SetDefault("realtime.audio.equalizer.filters", []map[string]interface{}{
+	v.SetDefault("realtime.audio.equalizer.enabled", false)
+	v.SetDefault("realtime.audio.equalizer.filters", []map[string]interface{}{
 		{
 			"type":      "HighPass",
 			"frequency": 100,
@@ -76,193 +106,232 @@ func setDefaultConfig() {
 	})
 
 	// Dashboard thumbnails configuration
-	viper.SetDefault("realtime.dashboard.thumbnails.debug", false)
-	viper.SetDefault("realtime.dashboard.thumbnails.summary", false)
-	viper.SetDefault("realtime.dashboard.thumbnails.recent", true)
-	viper.SetDefault("realtime.dashboard.thumbnails.imageprovider", "auto")
-	viper.SetDefault("realtime.dashboard.thumbnails.fallbackpolicy", "all")
-	viper.SetDefault("realtime.dashboard.summarylimit", 30)
+	v.SetDefault("realtime.dashboard.thumbnails.debug", false)
+	v.SetDefault("realtime.dashboard.thumbnails.summary", false)
+	v.SetDefault("realtime.dashboard.thumbnails.recent", true)
+	v.SetDefault("realtime.dashboard.thumbnails.imageprovider", "auto")
+	v.SetDefault("realtime.dashboard.thumbnails.fallbackpolicy", "all")
+	v.SetDefault("realtime.dashboard.summarylimit", 30)
 
 	// Retention policy configuration
-	viper.SetDefault("realtime.audio.export.retention.enabled", true)
-	viper.SetDefault("realtime.audio.export.retention.debug", false)
-	viper.SetDefault("realtime.audio.export.retention.policy", "usage")
-	viper.SetDefault("realtime.audio.export.retention.maxusage", "80%")
-	viper.SetDefault("realtime.audio.export.retention.maxage", "30d")
-	viper.SetDefault("realtime.audio.export.retention.minclips", 10)
-	viper.SetDefault("realtime.audio.export.retention.keepspectrograms", true)
+	v.SetDefault("realtime.audio.export.retention.enabled", true)
+	v.SetDefault("realtime.audio.export.retention.debug", false)
+	v.SetDefault("realtime.audio.export.retention.policy", "usage")
+	v.SetDefault("realtime.audio.export.retention.maxusage", "80%")
+	v.SetDefault("realtime.audio.export.retention.maxage", "30d")
+	v.SetDefault("realtime.audio.export.retention.minclips", 10)
+	v.SetDefault("realtime.audio.export.retention.keepspectrograms", true)
 
 	// Dynamic threshold configuration
-	viper.SetDefault("realtime.dynamicthreshold.enabled", true)
-	viper.SetDefault("realtime.dynamicthreshold.debug", false)
-	viper.SetDefault("realtime.dynamicthreshold.trigger", 0.90)
-	viper.SetDefault("realtime.dynamicthreshold.min", 0.20)
-	viper.SetDefault("realtime.dynamicthreshold.validhours", 24)
+	v.SetDefault("realtime.dynamicthreshold.enabled", true)
+	v.SetDefault("realtime.dynamicthreshold.debug", false)
+	v.SetDefault("realtime.dynamicthreshold.trigger", 0.90)
+	v.SetDefault("realtime.dynamicthreshold.min", 0.20)
+	v.SetDefault("realtime.dynamicthreshold.validhours", 24)
 
 	// Log configuration
-	viper.SetDefault("realtime.log.enabled", false)
-	viper.SetDefault("realtime.log.path", "birdnet.txt")
+	v.SetDefault("realtime.log.enabled", false)
+	v.SetDefault("realtime.log.path", "birdnet.txt")
 
 	// BirdWeather configuration
-	viper.SetDefault("realtime.birdweather.enabled", false)
-	viper.SetDefault("realtime.birdweather.debug", false)
-	viper.SetDefault("realtime.birdweather.id", "")
-	viper.SetDefault("realtime.birdweather.threshold", 0.7)
-	viper.SetDefault("realtime.birdweather.locationaccuracy", 0)
-	viper.SetDefault("realtime.birdweather.retrysettings.enabled", true)
-	viper.SetDefault("realtime.birdweather.retrysettings.maxretries", 10)
-	viper.SetDefault("realtime.birdweather.retrysettings.initialdelay", 60)
-	viper.SetDefault("realtime.birdweather.retrysettings.maxdelay", 3600)
-	viper.SetDefault("realtime.birdweather.retrysettings.backoffmultiplier", 2.0)
+	v.SetDefault("realtime.birdweather.enabled", false)
+	v.SetDefault("realtime.birdweather.debug", false)
+	v.SetDefault("realtime.birdweather.id", "")
+	v.SetDefault("realtime.birdweather.threshold", 0.7)
+	v.SetDefault("realtime.birdweather.locationaccuracy", 0)
+	v.SetDefault("realtime.birdweather.retrysettings.enabled", true)
+	v.SetDefault("realtime.birdweather.retrysettings.maxretries", 10)
+	v.SetDefault("realtime.birdweather.retrysettings.initialdelay", 60)
+	v.SetDefault("realtime.birdweather.retrysettings.maxdelay", 3600)
+	v.SetDefault("realtime.birdweather.retrysettings.backoffmultiplier", 2.0)
+	v.SetDefault("realtime.birdweather.ratelimit.maxperminute", 0)
+	v.SetDefault("realtime.birdweather.ratelimit.burstsize", 0)
+	v.SetDefault("realtime.birdweather.uploadmode", BirdweatherUploadDetections)
+	v.SetDefault("realtime.birdweather.soundscapeinterval", DefaultSoundscapeInterval)
 
 	// OpenWeather configuration
 	/*
-		viper.SetDefault("realtime.OpenWeather.Enabled", false)
-		viper.SetDefault("realtime.OpenWeather.Debug", false)
-		viper.SetDefault("realtime.OpenWeather.APIKey", "")
-		viper.SetDefault("realtime.OpenWeather.Endpoint", "https://api.openweathermap.org/data/2.5/weather")
-		viper.SetDefault("realtime.OpenWeather.Interval", 60) // default to fetch every 60 minutes
-		viper.SetDefault("realtime.OpenWeather.Units", "standard")
-		viper.SetDefault("realtime.OpenWeather.Language", "en")
+		v.SetDefault("realtime.OpenWeather.Enabled", false)
+		v.SetDefault("realtime.OpenWeather.Debug", false)
+		v.SetDefault("realtime.OpenWeather.APIKey", "")
+		v.SetDefault("realtime.OpenWeather.Endpoint", "https://api.openweathermap.org/data/2.5/weather")
+		v.SetDefault("realtime.OpenWeather.Interval", 60) // default to fetch every 60 minutes
+		v.SetDefault("realtime.OpenWeather.Units", "standard")
+		v.SetDefault("realtime.OpenWeather.Language", "en")
 	*/
 
 	// New weather configuration
-	viper.SetDefault("realtime.weather.debug", false)
-	viper.SetDefault("realtime.weather.pollinterval", 60)
-	viper.SetDefault("realtime.weather.provider", "yrno")
+	v.SetDefault("realtime.weather.debug", false)
+	v.SetDefault("realtime.weather.pollinterval", DefaultWeatherPollInterval)
+	v.SetDefault("realtime.weather.polljitter", 0)
+	v.SetDefault("realtime.weather.provider", "yrno")
 
 	// OpenWeather specific configuration
-	viper.SetDefault("realtime.weather.openweather.apikey", "")
-	viper.SetDefault("realtime.weather.openweather.endpoint", "https://api.openweathermap.org/data/2.5/weather")
-	viper.SetDefault("realtime.weather.openweather.units", "metric")
-	viper.SetDefault("realtime.weather.openweather.language", "en")
+	v.SetDefault("realtime.weather.openweather.apikey", "")
+	v.SetDefault("realtime.weather.openweather.endpoint", "https://api.openweathermap.org/data/2.5/weather")
+	v.SetDefault("realtime.weather.openweather.units", "metric")
+	v.SetDefault("realtime.weather.openweather.language", "en")
 
 	// RTSP configuration
-	viper.SetDefault("realtime.rtsp.urls", []string{})
-	viper.SetDefault("realtime.rtsp.transport", "tcp")
-	viper.SetDefault("realtime.rtsp.health.healthydatathreshold", 60)
-	viper.SetDefault("realtime.rtsp.health.monitoringinterval", 30)
-	viper.SetDefault("realtime.rtsp.ffmpegparameters", []string{})
+	v.SetDefault("realtime.rtsp.urls", []string{})
+	v.SetDefault("realtime.rtsp.transport", "tcp")
+	v.SetDefault("realtime.rtsp.health.healthydatathreshold", 60)
+	v.SetDefault("realtime.rtsp.health.monitoringinterval", 30)
+	v.SetDefault("realtime.rtsp.reconnectbackoff.enabled", true)
+	v.SetDefault("realtime.rtsp.reconnectbackoff.initialdelay", 5)
+	v.SetDefault("realtime.rtsp.reconnectbackoff.maxdelay", 120)
+	v.SetDefault("realtime.rtsp.reconnectbackoff.backoffmultiplier", 2.0)
+	v.SetDefault("realtime.rtsp.ffmpegparameters", []string{})
 
 	// MQTT configuration
-	viper.SetDefault("realtime.mqtt.enabled", false)
-	viper.SetDefault("realtime.mqtt.debug", false)
-	viper.SetDefault("realtime.mqtt.broker", "tcp://localhost:1883")
-	viper.SetDefault("realtime.mqtt.topic", "birdnet")
-	viper.SetDefault("realtime.mqtt.username", "")
-	viper.SetDefault("realtime.mqtt.password", "")
-	viper.SetDefault("realtime.mqtt.retain", false)
-	viper.SetDefault("realtime.mqtt.retrysettings.enabled", true)
-	viper.SetDefault("realtime.mqtt.retrysettings.maxretries", 5)
-	viper.SetDefault("realtime.mqtt.retrysettings.initialdelay", 30)
-	viper.SetDefault("realtime.mqtt.retrysettings.maxdelay", 3600)
-	viper.SetDefault("realtime.mqtt.retrysettings.backoffmultiplier", 2.0)
+	v.SetDefault("realtime.mqtt.enabled", false)
+	v.SetDefault("realtime.mqtt.debug", false)
+	v.SetDefault("realtime.mqtt.broker", "tcp://localhost:1883")
+	v.SetDefault("realtime.mqtt.topic", "birdnet")
+	v.SetDefault("realtime.mqtt.username", "")
+	v.SetDefault("realtime.mqtt.password", "")
+	v.SetDefault("realtime.mqtt.retain", false)
+	v.SetDefault("realtime.mqtt.retrysettings.enabled", true)
+	v.SetDefault("realtime.mqtt.retrysettings.maxretries", 5)
+	v.SetDefault("realtime.mqtt.retrysettings.initialdelay", 30)
+	v.SetDefault("realtime.mqtt.retrysettings.maxdelay", 3600)
+	v.SetDefault("realtime.mqtt.retrysettings.backoffmultiplier", 2.0)
 
 	// Privacy filter configuration
-	viper.SetDefault("realtime.privacyfilter.enabled", true)
-	viper.SetDefault("realtime.privacyfilter.debug", false)
-	viper.SetDefault("realtime.privacyfilter.confidence", 0.05)
+	v.SetDefault("realtime.privacyfilter.enabled", true)
+	v.SetDefault("realtime.privacyfilter.debug", false)
+	v.SetDefault("realtime.privacyfilter.confidence", 0.05)
+	v.SetDefault("realtime.privacyfilter.privacy.roundtimestampto", "0s")
+	v.SetDefault("realtime.privacyfilter.privacy.storelocation", true)
 
 	// Dog bark filter configuration
-	viper.SetDefault("realtime.dogbarkfilter.enabled", false)
-	viper.SetDefault("realtime.dogbarkfilter.debug", false)
-	viper.SetDefault("realtime.dogbarkfilter.remember", 5)
-	viper.SetDefault("realtime.dogbarkfilter.confidence", 0.1)
-	viper.SetDefault("realtime.dogbarkfilter.species", []string{})
+	v.SetDefault("realtime.dogbarkfilter.enabled", false)
+	v.SetDefault("realtime.dogbarkfilter.debug", false)
+	v.SetDefault("realtime.dogbarkfilter.remember", 5)
+	v.SetDefault("realtime.dogbarkfilter.confidence", 0.1)
+	v.SetDefault("realtime.dogbarkfilter.species", []string{})
 
 	// Telemetry configuration
-	viper.SetDefault("realtime.telemetry.enabled", false)
-	viper.SetDefault("realtime.telemetry.listen", "0.0.0.0:8090")
+	v.SetDefault("realtime.telemetry.enabled", false)
+	v.SetDefault("realtime.telemetry.listen", "0.0.0.0:8090")
+	v.SetDefault("realtime.telemetry.namespace", "")
+	v.SetDefault("realtime.telemetry.labels", map[string]string{})
 
 	// System monitoring configuration
-	viper.SetDefault("realtime.monitoring.enabled", true)
-	viper.SetDefault("realtime.monitoring.checkinterval", 60)
-	viper.SetDefault("realtime.monitoring.criticalresendinterval", 30)
-	viper.SetDefault("realtime.monitoring.hysteresispercent", 5.0)
+	v.SetDefault("realtime.monitoring.enabled", true)
+	v.SetDefault("realtime.monitoring.checkinterval", 60)
+	v.SetDefault("realtime.monitoring.criticalresendinterval", 30)
+	v.SetDefault("realtime.monitoring.hysteresispercent", 5.0)
 	// CPU monitoring
-	viper.SetDefault("realtime.monitoring.cpu.enabled", true)
-	viper.SetDefault("realtime.monitoring.cpu.warning", 85.0)
-	viper.SetDefault("realtime.monitoring.cpu.critical", 95.0)
+	v.SetDefault("realtime.monitoring.cpu.enabled", true)
+	v.SetDefault("realtime.monitoring.cpu.warning", 85.0)
+	v.SetDefault("realtime.monitoring.cpu.critical", 95.0)
 	// Memory monitoring
-	viper.SetDefault("realtime.monitoring.memory.enabled", true)
-	viper.SetDefault("realtime.monitoring.memory.warning", 85.0)
-	viper.SetDefault("realtime.monitoring.memory.critical", 95.0)
+	v.SetDefault("realtime.monitoring.memory.enabled", true)
+	v.SetDefault("realtime.monitoring.memory.warning", 85.0)
+	v.SetDefault("realtime.monitoring.memory.critical", 95.0)
 	// Disk monitoring
-	viper.SetDefault("realtime.monitoring.disk.enabled", true)
-	viper.SetDefault("realtime.monitoring.disk.warning", 85.0)
-	viper.SetDefault("realtime.monitoring.disk.critical", 95.0)
-	viper.SetDefault("realtime.monitoring.disk.paths", []string{"/"})
+	v.SetDefault("realtime.monitoring.disk.enabled", true)
+	v.SetDefault("realtime.monitoring.disk.warning", 85.0)
+	v.SetDefault("realtime.monitoring.disk.critical", 95.0)
+	v.SetDefault("realtime.monitoring.disk.paths", []string{"/"})
+	// Temperature monitoring, degrees Celsius
+	v.SetDefault("realtime.monitoring.temperature.enabled", false)
+	v.SetDefault("realtime.monitoring.temperature.warning", 70.0)
+	v.SetDefault("realtime.monitoring.temperature.critical", 80.0)
+	// Network monitoring
+	v.SetDefault("realtime.monitoring.network.enabled", false)
+	v.SetDefault("realtime.monitoring.network.interfaces", []string{})
+	v.SetDefault("realtime.monitoring.network.errors.enabled", true)
+	v.SetDefault("realtime.monitoring.network.errors.warning", 1.0)
+	v.SetDefault("realtime.monitoring.network.errors.critical", 5.0)
+	v.SetDefault("realtime.monitoring.network.throughput.enabled", false)
+	v.SetDefault("realtime.monitoring.network.throughput.warning", 50.0)
+	v.SetDefault("realtime.monitoring.network.throughput.critical", 90.0)
 
 	// Webserver configuration
-	viper.SetDefault("webserver.debug", false)
-	viper.SetDefault("webserver.enabled", true)
-	viper.SetDefault("webserver.port", "8080")
+	v.SetDefault("webserver.debug", false)
+	v.SetDefault("webserver.enabled", true)
+	v.SetDefault("webserver.port", "8080")
 
 	// Webserver log configuration
-	viper.SetDefault("webserver.log.enabled", false)
-	viper.SetDefault("webserver.log.path", "webui.log")
-	viper.SetDefault("webserver.log.rotation", RotationDaily)
-	viper.SetDefault("webserver.log.maxsize", 1048576)
-	viper.SetDefault("webserver.log.rotationday", time.Sunday)
+	v.SetDefault("webserver.log.enabled", false)
+	v.SetDefault("webserver.log.path", "webui.log")
+	v.SetDefault("webserver.log.rotation", RotationDaily)
+	v.SetDefault("webserver.log.maxsize", 1048576)
+	v.SetDefault("webserver.log.rotationday", time.Sunday)
+
+	// Webserver CORS configuration, same-origin only by default
+	v.SetDefault("webserver.cors.allowedorigins", []string{})
+	v.SetDefault("webserver.cors.allowedmethods", []string{})
+	v.SetDefault("webserver.cors.allowcredentials", false)
 
 	// Live stream configuration
-	viper.SetDefault("webserver.livestream.debug", false)
-	viper.SetDefault("webserver.livestream.bitrate", 128)
-	viper.SetDefault("webserver.livestream.sampleRate", 48000)
-	viper.SetDefault("webserver.livestream.segmentLength", 2)
-	viper.SetDefault("webserver.livestream.ffmpegLogLevel", "warning")
+	v.SetDefault("webserver.livestream.debug", false)
+	v.SetDefault("webserver.livestream.quality", "medium")
+	v.SetDefault("webserver.livestream.segmentLength", 2)
+	v.SetDefault("webserver.livestream.ffmpegLogLevel", "warning")
 
 	// File output configuration
-	viper.SetDefault("output.file.enabled", true)
-	viper.SetDefault("output.file.path", "output/")
-	viper.SetDefault("output.file.type", "table")
+	v.SetDefault("output.file.enabled", true)
+	v.SetDefault("output.file.path", "output/")
+	v.SetDefault("output.file.type", "table")
 
 	// SQLite output configuration
-	viper.SetDefault("output.sqlite.enabled", true)
-	viper.SetDefault("output.sqlite.path", "birdnet.db")
+	v.SetDefault("output.sqlite.enabled", true)
+	v.SetDefault("output.sqlite.path", "birdnet.db")
 
 	// MySQL output configuration
-	viper.SetDefault("output.mysql.enabled", false)
-	viper.SetDefault("output.mysql.username", "birdnet")
-	viper.SetDefault("output.mysql.password", "secret")
-	viper.SetDefault("output.mysql.database", "birdnet")
-	viper.SetDefault("output.mysql.host", "localhost")
-	viper.SetDefault("output.mysql.port", 3306)
+	v.SetDefault("output.mysql.enabled", false)
+	v.SetDefault("output.mysql.username", "birdnet")
+	v.SetDefault("output.mysql.password", "secret")
+	v.SetDefault("output.mysql.database", "birdnet")
+	v.SetDefault("output.mysql.host", "localhost")
+	v.SetDefault("output.mysql.port", 3306)
+	v.SetDefault("output.mysql.maxopenconns", DefaultMySQLMaxOpenConns)
+	v.SetDefault("output.mysql.maxidleconns", DefaultMySQLMaxIdleConns)
+	v.SetDefault("output.mysql.connmaxlifetime", DefaultMySQLConnMaxLifetime.String())
 
 	// Security configuration
-	viper.SetDefault("security.debug", false)
-	viper.SetDefault("security.host", "")
-	viper.SetDefault("security.autotls", false)
-	viper.SetDefault("security.redirecttohttps", false)
-	viper.SetDefault("security.allowsubnetbypass.enabled", false)
-	viper.SetDefault("security.allowsubnetbypass.subnet", "")
-	viper.SetDefault("security.sessionduration", "168h") // 7 days
+	v.SetDefault("security.debug", false)
+	v.SetDefault("security.host", "")
+	v.SetDefault("security.autotls", false)
+	v.SetDefault("security.redirecttohttps", false)
+	v.SetDefault("security.allowsubnetbypass.enabled", false)
+	v.SetDefault("security.allowsubnetbypass.subnet", "")
+	v.SetDefault("security.sessionduration", "7d")
+	v.SetDefault("security.protectedroutes", []string{})
+	v.SetDefault("security.publicroutes", []string{})
+	v.SetDefault("security.trustedproxies", DefaultTrustedProxies)
 
 	// Basic authentication configuration
-	viper.SetDefault("security.basicauth.enabled", false)
-	viper.SetDefault("security.basicauth.password", "")
-	viper.SetDefault("security.basicauth.clientid", "birdnet-client")
-	viper.SetDefault("security.basicauth.redirecturi", "/settings")
-	viper.SetDefault("security.basicauth.authcodeexp", "10m")
-	viper.SetDefault("security.basicauth.accesstokenexp", "1h")
+	v.SetDefault("security.basicauth.enabled", false)
+	v.SetDefault("security.basicauth.password", "")
+	v.SetDefault("security.basicauth.clientid", "birdnet-client")
+	v.SetDefault("security.basicauth.redirecturi", "/settings")
+	v.SetDefault("security.basicauth.authcodeexp", "10m")
+	v.SetDefault("security.basicauth.accesstokenexp", "1h")
 
 	// Google OAuth2 configuration
-	viper.SetDefault("security.googleauth.enabled", false)
-	viper.SetDefault("security.googleauth.clientid", "")
-	viper.SetDefault("security.googleauth.clientsecret", "")
-	viper.SetDefault("security.googleauth.redirecturi", "/settings")
-	viper.SetDefault("security.googleauth.userid", "")
+	v.SetDefault("security.googleauth.enabled", false)
+	v.SetDefault("security.googleauth.clientid", "")
+	v.SetDefault("security.googleauth.clientsecret", "")
+	v.SetDefault("security.googleauth.redirecturi", "/settings")
+	v.SetDefault("security.googleauth.userid", "")
 
 	// GitHub OAuth2 configuration
-	viper.SetDefault("security.githubauth.enabled", false)
-	viper.SetDefault("security.githubauth.clientid", "")
-	viper.SetDefault("security.githubauth.clientsecret", "")
-	viper.SetDefault("security.githubauth.redirecturi", "/settings")
-	viper.SetDefault("security.githubauth.userid", "")
+	v.SetDefault("security.githubauth.enabled", false)
+	v.SetDefault("security.githubauth.clientid", "")
+	v.SetDefault("security.githubauth.clientsecret", "")
+	v.SetDefault("security.githubauth.redirecturi", "/settings")
+	v.SetDefault("security.githubauth.userid", "")
 
 	// Sentry configuration
-	viper.SetDefault("sentry.enabled", false)
-	viper.SetDefault("sentry.dsn", "")
-	viper.SetDefault("sentry.samplerate", 1.0)
-	viper.SetDefault("sentry.debug", false)
+	v.SetDefault("sentry.enabled", false)
+	v.SetDefault("sentry.dsn", "")
+	v.SetDefault("sentry.samplerate", 1.0)
+	v.SetDefault("sentry.debug", false)
+
+	// Overrides set with SetDefaultOverride
+	applyDefaultOverrides(v)
 }
@@ -0,0 +1,129 @@
+// conf/describe.go catalog of all settings with their types, defaults and constraints
+package conf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SettingMeta describes a single setting of the config file
+type SettingMeta struct {
+	Path      string   `json:"path"`           // config key, e.g. birdnet.threshold
+	Type      string   `json:"type"`           // Go style type, e.g. float, []string or duration
+	Default   any      `json:"default"`        // default value, never set for sensitive settings
+	Sensitive bool     `json:"sensitive"`      // true for credentials
+	Enum      []any    `json:"enum,omitempty"` // accepted values, empty accepts any value
+	Min       *float64 `json:"min,omitempty"`  // inclusive minimum, nil is unbounded
+	Max       *float64 `json:"max,omitempty"`  // inclusive maximum, nil is unbounded
+}
+
+// DescribeSettings returns the settings stored in the config file in
+// declaration order. Defaults come from the embedded config.yaml layered over
+// the built-in defaults, constraints from the ones used by ValidateSettings.
+// Runtime values that are not stored in the config file are omitted.
+func DescribeSettings() []SettingMeta {
+	defaults, err := loadDefaultSettings()
+	if err != nil {
+		// The embedded config is part of the binary, failing to read it is a programming error
+		panic(fmt.Sprintf("conf: failed to load default settings: %v", err))
+	}
+
+	var settings []SettingMeta
+	describeStruct(reflect.ValueOf(defaults).Elem(), "", sensitiveKeys(), &settings)
+	return settings
+}
+
+// describeStruct appends the settings of the fields of a struct value
+func describeStruct(value reflect.Value, key string, sensitive map[string]bool, settings *[]SettingMeta) {
+	t := value.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		configName := strings.ToLower(field.Name)
+		yamlTag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if yamlTag == "-" {
+			continue
+		} else if yamlTag != "" {
+			configName = yamlTag
+		}
+		fieldKey := configName
+		if key != "" {
+			fieldKey = key + "." + configName
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Struct && fieldValue.Type() != timeType {
+			describeStruct(fieldValue, fieldKey, sensitive, settings)
+			continue
+		}
+
+		meta := SettingMeta{
+			Path:      fieldKey,
+			Type:      typeName(field.Type),
+			Sensitive: sensitive[fieldKey],
+		}
+		if !meta.Sensitive {
+			meta.Default = defaultValue(fieldValue)
+		}
+		if constraint, ok := settingConstraints[fieldKey]; ok {
+			meta.Enum = constraint.Enum
+			meta.Min = constraint.Minimum
+			meta.Max = constraint.Maximum
+		}
+		*settings = append(*settings, meta)
+	}
+}
+
+// typeName returns a Go style name of a setting type
+func typeName(t reflect.Type) string {
+	switch {
+	case t == durationType:
+		return "duration"
+	case t == timeType:
+		return "time"
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeName(t.Elem())
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "[]" + typeName(t.Elem())
+	case reflect.Map:
+		return "map[" + typeName(t.Key()) + "]" + typeName(t.Elem())
+	case reflect.Struct:
+		return t.Name()
+	default:
+		return "any"
+	}
+}
+
+// defaultValue returns a field value for SettingMeta.Default, durations are
+// formatted as strings
+func defaultValue(value reflect.Value) any {
+	switch {
+	case value.Type() == durationType:
+		return time.Duration(value.Int()).String()
+	case value.Kind() == reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		return value.Elem().Interface()
+	default:
+		return value.Interface()
+	}
+}
@@ -0,0 +1,80 @@
+package conf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribeSettings(t *testing.T) {
+	t.Parallel()
+
+	settings := DescribeSettings()
+	byPath := make(map[string]SettingMeta, len(settings))
+	for _, setting := range settings {
+		if _, ok := byPath[setting.Path]; ok {
+			t.Errorf("duplicate setting %s", setting.Path)
+		}
+		byPath[setting.Path] = setting
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		check func(t *testing.T, s SettingMeta)
+	}{
+		{"range constraint", "birdnet.threshold", func(t *testing.T, s SettingMeta) {
+			t.Helper()
+			if s.Type != "float" || s.Min == nil || *s.Min != 0 || s.Max == nil || *s.Max != 1 {
+				t.Errorf("got %+v, want float between 0 and 1", s)
+			}
+		}},
+		{"default from embedded config", "main.name", func(t *testing.T, s SettingMeta) {
+			t.Helper()
+			if s.Type != "string" || s.Default != "BirdNET-Go" {
+				t.Errorf("got %+v, want string default BirdNET-Go", s)
+			}
+		}},
+		{"enum", "realtime.audio.export.type", func(t *testing.T, s SettingMeta) {
+			t.Helper()
+			if !reflect.DeepEqual(s.Enum, constraintFor("realtime.audio.export.type").Enum) {
+				t.Errorf("Enum = %v, want export types", s.Enum)
+			}
+		}},
+		{"duration", "security.sessionduration", func(t *testing.T, s SettingMeta) {
+			t.Helper()
+			if s.Type != "duration" || s.Default != "168h0m0s" {
+				t.Errorf("got %+v, want duration default 168h0m0s", s)
+			}
+		}},
+		{"list", "realtime.rtsp.urls", func(t *testing.T, s SettingMeta) {
+			t.Helper()
+			if s.Type != "[]string" {
+				t.Errorf("Type = %q, want []string", s.Type)
+			}
+		}},
+		{"sensitive without default", "security.basicauth.password", func(t *testing.T, s SettingMeta) {
+			t.Helper()
+			if !s.Sensitive || s.Default != nil {
+				t.Errorf("got %+v, want sensitive setting without default", s)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			setting, ok := byPath[tt.path]
+			if !ok {
+				t.Fatalf("setting %s not described", tt.path)
+			}
+			tt.check(t, setting)
+		})
+	}
+
+	// Runtime values are not stored in the config file
+	for _, path := range []string{"version", "systemid", "input.path"} {
+		if _, ok := byPath[path]; ok {
+			t.Errorf("runtime value %s should not be described", path)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// conf/detection_filters.go validation of the privacy and dog bark filters
+package conf
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// maxClampedConfidence is the largest filter confidence that is clamped to 1
+// with a warning, larger values are rejected as a misunderstanding of the scale
+const maxClampedConfidence = 1.5
+
+// validateDetectionFilters validates the privacy and dog bark filter settings
+// of enabled filters
+func validateDetectionFilters(settings *Settings) error {
+	if filter := &settings.Realtime.PrivacyFilter; filter.Enabled {
+		if err := validateFilterConfidence("realtime.privacyfilter.confidence", &filter.Confidence, settings); err != nil {
+			return err
+		}
+	}
+
+	// Anonymization of stored detections applies whether or not the filter is enabled
+	if err := settings.Realtime.PrivacyFilter.Privacy.Validate(); err != nil {
+		return err
+	}
+
+	filter := &settings.Realtime.DogBarkFilter
+	if !filter.Enabled {
+		return nil
+	}
+	if err := validateFilterConfidence("realtime.dogbarkfilter.confidence", &filter.Confidence, settings); err != nil {
+		return err
+	}
+	if c := constraintFor("realtime.dogbarkfilter.remember"); !c.inRange(float64(filter.Remember)) {
+		return errors.New(fmt.Errorf("dog bark filter remember must be %s seconds, got %d", c, filter.Remember)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeDogBarkFilterRemember).
+			Build()
+	}
+	for i, species := range filter.Species {
+		if strings.TrimSpace(species) == "" {
+			return errors.New(fmt.Errorf("dog bark filter species entry %d is empty", i)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeDogBarkFilterSpecies).
+				Build()
+		}
+	}
+
+	return nil
+}
+
+// validateFilterConfidence checks a filter confidence threshold. Values
+// slightly above 1 are clamped to 1 with a warning, negative values and values
+// above maxClampedConfidence are errors since the filter would never trigger.
+func validateFilterConfidence(key string, confidence *float32, settings *Settings) error {
+	c := constraintFor(key)
+	value := float64(*confidence)
+	if c.inRange(value) {
+		return nil
+	}
+
+	if value < 0 || value > maxClampedConfidence {
+		return errors.New(fmt.Errorf("%s must be %s, got %v", key, c, value)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeFilterConfidence).
+			Context("setting", key).
+			Build()
+	}
+
+	message := fmt.Sprintf("%s %v is above 1, using 1", key, value)
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeFilterConfidence, "confidence-clamped")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-filter-validation: %s", message))
+	*confidence = float32(*c.Maximum)
+	return nil
+}
@@ -0,0 +1,59 @@
+package conf
+
+import "testing"
+
+func TestValidateDetectionFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		modify          func(s *Settings)
+		wantErr         bool
+		wantWarnings    int
+		wantPrivacy     float32
+		wantDogBarkConf float32
+	}{
+		{"valid", func(s *Settings) {}, false, 0, 0.05, 0.1},
+		{"disabled filters are not checked", func(s *Settings) {
+			s.Realtime.PrivacyFilter = PrivacyFilterSettings{Confidence: -1}
+			s.Realtime.DogBarkFilter = DogBarkFilterSettings{Confidence: 5, Remember: -1}
+		}, false, 0, -1, 5},
+		{"privacy confidence slightly above one is clamped", func(s *Settings) {
+			s.Realtime.PrivacyFilter.Confidence = 1.2
+		}, false, 1, 1, 0.1},
+		{"dog bark confidence slightly above one is clamped", func(s *Settings) {
+			s.Realtime.DogBarkFilter.Confidence = 1.5
+		}, false, 1, 0.05, 1},
+		{"negative privacy confidence", func(s *Settings) { s.Realtime.PrivacyFilter.Confidence = -0.1 }, true, 0, 0, 0},
+		{"dog bark confidence far above one", func(s *Settings) { s.Realtime.DogBarkFilter.Confidence = 10 }, true, 0, 0, 0},
+		{"negative remember", func(s *Settings) { s.Realtime.DogBarkFilter.Remember = -5 }, true, 0, 0, 0},
+		{"empty species entry", func(s *Settings) { s.Realtime.DogBarkFilter.Species = []string{"Eurasian Magpie", " "} }, true, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.Realtime.PrivacyFilter = PrivacyFilterSettings{Enabled: true, Confidence: 0.05}
+			settings.Realtime.DogBarkFilter = DogBarkFilterSettings{Enabled: true, Confidence: 0.1, Remember: 5}
+			tt.modify(settings)
+
+			err := validateDetectionFilters(settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateDetectionFilters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(settings.ValidationWarnings) != tt.wantWarnings {
+				t.Errorf("ValidationWarnings = %v, want %d warnings", settings.ValidationWarnings, tt.wantWarnings)
+			}
+			if got := settings.Realtime.PrivacyFilter.Confidence; got != tt.wantPrivacy {
+				t.Errorf("PrivacyFilter.Confidence = %v, want %v", got, tt.wantPrivacy)
+			}
+			if got := settings.Realtime.DogBarkFilter.Confidence; got != tt.wantDogBarkConf {
+				t.Errorf("DogBarkFilter.Confidence = %v, want %v", got, tt.wantDogBarkConf)
+			}
+		})
+	}
+}
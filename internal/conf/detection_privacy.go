@@ -0,0 +1,52 @@
+// conf/detection_privacy.go anonymization of detections stored in the database
+package conf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Validate checks that the timestamp rounding interval is a whole number of
+// seconds that divides a day evenly, so that rounded timestamps fall on the
+// same wall clock times every day
+func (p *DetectionPrivacySettings) Validate() error {
+	interval := p.RoundTimestampTo
+	if interval == 0 {
+		return nil
+	}
+	if interval < time.Second || interval > Day || interval%time.Second != 0 || Day%interval != 0 {
+		return errors.New(fmt.Errorf("privacy timestamp rounding must be 0 or a whole number of seconds between 1s and 24h that divides a day evenly, got %s", interval)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodePrivacyRoundTimestamp).
+			Build()
+	}
+	return nil
+}
+
+// Anonymizes reports whether detections are changed before they are stored,
+// the defaults store them unchanged
+func (p *DetectionPrivacySettings) Anonymizes() bool {
+	return p.RoundTimestampTo > 0 || !p.StoreLocation
+}
+
+// AnonymizeTime rounds t down to the timestamp rounding interval, counted
+// from midnight in the location of t. t is returned unchanged when rounding
+// is disabled.
+func (p *DetectionPrivacySettings) AnonymizeTime(t time.Time) time.Time {
+	if p.RoundTimestampTo <= 0 || t.IsZero() {
+		return t
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return midnight.Add(t.Sub(midnight).Truncate(p.RoundTimestampTo))
+}
+
+// AnonymizeLocation returns the latitude and longitude to store with a
+// detection, zero when the location is not stored
+func (p *DetectionPrivacySettings) AnonymizeLocation(latitude, longitude float64) (storedLatitude, storedLongitude float64) {
+	if !p.StoreLocation {
+		return 0, 0
+	}
+	return latitude, longitude
+}
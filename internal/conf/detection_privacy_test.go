@@ -0,0 +1,64 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectionPrivacySettingsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		interval time.Duration
+		wantErr  bool
+	}{
+		{0, false},
+		{time.Minute, false},
+		{15 * time.Minute, false},
+		{time.Hour, false},
+		{Day, false},
+		{-time.Minute, true},
+		{500 * time.Millisecond, true},
+		{7 * time.Minute, true},
+		{2 * Day, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.interval.String(), func(t *testing.T) {
+			t.Parallel()
+			privacy := DetectionPrivacySettings{RoundTimestampTo: tt.interval, StoreLocation: true}
+			if err := privacy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDetectionPrivacySettingsAnonymize(t *testing.T) {
+	t.Parallel()
+
+	detected := time.Date(2026, 5, 17, 6, 47, 31, 0, time.FixedZone("IST", 5*3600+1800))
+
+	full := DetectionPrivacySettings{StoreLocation: true}
+	if full.Anonymizes() {
+		t.Error("Anonymizes() with the defaults = true, want false")
+	}
+	if got := full.AnonymizeTime(detected); !got.Equal(detected) {
+		t.Errorf("AnonymizeTime() without rounding = %v, want %v", got, detected)
+	}
+	if lat, lon := full.AnonymizeLocation(60.17, 24.94); lat != 60.17 || lon != 24.94 {
+		t.Errorf("AnonymizeLocation() = %v, %v, want the location", lat, lon)
+	}
+
+	private := DetectionPrivacySettings{RoundTimestampTo: time.Hour}
+	if !private.Anonymizes() {
+		t.Error("Anonymizes() with rounding = false, want true")
+	}
+	// Rounding is counted from local midnight, not from the Unix epoch
+	want := time.Date(2026, 5, 17, 6, 0, 0, 0, detected.Location())
+	if got := private.AnonymizeTime(detected); !got.Equal(want) {
+		t.Errorf("AnonymizeTime() = %v, want %v", got, want)
+	}
+	if lat, lon := private.AnonymizeLocation(60.17, 24.94); lat != 0 || lon != 0 {
+		t.Errorf("AnonymizeLocation() = %v, %v, want no location", lat, lon)
+	}
+}
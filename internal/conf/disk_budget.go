@@ -0,0 +1,45 @@
+// conf/disk_budget.go disk usage budget of the audio export directory
+package conf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// DiskBudget returns the used bytes of the filesystem holding the audio
+// export directory and the usage limit of Retention.MaxUsage in bytes. A
+// percentage limit such as "80%" is resolved against the size of the
+// filesystem. The directory is not created, an error of category file-io is
+// returned when it does not exist yet.
+func (e ExportSettings) DiskBudget() (usedBytes, limitBytes uint64, err error) {
+	path := filepath.Clean(os.ExpandEnv(e.Path))
+	if _, err := os.Stat(path); err != nil {
+		message := fmt.Errorf("cannot stat audio export directory %s: %w", path, err)
+		if os.IsNotExist(err) {
+			message = fmt.Errorf("audio export directory %s does not exist yet: %w", path, err)
+		}
+		return 0, 0, errors.New(message).
+			Category(errors.CategoryFileIO).
+			Context("operation", "export-disk-budget").
+			Context("path", path).
+			Build()
+	}
+
+	totalBytes, usedBytes, err := filesystemUsage(path)
+	if err != nil {
+		return 0, 0, errors.New(fmt.Errorf("cannot get filesystem usage of audio export directory %s: %w", path, err)).
+			Category(errors.CategoryDiskUsage).
+			Context("operation", "export-disk-budget").
+			Context("path", path).
+			Build()
+	}
+
+	limitBytes, _, err = ParseDiskLimit(e.Retention.MaxUsage, totalBytes)
+	if err != nil {
+		return 0, 0, err
+	}
+	return usedBytes, limitBytes, nil
+}
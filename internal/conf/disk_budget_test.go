@@ -0,0 +1,74 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestExportDiskBudget(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	totalBytes, _, err := filesystemUsage(dir)
+	if err != nil {
+		t.Fatalf("filesystemUsage() error = %v", err)
+	}
+
+	t.Run("percentage limit", func(t *testing.T) {
+		t.Parallel()
+		export := ExportSettings{Path: dir, Retention: RetentionSettings{MaxUsage: "50%"}}
+		used, limit, err := export.DiskBudget()
+		if err != nil {
+			t.Fatalf("DiskBudget() error = %v", err)
+		}
+		if limit != totalBytes/2 {
+			t.Errorf("limit = %d, want %d", limit, totalBytes/2)
+		}
+		if used > totalBytes {
+			t.Errorf("used = %d exceeds filesystem size %d", used, totalBytes)
+		}
+	})
+
+	t.Run("absolute limit", func(t *testing.T) {
+		t.Parallel()
+		export := ExportSettings{Path: dir, Retention: RetentionSettings{MaxUsage: "1MiB"}}
+		_, limit, err := export.DiskBudget()
+		if err != nil {
+			t.Fatalf("DiskBudget() error = %v", err)
+		}
+		if limit != 1<<20 {
+			t.Errorf("limit = %d, want %d", limit, 1<<20)
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+		export := ExportSettings{Path: dir, Retention: RetentionSettings{MaxUsage: "lots"}}
+		if _, _, err := export.DiskBudget(); err == nil {
+			t.Error("DiskBudget() accepted an invalid maxusage")
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		t.Parallel()
+		missing := filepath.Join(dir, "clips")
+		export := ExportSettings{Path: missing, Retention: RetentionSettings{MaxUsage: "80%"}}
+		_, _, err := export.DiskBudget()
+		if err == nil {
+			t.Fatal("DiskBudget() succeeded for a missing directory")
+		}
+		var enhanced *errors.EnhancedError
+		if !errors.As(err, &enhanced) || enhanced.Category != errors.CategoryFileIO {
+			t.Errorf("DiskBudget() error = %v, want a file-io error", err)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("DiskBudget() error = %v, want it to wrap os.ErrNotExist", err)
+		}
+		if _, err := os.Stat(missing); !os.IsNotExist(err) {
+			t.Error("DiskBudget() created the export directory")
+		}
+	})
+}
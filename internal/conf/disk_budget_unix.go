@@ -0,0 +1,26 @@
+//go:build !windows
+
+package conf
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// filesystemUsage returns the total and used bytes of the filesystem holding
+// path, space reserved for root counts as used
+func filesystemUsage(path string) (totalBytes, usedBytes uint64, err error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	// Validate that Bsize is positive to avoid overflow when converting to uint64
+	if stat.Bsize <= 0 {
+		return 0, 0, fmt.Errorf("invalid block size %d from filesystem", stat.Bsize)
+	}
+	bsize := uint64(stat.Bsize) // Bsize validated as positive, safe conversion
+	totalBytes = stat.Blocks * bsize
+	return totalBytes, totalBytes - stat.Bavail*bsize, nil
+}
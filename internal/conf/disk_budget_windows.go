@@ -0,0 +1,22 @@
+//go:build windows
+
+package conf
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// filesystemUsage returns the total and used bytes of the volume holding
+// path, space not available to the user counts as used
+func filesystemUsage(path string) (totalBytes, usedBytes uint64, err error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var free, total, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &free, &total, &totalFree); err != nil {
+		return 0, 0, err
+	}
+	return total, total - free, nil
+}
@@ -0,0 +1,104 @@
+// conf/duration.go duration strings with day and week units
+package conf
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Units of ParseHumanDuration longer than the units of time.ParseDuration
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// humanDurationPattern matches one number and unit of a duration string
+var humanDurationPattern = regexp.MustCompile(`^([0-9]*\.?[0-9]+)(ns|us|µs|ms|s|m|h|d|w)`)
+
+// ParseHumanDuration parses a duration string such as "30d", "1w2d" or "1h30m".
+// It accepts the units of time.ParseDuration and additionally "d" for days and
+// "w" for weeks, where a day is always 24 hours.
+func ParseHumanDuration(s string) (time.Duration, error) {
+	input := strings.TrimSpace(s)
+	if input == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+	if input == "0" {
+		return 0, nil
+	}
+
+	negative := strings.HasPrefix(input, "-")
+	rest := strings.TrimPrefix(strings.TrimPrefix(input, "-"), "+")
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var total time.Duration
+	for rest != "" {
+		match := humanDurationPattern.FindStringSubmatch(rest)
+		if match == nil {
+			return 0, fmt.Errorf("invalid duration %q, use a number and unit such as 30d, 12h or 90s", s)
+		}
+		rest = rest[len(match[0]):]
+
+		var part time.Duration
+		switch match[2] {
+		case "d", "w":
+			value, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+			}
+			unit := Day
+			if match[2] == "w" {
+				unit = Week
+			}
+			if value*float64(unit) > math.MaxInt64 {
+				return 0, fmt.Errorf("duration %q is out of range", s)
+			}
+			part = time.Duration(value * float64(unit))
+		default:
+			parsed, err := time.ParseDuration(match[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+			}
+			part = parsed
+		}
+
+		if total > math.MaxInt64-part {
+			return 0, fmt.Errorf("duration %q is out of range", s)
+		}
+		total += part
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// humanDurationHook is a mapstructure decode hook parsing strings into
+// time.Duration fields with ParseHumanDuration, so that config files can use
+// values such as "30d"
+func humanDurationHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != durationType {
+		return data, nil
+	}
+	return ParseHumanDuration(reflect.ValueOf(data).String())
+}
+
+// settingsDecodeHook returns the decode hooks for unmarshaling settings, the
+// viper defaults with human duration and percentage parsing
+func settingsDecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		humanDurationHook,
+		percentageHook,
+		mapstructure.StringToSliceHookFunc(","),
+	)
+}
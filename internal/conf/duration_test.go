@@ -0,0 +1,108 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseHumanDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"90s", 90 * time.Second, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"30d", 30 * Day, false},
+		{"2w", 14 * Day, false},
+		{"1w2d3h", 9*Day + 3*time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{" 7d ", 7 * Day, false},
+		{"-1d", -Day, false},
+		{"500ms", 500 * time.Millisecond, false},
+		{"", 0, true},
+		{"30", 0, true},
+		{"d", 0, true},
+		{"5x", 0, true},
+		{"1d foo", 0, true},
+		{"99999999w", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseHumanDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHumanDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseHumanDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDurationFieldsRoundTrip checks that duration settings written with day
+// and week units load, and that saved settings load back unchanged
+func TestDurationFieldsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	config := `
+security:
+  sessionduration: 30d
+  basicauth:
+    authcodeexp: 15m
+    accesstokenexp: 1d
+backup:
+  operationtimeouts:
+    backup: 1d
+    store: 1h30m
+    cleanup: 1w
+    delete: 90s
+`
+	tests := []struct {
+		name string
+		get  func(s *Settings) time.Duration
+		want time.Duration
+	}{
+		{"security.sessionduration", func(s *Settings) time.Duration { return s.Security.SessionDuration }, 30 * Day},
+		{"security.basicauth.authcodeexp", func(s *Settings) time.Duration { return s.Security.BasicAuth.AuthCodeExp }, 15 * time.Minute},
+		{"security.basicauth.accesstokenexp", func(s *Settings) time.Duration { return s.Security.BasicAuth.AccessTokenExp }, Day},
+		{"backup.operationtimeouts.backup", func(s *Settings) time.Duration { return s.Backup.OperationTimeouts.Backup }, Day},
+		{"backup.operationtimeouts.store", func(s *Settings) time.Duration { return s.Backup.OperationTimeouts.Store }, 90 * time.Minute},
+		{"backup.operationtimeouts.cleanup", func(s *Settings) time.Duration { return s.Backup.OperationTimeouts.Cleanup }, Week},
+		{"backup.operationtimeouts.delete", func(s *Settings) time.Duration { return s.Backup.OperationTimeouts.Delete }, 90 * time.Second},
+	}
+
+	loaded, err := LoadFromReader(strings.NewReader(config))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+
+	saved, err := yaml.Marshal(loaded)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	reloaded, err := LoadFromReader(strings.NewReader(string(saved)))
+	if err != nil {
+		t.Fatalf("LoadFromReader() of saved settings error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.get(loaded); got != tt.want {
+				t.Errorf("loaded %s = %v, want %v", tt.name, got, tt.want)
+			}
+			if got := tt.get(reloaded); got != tt.want {
+				t.Errorf("reloaded %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
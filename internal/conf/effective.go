@@ -0,0 +1,88 @@
+// conf/effective.go fully resolved settings snapshots
+package conf
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// EffectiveYAML returns the settings as YAML with every setting materialized,
+// including the defaults of settings missing from the config file and fields
+// omitted when empty. Restoring it gives the same settings even when a later
+// version changes the defaults. Keys are lowercase as viper reads them. As in
+// the config file, secrets read from secret files are left out, other secrets
+// are included and must be removed by the caller when needed.
+func (s *Settings) EffectiveYAML() ([]byte, error) {
+	settingsMutex.RLock()
+	settingsCopy := *s
+	speciesListMutex.RLock()
+	settingsCopy.BirdNET.RangeFilter.Species = append([]string(nil), s.BirdNET.RangeFilter.Species...)
+	speciesListMutex.RUnlock()
+	restoreInlineSecrets(&settingsCopy)
+	data, err := yaml.Marshal(&settingsCopy)
+	settingsMutex.RUnlock()
+	if err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "yaml-marshal").
+			Build()
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "yaml-unmarshal").
+			Build()
+	}
+
+	v := viper.New()
+	setDefaults(v)
+	effective := v.AllSettings()
+	mergeSettingsMap(effective, lowercaseKeys(values).(map[string]any))
+
+	data, err = yaml.Marshal(effective)
+	if err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "yaml-marshal").
+			Build()
+	}
+	return data, nil
+}
+
+// lowercaseKeys returns value with the keys of all nested maps lowercased
+func lowercaseKeys(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		lowered := make(map[string]any, len(value))
+		for key, nested := range value {
+			lowered[strings.ToLower(key)] = lowercaseKeys(nested)
+		}
+		return lowered
+	case []any:
+		for i, nested := range value {
+			value[i] = lowercaseKeys(nested)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// mergeSettingsMap merges values into dst, values replace those of dst except
+// for nested maps which are merged
+func mergeSettingsMap(dst, values map[string]any) {
+	for key, value := range values {
+		nested, isMap := value.(map[string]any)
+		existing, existingIsMap := dst[key].(map[string]any)
+		if isMap && existingIsMap {
+			mergeSettingsMap(existing, nested)
+			continue
+		}
+		dst[key] = value
+	}
+}
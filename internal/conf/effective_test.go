@@ -0,0 +1,89 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestEffectiveYAML(t *testing.T) {
+	settings, err := LoadFromReader(strings.NewReader("main:\n  name: node\n"))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+
+	effective, err := settings.EffectiveYAML()
+	if err != nil {
+		t.Fatalf("EffectiveYAML() error = %v", err)
+	}
+	if strings.Contains(string(effective), "executeDefaults") {
+		t.Error("EffectiveYAML() contains mixed case keys, want lowercase keys")
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(effective, &values); err != nil {
+		t.Fatalf("EffectiveYAML() is not valid YAML: %v", err)
+	}
+	for _, key := range []string{"main", "birdnet", "realtime", "output", "security"} {
+		if _, ok := values[key]; !ok {
+			t.Errorf("EffectiveYAML() is missing the %s section", key)
+		}
+	}
+
+	// Loading the snapshot reproduces the settings
+	restored, err := LoadFromReader(strings.NewReader(string(effective)))
+	if err != nil {
+		t.Fatalf("LoadFromReader(effective) error = %v", err)
+	}
+	want, err := yaml.Marshal(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := yaml.Marshal(restored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("settings restored from EffectiveYAML() differ:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEffectiveYAMLOmitsSecretFileValues(t *testing.T) {
+	settings := &Settings{}
+	settings.Realtime.MQTT.Password = "from-file"
+	settings.Realtime.MQTT.PasswordFile = "/run/secrets/mqtt"
+	settings.inlineSecrets = map[string]string{"realtime.mqtt.password": ""}
+
+	effective, err := settings.EffectiveYAML()
+	if err != nil {
+		t.Fatalf("EffectiveYAML() error = %v", err)
+	}
+	if strings.Contains(string(effective), "from-file") {
+		t.Error("EffectiveYAML() contains a secret read from a secret file")
+	}
+	if settings.Realtime.MQTT.Password != "from-file" {
+		t.Errorf("EffectiveYAML() changed the settings, MQTT password = %q", settings.Realtime.MQTT.Password)
+	}
+}
+
+func TestMergeSettingsMap(t *testing.T) {
+	t.Parallel()
+
+	dst := map[string]any{
+		"main":    map[string]any{"name": "default", "timeas24h": true},
+		"birdnet": map[string]any{"threshold": 0.8},
+	}
+	mergeSettingsMap(dst, lowercaseKeys(map[string]any{
+		"Main":    map[string]any{"Name": "node"},
+		"birdnet": nil,
+	}).(map[string]any))
+
+	main := dst["main"].(map[string]any)
+	if main["name"] != "node" || main["timeas24h"] != true {
+		t.Errorf("merged main = %v, want name node and the default timeas24h", main)
+	}
+	if dst["birdnet"] != nil {
+		t.Errorf("merged birdnet = %v, want the nil value to replace the default", dst["birdnet"])
+	}
+}
@@ -0,0 +1,258 @@
+// conf/encryption.go optional encryption of the config file at rest
+package conf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Environment variables holding the key of an encrypted config file. The key
+// is any secret string, ConfigKeyFileEnv names a file containing it, e.g. a
+// Docker or Kubernetes secret. Without a key the config file is plaintext.
+const (
+	ConfigKeyEnv     = "BIRDNET_GO_CONFIG_KEY"
+	ConfigKeyFileEnv = "BIRDNET_GO_CONFIG_KEY_FILE"
+)
+
+// encryptedConfigHeader starts encrypted config files, it is followed by the
+// base64 encoded salt, nonce and AES-256-GCM ciphertext of the YAML content
+const encryptedConfigHeader = "BIRDNET-GO ENCRYPTED CONFIG v1\n"
+
+// Parameters of deriving the AES key from the config key with scrypt
+const (
+	configKeySaltSize = 16
+	configKeyScryptN  = 1 << 15
+	configKeyScryptR  = 8
+	configKeyScryptP  = 1
+	configKeySize     = 32
+)
+
+// IsEncryptedConfig reports whether the file at path is an encrypted config
+// file, plaintext config files are YAML
+func IsEncryptedConfig(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-config-file").
+			Context("path", path).
+			Build()
+	}
+	defer f.Close()
+
+	header := make([]byte, len(encryptedConfigHeader))
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false, errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-config-file").
+			Context("path", path).
+			Build()
+	}
+	return isEncryptedConfigData(header[:n]), nil
+}
+
+// isEncryptedConfigData reports whether config file content is encrypted
+func isEncryptedConfigData(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedConfigHeader))
+}
+
+// configEncryptionKey returns the config key from ConfigKeyEnv or the file
+// named by ConfigKeyFileEnv, an empty string when neither is set
+func configEncryptionKey() (string, error) {
+	if key := strings.TrimSpace(os.Getenv(ConfigKeyEnv)); key != "" {
+		return key, nil
+	}
+	keyFile := strings.TrimSpace(os.Getenv(ConfigKeyFileEnv))
+	if keyFile == "" {
+		return "", nil
+	}
+
+	f, err := os.Open(keyFile)
+	if err != nil {
+		return "", errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-config-key-file").
+			Context("path", keyFile).
+			Build()
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxSecretFileSize))
+	if err != nil {
+		return "", errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-config-key-file").
+			Context("path", keyFile).
+			Build()
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", errors.New(fmt.Errorf("config key file %s named by %s is empty", keyFile, ConfigKeyFileEnv)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "read-config-key-file").
+			Context("path", keyFile).
+			Build()
+	}
+	return key, nil
+}
+
+// configCipher returns the AES-256-GCM cipher of key and salt
+func configCipher(key string, salt []byte) (cipher.AEAD, error) {
+	derived, err := scrypt.Key([]byte(key), salt, configKeyScryptN, configKeyScryptR, configKeyScryptP, configKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptConfigData encrypts config file content when a config key is set
+// and returns it unchanged otherwise
+func encryptConfigData(plaintext []byte) ([]byte, error) {
+	key, err := configEncryptionKey()
+	if err != nil || key == "" {
+		return plaintext, err
+	}
+
+	salt := make([]byte, configKeySaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategorySystem).
+			Context("operation", "encrypt-config").
+			Build()
+	}
+	aead, err := configCipher(key, salt)
+	if err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategorySystem).
+			Context("operation", "encrypt-config").
+			Build()
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategorySystem).
+			Context("operation", "encrypt-config").
+			Build()
+	}
+
+	sealed := aead.Seal(slices.Concat(salt, nonce), nonce, plaintext, []byte(encryptedConfigHeader))
+	encoded := base64.StdEncoding.EncodeToString(sealed)
+	return []byte(encryptedConfigHeader + encoded + "\n"), nil
+}
+
+// decryptConfigData returns the YAML content of an encrypted config file,
+// plaintext content is returned unchanged
+func decryptConfigData(data []byte) ([]byte, error) {
+	if !isEncryptedConfigData(data) {
+		return data, nil
+	}
+
+	key, err := configEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, errors.New(fmt.Errorf("config file is encrypted, set %s or %s to decrypt it", ConfigKeyEnv, ConfigKeyFileEnv)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "decrypt-config").
+			Build()
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data[len(encryptedConfigHeader):])))
+	if err != nil {
+		return nil, errors.New(fmt.Errorf("encrypted config file is corrupted: %w", err)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "decrypt-config").
+			Build()
+	}
+	if len(sealed) < configKeySaltSize {
+		return nil, errors.New(fmt.Errorf("encrypted config file is truncated")).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "decrypt-config").
+			Build()
+	}
+	aead, err := configCipher(key, sealed[:configKeySaltSize])
+	if err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategorySystem).
+			Context("operation", "decrypt-config").
+			Build()
+	}
+	sealed = sealed[configKeySaltSize:]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New(fmt.Errorf("encrypted config file is truncated")).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "decrypt-config").
+			Build()
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(encryptedConfigHeader))
+	if err != nil {
+		return nil, errors.New(fmt.Errorf("cannot decrypt config file, the config key is wrong or the file was modified")).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "decrypt-config").
+			Build()
+	}
+	return plaintext, nil
+}
+
+// readConfigFile returns the YAML content of the config file at path,
+// decrypting it when it is encrypted
+func readConfigFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decryptConfigData(data)
+}
+
+// writeConfigFile writes YAML content to the config file at path
+// atomically, encrypted when a config key is set. The config may contain
+// secrets so only the owner can read it.
+func writeConfigFile(path string, yamlData []byte) error {
+	data, err := encryptConfigData(yamlData)
+	if err != nil {
+		return err
+	}
+	return AtomicWriteFile(path, data, 0o600)
+}
+
+// readInConfig reads the config file into viper like viper.ReadInConfig,
+// decrypting the first config.yaml of configPaths when it is encrypted
+func readInConfig(configPaths []string) error {
+	for _, dir := range configPaths {
+		path := filepath.Join(dir, "config.yaml")
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		encrypted, err := IsEncryptedConfig(path)
+		if err != nil || !encrypted {
+			break
+		}
+
+		data, err := readConfigFile(path)
+		if err != nil {
+			return err
+		}
+		viper.SetConfigFile(path)
+		return viper.ReadConfig(bytes.NewReader(data))
+	}
+	return viper.ReadInConfig()
+}
@@ -0,0 +1,126 @@
+package conf
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// Not parallel, the tests set the config key environment variables
+
+const testConfigYAML = "birdnet:\n  threshold: 0.6\n"
+
+func TestEncryptConfigData(t *testing.T) {
+	t.Setenv(ConfigKeyFileEnv, "")
+	t.Setenv(ConfigKeyEnv, "")
+
+	// Without a key the content is written as it is
+	data, err := encryptConfigData([]byte(testConfigYAML))
+	if err != nil || string(data) != testConfigYAML {
+		t.Fatalf("encryptConfigData() without a key = %q, %v, want the plaintext", data, err)
+	}
+
+	t.Setenv(ConfigKeyEnv, "correct horse battery staple")
+	encrypted, err := encryptConfigData([]byte(testConfigYAML))
+	if err != nil {
+		t.Fatalf("encryptConfigData() error = %v", err)
+	}
+	if !isEncryptedConfigData(encrypted) || bytes.Contains(encrypted, []byte("threshold")) {
+		t.Fatalf("encryptConfigData() = %q, want encrypted content", encrypted)
+	}
+	decrypted, err := decryptConfigData(encrypted)
+	if err != nil || string(decrypted) != testConfigYAML {
+		t.Fatalf("decryptConfigData() = %q, %v, want the plaintext", decrypted, err)
+	}
+
+	// Plaintext content is read as it is even with a key
+	if data, err := decryptConfigData([]byte(testConfigYAML)); err != nil || string(data) != testConfigYAML {
+		t.Errorf("decryptConfigData() of plaintext = %q, %v, want the plaintext", data, err)
+	}
+
+	t.Setenv(ConfigKeyEnv, "wrong key")
+	if _, err := decryptConfigData(encrypted); err == nil {
+		t.Error("decryptConfigData() with a wrong key error = nil, want an error")
+	}
+
+	t.Setenv(ConfigKeyEnv, "")
+	if _, err := decryptConfigData(encrypted); err == nil || !strings.Contains(err.Error(), ConfigKeyEnv) {
+		t.Errorf("decryptConfigData() without a key error = %v, want an error naming %s", err, ConfigKeyEnv)
+	}
+
+	tampered := bytes.Clone(encrypted)
+	tampered[len(tampered)-5] ^= 1
+	t.Setenv(ConfigKeyEnv, "correct horse battery staple")
+	if _, err := decryptConfigData(tampered); err == nil {
+		t.Error("decryptConfigData() of modified content error = nil, want an error")
+	}
+}
+
+func TestConfigKeyFile(t *testing.T) {
+	t.Setenv(ConfigKeyEnv, "")
+	keyFile := filepath.Join(t.TempDir(), "config.key")
+	if err := os.WriteFile(keyFile, []byte("secret from a file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ConfigKeyFileEnv, keyFile)
+
+	if key, err := configEncryptionKey(); err != nil || key != "secret from a file" {
+		t.Errorf("configEncryptionKey() = %q, %v, want the key file content", key, err)
+	}
+
+	// The key variable takes precedence over the key file
+	t.Setenv(ConfigKeyEnv, "secret from the environment")
+	if key, err := configEncryptionKey(); err != nil || key != "secret from the environment" {
+		t.Errorf("configEncryptionKey() = %q, %v, want the environment value", key, err)
+	}
+
+	t.Setenv(ConfigKeyEnv, "")
+	t.Setenv(ConfigKeyFileEnv, filepath.Join(t.TempDir(), "missing.key"))
+	if _, err := configEncryptionKey(); err == nil {
+		t.Error("configEncryptionKey() with a missing key file error = nil, want an error")
+	}
+}
+
+func TestReadEncryptedConfig(t *testing.T) {
+	configDir := t.TempDir()
+	t.Cleanup(viper.Reset)
+	t.Setenv(ConfigKeyFileEnv, "")
+	t.Setenv(ConfigKeyEnv, "correct horse battery staple")
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	if err := writeConfigFile(configPath, []byte(testConfigYAML)); err != nil {
+		t.Fatalf("writeConfigFile() error = %v", err)
+	}
+	if encrypted, err := IsEncryptedConfig(configPath); err != nil || !encrypted {
+		t.Fatalf("IsEncryptedConfig() = %v, %v, want true", encrypted, err)
+	}
+
+	viper.Reset()
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath(configDir)
+	if err := readInConfig([]string{configDir}); err != nil {
+		t.Fatalf("readInConfig() error = %v", err)
+	}
+	if got := viper.GetFloat64("birdnet.threshold"); got != 0.6 {
+		t.Errorf("birdnet.threshold = %v, want 0.6", got)
+	}
+	if viper.ConfigFileUsed() != configPath {
+		t.Errorf("ConfigFileUsed() = %q, want %q", viper.ConfigFileUsed(), configPath)
+	}
+
+	t.Setenv(ConfigKeyEnv, "")
+	if err := writeConfigFile(configPath, []byte(testConfigYAML)); err != nil {
+		t.Fatalf("writeConfigFile() without a key error = %v", err)
+	}
+	if encrypted, err := IsEncryptedConfig(configPath); err != nil || encrypted {
+		t.Errorf("IsEncryptedConfig() of a plaintext config = %v, %v, want false", encrypted, err)
+	}
+	if _, err := IsEncryptedConfig(filepath.Join(configDir, "missing.yaml")); err == nil {
+		t.Error("IsEncryptedConfig() of a missing file error = nil, want an error")
+	}
+}
@@ -0,0 +1,87 @@
+// conf/export.go audio export format and bitrate helpers
+package conf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Audio export bitrate limits in kbps
+const (
+	MinExportBitrate = 32
+	MaxExportBitrate = 320
+)
+
+// DefaultExportBitrate is used for lossy formats when no bitrate is configured
+const DefaultExportBitrate = "128k"
+
+// templateExportBitrate is the bitrate of the config.yaml template, it is kept
+// with lossless types so that switching to a lossy type has a bitrate
+const templateExportBitrate = "96k"
+
+// maxFormatBitrate holds encoder specific bitrate limits in kbps
+var maxFormatBitrate = map[string]int{
+	"opus": 256,
+}
+
+// IsLossyExportType reports whether an audio export type uses a bitrate setting
+func IsLossyExportType(exportType string) bool {
+	switch exportType {
+	case "aac", "opus", "mp3":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseBitrate parses a bitrate such as "128k" into kbps
+func parseBitrate(bitrate string) (int, error) {
+	if !strings.HasSuffix(bitrate, "k") {
+		return 0, fmt.Errorf("bitrate %q must end with 'k' (e.g., '128k')", bitrate)
+	}
+	value, err := strconv.Atoi(strings.TrimSuffix(bitrate, "k"))
+	if err != nil {
+		return 0, fmt.Errorf("bitrate %q is not a number: %w", bitrate, err)
+	}
+	return value, nil
+}
+
+// exportBitrateWarning returns a warning when a bitrate is configured for a
+// type that ignores it, or an empty string. The default bitrates are not
+// reported, the shipped configuration sets one for the wav default.
+func exportBitrateWarning(settings *ExportSettings) string {
+	if IsLossyExportType(settings.Type) {
+		return ""
+	}
+	switch settings.Bitrate {
+	case "", DefaultExportBitrate, templateExportBitrate:
+		return ""
+	}
+	return fmt.Sprintf("audio export bitrate %s is ignored for %s exports", settings.Bitrate, settings.Type)
+}
+
+// EffectiveBitrate returns the bitrate passed to the encoder for the export
+// type. Lossless types ignore the bitrate and return an empty string, lossy
+// types fall back to DefaultExportBitrate when Bitrate is empty and are
+// capped at the encoder's maximum.
+func (e ExportSettings) EffectiveBitrate() string {
+	if !IsLossyExportType(e.Type) {
+		return ""
+	}
+
+	bitrate := e.Bitrate
+	if bitrate == "" {
+		bitrate = DefaultExportBitrate
+	}
+
+	value, err := parseBitrate(bitrate)
+	if err != nil {
+		// Invalid bitrates are rejected during validation
+		return DefaultExportBitrate
+	}
+	if limit, ok := maxFormatBitrate[e.Type]; ok && value > limit {
+		value = limit
+	}
+	return fmt.Sprintf("%dk", value)
+}
@@ -0,0 +1,213 @@
+package conf
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestExportSettingsEffectiveBitrate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings ExportSettings
+		want     string
+	}{
+		{"wav ignores bitrate", ExportSettings{Type: "wav", Bitrate: "192k"}, ""},
+		{"flac ignores bitrate", ExportSettings{Type: "flac", Bitrate: "192k"}, ""},
+		{"mp3 configured bitrate", ExportSettings{Type: "mp3", Bitrate: "192k"}, "192k"},
+		{"mp3 empty bitrate uses default", ExportSettings{Type: "mp3"}, DefaultExportBitrate},
+		{"aac configured bitrate", ExportSettings{Type: "aac", Bitrate: "96k"}, "96k"},
+		{"opus capped at encoder maximum", ExportSettings{Type: "opus", Bitrate: "320k"}, "256k"},
+		{"opus below maximum", ExportSettings{Type: "opus", Bitrate: "96k"}, "96k"},
+		{"invalid bitrate uses default", ExportSettings{Type: "mp3", Bitrate: "fast"}, DefaultExportBitrate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.settings.EffectiveBitrate(); got != tt.want {
+				t.Errorf("EffectiveBitrate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateExportFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings ExportSettings
+		errType  string
+	}{
+		{"wav without bitrate", ExportSettings{Type: "wav"}, ""},
+		{"wav with ignored bitrate", ExportSettings{Type: "wav", Bitrate: "192k"}, ""},
+		{"flac", ExportSettings{Type: "flac", Bitrate: "96k"}, ""},
+		{"mp3 valid bitrate", ExportSettings{Type: "mp3", Bitrate: "320k"}, ""},
+		{"mp3 empty bitrate", ExportSettings{Type: "mp3"}, "audio-export-bitrate-format"},
+		{"mp3 missing suffix", ExportSettings{Type: "mp3", Bitrate: "128"}, "audio-export-bitrate-format"},
+		{"mp3 non-numeric bitrate", ExportSettings{Type: "mp3", Bitrate: "highk"}, "audio-export-bitrate-value"},
+		{"mp3 bitrate too low", ExportSettings{Type: "mp3", Bitrate: "16k"}, "audio-export-bitrate-range"},
+		{"aac bitrate too high", ExportSettings{Type: "aac", Bitrate: "512k"}, "audio-export-bitrate-range"},
+		{"unsupported type", ExportSettings{Type: "ogg", Bitrate: "128k"}, "audio-export-type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateExportFormat(&tt.settings)
+			if tt.errType == "" {
+				if err != nil {
+					t.Fatalf("validateExportFormat() unexpected error = %v", err)
+				}
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError, got %T (%v)", err, err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("validation_type = %v, want %s", ctx, tt.errType)
+			}
+		})
+	}
+}
+
+func TestExportBitrateWarning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings ExportSettings
+		wantWarn bool
+	}{
+		{"wav without bitrate", ExportSettings{Type: "wav"}, false},
+		{"wav with template bitrate", ExportSettings{Type: "wav", Bitrate: "96k"}, false},
+		{"wav with default bitrate", ExportSettings{Type: "wav", Bitrate: DefaultExportBitrate}, false},
+		{"wav with ignored bitrate", ExportSettings{Type: "wav", Bitrate: "192k"}, true},
+		{"flac with ignored bitrate", ExportSettings{Type: "flac", Bitrate: "256k"}, true},
+		{"mp3 uses bitrate", ExportSettings{Type: "mp3", Bitrate: "192k"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := exportBitrateWarning(&tt.settings); (got != "") != tt.wantWarn {
+				t.Errorf("exportBitrateWarning() = %q, wantWarn %v", got, tt.wantWarn)
+			}
+		})
+	}
+
+	// The shipped configuration does not warn
+	settings, err := loadDefaultSettings()
+	if err != nil {
+		t.Fatalf("loadDefaultSettings() error = %v", err)
+	}
+	if got := exportBitrateWarning(&settings.Realtime.Audio.Export); got != "" {
+		t.Errorf("exportBitrateWarning() of the default configuration = %q, want none", got)
+	}
+}
+
+func TestRetentionSettingsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings RetentionSettings
+		errType  string
+	}{
+		{"none policy ignores other fields", RetentionSettings{Policy: "none"}, ""},
+		{"age policy", RetentionSettings{Policy: "age", MaxAge: "30d", MinClips: 10}, ""},
+		{"age policy hours", RetentionSettings{Policy: "age", MaxAge: "72"}, ""},
+		{"age policy missing max age", RetentionSettings{Policy: "age"}, "retention-max-age"},
+		{"age policy invalid max age", RetentionSettings{Policy: "age", MaxAge: "30x"}, "retention-max-age"},
+		{"age policy zero max age", RetentionSettings{Policy: "age", MaxAge: "0d"}, "retention-max-age"},
+		{"usage policy", RetentionSettings{Policy: "usage", MaxUsage: "80%"}, ""},
+		{"usage policy upper bound", RetentionSettings{Policy: "usage", MaxUsage: "100%"}, ""},
+		{"usage policy missing max usage", RetentionSettings{Policy: "usage"}, "retention-max-usage"},
+		{"usage policy without percent sign", RetentionSettings{Policy: "usage", MaxUsage: "80"}, ""},
+		{"usage policy bare number above 100", RetentionSettings{Policy: "usage", MaxUsage: "500"}, "retention-max-usage"},
+		{"usage policy zero", RetentionSettings{Policy: "usage", MaxUsage: "0%"}, "retention-max-usage"},
+		{"usage policy above 100", RetentionSettings{Policy: "usage", MaxUsage: "150%"}, "retention-max-usage"},
+		{"usage policy absolute size", RetentionSettings{Policy: "usage", MaxUsage: "50GB"}, ""},
+		{"usage policy invalid size", RetentionSettings{Policy: "usage", MaxUsage: "50XB"}, "retention-max-usage"},
+		{"negative min clips", RetentionSettings{Policy: "none", MinClips: -1}, "retention-min-clips"},
+		{"unknown policy", RetentionSettings{Policy: "size"}, "retention-policy"},
+		{"empty policy", RetentionSettings{}, "retention-policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.settings.Validate()
+			if tt.errType == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError, got %T (%v)", err, err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("validation_type = %v, want %s", ctx, tt.errType)
+			}
+		})
+	}
+}
+
+func TestParseDiskLimit(t *testing.T) {
+	t.Parallel()
+
+	const totalBytes = 200 * 1000 * 1000 * 1000 // 200GB
+
+	tests := []struct {
+		name          string
+		input         string
+		wantBytes     uint64
+		wantIsPercent bool
+		wantErr       bool
+	}{
+		{"percentage", "80%", 160_000_000_000, true, false},
+		{"percentage with whitespace", " 50% ", 100_000_000_000, true, false},
+		{"percentage clamped to 100", "150%", totalBytes, true, false},
+		{"percentage clamped to 1", "0%", 2_000_000_000, true, false},
+		{"gigabytes", "50GB", 50_000_000_000, false, false},
+		{"lowercase unit", "50gb", 50_000_000_000, false, false},
+		{"mebibytes", "500MiB", 500 * 1024 * 1024, false, false},
+		{"space before unit", "1.5 TB", 1_500_000_000_000, false, false},
+		{"bytes", "1024B", 1024, false, false},
+		{"invalid percentage", "abc%", 0, true, true},
+		{"number without unit is a percentage", "80", 160_000_000_000, true, false},
+		{"unknown unit", "5PB", 0, false, true},
+		{"zero size", "0GB", 0, false, true},
+		{"empty", "", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotBytes, gotIsPercent, err := ParseDiskLimit(tt.input, totalBytes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDiskLimit(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var enhancedErr *errors.EnhancedError
+				if !stderrors.As(err, &enhancedErr) || enhancedErr.Category != errors.CategoryValidation {
+					t.Errorf("ParseDiskLimit(%q) error = %v, want validation category", tt.input, err)
+				}
+				return
+			}
+			if gotBytes != tt.wantBytes {
+				t.Errorf("ParseDiskLimit(%q) bytes = %d, want %d", tt.input, gotBytes, tt.wantBytes)
+			}
+			if gotIsPercent != tt.wantIsPercent {
+				t.Errorf("ParseDiskLimit(%q) isPercent = %v, want %v", tt.input, gotIsPercent, tt.wantIsPercent)
+			}
+		})
+	}
+}
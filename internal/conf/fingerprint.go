@@ -0,0 +1,116 @@
+// conf/fingerprint.go fingerprints of the config file for change detection
+package conf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFingerprint returns a fingerprint of the current content of the config
+// file, a SHA-256 hash of its canonicalized YAML. Key order, formatting and
+// comments do not change the fingerprint.
+func ConfigFingerprint() (string, error) {
+	configPath, err := configFilePath()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := readConfigFile(configPath)
+	if err != nil {
+		return "", errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-config-fingerprint").
+			Context("path", configPath).
+			Build()
+	}
+
+	fingerprint, err := fingerprintConfig(data)
+	if err != nil {
+		return "", errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "canonicalize-config").
+			Context("path", configPath).
+			Build()
+	}
+	return fingerprint, nil
+}
+
+// ConfigChangedExternally reports whether the config file changed since it
+// was last loaded or written by this process
+func ConfigChangedExternally() (bool, error) {
+	fingerprint, err := ConfigFingerprint()
+	if err != nil {
+		return false, err
+	}
+
+	lastChecksum.Lock()
+	defer lastChecksum.Unlock()
+	return fingerprint != lastChecksum.fingerprint, nil
+}
+
+// configFilePath returns the path of the config file in use
+func configFilePath() (string, error) {
+	if configPath := viper.ConfigFileUsed(); configPath != "" {
+		return configPath, nil
+	}
+	return FindConfigFile()
+}
+
+// fingerprintConfig returns the fingerprint of YAML config file content
+func fingerprintConfig(data []byte) (string, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+
+	// JSON encoding sorts map keys and drops comments and formatting
+	canonical, err := json.Marshal(canonicalYAML(doc))
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// contentFingerprint returns the fingerprint of config file content, or a hash
+// of the raw content when it is not valid YAML so that broken edits are
+// still noticed
+func contentFingerprint(data []byte) string {
+	if fingerprint, err := fingerprintConfig(data); err == nil {
+		return fingerprint
+	}
+	sum := sha256.Sum256(data)
+	return "raw:" + hex.EncodeToString(sum[:])
+}
+
+// canonicalYAML converts maps with non-string keys decoded from YAML into
+// maps with string keys, which JSON can encode
+func canonicalYAML(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = canonicalYAML(item)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = canonicalYAML(item)
+		}
+		return m
+	case []any:
+		for i, item := range v {
+			v[i] = canonicalYAML(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
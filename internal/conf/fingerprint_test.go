@@ -0,0 +1,49 @@
+package conf
+
+import "testing"
+
+func TestFingerprintConfig(t *testing.T) {
+	t.Parallel()
+
+	base := "main:\n  name: node # node name\n  timeas24h: true\nbirdnet:\n  threshold: 0.8\n"
+	tests := []struct {
+		name string
+		yaml string
+		same bool
+	}{
+		{"identical", base, true},
+		{"reordered keys", "birdnet:\n  threshold: 0.8\nmain:\n  timeas24h: true\n  name: node\n", true},
+		{"comments and formatting", "# header\nmain:\n    name: \"node\"\n    timeas24h: true   # 24h clock\n\nbirdnet: {threshold: 0.8}\n", true},
+		{"changed value", "main:\n  name: other\n  timeas24h: true\nbirdnet:\n  threshold: 0.8\n", false},
+		{"added key", base + "debug: true\n", false},
+	}
+
+	want, err := fingerprintConfig([]byte(base))
+	if err != nil {
+		t.Fatalf("fingerprintConfig(base) error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := fingerprintConfig([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("fingerprintConfig() error = %v", err)
+			}
+			if (got == want) != tt.same {
+				t.Errorf("fingerprint equal = %v, want %v", got == want, tt.same)
+			}
+		})
+	}
+}
+
+func TestFingerprintConfigNonStringKeys(t *testing.T) {
+	t.Parallel()
+
+	if _, err := fingerprintConfig([]byte("1: one\ntrue: yes\n")); err != nil {
+		t.Errorf("fingerprintConfig() error = %v", err)
+	}
+	if _, err := fingerprintConfig([]byte("main: [")); err == nil {
+		t.Error("fingerprintConfig() of invalid YAML succeeded")
+	}
+}
@@ -0,0 +1,41 @@
+package conf
+
+import "testing"
+
+func TestFTPBackupSettingsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		settings    FTPBackupSettings
+		wantErr     bool
+		wantTLSMode string
+		wantPort    int
+	}{
+		{"plain FTP", FTPBackupSettings{Host: "nas", Passive: true}, false, "", 21},
+		{"UseTLS implies explicit", FTPBackupSettings{Host: "nas", UseTLS: true}, false, FTPTLSModeExplicit, 21},
+		{"implicit TLS port", FTPBackupSettings{Host: "nas", UseTLS: true, TLSMode: FTPTLSModeImplicit}, false, FTPTLSModeImplicit, 990},
+		{"explicit port kept", FTPBackupSettings{Host: "nas", Port: 2121, UseTLS: true, TLSMode: FTPTLSModeImplicit}, false, FTPTLSModeImplicit, 2121},
+		{"TLS mode without UseTLS", FTPBackupSettings{Host: "nas", TLSMode: FTPTLSModeExplicit}, true, "", 0},
+		{"unknown TLS mode", FTPBackupSettings{Host: "nas", UseTLS: true, TLSMode: "starttls"}, true, "", 0},
+		{"negative timeout", FTPBackupSettings{Host: "nas", TimeoutSeconds: -1}, true, "", 0},
+		{"missing host", FTPBackupSettings{}, true, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := tt.settings
+			err := settings.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if settings.TLSMode != tt.wantTLSMode || settings.Port != tt.wantPort {
+				t.Errorf("Validate() set TLSMode %q and Port %d, want %q and %d",
+					settings.TLSMode, settings.Port, tt.wantTLSMode, tt.wantPort)
+			}
+		})
+	}
+}
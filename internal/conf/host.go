@@ -0,0 +1,128 @@
+// conf/host.go validation of the security host used for TLS and OAuth redirects
+package conf
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// hostnameLabelPattern matches a single DNS label of a hostname
+var hostnameLabelPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// validateTLSHostname checks that host is a public DNS name for which Let's
+// Encrypt can issue a certificate
+func validateTLSHostname(host string) error {
+	switch {
+	case strings.Contains(host, "://") || strings.ContainsAny(host, "/:"):
+		return fmt.Errorf("security.host %q must be a bare hostname such as birdnet.example.com, without scheme, port or path", host)
+	case net.ParseIP(host) != nil:
+		return fmt.Errorf("security.host %q is an IP address, Let's Encrypt only issues certificates for DNS names", host)
+	case strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost"):
+		return fmt.Errorf("security.host %q is not publicly resolvable, set it to a DNS name pointing to this system", host)
+	case len(host) > 253 || !strings.Contains(strings.Trim(host, "."), "."):
+		return fmt.Errorf("security.host %q must be a fully qualified DNS name such as birdnet.example.com", host)
+	}
+
+	for label := range strings.SplitSeq(strings.TrimSuffix(host, "."), ".") {
+		if !hostnameLabelPattern.MatchString(label) {
+			return fmt.Errorf("security.host %q is not a valid hostname, invalid label %q", host, label)
+		}
+	}
+	return nil
+}
+
+// RedirectBaseURL returns the base URL of OAuth redirect URIs derived from
+// Host. A Host without scheme uses https when RedirectToHTTPS or AutoTLS is
+// set. Plain http URLs without an explicit port get webServerPort, the port of
+// WebServerSettings.PortNumber, https is assumed to be served on the standard
+// port by AutoTLS or a reverse proxy.
+func (s *Security) RedirectBaseURL(webServerPort string) (string, error) {
+	protocol := "http"
+	if s.RedirectToHTTPS || s.AutoTLS {
+		protocol = "https"
+	}
+
+	host := strings.TrimRight(s.Host, "/")
+	if !strings.Contains(host, "://") {
+		host = fmt.Sprintf("%s://%s", protocol, host)
+	}
+
+	parsedHost, err := url.Parse(host)
+	if err != nil || parsedHost.Host == "" {
+		return "", fmt.Errorf("invalid host address %q", s.Host)
+	}
+	if parsedHost.RawQuery != "" || parsedHost.Fragment != "" {
+		return "", fmt.Errorf("host address %q must not contain query parameters or fragments", s.Host)
+	}
+
+	if parsedHost.Scheme == "http" && parsedHost.Port() == "" && webServerPort != "" && webServerPort != "80" {
+		parsedHost.Host = net.JoinHostPort(parsedHost.Hostname(), webServerPort)
+		host = strings.TrimRight(parsedHost.String(), "/")
+	}
+
+	return host, nil
+}
+
+// RedirectURI returns the OAuth redirect URI of a provider derived from Host,
+// see RedirectBaseURL. An empty path uses the /auth/<provider>/callback route
+// of the web server. It returns an empty string when Host is not usable.
+func (s *Security) RedirectURI(provider, path, webServerPort string) string {
+	base, err := s.RedirectBaseURL(webServerPort)
+	if err != nil {
+		return ""
+	}
+	if path == "" {
+		path = fmt.Sprintf("/auth/%s/callback", provider)
+	}
+	return base + "/" + strings.TrimLeft(path, "/")
+}
+
+// validateRedirectURIs warns when a manually configured OAuth redirect URI does
+// not match the one derived from Host, the usual cause of redirect_uri
+// mismatch errors from OAuth providers
+func validateRedirectURIs(settings *Settings) {
+	security := &settings.Security
+	webServerPort := settings.WebServer.PortNumber()
+
+	providers := []struct {
+		name     string
+		provider *SocialProvider
+	}{
+		{"google", &security.GoogleAuth},
+		{"github", &security.GithubAuth},
+	}
+
+	for _, p := range providers {
+		if !p.provider.Enabled || p.provider.RedirectURI == "" {
+			continue
+		}
+		derived := security.RedirectURI(p.name, "", webServerPort)
+		if derived == "" || sameRedirectURI(p.provider.RedirectURI, derived) {
+			continue
+		}
+
+		message := fmt.Sprintf("%s redirect URI %q does not match %q derived from security.host, the OAuth provider will reject logins unless it is registered with the configured URI",
+			p.name, p.provider.RedirectURI, derived)
+		log.Printf("Configuration warning: %s", message)
+		logValidationWarning(fmt.Errorf("%s", message), ErrCodeRedirectURI, "redirect-uri-mismatch")
+		settings.ValidationWarnings = append(settings.ValidationWarnings,
+			fmt.Sprintf("config-security-validation: %s", message))
+	}
+}
+
+// sameRedirectURI reports whether two redirect URIs are equal, ignoring the
+// case of the scheme and host and a trailing slash
+func sameRedirectURI(a, b string) bool {
+	parsedA, errA := url.Parse(strings.TrimRight(a, "/"))
+	parsedB, errB := url.Parse(strings.TrimRight(b, "/"))
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return strings.EqualFold(parsedA.Scheme, parsedB.Scheme) &&
+		strings.EqualFold(parsedA.Host, parsedB.Host) &&
+		parsedA.Path == parsedB.Path
+}
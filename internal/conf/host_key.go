@@ -0,0 +1,54 @@
+// conf/host_key.go SSH host key verification of SFTP and rsync backup targets
+package conf
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ValidHostKeyFingerprint reports whether fingerprint is a SHA256 SSH host key
+// fingerprint as printed by ssh-keygen -l, e.g.
+// "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8". Base64 padding is
+// accepted.
+func ValidHostKeyFingerprint(fingerprint string) bool {
+	hash, found := strings.CutPrefix(fingerprint, "SHA256:")
+	if !found {
+		return false
+	}
+	sum, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(hash, "="))
+	return err == nil && len(sum) == 32
+}
+
+// defaultKnownHostsPath returns the known_hosts file of the user running
+// BirdNET-Go, or an empty string when the home directory is unknown
+func defaultKnownHostsPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".ssh", "known_hosts")
+}
+
+// knownHostsDefaultWarning returns a warning for an SFTP backup target setting
+// none of known_hosts_file, host_key_fingerprint and insecure_ignore_host_key,
+// whose host key is verified against the default known_hosts file. Configs
+// written before host key verification fail to connect to servers missing
+// from that file.
+func knownHostsDefaultWarning(target BackupTarget) string {
+	if !strings.EqualFold(target.Type, "sftp") {
+		return ""
+	}
+	settings, err := target.Decode()
+	if err != nil {
+		return ""
+	}
+	sftp := settings.(*SFTPBackupSettings)
+	if sftp.Host == "" || sftp.KnownHostsPath != "" || sftp.HostKeyFingerprint != "" || sftp.InsecureIgnoreHostKey {
+		return ""
+	}
+	return fmt.Sprintf("SFTP backup target %s sets no known_hosts_file or host_key_fingerprint, its host key is verified against %s, set insecure_ignore_host_key to skip verification",
+		sftp.Host, defaultKnownHostsPath())
+}
@@ -0,0 +1,69 @@
+package conf
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestValidHostKeyFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fingerprint string
+		want        bool
+	}{
+		{"SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", true},
+		{"SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8=", true},
+		{"nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", false},
+		{"SHA256:tooshort", false},
+		{"MD5:16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidHostKeyFingerprint(tt.fingerprint); got != tt.want {
+			t.Errorf("ValidHostKeyFingerprint(%q) = %v, want %v", tt.fingerprint, got, tt.want)
+		}
+	}
+}
+
+func TestSFTPBackupSettingsValidateHostKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings SFTPBackupSettings
+		wantErr  bool
+	}{
+		{"default known hosts", SFTPBackupSettings{Host: "nas", Username: "birdnet"}, false},
+		{"known hosts", SFTPBackupSettings{Host: "nas", Username: "birdnet", KnownHostsPath: "/etc/ssh/ssh_known_hosts"}, false},
+		{"fingerprint", SFTPBackupSettings{Host: "nas", Username: "birdnet", HostKeyFingerprint: "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"}, false},
+		{"invalid fingerprint", SFTPBackupSettings{Host: "nas", Username: "birdnet", HostKeyFingerprint: "nThbg6kX"}, true},
+		{"insecure opt-out", SFTPBackupSettings{Host: "nas", Username: "birdnet", InsecureIgnoreHostKey: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := tt.settings
+			if err := settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSFTPBackupSettingsDefaultKnownHosts(t *testing.T) {
+	t.Setenv("HOME", "/home/birdnet")
+
+	settings := SFTPBackupSettings{Host: "nas", Username: "birdnet"}
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if want := filepath.Join("/home/birdnet", ".ssh", "known_hosts"); settings.KnownHostsPath != want {
+		t.Errorf("KnownHostsPath = %q, want %q", settings.KnownHostsPath, want)
+	}
+
+	pinned := SFTPBackupSettings{Host: "nas", Username: "birdnet", HostKeyFingerprint: "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"}
+	if err := pinned.Validate(); err != nil || pinned.KnownHostsPath != "" {
+		t.Errorf("Validate() with a pinned fingerprint KnownHostsPath = %q, %v, want no known hosts file", pinned.KnownHostsPath, err)
+	}
+}
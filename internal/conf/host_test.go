@@ -0,0 +1,105 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedirectBaseURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		security Security
+		want     string
+		wantErr  bool
+	}{
+		{"hostname", Security{Host: "birdnet.local:8080"}, "http://birdnet.local:8080", false},
+		{"redirect to https", Security{Host: "birdnet.example.com", RedirectToHTTPS: true}, "https://birdnet.example.com", false},
+		{"url with trailing slash", Security{Host: "https://birdnet.example.com/"}, "https://birdnet.example.com", false},
+		{"hostname starting with http", Security{Host: "httpbin.example.com"}, "http://httpbin.example.com", false},
+		{"empty host", Security{}, "", true},
+		{"fragment", Security{Host: "birdnet.example.com/#top"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.security.RedirectBaseURL("")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RedirectBaseURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RedirectBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedirectURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		security Security
+		port     string
+		provider string
+		path     string
+		want     string
+	}{
+		{"http adds web server port", Security{Host: "192.168.1.10"}, "8080", "google", "", "http://192.168.1.10:8080/auth/google/callback"},
+		{"explicit port is kept", Security{Host: "birdnet.local:9000"}, "8080", "github", "", "http://birdnet.local:9000/auth/github/callback"},
+		{"default http port is omitted", Security{Host: "birdnet.local"}, "80", "github", "", "http://birdnet.local/auth/github/callback"},
+		{"redirect to https omits port", Security{Host: "birdnet.example.com", RedirectToHTTPS: true}, "8080", "google", "", "https://birdnet.example.com/auth/google/callback"},
+		{"autotls uses https", Security{Host: "birdnet.example.com", AutoTLS: true}, "8080", "google", "", "https://birdnet.example.com/auth/google/callback"},
+		{"custom path", Security{Host: "https://birdnet.example.com/"}, "8080", "basic", "/oauth/callback", "https://birdnet.example.com/oauth/callback"},
+		{"unusable host", Security{}, "8080", "google", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.security.RedirectURI(tt.provider, tt.path, tt.port); got != tt.want {
+				t.Errorf("RedirectURI(%q, %q) = %q, want %q", tt.provider, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRedirectURIs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		redirectURI string
+		wantWarning bool
+	}{
+		{"matching", "https://birdnet.example.com/auth/google/callback", false},
+		{"matching ignoring case and trailing slash", "https://BirdNET.example.com/auth/google/callback/", false},
+		{"not configured", "", false},
+		{"different host", "https://old.example.com/auth/google/callback", true},
+		{"different scheme", "http://birdnet.example.com/auth/google/callback", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := &Settings{}
+			settings.WebServer.Port = "8080"
+			settings.Security.Host = "birdnet.example.com"
+			settings.Security.RedirectToHTTPS = true
+			settings.Security.GoogleAuth = SocialProvider{Enabled: true, RedirectURI: tt.redirectURI}
+
+			validateRedirectURIs(settings)
+
+			gotWarning := len(settings.ValidationWarnings) > 0
+			if gotWarning != tt.wantWarning {
+				t.Fatalf("warnings = %v, want warning %v", settings.ValidationWarnings, tt.wantWarning)
+			}
+			if gotWarning && !strings.HasPrefix(settings.ValidationWarnings[0], "config-security-validation:") {
+				t.Errorf("unexpected warning %q", settings.ValidationWarnings[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// conf/input.go options of file and directory analysis runs
+package conf
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// EffectiveWorkers returns the number of files analyzed in parallel, Workers
+// of 0 analyzes one file at a time
+func (i InputConfig) EffectiveWorkers() int {
+	return max(i.Workers, 1)
+}
+
+// MatchesFile reports whether a file name matches FilePattern, every name
+// matches an empty pattern. Only the base name of path is matched.
+func (i InputConfig) MatchesFile(path string) bool {
+	if i.FilePattern == "" {
+		return true
+	}
+	matched, err := filepath.Match(i.FilePattern, filepath.Base(path))
+	return err == nil && matched
+}
+
+// Validate checks the worker count and that FilePattern is a valid glob
+func (i *InputConfig) Validate() error {
+	if i.Workers < 0 {
+		return errors.New(fmt.Errorf("number of analysis workers must be 0 or more, got %d", i.Workers)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeInputWorkers).
+			Build()
+	}
+	if _, err := filepath.Match(i.FilePattern, ""); err != nil {
+		return errors.New(fmt.Errorf("invalid file pattern %q: %w", i.FilePattern, err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeInputFilePattern).
+			Build()
+	}
+	return nil
+}
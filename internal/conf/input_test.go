@@ -0,0 +1,64 @@
+package conf
+
+import "testing"
+
+func TestInputConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    InputConfig
+		wantCode string
+	}{
+		{"defaults", InputConfig{}, ""},
+		{"workers and pattern", InputConfig{Workers: 4, FilePattern: "*_2024*.wav"}, ""},
+		{"negative workers", InputConfig{Workers: -1}, ErrCodeInputWorkers},
+		{"malformed pattern", InputConfig{FilePattern: "[a-"}, ErrCodeInputFilePattern},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.input.Validate()
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if code := validationCode(err); err == nil || code != tt.wantCode {
+				t.Errorf("Validate() error = %v with code %q, want code %q", err, code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestInputConfigEffectiveWorkers(t *testing.T) {
+	t.Parallel()
+
+	for workers, want := range map[int]int{0: 1, 1: 1, 6: 6} {
+		if got := (InputConfig{Workers: workers}).EffectiveWorkers(); got != want {
+			t.Errorf("EffectiveWorkers() with Workers %d = %d, want %d", workers, got, want)
+		}
+	}
+}
+
+func TestInputConfigMatchesFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"", "/archive/2024/rec.wav", true},
+		{"*_2024*.wav", "/archive/site_20240501.wav", true},
+		{"*_2024*.wav", "/archive/site_20230501.wav", false},
+		{"*_2024*.wav", "/archive_2024/site.flac", false},
+		{"[a-", "/archive/rec.wav", false},
+	}
+	for _, tt := range tests {
+		if got := (InputConfig{FilePattern: tt.pattern}).MatchesFile(tt.path); got != tt.want {
+			t.Errorf("MatchesFile(%q) with pattern %q = %v, want %v", tt.path, tt.pattern, got, tt.want)
+		}
+	}
+}
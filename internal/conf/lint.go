@@ -0,0 +1,78 @@
+// conf/lint.go advisory checks of settings that are valid but likely mistakes
+package conf
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Lint returns advisory warnings about settings that pass ValidateSettings but
+// are likely mistakes, such as an enabled integration missing its ID. The
+// issues all have SeverityWarning and never prevent the settings from being
+// used, the web UI shows them as config health.
+func (s *Settings) Lint() []ValidationIssue {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	var issues []ValidationIssue
+	warn := func(field, format string, args ...any) {
+		issues = append(issues, ValidationIssue{
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+			Severity: SeverityWarning,
+		})
+	}
+
+	if s.BirdNET.Threshold == 0 {
+		warn("birdnet.threshold", "BirdNET threshold is 0, every prediction is reported as a detection")
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.Realtime.Species.Config)) {
+		if s.Realtime.Species.Config[name].Threshold == 0 {
+			warn("realtime.species.config."+name+".threshold",
+				"species %q has threshold 0, every prediction of it is reported as a detection, set the threshold along with other species settings", name)
+		}
+	}
+
+	if birdweather := s.Realtime.Birdweather; birdweather.Enabled {
+		if birdweather.ID == "" {
+			warn("realtime.birdweather.id", "BirdWeather is enabled without a station ID, uploads are disabled")
+		}
+		if birdweather.Threshold == 0 {
+			warn("realtime.birdweather.threshold", "BirdWeather threshold is 0, every detection is uploaded")
+		}
+	}
+
+	if mqtt := s.Realtime.MQTT; mqtt.Enabled && strings.TrimSpace(mqtt.Topic) == "" {
+		warn("realtime.mqtt.topic", "MQTT is enabled with an empty topic, detections are not published")
+	}
+
+	export := s.Realtime.Audio.Export
+	noRetention := export.Retention.Policy == "" || export.Retention.Policy == "none"
+	switch {
+	case export.Enabled && noRetention && !s.monitorsExportDisk():
+		warn("realtime.audio.export.retention.policy",
+			"audio export is enabled with retention policy none and disk monitoring disabled, clips are never deleted and will eventually fill the disk unnoticed, use the age or usage policy")
+	case !export.Enabled && !noRetention:
+		warn("realtime.audio.export.enabled",
+			"retention policy %s is set but audio export is disabled, no clips are saved or cleaned up, enable audio export", export.Retention.Policy)
+	}
+
+	if s.Security.AutoTLS {
+		hint := "make sure ports 80 and 443 are reachable from the internet"
+		if RunningInContainer() {
+			hint = "map ports 80 and 443 of the container, e.g. with docker-compose.autotls.yml"
+		}
+		warn("security.autotls", "AutoTLS serves HTTPS on port 443 and answers Let's Encrypt challenges on port 80, %s", hint)
+	}
+
+	return issues
+}
+
+// monitorsExportDisk reports whether system monitoring watches the disk usage
+// of the audio export path, the monitor adds the export path to the monitored
+// disk paths when disk monitoring is enabled
+func (s *Settings) monitorsExportDisk() bool {
+	return s.Realtime.Monitoring.Enabled && s.Realtime.Monitoring.Disk.Enabled
+}
@@ -0,0 +1,73 @@
+package conf
+
+import "testing"
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	healthy := func() *Settings {
+		s := &Settings{}
+		s.BirdNET.Threshold = 0.8
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(s *Settings)
+		wantFields []string
+	}{
+		{"healthy", func(s *Settings) {}, nil},
+		{"zero threshold", func(s *Settings) { s.BirdNET.Threshold = 0 }, []string{"birdnet.threshold"}},
+		{"species without threshold", func(s *Settings) {
+			s.Realtime.Species.Config = map[string]SpeciesConfig{"house sparrow": {Interval: 300}}
+		}, []string{"realtime.species.config.house sparrow.threshold"}},
+		{"birdweather without id", func(s *Settings) {
+			s.Realtime.Birdweather = BirdweatherSettings{Enabled: true, Threshold: 0.8}
+		}, []string{"realtime.birdweather.id"}},
+		{"mqtt without topic", func(s *Settings) {
+			s.Realtime.MQTT = MQTTSettings{Enabled: true, Broker: "tcp://localhost:1883", Topic: " "}
+		}, []string{"realtime.mqtt.topic"}},
+		{"export without retention", func(s *Settings) {
+			s.Realtime.Audio.Export.Enabled = true
+			s.Realtime.Audio.Export.Retention.Policy = "none"
+		}, []string{"realtime.audio.export.retention.policy"}},
+		{"export with retention", func(s *Settings) {
+			s.Realtime.Audio.Export.Enabled = true
+			s.Realtime.Audio.Export.Retention.Policy = "age"
+		}, nil},
+		{"export without retention on a monitored disk", func(s *Settings) {
+			s.Realtime.Audio.Export.Enabled = true
+			s.Realtime.Audio.Export.Retention.Policy = "none"
+			s.Realtime.Monitoring.Enabled = true
+			s.Realtime.Monitoring.Disk.Enabled = true
+		}, nil},
+		{"retention without export", func(s *Settings) {
+			s.Realtime.Audio.Export.Retention.Policy = "usage"
+		}, []string{"realtime.audio.export.enabled"}},
+		{"no export and no retention", func(s *Settings) {
+			s.Realtime.Audio.Export.Retention.Policy = "none"
+		}, nil},
+		{"autotls", func(s *Settings) { s.Security.AutoTLS = true }, []string{"security.autotls"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := healthy()
+			tt.modify(settings)
+
+			issues := settings.Lint()
+			if len(issues) != len(tt.wantFields) {
+				t.Fatalf("Lint() = %v, want issues for %v", issues, tt.wantFields)
+			}
+			for i, issue := range issues {
+				if issue.Field != tt.wantFields[i] {
+					t.Errorf("issue %d field = %q, want %q", i, issue.Field, tt.wantFields[i])
+				}
+				if issue.Severity != SeverityWarning {
+					t.Errorf("issue %d severity = %q, want warning", i, issue.Severity)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// conf/listen.go listen addresses of the web server and telemetry endpoint
+package conf
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// ParsePort parses a TCP port number between 1 and 65535
+func ParsePort(port string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(port))
+	if err != nil || n < 1 || n > 65535 {
+		return 0, fmt.Errorf("port %q must be a number between 1 and 65535", port)
+	}
+	return n, nil
+}
+
+// NormalizeListenAddress validates a host:port listen address and returns it
+// in the form net.Listen accepts. The host may be empty to listen on all
+// interfaces, IPv6 addresses must be in brackets such as "[::1]:8090".
+func NormalizeListenAddress(address string) (string, error) {
+	host, port, err := net.SplitHostPort(strings.TrimSpace(address))
+	if err != nil {
+		return "", fmt.Errorf("listen address %q must be host:port, e.g. 0.0.0.0:8090 or [::1]:8090", address)
+	}
+	n, err := ParsePort(port)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(host, " /") {
+		return "", fmt.Errorf("listen address %q has an invalid host %q", address, host)
+	}
+	return net.JoinHostPort(host, strconv.Itoa(n)), nil
+}
+
+// NormalizeWebServerPort validates a web server port setting, a port number
+// such as "8080" or a host:port address, and returns the listen address for
+// net.Listen. A port number listens on all interfaces.
+func NormalizeWebServerPort(port string) (string, error) {
+	if !strings.Contains(port, ":") {
+		n, err := ParsePort(port)
+		if err != nil {
+			return "", err
+		}
+		return ":" + strconv.Itoa(n), nil
+	}
+	return NormalizeListenAddress(port)
+}
+
+// ListenAddress returns the address the web server listens on, see
+// NormalizeWebServerPort. An invalid port is returned as ":" + Port, so that
+// the server reports the error.
+func (w *WebServerSettings) ListenAddress() string {
+	address, err := NormalizeWebServerPort(w.Port)
+	if err != nil {
+		return ":" + w.Port
+	}
+	return address
+}
+
+// PortNumber returns the port of the web server without its host, e.g. "8080"
+// for "127.0.0.1:8080"
+func (w *WebServerSettings) PortNumber() string {
+	if _, port, err := net.SplitHostPort(w.ListenAddress()); err == nil {
+		return port
+	}
+	return w.Port
+}
+
+// ListenAddress returns the address the telemetry endpoint listens on, see
+// NormalizeListenAddress. An invalid address is returned unchanged, so that
+// the endpoint reports the error.
+func (t *TelemetrySettings) ListenAddress() string {
+	address, err := NormalizeListenAddress(t.Listen)
+	if err != nil {
+		return t.Listen
+	}
+	return address
+}
+
+// validateWebServerPort checks that the web server port is a port number or
+// a host:port address
+func validateWebServerPort(port string) error {
+	if _, err := NormalizeWebServerPort(port); err != nil {
+		return errors.New(fmt.Errorf("invalid webserver.port: %w", err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeWebServerPort).
+			Context("field", "webserver.port").
+			Build()
+	}
+	return nil
+}
+
+// validateTelemetryListen checks the listen address of an enabled telemetry
+// endpoint
+func validateTelemetryListen(settings *TelemetrySettings) error {
+	if !settings.Enabled {
+		return nil
+	}
+	if _, err := NormalizeListenAddress(settings.Listen); err != nil {
+		return errors.New(fmt.Errorf("invalid realtime.telemetry.listen: %w", err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeTelemetryListen).
+			Context("field", "realtime.telemetry.listen").
+			Build()
+	}
+	return nil
+}
+
+// webServerListenAddresses returns the addresses the web server listens on,
+// AutoTLS serves HTTPS on port 443 and redirects HTTP on port 80
+func webServerListenAddresses(settings *Settings) []string {
+	if settings.Security.AutoTLS {
+		return []string{":80", ":443"}
+	}
+	return []string{settings.WebServer.ListenAddress()}
+}
+
+// listenAddressesOverlap reports whether two normalized listen addresses
+// cannot both be bound, they have the same port and either the same host or
+// one of them listens on all interfaces
+func listenAddressesOverlap(a, b string) bool {
+	hostA, portA, errA := net.SplitHostPort(a)
+	hostB, portB, errB := net.SplitHostPort(b)
+	if errA != nil || errB != nil || portA != portB {
+		return false
+	}
+	hostA, hostB = canonicalListenHost(hostA), canonicalListenHost(hostB)
+	return hostA == "" || hostB == "" || hostA == hostB
+}
+
+// canonicalListenHost returns the host of a listen address in a comparable
+// form, an empty string for the wildcard addresses listening on all
+// interfaces
+func canonicalListenHost(host string) string {
+	if strings.EqualFold(host, "localhost") {
+		return "127.0.0.1"
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return strings.ToLower(host)
+	}
+	if ip.IsUnspecified() {
+		return ""
+	}
+	return ip.String()
+}
+
+// validateListenConflicts checks that an enabled telemetry endpoint does not
+// listen on an address of the enabled web server, which fails at runtime
+// with "address already in use"
+func validateListenConflicts(settings *Settings) error {
+	telemetry := &settings.Realtime.Telemetry
+	if !settings.WebServer.Enabled || !telemetry.Enabled {
+		return nil
+	}
+	if _, err := NormalizeWebServerPort(settings.WebServer.Port); err != nil {
+		return nil // reported by the web server validation
+	}
+
+	for _, webAddress := range webServerListenAddresses(settings) {
+		if listenAddressesOverlap(webAddress, telemetry.ListenAddress()) {
+			return errors.New(fmt.Errorf("realtime.telemetry.listen %q conflicts with web server address %q, use a different port",
+				telemetry.Listen, webAddress)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeListenConflict).
+				Context("field", "realtime.telemetry.listen").
+				Build()
+		}
+	}
+	return nil
+}
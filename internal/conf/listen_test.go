@@ -0,0 +1,132 @@
+package conf
+
+import "testing"
+
+func TestNormalizeListenAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		want    string
+		wantErr bool
+	}{
+		{"0.0.0.0:8090", "0.0.0.0:8090", false},
+		{":8090", ":8090", false},
+		{"localhost:08090", "localhost:8090", false},
+		{"[::1]:8090", "[::1]:8090", false},
+		{"[::]:8090", "[::]:8090", false},
+		{" 127.0.0.1:8090 ", "127.0.0.1:8090", false},
+		{"::1:8090", "", true},
+		{"8090", "", true},
+		{":abc", "", true},
+		{":0", "", true},
+		{":65536", "", true},
+		{"", "", true},
+		{"bad host:8090", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			t.Parallel()
+			got, err := NormalizeListenAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeListenAddress(%q) error = %v, wantErr %v", tt.address, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeListenAddress(%q) = %q, want %q", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWebServerPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		port    string
+		want    string
+		wantErr bool
+	}{
+		{"8080", ":8080", false},
+		{"1", ":1", false},
+		{"65535", ":65535", false},
+		{"127.0.0.1:8080", "127.0.0.1:8080", false},
+		{"[::1]:8080", "[::1]:8080", false},
+		{"0", "", true},
+		{"65536", "", true},
+		{"http", "", true},
+		{":abc", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.port, func(t *testing.T) {
+			t.Parallel()
+			got, err := NormalizeWebServerPort(tt.port)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeWebServerPort(%q) error = %v, wantErr %v", tt.port, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeWebServerPort(%q) = %q, want %q", tt.port, got, tt.want)
+			}
+		})
+	}
+
+	w := WebServerSettings{Port: "[::1]:9000"}
+	if got := w.PortNumber(); got != "9000" {
+		t.Errorf("PortNumber() = %q, want 9000", got)
+	}
+}
+
+func TestValidateListenSettings(t *testing.T) {
+	t.Parallel()
+
+	if err := validateWebServerSettings(&WebServerSettings{Enabled: true, Port: ":abc"}); err == nil {
+		t.Error("validateWebServerSettings() accepted port :abc")
+	}
+	if err := validateTelemetrySettings(&TelemetrySettings{Enabled: true, Listen: "8090"}); err == nil {
+		t.Error("validateTelemetrySettings() accepted a listen address without host")
+	}
+	if err := validateTelemetrySettings(&TelemetrySettings{Enabled: true, Listen: "[::]:8090"}); err != nil {
+		t.Errorf("validateTelemetrySettings() rejected an IPv6 listen address: %v", err)
+	}
+	if err := validateTelemetrySettings(&TelemetrySettings{Listen: "invalid"}); err != nil {
+		t.Errorf("validateTelemetrySettings() validated the address of a disabled endpoint: %v", err)
+	}
+}
+
+func TestValidateListenConflicts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		port      string
+		listen    string
+		autoTLS   bool
+		telemetry bool
+		wantErr   bool
+	}{
+		{"different ports", "8080", "0.0.0.0:8090", false, true, false},
+		{"same port", "8080", ":8080", false, true, true},
+		{"wildcard and specific address", "8080", "192.168.1.10:8080", false, true, true},
+		{"specific address and wildcard", "127.0.0.1:8090", "0.0.0.0:8090", false, true, true},
+		{"IPv6 wildcard", "8080", "[::]:8080", false, true, true},
+		{"localhost and loopback", "localhost:8080", "127.0.0.1:8080", false, true, true},
+		{"different addresses", "127.0.0.1:8080", "192.168.1.10:8080", false, true, false},
+		{"autotls https port", "8080", ":443", true, true, true},
+		{"autotls frees web server port", "8080", ":8080", true, true, false},
+		{"telemetry disabled", "8080", ":8080", false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.WebServer.Enabled = true
+			settings.WebServer.Port = tt.port
+			settings.Security.AutoTLS = tt.autoTLS
+			settings.Realtime.Telemetry.Enabled = tt.telemetry
+			settings.Realtime.Telemetry.Listen = tt.listen
+			err := validateListenConflicts(settings)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateListenConflicts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
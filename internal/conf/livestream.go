@@ -0,0 +1,40 @@
+// conf/livestream.go live stream quality presets
+package conf
+
+// Live stream quality presets
+const (
+	LiveStreamQualityLow    = "low"
+	LiveStreamQualityMedium = "medium"
+	LiveStreamQualityHigh   = "high"
+)
+
+// liveStreamPreset holds the encoding settings of a quality preset
+type liveStreamPreset struct {
+	BitRate    int // bitrate in kbps
+	SampleRate int // sample rate in Hz
+}
+
+// liveStreamPresets maps quality presets to encoding settings. Medium matches
+// the encoding used before presets were introduced.
+var liveStreamPresets = map[string]liveStreamPreset{
+	LiveStreamQualityLow:    {BitRate: 64, SampleRate: 22050},
+	LiveStreamQualityMedium: {BitRate: 128, SampleRate: 48000},
+	LiveStreamQualityHigh:   {BitRate: 256, SampleRate: 48000},
+}
+
+// ApplyQualityPreset fills unset BitRate and SampleRate from the Quality
+// preset, medium when no quality is configured. Explicitly configured values
+// take precedence over the preset.
+func (l *LiveStreamSettings) ApplyQualityPreset() {
+	preset, ok := liveStreamPresets[l.Quality]
+	if !ok {
+		preset = liveStreamPresets[LiveStreamQualityMedium]
+	}
+
+	if l.BitRate == 0 {
+		l.BitRate = preset.BitRate
+	}
+	if l.SampleRate == 0 {
+		l.SampleRate = preset.SampleRate
+	}
+}
@@ -0,0 +1,81 @@
+package conf
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestLiveStreamApplyQualityPreset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		settings       LiveStreamSettings
+		wantBitRate    int
+		wantSampleRate int
+	}{
+		{"no quality uses medium", LiveStreamSettings{}, 128, 48000},
+		{"low preset", LiveStreamSettings{Quality: "low"}, 64, 22050},
+		{"high preset", LiveStreamSettings{Quality: "high"}, 256, 48000},
+		{"explicit bitrate wins", LiveStreamSettings{Quality: "low", BitRate: 96}, 96, 22050},
+		{"explicit values win", LiveStreamSettings{Quality: "high", BitRate: 32, SampleRate: 16000}, 32, 16000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := tt.settings
+			settings.ApplyQualityPreset()
+			if settings.BitRate != tt.wantBitRate || settings.SampleRate != tt.wantSampleRate {
+				t.Errorf("got %d kbps at %d Hz, want %d kbps at %d Hz",
+					settings.BitRate, settings.SampleRate, tt.wantBitRate, tt.wantSampleRate)
+			}
+		})
+	}
+}
+
+func TestValidateWebServerLiveStream(t *testing.T) {
+	t.Parallel()
+
+	valid := LiveStreamSettings{Quality: "medium", SegmentLength: 2, FfmpegLogLevel: "warning"}
+
+	tests := []struct {
+		name    string
+		modify  func(l *LiveStreamSettings)
+		errType string
+	}{
+		{"valid", func(l *LiveStreamSettings) {}, ""},
+		{"empty log level", func(l *LiveStreamSettings) { l.FfmpegLogLevel = "" }, ""},
+		{"unknown quality", func(l *LiveStreamSettings) { l.Quality = "ultra" }, "livestream-quality"},
+		{"segment too short", func(l *LiveStreamSettings) { l.SegmentLength = 1 }, "livestream-segment-length"},
+		{"segment too long", func(l *LiveStreamSettings) { l.SegmentLength = 31 }, "livestream-segment-length"},
+		{"unsupported sample rate", func(l *LiveStreamSettings) { l.SampleRate = 32000 }, "livestream-sample-rate"},
+		{"negative bitrate", func(l *LiveStreamSettings) { l.BitRate = -1 }, "livestream-bitrate"},
+		{"unknown log level", func(l *LiveStreamSettings) { l.FfmpegLogLevel = "warn" }, "livestream-ffmpeg-log-level"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := &WebServerSettings{LiveStream: valid}
+			tt.modify(&settings.LiveStream)
+			err := validateWebServerSettings(settings)
+			if (err != nil) != (tt.errType != "") {
+				t.Fatalf("validateWebServerSettings() error = %v, want error type %q", err, tt.errType)
+			}
+			if err == nil {
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError type, got %T", err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("expected validation_type = %s, got %v", tt.errType, ctx)
+			}
+		})
+	}
+}
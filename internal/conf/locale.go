@@ -4,6 +4,8 @@ package conf
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/tphakala/birdnet-go/internal/errors"
@@ -130,7 +132,7 @@ func GetLabelFilename(modelVersion, localeCode string) (string, error) {
 	if !exists {
 		return "", errors.New(fmt.Errorf("unsupported model version: %s", modelVersion)).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "model-version-support").
+			Context("validation_type", ErrCodeModelVersion).
 			Context("model_version", modelVersion).
 			Build()
 	}
@@ -140,7 +142,7 @@ func GetLabelFilename(modelVersion, localeCode string) (string, error) {
 	if !exists {
 		return "", errors.New(fmt.Errorf("unsupported locale code for model %s: %s", modelVersion, localeCode)).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "locale-code-support").
+			Context("validation_type", ErrCodeLocale).
 			Context("model_version", modelVersion).
 			Context("locale_code", localeCode).
 			Build()
@@ -149,42 +151,52 @@ func GetLabelFilename(modelVersion, localeCode string) (string, error) {
 	return config.BasePath + fmt.Sprintf(config.FilePattern, fileLocale), nil
 }
 
-// NormalizeLocale normalizes the input locale string and matches it to a known locale code or full name.
-// If the locale is not supported, it falls back to DefaultFallbackLocale.
-func NormalizeLocale(inputLocale string) (string, error) {
-	originalInput := inputLocale
-	inputLocale = strings.ToLower(inputLocale)
+// SupportedLocales returns the sorted codes of the locales with label files,
+// e.g. for the locale dropdown of the web UI
+func SupportedLocales() []string {
+	return slices.Sorted(maps.Keys(LocaleCodeMapping))
+}
+
+// NormalizeLocale maps a locale code or name to a supported locale code. It
+// matches case-insensitively, in order: locale codes such as "en-US" or
+// "pt_BR", language names such as "German" or "English (US)", language names
+// without region such as "english", and language codes with an unsupported or
+// missing region such as "de-AT" or "en". Candidates are tried in sorted code
+// order, so "english" and "en" are "en-uk". When nothing matches, matched is
+// false and code is DefaultFallbackLocale.
+func NormalizeLocale(input string) (code string, matched bool) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input)), "_", "-")
+	if normalized == "" {
+		return DefaultFallbackLocale, false
+	}
 
 	// Check if it's already a valid locale code
-	if _, exists := LocaleCodes[inputLocale]; exists {
-		return inputLocale, nil
+	if _, exists := LocaleCodeMapping[normalized]; exists {
+		return normalized, true
 	}
 
-	// Try to match by full name
-	for code, fullName := range LocaleCodes {
-		if strings.EqualFold(fullName, inputLocale) {
-			return code, nil
+	locales := SupportedLocales()
+
+	// Try to match by full name, then by name without the region
+	for _, code := range locales {
+		if strings.EqualFold(LocaleCodes[code], normalized) {
+			return code, true
 		}
 	}
-
-	// Fall back to DefaultFallbackLocale if the locale is not supported
-	// Get the human-readable name for the fallback locale
-	fallbackName, exists := LocaleCodes[DefaultFallbackLocale]
-	if !exists {
-		fallbackName = DefaultFallbackLocale // fallback to code if name not found
+	for _, code := range locales {
+		name, _, _ := strings.Cut(LocaleCodes[code], " (")
+		if strings.EqualFold(name, normalized) {
+			return code, true
+		}
 	}
 
-	// Create detailed error with available locales for debugging
-	availableLocales := make([]string, 0, len(LocaleCodes))
-	for code := range LocaleCodes {
-		availableLocales = append(availableLocales, code)
+	// Try to match the language, ignoring the region
+	language, _, _ := strings.Cut(normalized, "-")
+	for _, code := range locales {
+		if codeLanguage, _, _ := strings.Cut(code, "-"); codeLanguage == language {
+			return code, true
+		}
 	}
 
-	return DefaultFallbackLocale, errors.New(fmt.Errorf("locale '%s' not supported, falling back to %s", originalInput, fallbackName)).
-		Category(errors.CategoryValidation).
-		Context("validation_type", "locale-normalization").
-		Context("input_locale", originalInput).
-		Context("fallback_locale", DefaultFallbackLocale).
-		Context("available_locales_sample", availableLocales[:5]).
-		Build()
+	return DefaultFallbackLocale, false
 }
@@ -0,0 +1,108 @@
+package conf
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		wantCode    string
+		wantMatched bool
+	}{
+		{"exact code", "de", "de", true},
+		{"exact code with region", "pt-br", "pt-br", true},
+		{"uppercase region", "en-US", "en-us", true},
+		{"underscore separator", "pt_BR", "pt-br", true},
+		{"surrounding whitespace", " fi ", "fi", true},
+		{"full name", "German", "de", true},
+		{"full name with region", "english (us)", "en-us", true},
+		{"name without region", "english", "en-uk", true},
+		{"language with unsupported region", "de-AT", "de", true},
+		{"language without region", "en", "en-uk", true},
+		{"language of region only locale", "hi", "hi-in", true},
+		{"unknown code", "xx-invalid", DefaultFallbackLocale, false},
+		{"unknown name", "Klingon", DefaultFallbackLocale, false},
+		{"empty", "", DefaultFallbackLocale, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, matched := NormalizeLocale(tt.input)
+			if code != tt.wantCode || matched != tt.wantMatched {
+				t.Errorf("NormalizeLocale(%q) = %q, %v, want %q, %v", tt.input, code, matched, tt.wantCode, tt.wantMatched)
+			}
+		})
+	}
+}
+
+func TestSupportedLocales(t *testing.T) {
+	t.Parallel()
+
+	locales := SupportedLocales()
+	if !slices.IsSorted(locales) {
+		t.Errorf("SupportedLocales() = %v, want sorted codes", locales)
+	}
+	if !slices.Contains(locales, DefaultFallbackLocale) {
+		t.Errorf("SupportedLocales() does not contain the fallback locale %s", DefaultFallbackLocale)
+	}
+	for _, code := range locales {
+		if LocaleCodes[code] == "" {
+			t.Errorf("supported locale %s has no name", code)
+		}
+		if normalized, matched := NormalizeLocale(strings.ToUpper(code)); normalized != code || !matched {
+			t.Errorf("NormalizeLocale(%q) = %q, %v, want %q, true", strings.ToUpper(code), normalized, matched, code)
+		}
+	}
+}
+
+func TestValidateLocaleFallbackWarning(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.BirdNET.Locale = "Klingon"
+	if message := validateLocale(&settings.BirdNET, settings); message == "" {
+		t.Error("validateLocale() returned no warning for an unsupported locale")
+	}
+	if settings.BirdNET.Locale != DefaultFallbackLocale {
+		t.Errorf("Locale = %q, want fallback %q", settings.BirdNET.Locale, DefaultFallbackLocale)
+	}
+	if len(settings.ValidationWarnings) != 1 || !strings.HasPrefix(settings.ValidationWarnings[0], "config-locale-validation: ") {
+		t.Errorf("ValidationWarnings = %v, want one config-locale-validation warning", settings.ValidationWarnings)
+	}
+
+	settings = &Settings{}
+	settings.BirdNET.Locale = "en-US"
+	if message := validateLocale(&settings.BirdNET, settings); message != "" || settings.BirdNET.Locale != "en-us" {
+		t.Errorf("validateLocale(en-US) = %q with locale %q, want no warning and en-us", message, settings.BirdNET.Locale)
+	}
+}
+
+func TestValidateSecondaryLocale(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.BirdNET.SecondaryLocale = "Finnish"
+	if message := validateSecondaryLocale(&settings.BirdNET, settings); message != "" || settings.BirdNET.SecondaryLocale != "fi" {
+		t.Errorf("validateSecondaryLocale(Finnish) = %q with locale %q, want no warning and fi", message, settings.BirdNET.SecondaryLocale)
+	}
+
+	settings.BirdNET.SecondaryLocale = "Klingon"
+	if message := validateSecondaryLocale(&settings.BirdNET, settings); !strings.Contains(message, "secondary locale") {
+		t.Errorf("validateSecondaryLocale(Klingon) = %q, want a secondary locale warning", message)
+	}
+	if len(settings.ValidationWarnings) != 1 {
+		t.Errorf("ValidationWarnings = %v, want one warning", settings.ValidationWarnings)
+	}
+
+	settings = &Settings{}
+	if message := validateSecondaryLocale(&settings.BirdNET, settings); message != "" || settings.BirdNET.SecondaryLocale != "" {
+		t.Errorf("validateSecondaryLocale(\"\") = %q with locale %q, want secondary locale to stay disabled", message, settings.BirdNET.SecondaryLocale)
+	}
+}
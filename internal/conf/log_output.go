@@ -0,0 +1,76 @@
+// conf/log_output.go log output targets and formats
+package conf
+
+import (
+	"fmt"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Values of LogConfig.Output
+const (
+	LogOutputFile     = "file"     // log file at Path, rotated as configured
+	LogOutputStdout   = "stdout"   // standard output, e.g. for Docker
+	LogOutputStderr   = "stderr"   // standard error
+	LogOutputSyslog   = "syslog"   // local syslog daemon, not available on Windows
+	LogOutputJournald = "journald" // systemd journal, Linux only
+)
+
+// Values of LogConfig.Format
+const (
+	LogFormatText = "text" // key=value lines
+	LogFormatJSON = "json" // one JSON object per line
+)
+
+// OutputOrDefault returns where the log is written, file when it is not set
+func (lc *LogConfig) OutputOrDefault() string {
+	if lc.Output == "" {
+		return LogOutputFile
+	}
+	return lc.Output
+}
+
+// FormatOrDefault returns the log record format, json when it is not set
+func (lc *LogConfig) FormatOrDefault() string {
+	if lc.Format == "" {
+		return LogFormatJSON
+	}
+	return lc.Format
+}
+
+// validateLogConfig checks the output and format of the log with config key
+// key, such as "main.log". Only the application log in main.log can be
+// written elsewhere than a file, the service loggers follow it. An enabled
+// log written to a file needs a path.
+func validateLogConfig(lc *LogConfig, key string) error {
+	if key == "main.log" {
+		if c := constraintFor("main.log.output"); !c.allows(lc.OutputOrDefault()) {
+			return errors.New(fmt.Errorf("unknown main.log.output %q, must be %s", lc.Output, c)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeLogOutput).
+				Context("field", "main.log.output").
+				Build()
+		}
+		if c := constraintFor("main.log.format"); !c.allows(lc.FormatOrDefault()) {
+			return errors.New(fmt.Errorf("unknown main.log.format %q, must be %s", lc.Format, c)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeLogFormat).
+				Context("field", "main.log.format").
+				Build()
+		}
+	} else if lc.OutputOrDefault() != LogOutputFile || lc.Format != "" {
+		return errors.New(fmt.Errorf("%s is always written to a file, output and format are only supported in main.log", key)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeLogOutput).
+			Context("field", key+".output").
+			Build()
+	}
+	if lc.Enabled && lc.OutputOrDefault() == LogOutputFile && lc.Path == "" {
+		return errors.New(fmt.Errorf("%s.path must be set when the log is written to a file", key)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeLogPath).
+			Context("field", key+".path").
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLogConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		key     string
+		config  LogConfig
+		wantErr bool
+	}{
+		{"defaults", "main.log", LogConfig{Enabled: true, Path: "birdnet.log"}, false},
+		{"stdout json", "main.log", LogConfig{Enabled: true, Output: LogOutputStdout, Format: LogFormatJSON}, false},
+		{"journald text", "main.log", LogConfig{Enabled: true, Output: LogOutputJournald, Format: LogFormatText}, false},
+		{"syslog", "main.log", LogConfig{Enabled: true, Output: LogOutputSyslog}, false},
+		{"unknown output", "main.log", LogConfig{Output: "kafka"}, true},
+		{"unknown format", "main.log", LogConfig{Format: "xml"}, true},
+		{"file without path", "main.log", LogConfig{Enabled: true, Output: LogOutputFile}, true},
+		{"disabled file without path", "main.log", LogConfig{Output: LogOutputFile}, false},
+		{"web server file", "webserver.log", LogConfig{Enabled: true, Path: "webui.log"}, false},
+		{"web server stdout", "webserver.log", LogConfig{Enabled: true, Output: LogOutputStdout}, true},
+		{"web server format", "webserver.log", LogConfig{Enabled: true, Path: "webui.log", Format: LogFormatText}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateLogConfig(&tt.config, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateLogConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadLogOutput(t *testing.T) {
+	t.Parallel()
+
+	settings, err := LoadFromReader(strings.NewReader("main:\n  log:\n    output: stdout\n    format: text\n    path: \"\"\n"))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	if got := settings.Main.Log.OutputOrDefault(); got != LogOutputStdout {
+		t.Errorf("OutputOrDefault() = %q, want %q", got, LogOutputStdout)
+	}
+	if got := settings.Main.Log.FormatOrDefault(); got != LogFormatText {
+		t.Errorf("FormatOrDefault() = %q, want %q", got, LogFormatText)
+	}
+
+	if _, err := LoadFromReader(strings.NewReader("main:\n  log:\n    output: kafka\n")); err == nil {
+		t.Error("LoadFromReader() accepted an unknown log output")
+	}
+}
@@ -0,0 +1,54 @@
+// conf/merge.go runtime merge of partial config files onto the live settings
+package conf
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// MergeConfigFile merges the partial YAML config file at path onto the live
+// settings and returns the config keys of the settings it changed, e.g. to
+// apply per-site overrides to a shared base config. Sections of the file are
+// merged field by field and lists and maps replace the live values, like
+// ApplyPatch. An unknown key or a failed validation leaves the settings
+// unchanged. Errors never quote secret values of the file. The settings are
+// not saved.
+func MergeConfigFile(path string) ([]string, error) {
+	settings := GetSettings()
+	if settings == nil {
+		return nil, errors.New(fmt.Errorf("settings are not loaded")).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "merge-config-file").
+			Build()
+	}
+	return settings.mergeConfigFile(path)
+}
+
+// mergeConfigFile merges the partial config file at path onto s
+func (s *Settings) mergeConfigFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "merge-config-file").
+			Context("path", path).
+			Build()
+	}
+	// Partial config files may be encrypted like the config file
+	if data, err = decryptConfigData(data); err != nil {
+		return nil, err
+	}
+
+	var overlay map[string]any
+	if err := yaml.Unmarshal(data, &overlay); err != nil {
+		return nil, errors.New(fmt.Errorf("config file %s is not valid YAML: %w", path, err)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "merge-config-file").
+			Context("path", path).
+			Build()
+	}
+	return s.applyPatchFrom(overlay, ChangeSourceMerge)
+}
@@ -0,0 +1,98 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeMergeFile writes a partial config file for MergeConfigFile tests
+func writeMergeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "site.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMergeConfigFile(t *testing.T) {
+	t.Parallel()
+
+	settings := newPatchTestSettings(t)
+	settings.Realtime.MQTT.Broker = "tcp://base:1883"
+	path := writeMergeFile(t, `
+main:
+  name: site-a
+birdnet:
+  latitude: 60.17
+  longitude: 24.94
+realtime:
+  mqtt:
+    password: overlay-secret
+`)
+
+	changed, err := settings.mergeConfigFile(path)
+	if err != nil {
+		t.Fatalf("mergeConfigFile() error = %v", err)
+	}
+	want := []string{"birdnet.latitude", "birdnet.longitude", "main.name", "realtime.mqtt.password"}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("mergeConfigFile() changed = %v, want %v", changed, want)
+	}
+	if settings.Main.Name != "site-a" || settings.BirdNET.Latitude != 60.17 {
+		t.Errorf("Name = %q, Latitude = %v, want the overlay values", settings.Main.Name, settings.BirdNET.Latitude)
+	}
+	if settings.Realtime.MQTT.Broker != "tcp://base:1883" {
+		t.Errorf("Broker = %q, want the base value kept", settings.Realtime.MQTT.Broker)
+	}
+}
+
+func TestMergeConfigFileRejected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown key", "birdnet:\n  treshold: 0.5\n"},
+		{"invalid value", "birdnet:\n  threshold: 5\n"},
+		{"invalid yaml", "birdnet: [\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := newPatchTestSettings(t)
+			before := cloneSettings(settings)
+			if _, err := settings.mergeConfigFile(writeMergeFile(t, tt.content)); err == nil {
+				t.Fatal("mergeConfigFile() error = nil, want an error")
+			}
+			if changed := Diff(before, settings); len(changed) != 0 {
+				t.Errorf("settings changed %v after a rejected merge", changed)
+			}
+		})
+	}
+}
+
+func TestMergeConfigFileHidesSecrets(t *testing.T) {
+	t.Parallel()
+
+	settings := newPatchTestSettings(t)
+	path := writeMergeFile(t, `
+notifications:
+  - type: discord
+    enabled: true
+    settings:
+      webhookurl: discord.com/api/webhooks/1/overlay-secret
+`)
+	_, err := settings.mergeConfigFile(path)
+	if err == nil {
+		t.Fatal("mergeConfigFile() error = nil, want an error")
+	}
+	if strings.Contains(err.Error(), "overlay-secret") {
+		t.Errorf("mergeConfigFile() error = %v, quotes the secret", err)
+	}
+}
@@ -0,0 +1,118 @@
+// conf/migrate.go one-time migrations of legacy configuration
+package conf
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// CurrentConfigVersion is the config file format version of this build.
+// Config files without a configversion are version 1.
+const CurrentConfigVersion = 2
+
+// migration upgrades settings from the previous config version to version
+type migration struct {
+	version     int
+	description string
+	migrate     func(settings *Settings) error
+}
+
+// migrations lists the config migrations by version, a migration to each
+// version from 2 to CurrentConfigVersion must be registered in order
+var migrations = []migration{
+	{
+		version:     2,
+		description: "move legacy realtime.openweather settings to realtime.weather",
+		migrate: func(settings *Settings) error {
+			migrateLegacyOpenWeather(settings, false)
+			return nil
+		},
+	},
+}
+
+func init() {
+	if err := checkMigrations(migrations, CurrentConfigVersion); err != nil {
+		panic(err)
+	}
+}
+
+// checkMigrations returns an error unless registered has exactly one
+// migration to each version from 2 to current, in increasing order
+func checkMigrations(registered []migration, current int) error {
+	for i, m := range registered {
+		if want := i + 2; m.version != want {
+			return fmt.Errorf("config migration %q has version %d, want %d", m.description, m.version, want)
+		}
+		if m.migrate == nil {
+			return fmt.Errorf("config migration %d has no migrate function", m.version)
+		}
+	}
+	if len(registered)+1 != current {
+		return fmt.Errorf("config migrations end at version %d, want %d", len(registered)+1, current)
+	}
+	return nil
+}
+
+// runMigrations applies the migrations after config version from up to and
+// including version to, and sets settings.ConfigVersion to to. It returns
+// the descriptions of the applied migrations in order.
+func runMigrations(settings *Settings, from, to int) ([]string, error) {
+	if from < 1 || to > CurrentConfigVersion || from > to {
+		return nil, errors.New(fmt.Errorf("cannot migrate config from version %d to %d, supported versions are 1 to %d", from, to, CurrentConfigVersion)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "migrate-config").
+			Build()
+	}
+
+	var applied []string
+	for _, m := range migrations[from-1 : to-1] {
+		if err := m.migrate(settings); err != nil {
+			return applied, errors.New(fmt.Errorf("config migration to version %d failed: %w", m.version, err)).
+				Category(errors.CategoryConfiguration).
+				Context("operation", "migrate-config").
+				Context("version", m.version).
+				Build()
+		}
+		applied = append(applied, m.description)
+	}
+	settings.ConfigVersion = to
+	return applied, nil
+}
+
+// migrateLegacyOpenWeather moves enabled legacy Realtime.OpenWeather settings
+// into Realtime.Weather and selects the OpenWeather provider. When the config
+// file already has a weather section it takes precedence and the legacy
+// settings are dropped. The legacy settings are cleared in both cases, it
+// reports whether the settings changed and should be written back.
+func migrateLegacyOpenWeather(settings *Settings, weatherConfigured bool) bool {
+	legacy := settings.Realtime.OpenWeather
+	if !legacy.Enabled {
+		return false
+	}
+	settings.Realtime.OpenWeather = OpenWeatherSettings{}
+
+	if weatherConfigured {
+		log.Printf("Config migration: dropping legacy realtime.openweather settings, realtime.weather is already configured")
+		return true
+	}
+
+	weather := &settings.Realtime.Weather
+	weather.Provider = "openweather"
+	weather.OpenWeather.Enabled = true
+	weather.OpenWeather.APIKey = legacy.APIKey
+	// Keep defaults for values the legacy settings did not define
+	if legacy.Endpoint != "" {
+		weather.OpenWeather.Endpoint = legacy.Endpoint
+	}
+	if legacy.Units != "" {
+		weather.OpenWeather.Units = legacy.Units
+	}
+	if legacy.Language != "" {
+		weather.OpenWeather.Language = legacy.Language
+	}
+
+	log.Printf("Config migration: moved legacy realtime.openweather settings to realtime.weather with provider openweather")
+	return true
+}
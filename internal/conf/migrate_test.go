@@ -0,0 +1,192 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMigrateLegacyOpenWeather(t *testing.T) {
+	t.Parallel()
+
+	newSettings := func(legacy OpenWeatherSettings) *Settings {
+		s := &Settings{}
+		s.Realtime.Weather.Provider = "yrno"
+		s.Realtime.Weather.OpenWeather = OpenWeatherSettings{
+			Endpoint: "https://api.openweathermap.org/data/2.5/weather",
+			Units:    "metric",
+			Language: "en",
+		}
+		s.Realtime.OpenWeather = legacy
+		return s
+	}
+
+	t.Run("legacy disabled", func(t *testing.T) {
+		t.Parallel()
+		s := newSettings(OpenWeatherSettings{APIKey: "unused"})
+		if migrateLegacyOpenWeather(s, false) {
+			t.Error("expected no migration for disabled legacy settings")
+		}
+		if s.Realtime.Weather.Provider != "yrno" {
+			t.Errorf("Provider = %q, want yrno", s.Realtime.Weather.Provider)
+		}
+	})
+
+	t.Run("legacy enabled", func(t *testing.T) {
+		t.Parallel()
+		s := newSettings(OpenWeatherSettings{Enabled: true, APIKey: "key", Units: "imperial"})
+		if !migrateLegacyOpenWeather(s, false) {
+			t.Fatal("expected migration for enabled legacy settings")
+		}
+
+		weather := s.Realtime.Weather
+		if weather.Provider != "openweather" || weather.OpenWeather.APIKey != "key" || weather.OpenWeather.Units != "imperial" {
+			t.Errorf("unexpected migrated weather settings: %+v", weather)
+		}
+		if weather.OpenWeather.Endpoint == "" || weather.OpenWeather.Language != "en" {
+			t.Errorf("migration overwrote defaults with empty legacy values: %+v", weather.OpenWeather)
+		}
+		if s.Realtime.OpenWeather != (OpenWeatherSettings{}) {
+			t.Errorf("legacy settings not cleared: %+v", s.Realtime.OpenWeather)
+		}
+		if provider, _ := s.GetWeatherSettings(); provider != "openweather" {
+			t.Errorf("GetWeatherSettings() provider = %q, want openweather", provider)
+		}
+	})
+
+	t.Run("weather already configured", func(t *testing.T) {
+		t.Parallel()
+		s := newSettings(OpenWeatherSettings{Enabled: true, APIKey: "key"})
+		if !migrateLegacyOpenWeather(s, true) {
+			t.Fatal("expected legacy settings to be cleaned up")
+		}
+		if s.Realtime.Weather.Provider != "yrno" || s.Realtime.Weather.OpenWeather.APIKey != "" {
+			t.Errorf("configured weather settings changed: %+v", s.Realtime.Weather)
+		}
+		if s.Realtime.OpenWeather != (OpenWeatherSettings{}) {
+			t.Errorf("legacy settings not cleared: %+v", s.Realtime.OpenWeather)
+		}
+	})
+}
+
+func TestCheckMigrations(t *testing.T) {
+	t.Parallel()
+
+	noop := func(*Settings) error { return nil }
+	tests := []struct {
+		name       string
+		registered []migration
+		current    int
+		wantErr    bool
+	}{
+		{"registered migrations", migrations, CurrentConfigVersion, false},
+		{"none", nil, 1, false},
+		{"sequence", []migration{{2, "a", noop}, {3, "b", noop}}, 3, false},
+		{"not starting at 2", []migration{{3, "a", noop}}, 3, true},
+		{"gap", []migration{{2, "a", noop}, {4, "b", noop}}, 4, true},
+		{"duplicate", []migration{{2, "a", noop}, {2, "b", noop}}, 3, true},
+		{"out of order", []migration{{3, "a", noop}, {2, "b", noop}}, 3, true},
+		{"missing current", []migration{{2, "a", noop}}, 3, true},
+		{"beyond current", []migration{{2, "a", noop}, {3, "b", noop}}, 2, true},
+		{"no migrate function", []migration{{2, "a", nil}}, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkMigrations(tt.registered, tt.current)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunMigrations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid versions", func(t *testing.T) {
+		t.Parallel()
+		for _, versions := range [][2]int{{0, CurrentConfigVersion}, {CurrentConfigVersion, 1}, {1, CurrentConfigVersion + 1}} {
+			s := &Settings{}
+			if _, err := runMigrations(s, versions[0], versions[1]); err == nil {
+				t.Errorf("runMigrations(%d, %d) succeeded, want error", versions[0], versions[1])
+			}
+			if s.ConfigVersion != 0 {
+				t.Errorf("runMigrations(%d, %d) set ConfigVersion to %d", versions[0], versions[1], s.ConfigVersion)
+			}
+		}
+	})
+
+	t.Run("current version", func(t *testing.T) {
+		t.Parallel()
+		s := &Settings{}
+		s.Realtime.OpenWeather.Enabled = true
+		applied, err := runMigrations(s, CurrentConfigVersion, CurrentConfigVersion)
+		if err != nil {
+			t.Fatalf("runMigrations() error = %v", err)
+		}
+		if len(applied) != 0 || !s.Realtime.OpenWeather.Enabled {
+			t.Errorf("runMigrations() applied %v, want none", applied)
+		}
+		if s.ConfigVersion != CurrentConfigVersion {
+			t.Errorf("ConfigVersion = %d, want %d", s.ConfigVersion, CurrentConfigVersion)
+		}
+	})
+
+	t.Run("from version 1", func(t *testing.T) {
+		t.Parallel()
+		applied, err := runMigrations(&Settings{}, 1, CurrentConfigVersion)
+		if err != nil {
+			t.Fatalf("runMigrations() error = %v", err)
+		}
+		if len(applied) != CurrentConfigVersion-1 {
+			t.Errorf("runMigrations() applied %d migrations, want %d: %v", len(applied), CurrentConfigVersion-1, applied)
+		}
+	})
+}
+
+func TestLoadVersion1Config(t *testing.T) {
+	t.Parallel()
+
+	// Version 1 config files have no configversion and may have the legacy
+	// OpenWeather settings
+	const v1Config = `
+main:
+  name: migrated-node
+realtime:
+  openweather:
+    enabled: true
+    apikey: legacy-key
+    units: imperial
+`
+	const expectedConfig = `
+configversion: 2
+main:
+  name: migrated-node
+realtime:
+  weather:
+    provider: openweather
+    openweather:
+      enabled: true
+      apikey: legacy-key
+      units: imperial
+`
+
+	got, err := LoadFromReader(strings.NewReader(v1Config))
+	if err != nil {
+		t.Fatalf("LoadFromReader(v1) error = %v", err)
+	}
+	want, err := LoadFromReader(strings.NewReader(expectedConfig))
+	if err != nil {
+		t.Fatalf("LoadFromReader(expected) error = %v", err)
+	}
+
+	if got.ConfigVersion != CurrentConfigVersion {
+		t.Errorf("ConfigVersion = %d, want %d", got.ConfigVersion, CurrentConfigVersion)
+	}
+	if changed := Diff(want, got); len(changed) != 0 {
+		t.Errorf("migrated settings differ from the expected settings: %v", changed)
+	}
+	if got.Realtime.OpenWeather != (OpenWeatherSettings{}) {
+		t.Errorf("legacy settings not cleared: %+v", got.Realtime.OpenWeather)
+	}
+}
@@ -0,0 +1,66 @@
+// conf/model_path.go resolution of external model and label file paths
+package conf
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// ConfigDir returns the directory of the config file in use, an empty string
+// when there is no config file
+func ConfigDir() string {
+	configPath, err := configFilePath()
+	if err != nil || configPath == "" {
+		return ""
+	}
+	return filepath.Dir(configPath)
+}
+
+// ResolveModelPath returns the path of the external model file, a relative
+// ModelPath is resolved against configDir so that it does not depend on the
+// working directory. An empty string means the embedded model.
+func (b BirdNETConfig) ResolveModelPath(configDir string) string {
+	return resolveConfigRelativePath(b.ModelPath, configDir)
+}
+
+// ResolveLabelPath returns the path of the external label file, a relative
+// LabelPath is resolved against configDir like ResolveModelPath. An empty
+// string means the embedded labels.
+func (b BirdNETConfig) ResolveLabelPath(configDir string) string {
+	return resolveConfigRelativePath(b.LabelPath, configDir)
+}
+
+// resolveConfigRelativePath joins a relative path to configDir, absolute
+// paths and paths without a config directory are returned cleaned
+func resolveConfigRelativePath(path, configDir string) string {
+	if path == "" {
+		return ""
+	}
+	if filepath.IsAbs(path) || configDir == "" {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(configDir, path)
+}
+
+// warnMissingModelFile returns a warning when the external model or label
+// file named by the resolved path cannot be found, an empty string otherwise.
+// It is a warning as the embedded model and labels are used when the paths
+// are empty.
+func warnMissingModelFile(name, path string, settings *Settings) string {
+	if path == "" {
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err == nil && !info.IsDir() {
+		return ""
+	}
+
+	message := fmt.Sprintf("BirdNET %s file %s not found, relative paths are resolved against the config directory", name, path)
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeBirdNETModelPath, "file-not-found")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-birdnet-validation: %s", message))
+	return message
+}
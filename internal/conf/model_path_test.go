@@ -0,0 +1,68 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveModelPath(t *testing.T) {
+	t.Parallel()
+
+	configDir := filepath.Join(t.TempDir(), "config")
+	absolute := filepath.Join(t.TempDir(), "model.tflite")
+
+	tests := []struct {
+		name      string
+		path      string
+		configDir string
+		want      string
+	}{
+		{"embedded", "", configDir, ""},
+		{"relative", "models/custom.tflite", configDir, filepath.Join(configDir, "models", "custom.tflite")},
+		{"relative with parent", "../custom.tflite", configDir, filepath.Join(filepath.Dir(configDir), "custom.tflite")},
+		{"absolute", absolute, configDir, absolute},
+		{"no config dir", "models/custom.tflite", "", filepath.Join("models", "custom.tflite")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := BirdNETConfig{ModelPath: tt.path, LabelPath: tt.path}
+			if got := b.ResolveModelPath(tt.configDir); got != tt.want {
+				t.Errorf("ResolveModelPath(%q) = %q, want %q", tt.configDir, got, tt.want)
+			}
+			if got := b.ResolveLabelPath(tt.configDir); got != tt.want {
+				t.Errorf("ResolveLabelPath(%q) = %q, want %q", tt.configDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarnMissingModelFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	model := filepath.Join(dir, "model.tflite")
+	if err := os.WriteFile(model, []byte("model"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := &Settings{}
+	if warning := warnMissingModelFile("model", "", settings); warning != "" {
+		t.Errorf("warnMissingModelFile() of the embedded model = %q, want no warning", warning)
+	}
+	if warning := warnMissingModelFile("model", model, settings); warning != "" {
+		t.Errorf("warnMissingModelFile() of an existing file = %q, want no warning", warning)
+	}
+	if warning := warnMissingModelFile("model", dir, settings); warning == "" {
+		t.Error("warnMissingModelFile() of a directory = no warning, want a warning")
+	}
+	missing := filepath.Join(dir, "labels.txt")
+	if warning := warnMissingModelFile("label", missing, settings); !strings.Contains(warning, missing) {
+		t.Errorf("warnMissingModelFile() of a missing file = %q, want a warning naming it", warning)
+	}
+	if len(settings.ValidationWarnings) != 2 {
+		t.Errorf("ValidationWarnings = %q, want 2 warnings", settings.ValidationWarnings)
+	}
+}
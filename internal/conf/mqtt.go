@@ -0,0 +1,131 @@
+// conf/mqtt.go MQTT broker lists with failover and TLS settings
+package conf
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// mqttBrokerSchemes lists the broker URL schemes supported by the MQTT client
+var mqttBrokerSchemes = []string{"tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss"}
+
+// mqttTLSSchemes lists the broker URL schemes connecting with TLS
+var mqttTLSSchemes = []string{"ssl", "tls", "mqtts", "wss"}
+
+// BrokerList returns the MQTT broker URLs in the order the client tries them,
+// the first one is the primary broker and the others are failovers. An
+// explicit Brokers list is returned as is, otherwise the legacy Broker setting
+// is the only broker.
+func (m MQTTSettings) BrokerList() []string {
+	if len(m.Brokers) > 0 {
+		return slices.Clone(m.Brokers)
+	}
+	if m.Broker == "" {
+		return nil
+	}
+	return []string{m.Broker}
+}
+
+// BrokerUsesTLS reports whether an MQTT broker URL connects with TLS, based
+// on its scheme such as ssl:// or wss://
+func BrokerUsesTLS(broker string) bool {
+	scheme, _, found := strings.Cut(broker, "://")
+	return found && slices.Contains(mqttTLSSchemes, strings.ToLower(scheme))
+}
+
+// validateBrokers checks that every broker is a URL with a supported scheme
+// and a host, listed at most once
+func (m MQTTSettings) validateBrokers() error {
+	brokers := m.BrokerList()
+	for i, broker := range brokers {
+		u, err := url.Parse(broker)
+		if err != nil || !slices.Contains(mqttBrokerSchemes, strings.ToLower(u.Scheme)) || u.Host == "" {
+			return errors.New(fmt.Errorf("invalid MQTT broker URL %q, use a URL such as tcp://localhost:1883 with one of the schemes %s",
+				broker, strings.Join(mqttBrokerSchemes, ", "))).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeMQTTBrokerURL).
+				Build()
+		}
+		if slices.Contains(brokers[:i], broker) {
+			return errors.New(fmt.Errorf("MQTT broker %q is listed more than once", broker)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeMQTTBrokerURL).
+				Build()
+		}
+	}
+	return nil
+}
+
+// enableTLSFromBrokers enables TLS when a broker URL has a TLS scheme such as
+// ssl:// or wss://, the TLS settings then apply to the brokers with a TLS
+// scheme
+func (m *MQTTSettings) enableTLSFromBrokers() {
+	if !m.TLS.Enabled && slices.ContainsFunc(m.BrokerList(), BrokerUsesTLS) {
+		m.TLS.Enabled = true
+	}
+}
+
+// validateFiles checks that the configured certificate and key files can be
+// read and that a client certificate comes with its key
+func (t MQTTTLSSettings) validateFiles() error {
+	files := []struct {
+		name string
+		path string
+	}{
+		{"CA certificate", t.CACert},
+		{"client certificate", t.ClientCert},
+		{"client key", t.ClientKey},
+	}
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		f, err := os.Open(file.path)
+		if err != nil {
+			return errors.New(fmt.Errorf("MQTT TLS %s %s cannot be read: %w", file.name, file.path, err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeMQTTTLSFile).
+				Context("path", file.path).
+				Build()
+		}
+		f.Close()
+	}
+
+	if (t.ClientCert == "") != (t.ClientKey == "") {
+		return errors.New(fmt.Errorf("MQTT TLS client certificate and client key must be set together")).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeMQTTTLSClientPair).
+			Build()
+	}
+	if t.ClientCert != "" {
+		if _, err := tls.LoadX509KeyPair(t.ClientCert, t.ClientKey); err != nil {
+			return errors.New(fmt.Errorf("MQTT TLS client certificate %s and key %s do not form a usable pair: %w", t.ClientCert, t.ClientKey, err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeMQTTTLSClientPair).
+				Build()
+		}
+	}
+	return nil
+}
+
+// warnInsecureMQTT returns a warning when TLS certificate verification of
+// the MQTT brokers is disabled, an empty string otherwise
+func warnInsecureMQTT(mqtt *MQTTSettings, settings *Settings) string {
+	if !mqtt.Enabled || !mqtt.TLS.Enabled || !mqtt.TLS.InsecureSkipVerify {
+		return ""
+	}
+
+	message := "MQTT TLS certificate verification is disabled by insecureskipverify, broker identities are not verified"
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeMQTTTLSVerification, "insecure-skip-verify")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-mqtt-validation: %s", message))
+	return message
+}
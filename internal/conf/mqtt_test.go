@@ -0,0 +1,143 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMQTTBrokerList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings MQTTSettings
+		want     []string
+	}{
+		{"not set", MQTTSettings{}, nil},
+		{"legacy broker", MQTTSettings{Broker: "tcp://localhost:1883"}, []string{"tcp://localhost:1883"}},
+		{"brokers override broker", MQTTSettings{Broker: "tcp://localhost:1883", Brokers: []string{"ssl://primary:8883", "tcp://backup:1883"}},
+			[]string{"ssl://primary:8883", "tcp://backup:1883"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.settings.BrokerList(); !slices.Equal(got, tt.want) {
+				t.Errorf("BrokerList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrokerUsesTLS(t *testing.T) {
+	t.Parallel()
+
+	for broker, want := range map[string]bool{
+		"tcp://localhost:1883":  false,
+		"ws://localhost:9001":   false,
+		"ssl://localhost:8883":  true,
+		"TLS://localhost:8883":  true,
+		"mqtts://localhost":     true,
+		"wss://localhost:8884":  true,
+		"localhost:8883":        false,
+		"sslish://localhost:80": false,
+	} {
+		if got := BrokerUsesTLS(broker); got != want {
+			t.Errorf("BrokerUsesTLS(%q) = %v, want %v", broker, got, want)
+		}
+	}
+}
+
+func TestValidateMQTTBrokers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		broker  string
+		brokers []string
+		wantErr bool
+	}{
+		{"legacy broker", "tcp://localhost:1883", nil, false},
+		{"failover brokers", "", []string{"ssl://primary:8883", "wss://backup:8884/mqtt"}, false},
+		{"no broker", "", nil, true},
+		{"missing scheme", "localhost:1883", nil, true},
+		{"unsupported scheme", "http://localhost:1883", nil, true},
+		{"missing host", "tcp://", nil, true},
+		{"invalid failover broker", "tcp://localhost:1883", []string{"tcp://primary:1883", "backup"}, true},
+		{"duplicate broker", "", []string{"tcp://primary:1883", "tcp://primary:1883"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := MQTTSettings{Enabled: true, Broker: tt.broker, Brokers: tt.brokers, Topic: "birdnet"}
+			if err := validateMQTTSettings(&settings); (err != nil) != tt.wantErr {
+				t.Errorf("validateMQTTSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMQTTTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	caCert, clientCert, clientKey := generateTestCertificate(t)
+	_, _, otherKey := generateTestCertificate(t)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	caPath := write("ca.pem", caCert)
+	certPath := write("client.pem", clientCert)
+	keyPath := write("client.key", clientKey)
+	otherKeyPath := write("other.key", otherKey)
+	missingPath := filepath.Join(dir, "missing.pem")
+
+	tests := []struct {
+		name    string
+		broker  string
+		tls     MQTTTLSSettings
+		wantTLS bool
+		wantErr bool
+	}{
+		{"plain tcp", "tcp://localhost:1883", MQTTTLSSettings{CACert: missingPath}, false, false},
+		{"ssl auto-enables TLS", "ssl://localhost:8883", MQTTTLSSettings{}, true, false},
+		{"wss auto-enables TLS", "wss://localhost:8884", MQTTTLSSettings{CACert: caPath}, true, false},
+		{"client pair", "tcp://localhost:1883", MQTTTLSSettings{Enabled: true, CACert: caPath, ClientCert: certPath, ClientKey: keyPath}, true, false},
+		{"missing CA file", "ssl://localhost:8883", MQTTTLSSettings{CACert: missingPath}, true, true},
+		{"certificate without key", "ssl://localhost:8883", MQTTTLSSettings{ClientCert: certPath}, true, true},
+		{"key without certificate", "ssl://localhost:8883", MQTTTLSSettings{ClientKey: keyPath}, true, true},
+		{"mismatched pair", "ssl://localhost:8883", MQTTTLSSettings{ClientCert: certPath, ClientKey: otherKeyPath}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := MQTTSettings{Enabled: true, Broker: tt.broker, Topic: "birdnet", TLS: tt.tls}
+			if err := validateMQTTSettings(&settings); (err != nil) != tt.wantErr {
+				t.Errorf("validateMQTTSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if settings.TLS.Enabled != tt.wantTLS {
+				t.Errorf("TLS.Enabled = %v, want %v", settings.TLS.Enabled, tt.wantTLS)
+			}
+		})
+	}
+}
+
+func TestWarnInsecureMQTT(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	mqtt := &MQTTSettings{Enabled: true, TLS: MQTTTLSSettings{Enabled: true, InsecureSkipVerify: true}}
+	if warning := warnInsecureMQTT(mqtt, settings); warning == "" || len(settings.ValidationWarnings) != 1 {
+		t.Errorf("warnInsecureMQTT() = %q with warnings %v, want a warning", warning, settings.ValidationWarnings)
+	}
+
+	mqtt.TLS.Enabled = false
+	if warning := warnInsecureMQTT(mqtt, settings); warning != "" {
+		t.Errorf("warnInsecureMQTT() without TLS = %q, want no warning", warning)
+	}
+}
@@ -0,0 +1,223 @@
+// conf/notification_channel.go typed settings of detection notification channels
+package conf
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Types of NotificationChannel
+const (
+	NotificationChannelWebhook  = "webhook"
+	NotificationChannelTelegram = "telegram"
+	NotificationChannelDiscord  = "discord"
+	NotificationChannelNtfy     = "ntfy"
+)
+
+// NotificationChannel sends notifications of detections to an external
+// service such as a webhook or a Telegram chat
+type NotificationChannel struct {
+	Name        string         `yaml:"name,omitempty"`    // Optional name of the channel shown in logs
+	Type        string         `yaml:"type"`              // Channel type: webhook, telegram, discord or ntfy
+	Enabled     bool           `yaml:"enabled"`           // true to send notifications to the channel
+	Threshold   float64        `yaml:"threshold"`         // Minimum confidence of notified detections between 0 and 1, 0 notifies every detection
+	Species     []string       `yaml:"species,omitempty"` // Common or scientific names of the notified species, empty notifies all species
+	Settings    map[string]any `yaml:"settings"`          // Channel specific settings, use Decode to read them as the NotificationChannelSettings of the type
+	BatchWindow time.Duration  `yaml:"batchwindow"`       // Collects the detections of this window into one digest, 0 sends each detection immediately
+	MaxPerHour  int            `yaml:"maxperhour"`        // Maximum notifications per hour, a digest counts as one, further detections are dropped. 0 is unlimited.
+}
+
+// NotificationChannelSettings is implemented by the typed settings of each
+// notification channel type
+type NotificationChannelSettings interface {
+	Validate() error
+}
+
+// WebhookNotificationSettings defines settings of a webhook channel, the
+// detection is sent as a JSON body
+type WebhookNotificationSettings struct {
+	URL            string            `yaml:"url"`            // HTTP or HTTPS URL the detection is sent to
+	Method         string            `yaml:"method"`         // HTTP method, POST or PUT (default: POST)
+	Headers        map[string]string `yaml:"headers"`        // Additional request headers, e.g. Authorization
+	TimeoutSeconds int               `yaml:"timeoutseconds"` // Request timeout in seconds (default: 10)
+}
+
+// Validate validates webhook settings
+func (s *WebhookNotificationSettings) Validate() error {
+	if err := validateNotificationURL("webhook url", s.URL); err != nil {
+		return err
+	}
+	if s.Method == "" {
+		s.Method = "POST"
+	}
+	s.Method = strings.ToUpper(s.Method)
+	if s.Method != "POST" && s.Method != "PUT" {
+		return fmt.Errorf("webhook method %q is invalid, use POST or PUT", s.Method)
+	}
+	if s.TimeoutSeconds < 0 {
+		return fmt.Errorf("webhook timeout must not be negative, got %d seconds", s.TimeoutSeconds)
+	}
+	if s.TimeoutSeconds == 0 {
+		s.TimeoutSeconds = 10
+	}
+	return nil
+}
+
+// TelegramNotificationSettings defines settings of a Telegram bot channel
+type TelegramNotificationSettings struct {
+	BotToken string `yaml:"bottoken"` // Bot API token from @BotFather (sensitive)
+	ChatID   string `yaml:"chatid"`   // Chat, group or channel ID the bot posts to, e.g. "-1001234567890" or "@birdfeed"
+}
+
+// Validate validates Telegram settings
+func (s *TelegramNotificationSettings) Validate() error {
+	if s.BotToken == "" {
+		return fmt.Errorf("telegram bot token cannot be empty")
+	}
+	if s.ChatID == "" {
+		return fmt.Errorf("telegram chat ID cannot be empty")
+	}
+	return nil
+}
+
+// DiscordNotificationSettings defines settings of a Discord webhook channel
+type DiscordNotificationSettings struct {
+	WebhookURL string `yaml:"webhookurl"` // Webhook URL of the Discord channel (sensitive)
+	Username   string `yaml:"username"`   // Optional name the messages are posted as
+}
+
+// Validate validates Discord settings
+func (s *DiscordNotificationSettings) Validate() error {
+	return validateNotificationURL("discord webhook url", s.WebhookURL)
+}
+
+// NtfyNotificationSettings defines settings of an ntfy topic channel
+type NtfyNotificationSettings struct {
+	Server   string `yaml:"server"`   // ntfy server URL (default: https://ntfy.sh)
+	Topic    string `yaml:"topic"`    // Topic the notifications are published to
+	Token    string `yaml:"token"`    // Optional access token of protected topics (sensitive)
+	Priority int    `yaml:"priority"` // Message priority from 1 (min) to 5 (max), 0 uses the server default
+}
+
+// Validate validates ntfy settings
+func (s *NtfyNotificationSettings) Validate() error {
+	if s.Server == "" {
+		s.Server = "https://ntfy.sh"
+	}
+	if err := validateNotificationURL("ntfy server", s.Server); err != nil {
+		return err
+	}
+	if s.Topic == "" {
+		return fmt.Errorf("ntfy topic cannot be empty")
+	}
+	if s.Priority < 0 || s.Priority > 5 {
+		return fmt.Errorf("ntfy priority must be between 1 and 5, got %d", s.Priority)
+	}
+	return nil
+}
+
+// validateNotificationURL checks that a setting is an absolute HTTP or HTTPS
+// URL
+func validateNotificationURL(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s cannot be empty", name)
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		// The URL is not quoted as it may hold a token, e.g. of a Discord webhook
+		return fmt.Errorf("%s must be an http or https URL", name)
+	}
+	return nil
+}
+
+// Decode returns the settings map of the notification channel as the typed
+// settings of its type, e.g. *TelegramNotificationSettings for "telegram".
+// Settings that are not in the config keep their defaults. The settings are
+// not validated, call Validate on the result.
+func (c NotificationChannel) Decode() (NotificationChannelSettings, error) {
+	var settings NotificationChannelSettings
+	switch strings.ToLower(c.Type) {
+	case NotificationChannelWebhook:
+		settings = &WebhookNotificationSettings{}
+	case NotificationChannelTelegram:
+		settings = &TelegramNotificationSettings{}
+	case NotificationChannelDiscord:
+		settings = &DiscordNotificationSettings{}
+	case NotificationChannelNtfy:
+		settings = &NtfyNotificationSettings{}
+	default:
+		return nil, errors.New(fmt.Errorf("unknown notification channel type %q, use %s, %s, %s or %s", c.Type,
+			NotificationChannelWebhook, NotificationChannelTelegram, NotificationChannelDiscord, NotificationChannelNtfy)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeNotificationType).
+			Build()
+	}
+
+	data, err := yaml.Marshal(c.Settings)
+	if err == nil {
+		err = yaml.Unmarshal(data, settings)
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Errorf("invalid settings of notification channel type %s: %w", c.Type, err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeNotificationSettings).
+			Build()
+	}
+	return settings, nil
+}
+
+// Validate checks the threshold and rate limits of the channel and decodes
+// and validates its type specific settings
+func (c NotificationChannel) Validate() error {
+	if c.Threshold < 0 || c.Threshold > 1 {
+		return errors.New(fmt.Errorf("notification threshold must be between 0 and 1, got %g", c.Threshold)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeNotificationThreshold).
+			Build()
+	}
+	if c.BatchWindow < 0 {
+		return errors.New(fmt.Errorf("notification batch window must not be negative, got %s", c.BatchWindow)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeNotificationBatchWindow).
+			Build()
+	}
+	if c.MaxPerHour < 0 {
+		return errors.New(fmt.Errorf("notification maxperhour must not be negative, got %d", c.MaxPerHour)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeNotificationRateLimit).
+			Build()
+	}
+	settings, err := c.Decode()
+	if err != nil {
+		return err
+	}
+	if err := settings.Validate(); err != nil {
+		return errors.New(err).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeNotificationSettings).
+			Build()
+	}
+	return nil
+}
+
+// Notifies reports whether a detection of a species, given by its common and
+// scientific name, with confidence between 0 and 1 is sent to the channel
+func (c NotificationChannel) Notifies(common, scientific string, confidence float64) bool {
+	if !c.Enabled || confidence < c.Threshold {
+		return false
+	}
+	if len(c.Species) == 0 {
+		return true
+	}
+	for _, entry := range c.Species {
+		if speciesNameMatches(entry, common, scientific) {
+			return true
+		}
+	}
+	return false
+}
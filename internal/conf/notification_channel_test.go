@@ -0,0 +1,128 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotificationChannelDecode(t *testing.T) {
+	t.Parallel()
+
+	settings, err := NotificationChannel{Type: "Telegram", Settings: map[string]any{
+		"bottoken": "123:abc",
+		"chatid":   "-1001234567890",
+	}}.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	telegram, ok := settings.(*TelegramNotificationSettings)
+	if !ok {
+		t.Fatalf("Decode() = %T, want *TelegramNotificationSettings", settings)
+	}
+	if telegram.BotToken != "123:abc" || telegram.ChatID != "-1001234567890" {
+		t.Errorf("Decode() = %+v, want the map values", telegram)
+	}
+
+	if _, err := (NotificationChannel{Type: "pager"}).Decode(); err == nil {
+		t.Error("Decode() of an unknown type error = nil, want an error")
+	}
+	if _, err := (NotificationChannel{Type: "ntfy", Settings: map[string]any{"priority": "urgent"}}).Decode(); err == nil {
+		t.Error("Decode() of an invalid priority error = nil, want an error")
+	}
+}
+
+func TestNotificationChannelValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		channel NotificationChannel
+		wantErr bool
+	}{
+		{"webhook", NotificationChannel{Type: "webhook", Settings: map[string]any{"url": "https://example.com/hook"}}, false},
+		{"webhook without url", NotificationChannel{Type: "webhook"}, true},
+		{"webhook with invalid url", NotificationChannel{Type: "webhook", Settings: map[string]any{"url": "example.com/hook"}}, true},
+		{"webhook with invalid method", NotificationChannel{Type: "webhook", Settings: map[string]any{"url": "https://example.com/hook", "method": "GET"}}, true},
+		{"telegram without chat", NotificationChannel{Type: "telegram", Settings: map[string]any{"bottoken": "123:abc"}}, true},
+		{"discord", NotificationChannel{Type: "discord", Settings: map[string]any{"webhookurl": "https://discord.com/api/webhooks/1/x"}}, false},
+		{"discord without webhook", NotificationChannel{Type: "discord"}, true},
+		{"ntfy", NotificationChannel{Type: "ntfy", Settings: map[string]any{"topic": "birds", "priority": 4}}, false},
+		{"ntfy without topic", NotificationChannel{Type: "ntfy"}, true},
+		{"ntfy priority out of range", NotificationChannel{Type: "ntfy", Settings: map[string]any{"topic": "birds", "priority": 6}}, true},
+		{"threshold out of range", NotificationChannel{Type: "discord", Threshold: 1.5, Settings: map[string]any{"webhookurl": "https://discord.com/api/webhooks/1/x"}}, true},
+		{"negative batch window", NotificationChannel{Type: "discord", BatchWindow: -time.Minute, Settings: map[string]any{"webhookurl": "https://discord.com/api/webhooks/1/x"}}, true},
+		{"negative maxperhour", NotificationChannel{Type: "discord", MaxPerHour: -1, Settings: map[string]any{"webhookurl": "https://discord.com/api/webhooks/1/x"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.channel.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationChannelNotifies(t *testing.T) {
+	t.Parallel()
+
+	channel := NotificationChannel{Enabled: true, Threshold: 0.8, Species: []string{"Snowy Owl", "Tyto alba"}}
+	tests := []struct {
+		name       string
+		common     string
+		scientific string
+		confidence float64
+		want       bool
+	}{
+		{"listed common name", "Snowy Owl", "Bubo scandiacus", 0.9, true},
+		{"listed scientific name", "Barn Owl", "Tyto alba", 0.8, true},
+		{"below threshold", "Snowy Owl", "Bubo scandiacus", 0.7, false},
+		{"not listed", "House Sparrow", "Passer domesticus", 0.95, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := channel.Notifies(tt.common, tt.scientific, tt.confidence); got != tt.want {
+				t.Errorf("Notifies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	all := NotificationChannel{Enabled: true}
+	if !all.Notifies("House Sparrow", "Passer domesticus", 0.1) {
+		t.Error("channel without species filter did not notify")
+	}
+	if (NotificationChannel{}).Notifies("House Sparrow", "Passer domesticus", 1) {
+		t.Error("disabled channel notified")
+	}
+}
+
+func TestLoadNotificationChannels(t *testing.T) {
+	t.Parallel()
+
+	settings, err := LoadFromReader(strings.NewReader(`
+notifications:
+  - type: ntfy
+    enabled: true
+    threshold: 0.9
+    settings:
+      topic: birds
+`))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	if len(settings.Notifications) != 1 || settings.Notifications[0].Threshold != 0.9 {
+		t.Fatalf("Notifications = %+v, want one ntfy channel", settings.Notifications)
+	}
+
+	_, err = LoadFromReader(strings.NewReader(`
+notifications:
+  - type: ntfy
+    enabled: true
+`))
+	if err == nil {
+		t.Error("LoadFromReader() accepted an ntfy channel without topic")
+	}
+}
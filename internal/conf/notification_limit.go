@@ -0,0 +1,91 @@
+// conf/notification_limit.go batching and hourly limits of notification channels
+package conf
+
+import (
+	"sync"
+	"time"
+)
+
+// NotificationAction tells what to do with a detection notified to a channel
+type NotificationAction int
+
+const (
+	NotificationSend  NotificationAction = iota // send the notification now
+	NotificationBatch                           // add the detection to the pending digest
+	NotificationDrop                            // drop the detection, the hourly limit is reached
+)
+
+// String returns the name of the action
+func (a NotificationAction) String() string {
+	switch a {
+	case NotificationSend:
+		return "send"
+	case NotificationBatch:
+		return "batch"
+	case NotificationDrop:
+		return "drop"
+	default:
+		return "unknown"
+	}
+}
+
+// NotificationLimiter applies the batch window and hourly limit of a
+// notification channel. Each message, a single notification or a digest,
+// counts once against the hourly limit, which resets an hour after the first
+// message of the hour. It is safe for concurrent use.
+type NotificationLimiter struct {
+	mu          sync.Mutex
+	batchWindow time.Duration
+	maxPerHour  int
+	hourStart   time.Time // start of the current hourly limit window
+	sent        int       // messages sent or reserved for the digest in the current hour
+	batchStart  time.Time // start of the pending digest, zero when there is none
+}
+
+// NewLimiter returns a limiter applying the batch window and hourly limit of
+// the channel
+func (c NotificationChannel) NewLimiter() *NotificationLimiter {
+	return &NotificationLimiter{batchWindow: c.BatchWindow, maxPerHour: c.MaxPerHour}
+}
+
+// Decide returns what to do with a detection notified at now. Detections of
+// an open batch window join its digest. Otherwise the detection is dropped
+// when the hourly limit is reached, and it is sent or starts a new digest,
+// which is due once the window has passed, see FlushDue.
+func (l *NotificationLimiter) Decide(now time.Time) NotificationAction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.batchStart.IsZero() && now.Before(l.batchStart.Add(l.batchWindow)) {
+		return NotificationBatch
+	}
+	if l.maxPerHour > 0 {
+		if l.hourStart.IsZero() || !now.Before(l.hourStart.Add(time.Hour)) {
+			l.hourStart, l.sent = now, 0
+		}
+		if l.sent >= l.maxPerHour {
+			return NotificationDrop
+		}
+	}
+	// The message is counted when it is decided, a digest reserves its send
+	l.sent++
+	if l.batchWindow <= 0 {
+		return NotificationSend
+	}
+	l.batchStart = now
+	return NotificationBatch
+}
+
+// FlushDue reports whether the pending digest is due at now and should be
+// sent. The digest is cleared when it is due, so that the next detection
+// starts a new one.
+func (l *NotificationLimiter) FlushDue(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.batchStart.IsZero() || now.Before(l.batchStart.Add(l.batchWindow)) {
+		return false
+	}
+	l.batchStart = time.Time{}
+	return true
+}
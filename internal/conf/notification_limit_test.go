@@ -0,0 +1,101 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationLimiterImmediate(t *testing.T) {
+	t.Parallel()
+
+	limiter := NotificationChannel{}.NewLimiter()
+	now := time.Date(2025, 5, 1, 5, 0, 0, 0, time.UTC)
+	for i := range 100 {
+		if got := limiter.Decide(now.Add(time.Duration(i) * time.Second)); got != NotificationSend {
+			t.Fatalf("Decide() #%d = %s, want send", i, got)
+		}
+	}
+	if limiter.FlushDue(now.Add(time.Hour)) {
+		t.Error("FlushDue() = true without a batch window")
+	}
+}
+
+func TestNotificationLimiterBatchBoundary(t *testing.T) {
+	t.Parallel()
+
+	limiter := NotificationChannel{BatchWindow: 5 * time.Minute}.NewLimiter()
+	start := time.Date(2025, 5, 1, 5, 0, 0, 0, time.UTC)
+
+	if got := limiter.Decide(start); got != NotificationBatch {
+		t.Fatalf("Decide() opening the batch = %s, want batch", got)
+	}
+	if got := limiter.Decide(start.Add(5*time.Minute - time.Nanosecond)); got != NotificationBatch {
+		t.Errorf("Decide() just before the window ends = %s, want batch", got)
+	}
+	if limiter.FlushDue(start.Add(5*time.Minute - time.Nanosecond)) {
+		t.Error("FlushDue() just before the window ends = true, want false")
+	}
+	if !limiter.FlushDue(start.Add(5 * time.Minute)) {
+		t.Error("FlushDue() when the window ends = false, want true")
+	}
+	if limiter.FlushDue(start.Add(5 * time.Minute)) {
+		t.Error("FlushDue() after flushing = true, want false")
+	}
+	if got := limiter.Decide(start.Add(5 * time.Minute)); got != NotificationBatch {
+		t.Errorf("Decide() after the flush = %s, want a new batch", got)
+	}
+	if !limiter.FlushDue(start.Add(10 * time.Minute)) {
+		t.Error("FlushDue() of the second batch = false, want true")
+	}
+}
+
+func TestNotificationLimiterHourlyCapReset(t *testing.T) {
+	t.Parallel()
+
+	limiter := NotificationChannel{MaxPerHour: 3}.NewLimiter()
+	start := time.Date(2025, 5, 1, 5, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		if got := limiter.Decide(start.Add(time.Duration(i) * time.Minute)); got != NotificationSend {
+			t.Fatalf("Decide() #%d = %s, want send", i, got)
+		}
+	}
+	if got := limiter.Decide(start.Add(59 * time.Minute)); got != NotificationDrop {
+		t.Errorf("Decide() over the cap = %s, want drop", got)
+	}
+	if got := limiter.Decide(start.Add(time.Hour - time.Nanosecond)); got != NotificationDrop {
+		t.Errorf("Decide() just before the hour ends = %s, want drop", got)
+	}
+	if got := limiter.Decide(start.Add(time.Hour)); got != NotificationSend {
+		t.Errorf("Decide() after the hour = %s, want send", got)
+	}
+	if got := limiter.Decide(start.Add(time.Hour + time.Minute)); got != NotificationSend {
+		t.Errorf("Decide() in the new hour = %s, want send", got)
+	}
+}
+
+func TestNotificationLimiterBatchAndCap(t *testing.T) {
+	t.Parallel()
+
+	limiter := NotificationChannel{BatchWindow: 10 * time.Minute, MaxPerHour: 2}.NewLimiter()
+	start := time.Date(2025, 5, 1, 5, 0, 0, 0, time.UTC)
+
+	// Each digest counts once, detections joining a digest are not capped
+	for i, offset := range []time.Duration{0, 20 * time.Minute} {
+		batchStart := start.Add(offset)
+		for j := range 50 {
+			if got := limiter.Decide(batchStart.Add(time.Duration(j) * time.Second)); got != NotificationBatch {
+				t.Fatalf("Decide() of digest %d detection %d = %s, want batch", i, j, got)
+			}
+		}
+		if !limiter.FlushDue(batchStart.Add(10 * time.Minute)) {
+			t.Fatalf("FlushDue() of digest %d = false, want true", i)
+		}
+	}
+	if got := limiter.Decide(start.Add(40 * time.Minute)); got != NotificationDrop {
+		t.Errorf("Decide() of a third digest in the hour = %s, want drop", got)
+	}
+	if got := limiter.Decide(start.Add(time.Hour)); got != NotificationBatch {
+		t.Errorf("Decide() after the hour = %s, want batch", got)
+	}
+}
@@ -0,0 +1,59 @@
+// conf/output.go output types of file and directory analysis results
+package conf
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+)
+
+// Output.File.Type values
+const (
+	OutputTypeTable = "table"
+	OutputTypeCSV   = "csv"
+	OutputTypeJSON  = "json"
+)
+
+// outputFileTypes lists the accepted Output.File.Type values
+var outputFileTypes = []string{OutputTypeTable, OutputTypeCSV, OutputTypeJSON}
+
+// resolveOutputFileType returns the lowercase output type, table when it is
+// empty or unknown, and whether the type is known
+func resolveOutputFileType(value string) (resolved string, known bool) {
+	resolved = strings.ToLower(strings.TrimSpace(value))
+	if resolved == "" {
+		return OutputTypeTable, true
+	}
+	if !slices.Contains(outputFileTypes, resolved) {
+		return OutputTypeTable, false
+	}
+	return resolved, true
+}
+
+// OutputFileType returns the writer type of analysis results, table, csv or
+// json. Output.File.Type is matched case-insensitively, empty and unknown
+// types resolve to table. Command line flags set the type after the settings
+// are validated, so unknown types are logged here as well.
+func (s *Settings) OutputFileType() string {
+	resolved, known := resolveOutputFileType(s.Output.File.Type)
+	if !known {
+		log.Printf("Configuration warning: output type %q is unknown, writing a %s", s.Output.File.Type, resolved)
+	}
+	return resolved
+}
+
+// validateOutputFileType normalizes Output.File.Type, unknown types fall back
+// to table with a warning
+func validateOutputFileType(settings *Settings) {
+	resolved, known := resolveOutputFileType(settings.Output.File.Type)
+	if !known {
+		message := fmt.Sprintf("output type %q is not one of %s, using %q",
+			settings.Output.File.Type, strings.Join(outputFileTypes, ", "), resolved)
+		log.Printf("Configuration warning: %s", message)
+		logValidationWarning(fmt.Errorf("%s", message), ErrCodeOutputFileType, "output-type-unknown")
+		settings.ValidationWarnings = append(settings.ValidationWarnings,
+			fmt.Sprintf("config-output-validation: %s", message))
+	}
+	settings.Output.File.Type = resolved
+}
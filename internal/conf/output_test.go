@@ -0,0 +1,52 @@
+package conf
+
+import (
+	"testing"
+)
+
+func TestOutputFileType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", OutputTypeTable},
+		{"table", OutputTypeTable},
+		{"CSV", OutputTypeCSV},
+		{" Csv ", OutputTypeCSV},
+		{"json", OutputTypeJSON},
+		{"JSON", OutputTypeJSON},
+		{"xml", OutputTypeTable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.Output.File.Type = tt.value
+			if got := settings.OutputFileType(); got != tt.want {
+				t.Errorf("OutputFileType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOutputFileType(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Output.File.Type = "JSON"
+	validateOutputFileType(settings)
+	if settings.Output.File.Type != OutputTypeJSON || len(settings.ValidationWarnings) != 0 {
+		t.Errorf("Type = %q, warnings %v, want json without warnings", settings.Output.File.Type, settings.ValidationWarnings)
+	}
+
+	settings.Output.File.Type = "xml"
+	validateOutputFileType(settings)
+	if settings.Output.File.Type != OutputTypeTable {
+		t.Errorf("Type = %q, want table for an unknown type", settings.Output.File.Type)
+	}
+	if len(settings.ValidationWarnings) != 1 {
+		t.Errorf("ValidationWarnings = %v, want one warning for an unknown type", settings.ValidationWarnings)
+	}
+}
@@ -0,0 +1,129 @@
+// conf/overrides.go build or provisioning time overrides of default settings
+package conf
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	defaultOverrides      map[string]any // config key to default value
+	defaultOverridesMutex sync.RWMutex
+)
+
+// SetDefaultOverride replaces the default value of the setting at a config
+// key such as "output.mysql.enabled". It must be called before Load, e.g. by
+// a provisioned binary that should default to MySQL without shipping a config
+// file. Overrides are written to the config file created on first run and are
+// used by ResetToDefaults.
+//
+// A value is taken from, in order of precedence: the config file, the
+// override, the embedded default config.yaml and the built-in defaults.
+// Settings are not read from environment variables.
+func SetDefaultOverride(path string, value any) {
+	defaultOverridesMutex.Lock()
+	defer defaultOverridesMutex.Unlock()
+
+	if defaultOverrides == nil {
+		defaultOverrides = make(map[string]any)
+	}
+	defaultOverrides[strings.ToLower(path)] = value
+}
+
+// applyDefaultOverrides sets the default overrides as defaults of v
+func applyDefaultOverrides(v *viper.Viper) {
+	defaultOverridesMutex.RLock()
+	defer defaultOverridesMutex.RUnlock()
+
+	for path, value := range defaultOverrides {
+		v.SetDefault(path, value)
+	}
+}
+
+// generateDefaultConfig returns the embedded default config.yaml with the
+// default overrides applied. Without overrides the embedded file is returned
+// unchanged, otherwise it is re-encoded keeping its comments.
+func generateDefaultConfig() (string, error) {
+	defaultConfig := getDefaultConfig()
+
+	defaultOverridesMutex.RLock()
+	defer defaultOverridesMutex.RUnlock()
+	if len(defaultOverrides) == 0 {
+		return defaultConfig, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(defaultConfig), &doc); err != nil {
+		return "", errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "parse-default-config").
+			Build()
+	}
+
+	for path, value := range defaultOverrides {
+		if err := setYAMLPath(doc.Content[0], strings.Split(path, "."), value); err != nil {
+			return "", errors.New(err).
+				Category(errors.CategoryConfiguration).
+				Context("operation", "apply-default-override").
+				Context("setting", path).
+				Build()
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	err := encoder.Encode(&doc)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		return "", errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "encode-default-config").
+			Build()
+	}
+	return buf.String(), nil
+}
+
+// setYAMLPath sets the value at a key path of a YAML mapping node, creating
+// missing mappings on the way
+func setYAMLPath(mapping *yaml.Node, keys []string, value any) error {
+	if mapping.Kind != yaml.MappingNode {
+		return errors.Newf("%q is not a section", keys[0]).
+			Component("conf").
+			Category(errors.CategoryConfiguration).
+			Build()
+	}
+
+	var valueNode *yaml.Node
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, keys[0]) {
+			valueNode = mapping.Content[i+1]
+			break
+		}
+	}
+
+	if valueNode == nil {
+		valueNode = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[0]}, valueNode)
+	}
+
+	if len(keys) > 1 {
+		return setYAMLPath(valueNode, keys[1:], value)
+	}
+
+	// Keep the line comment documenting the setting
+	lineComment := valueNode.LineComment
+	if err := valueNode.Encode(value); err != nil {
+		return err
+	}
+	valueNode.LineComment = lineComment
+	return nil
+}
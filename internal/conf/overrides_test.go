@@ -0,0 +1,72 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSetDefaultOverride changes the global default overrides and cannot run in parallel
+func TestSetDefaultOverride(t *testing.T) {
+	t.Cleanup(func() {
+		defaultOverridesMutex.Lock()
+		defaultOverrides = nil
+		defaultOverridesMutex.Unlock()
+	})
+
+	SetDefaultOverride("output.mysql.enabled", true)
+	SetDefaultOverride("Output.SQLite.Enabled", false)
+	SetDefaultOverride("main.name", "fleet-node")
+
+	generated, err := generateDefaultConfig()
+	if err != nil {
+		t.Fatalf("generateDefaultConfig() error = %v", err)
+	}
+	if !strings.Contains(generated, "# true to enable mysql output") {
+		t.Error("generated default config lost the comments of the embedded config")
+	}
+
+	// Settings read from the generated config file use the overrides
+	settings, err := LoadFromReader(strings.NewReader(generated))
+	if err != nil {
+		t.Fatalf("LoadFromReader(generated) error = %v", err)
+	}
+	if !settings.Output.MySQL.Enabled || settings.Output.SQLite.Enabled {
+		t.Errorf("generated config has mysql %v and sqlite %v, want mysql only",
+			settings.Output.MySQL.Enabled, settings.Output.SQLite.Enabled)
+	}
+	if settings.Main.Name != "fleet-node" {
+		t.Errorf("generated config Main.Name = %q, want fleet-node", settings.Main.Name)
+	}
+
+	// The config file takes precedence over overrides
+	settings, err = LoadFromReader(strings.NewReader("main:\n  name: from-file\n"))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	if settings.Main.Name != "from-file" {
+		t.Errorf("Main.Name = %q, want from-file", settings.Main.Name)
+	}
+	if !settings.Output.MySQL.Enabled {
+		t.Error("Output.MySQL.Enabled = false, want override true for a key missing from the file")
+	}
+
+	defaults, err := loadDefaultSettings()
+	if err != nil {
+		t.Fatalf("loadDefaultSettings() error = %v", err)
+	}
+	if defaults.Main.Name != "fleet-node" {
+		t.Errorf("default Main.Name = %q, want fleet-node", defaults.Main.Name)
+	}
+}
+
+func TestGenerateDefaultConfigWithoutOverrides(t *testing.T) {
+	t.Parallel()
+
+	generated, err := generateDefaultConfig()
+	if err != nil {
+		t.Fatalf("generateDefaultConfig() error = %v", err)
+	}
+	if generated != getDefaultConfig() {
+		t.Error("generateDefaultConfig() without overrides differs from the embedded config")
+	}
+}
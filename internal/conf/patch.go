@@ -0,0 +1,121 @@
+// conf/patch.go partial settings updates from dotted-key or nested-map patches
+package conf
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// ApplyPatch merges a partial update into the settings and returns the config
+// keys of the settings it changed. Patch keys are config keys, either dotted
+// such as "birdnet.threshold" or nested maps such as {"birdnet": {"threshold":
+// 0.8}}, and values are decoded like config file values, e.g. "30d" for
+// durations. Lists and maps are replaced as a whole. The patched settings are
+// validated before they replace the current ones, an unknown key or a failed
+// validation leaves the settings unchanged. The settings are not saved.
+func (s *Settings) ApplyPatch(patch map[string]any) ([]string, error) {
+	return s.applyPatchFrom(patch, ChangeSourcePatch)
+}
+
+// applyPatchFrom applies a patch like ApplyPatch and records the change with
+// source
+func (s *Settings) applyPatchFrom(patch map[string]any, source string) ([]string, error) {
+	settingsMutex.Lock()
+	defer settingsMutex.Unlock()
+
+	candidate := *s
+	candidate.ValidationWarnings = nil
+
+	var changed []string
+	if err := applyPatch(reflect.ValueOf(&candidate).Elem(), "", patch, &changed); err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	if err := ValidateSettings(&candidate); err != nil {
+		if err := checkValidationResult(err); err != nil {
+			return nil, err
+		}
+	}
+
+	configChanges.record(source, Diff(s, &candidate))
+	*s = candidate
+	slices.Sort(changed)
+	return slices.Compact(changed), nil
+}
+
+// applyPatch applies the entries of patch to the fields of a struct value,
+// prefix is the config key of the struct
+func applyPatch(value reflect.Value, prefix string, patch map[string]any, changed *[]string) error {
+	for key, patchValue := range patch {
+		path := strings.ToLower(key)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		field, err := lookupSection(value, strings.ToLower(key))
+		if err != nil {
+			return errors.Newf("unknown setting %q", path).
+				Component("conf").
+				Category(errors.CategoryValidation).
+				Context("operation", "apply-patch").
+				Context("setting", path).
+				Build()
+		}
+
+		// Nested maps patch the fields of struct settings
+		if nested, ok := patchValue.(map[string]any); ok && field.Kind() == reflect.Struct && field.Type() != timeType {
+			if err := applyPatch(field, path, nested, changed); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.Kind() == reflect.Struct && field.Type() != timeType {
+			return errors.Newf("setting %q is a section, patch its fields with an object", path).
+				Component("conf").
+				Category(errors.CategoryValidation).
+				Context("operation", "apply-patch").
+				Context("setting", path).
+				Build()
+		}
+
+		updated, err := decodePatchValue(field.Type(), patchValue)
+		if err != nil {
+			return errors.New(fmt.Errorf("invalid value for setting %q: %w", path, err)).
+				Component("conf").
+				Category(errors.CategoryValidation).
+				Context("operation", "apply-patch").
+				Context("setting", path).
+				Build()
+		}
+		if !reflect.DeepEqual(field.Interface(), updated.Interface()) {
+			field.Set(updated)
+			*changed = append(*changed, path)
+		}
+	}
+	return nil
+}
+
+// decodePatchValue decodes a patch value into a new value of type t
+func decodePatchValue(t reflect.Type, data any) (reflect.Value, error) {
+	result := reflect.New(t)
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  settingsDecodeHook(),
+		ErrorUnused: true,
+		Result:      result.Interface(),
+	})
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := decoder.Decode(data); err != nil {
+		return reflect.Value{}, err
+	}
+	return result.Elem(), nil
+}
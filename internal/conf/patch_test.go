@@ -0,0 +1,131 @@
+package conf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newPatchTestSettings returns valid default settings for ApplyPatch tests
+func newPatchTestSettings(t *testing.T) *Settings {
+	t.Helper()
+
+	settings, err := LoadFromReader(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	return settings
+}
+
+func TestApplyPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		patch       map[string]any
+		wantChanged []string
+		check       func(t *testing.T, s *Settings)
+	}{
+		{
+			name:        "dotted keys",
+			patch:       map[string]any{"birdnet.threshold": 0.5, "main.name": "patched"},
+			wantChanged: []string{"birdnet.threshold", "main.name"},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.BirdNET.Threshold != 0.5 || s.Main.Name != "patched" {
+					t.Errorf("Threshold = %v, Name = %q", s.BirdNET.Threshold, s.Main.Name)
+				}
+			},
+		},
+		{
+			name:        "nested maps",
+			patch:       map[string]any{"BirdNET": map[string]any{"RangeFilter": map[string]any{"threshold": 0.02}}},
+			wantChanged: []string{"birdnet.rangefilter.threshold"},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.BirdNET.RangeFilter.Threshold != 0.02 {
+					t.Errorf("RangeFilter.Threshold = %v, want 0.02", s.BirdNET.RangeFilter.Threshold)
+				}
+			},
+		},
+		{
+			name:        "json numbers and durations",
+			patch:       map[string]any{"realtime.interval": float64(30), "security.sessionduration": "30d"},
+			wantChanged: []string{"realtime.interval", "security.sessionduration"},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.Realtime.Interval != 30 || s.Security.SessionDuration != 30*24*time.Hour {
+					t.Errorf("Interval = %v, SessionDuration = %v", s.Realtime.Interval, s.Security.SessionDuration)
+				}
+			},
+		},
+		{
+			name:        "lists are replaced",
+			patch:       map[string]any{"realtime.species.include": []any{"Great Tit"}},
+			wantChanged: []string{"realtime.species.include"},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if !reflect.DeepEqual(s.Realtime.Species.Include, []string{"Great Tit"}) {
+					t.Errorf("Include = %v", s.Realtime.Species.Include)
+				}
+			},
+		},
+		{
+			name:        "unchanged values",
+			patch:       map[string]any{"main.name": "BirdNET-Go"},
+			wantChanged: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := newPatchTestSettings(t)
+			changed, err := settings.ApplyPatch(tt.patch)
+			if err != nil {
+				t.Fatalf("ApplyPatch() error = %v", err)
+			}
+			if !reflect.DeepEqual(changed, tt.wantChanged) {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if tt.check != nil {
+				tt.check(t, settings)
+			}
+		})
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		patch   map[string]any
+		wantErr string
+	}{
+		{"unknown key", map[string]any{"birdnet.treshold": 0.5}, `unknown setting "birdnet.treshold"`},
+		{"unknown nested key", map[string]any{"birdnet": map[string]any{"bogus": 1}}, `unknown setting "birdnet.bogus"`},
+		{"runtime value", map[string]any{"version": "1.0"}, `unknown setting "version"`},
+		{"section without object", map[string]any{"birdnet": 1}, "is a section"},
+		{"wrong type", map[string]any{"birdnet.threshold": "high"}, `invalid value for setting "birdnet.threshold"`},
+		{"failed validation", map[string]any{"birdnet.threshold": 2, "main.name": "patched"}, "threshold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := newPatchTestSettings(t)
+			before := *settings
+			_, err := settings.ApplyPatch(tt.patch)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ApplyPatch() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(*settings, before) {
+				t.Error("failed patch modified the settings")
+			}
+		})
+	}
+}
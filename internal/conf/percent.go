@@ -0,0 +1,54 @@
+// conf/percent.go percentage values with an optional percent sign
+package conf
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// ParsePercentage converts a percentage string such as "80" or "80%" to a
+// float64, the percent sign is optional
+func ParsePercentage(percentage string) (float64, error) {
+	input := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(percentage), "%"))
+	value, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return 0, errors.Newf("invalid percentage %q, expected a number such as \"80\" or \"80%%\"", percentage).
+			Component("conf").
+			Category(errors.CategoryValidation).
+			Context("input", percentage).
+			Build()
+	}
+	return value, nil
+}
+
+// ValidatePercentRange returns an error when the percentage v of the config
+// key field is not between 0 and 100
+func ValidatePercentRange(v float64, field string) error {
+	if v >= 0 && v <= 100 {
+		return nil
+	}
+	return errors.New(fmt.Errorf("%s must be between 0%% and 100%%, got %g%%", field, v)).
+		Category(errors.CategoryValidation).
+		Context("validation_type", ErrCodePercentRange).
+		Context("field", field).
+		Context("value", v).
+		Build()
+}
+
+// percentageHook is a mapstructure decode hook parsing strings with a percent
+// sign into float64 fields, so that config files can write thresholds such as
+// "80%"
+func percentageHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Float64 {
+		return data, nil
+	}
+	s := reflect.ValueOf(data).String()
+	if !strings.HasSuffix(strings.TrimSpace(s), "%") {
+		return data, nil
+	}
+	return ParsePercentage(s)
+}
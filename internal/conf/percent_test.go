@@ -0,0 +1,77 @@
+package conf
+
+import (
+	"testing"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+func TestParsePercentage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"80", 80, false},
+		{"80%", 80, false},
+		{" 12.5 % ", 12.5, false},
+		{"0%", 0, false},
+		{"150%", 150, false},
+		{"", 0, true},
+		{"%", 0, true},
+		{"eighty%", 0, true},
+		{"80%%", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePercentage(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePercentage(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePercentage(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePercentRange(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []float64{0, 5, 100} {
+		if err := ValidatePercentRange(v, "realtime.monitoring.cpu.warning"); err != nil {
+			t.Errorf("ValidatePercentRange(%v) error = %v, want nil", v, err)
+		}
+	}
+	for _, v := range []float64{-1, 100.5} {
+		if err := ValidatePercentRange(v, "realtime.monitoring.cpu.warning"); err == nil {
+			t.Errorf("ValidatePercentRange(%v) error = nil, want an error", v)
+		}
+	}
+}
+
+func TestPercentageDecodeHook(t *testing.T) {
+	t.Parallel()
+
+	var monitoring MonitoringSettings
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       settingsDecodeHook(),
+		WeaklyTypedInput: true,
+		Result:           &monitoring,
+	})
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	input := map[string]any{
+		"hysteresispercent": "7.5%",
+		"cpu":               map[string]any{"warning": "80%", "critical": 95},
+		"memory":            map[string]any{"warning": "70"},
+	}
+	if err := decoder.Decode(input); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if monitoring.HysteresisPercent != 7.5 || monitoring.CPU.Warning != 80 || monitoring.CPU.Critical != 95 || monitoring.Memory.Warning != 70 {
+		t.Errorf("decoded monitoring = %+v, want percentages parsed", monitoring)
+	}
+}
@@ -0,0 +1,115 @@
+// conf/public_view.go secret-free settings view for templates and the JSON API
+package conf
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// privateSettings lists config keys left out of PublicView with everything
+// below them, in addition to the credentials marked sensitive. They identify
+// or give access to other systems.
+var privateSettings = []string{
+	"security",                  // authentication providers, hosts and subnets
+	"output.mysql",              // database host and credentials
+	"backup",                    // backup targets hold credentials
+	"notifications",             // channel URLs embed tokens
+	"realtime.mqtt.broker",      // broker URLs may embed credentials
+	"realtime.mqtt.brokers",     // broker URLs may embed credentials
+	"realtime.mqtt.username",    // broker account
+	"realtime.mqtt.tls",         // certificate and key paths
+	"realtime.rtsp.urls",        // stream URLs often embed credentials
+	"realtime.telemetry.labels", // node identity
+}
+
+// publicRuntimeSettings lists the runtime values, not stored in the config
+// file, included in PublicView
+var publicRuntimeSettings = []string{"version", "builddate"}
+
+// PublicSettings is a copy of the settings safe to render in pages and
+// return from the JSON API, nested maps keyed by the lowercase config names
+// such as settings["realtime"]["audio"]["export"]["enabled"]. Changing it
+// does not change the settings.
+type PublicSettings map[string]any
+
+// PublicView returns the settings without credentials and the private
+// settings that identify or give access to other systems. It is built with
+// reflection so new settings are included unless they are sensitive or below
+// a private key. Durations are formatted as strings.
+func (s *Settings) PublicView() PublicSettings {
+	sensitive := sensitiveKeys()
+	view := publicStruct(reflect.ValueOf(s).Elem(), "", sensitive)
+	return PublicSettings(view)
+}
+
+// Get returns the value of a dotted config key such as "birdnet.locale",
+// false when the key is not in the view
+func (p PublicSettings) Get(key string) (any, bool) {
+	var value any = map[string]any(p)
+	for name := range strings.SplitSeq(strings.ToLower(key), ".") {
+		section, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = section[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// isPrivateSetting reports whether a config key is a private setting or
+// below one
+func isPrivateSetting(key string) bool {
+	for _, private := range privateSettings {
+		if key == private || strings.HasPrefix(key, private+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// publicStruct returns the public fields of a struct value as a map, key is
+// the config key of the struct. Empty sections are left out.
+func publicStruct(value reflect.Value, key string, sensitive map[string]bool) map[string]any {
+	view := make(map[string]any)
+	t := value.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		configName := strings.ToLower(field.Name)
+		yamlTag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if yamlTag == "-" {
+			if key != "" || !slices.Contains(publicRuntimeSettings, configName) {
+				continue
+			}
+		} else if yamlTag != "" {
+			configName = yamlTag
+		}
+		fieldKey := configName
+		if key != "" {
+			fieldKey = key + "." + configName
+		}
+		if sensitive[fieldKey] || isPrivateSetting(fieldKey) {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		switch {
+		case fieldValue.Type() == durationType:
+			view[configName] = time.Duration(fieldValue.Int()).String()
+		case fieldValue.Kind() == reflect.Struct && fieldValue.Type() != timeType:
+			if section := publicStruct(fieldValue, fieldKey, sensitive); len(section) > 0 {
+				view[configName] = section
+			}
+		default:
+			view[configName] = cloneValue(fieldValue).Interface()
+		}
+	}
+	return view
+}
@@ -0,0 +1,96 @@
+package conf
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublicViewLeavesOutSecrets(t *testing.T) {
+	t.Parallel()
+
+	settings := newPatchTestSettings(t)
+	const marker = "public-view-secret-marker"
+	settings.Backup.Targets = []BackupTarget{{Type: "s3", Settings: map[string]any{"bucket": "b"}}}
+	settings.Notifications = []NotificationChannel{{Type: "ntfy", Settings: map[string]any{"topic": "t"}}}
+	for _, field := range secretFields(settings) {
+		field.set(marker)
+	}
+	settings.Security.SessionSecret = marker
+	settings.Realtime.Birdweather.ID = marker
+	settings.Backup.EncryptionKey = marker
+	settings.Realtime.MQTT.Broker = "tcp://user:" + marker + "@broker:1883"
+	settings.Realtime.RTSP.URLs = []string{"rtsp://user:" + marker + "@camera/stream"}
+	settings.Output.MySQL.Username = marker
+
+	data, err := json.Marshal(settings.PublicView())
+	if err != nil {
+		t.Fatalf("json.Marshal(PublicView()) error = %v", err)
+	}
+	if strings.Contains(string(data), marker) {
+		t.Errorf("PublicView() contains a secret: %s", data)
+	}
+}
+
+func TestPublicViewValues(t *testing.T) {
+	t.Parallel()
+
+	settings := newPatchTestSettings(t)
+	settings.Version = "1.2.3"
+	settings.SystemID = "system-id"
+	settings.BirdNET.Locale = "fi"
+	settings.Realtime.Audio.Export.Enabled = true
+	settings.Realtime.DedupWindow = 90 * time.Second
+	view := settings.PublicView()
+
+	tests := []struct {
+		key  string
+		want any
+	}{
+		{"version", "1.2.3"},
+		{"birdnet.locale", "fi"},
+		{"Realtime.Audio.Export.Enabled", true},
+		{"realtime.dedupwindow", "1m30s"},
+	}
+	for _, tt := range tests {
+		if got, ok := view.Get(tt.key); !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Get(%q) = %v, %v, want %v", tt.key, got, ok, tt.want)
+		}
+	}
+
+	for _, key := range []string{"systemid", "security", "output.mysql", "realtime.mqtt.password", "birdnet.locale.missing", "missing"} {
+		if got, ok := view.Get(key); ok {
+			t.Errorf("Get(%q) = %v, want missing", key, got)
+		}
+	}
+}
+
+func TestPublicViewIsACopy(t *testing.T) {
+	t.Parallel()
+
+	settings := newPatchTestSettings(t)
+	settings.Realtime.Species.Include = []string{"Parus major"}
+	view := settings.PublicView()
+
+	include, _ := view.Get("realtime.species.include")
+	include.([]string)[0] = "changed"
+	view["birdnet"].(map[string]any)["locale"] = "changed"
+
+	if settings.Realtime.Species.Include[0] != "Parus major" || settings.BirdNET.Locale == "changed" {
+		t.Error("changing PublicView() changed the settings")
+	}
+}
+
+func TestPrivateSettingsAreKnownKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := make(map[string]bool)
+	collectConfigKeys(reflect.TypeOf(Settings{}), "", keys)
+	for _, key := range privateSettings {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("private setting %q is not a config key", key)
+		}
+	}
+}
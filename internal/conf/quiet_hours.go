@@ -0,0 +1,127 @@
+// conf/quiet_hours.go daily schedule suppressing detection side effects
+package conf
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Side effects suppressed during quiet hours
+const (
+	QuietHoursActions       = "actions"       // per-species command actions
+	QuietHoursNotifications = "notifications" // detection notifications
+	QuietHoursMQTT          = "mqtt"          // MQTT detection messages
+)
+
+// quietHoursTimeLayout is the HH:MM format of Start and End
+const quietHoursTimeLayout = "15:04"
+
+// InQuietHours reports whether t, in the configured time zone, is within the
+// quiet hours. A window ending before it starts crosses midnight, and its times
+// after midnight belong to the weekday the window started on. A window
+// starting and ending at the same time lasts the whole day.
+func (s *Settings) InQuietHours(t time.Time) bool {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	return s.Realtime.QuietHours.contains(t.In(s.Location()))
+}
+
+// QuietHoursSuppress reports whether a side effect, one of the QuietHours
+// constants, is suppressed at t. With no Suppress list every side effect is
+// suppressed during quiet hours.
+func (s *Settings) QuietHoursSuppress(effect string, t time.Time) bool {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	q := &s.Realtime.QuietHours
+	if len(q.Suppress) > 0 && !slices.Contains(q.Suppress, effect) {
+		return false
+	}
+	return q.contains(t.In(s.Location()))
+}
+
+// contains reports whether t, in its own location, is within the quiet hours,
+// see InQuietHours
+func (q *QuietHoursSettings) contains(t time.Time) bool {
+	if !q.Enabled {
+		return false
+	}
+	start, errStart := time.Parse(quietHoursTimeLayout, q.Start)
+	end, errEnd := time.Parse(quietHoursTimeLayout, q.End)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+
+	switch {
+	case startMinute == endMinute:
+	case startMinute < endMinute:
+		if minute < startMinute || minute >= endMinute {
+			return false
+		}
+	case minute >= startMinute:
+	case minute < endMinute:
+		// After midnight, the window started the day before
+		day = (day + 6) % 7
+	default:
+		return false
+	}
+	return q.onWeekday(day)
+}
+
+// onWeekday reports whether the quiet hours apply to windows starting on day,
+// every day when no weekdays are configured
+func (q *QuietHoursSettings) onWeekday(day time.Weekday) bool {
+	if len(q.Weekdays) == 0 {
+		return true
+	}
+	for _, name := range q.Weekdays {
+		if weekday, err := ParseWeekday(name); err == nil && weekday == day {
+			return true
+		}
+	}
+	return false
+}
+
+// validateQuietHours checks the start and end times, weekdays and suppressed
+// side effects of enabled quiet hours
+func validateQuietHours(settings *QuietHoursSettings) error {
+	if !settings.Enabled {
+		return nil
+	}
+	for _, value := range []string{settings.Start, settings.End} {
+		if _, err := time.Parse(quietHoursTimeLayout, value); err != nil {
+			return errors.New(fmt.Errorf("quiet hours start and end must be times in HH:MM format, got %q", value)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeQuietHoursTime).
+				Build()
+		}
+	}
+	for _, day := range settings.Weekdays {
+		if _, err := ParseWeekday(day); err != nil {
+			return errors.New(fmt.Errorf("quiet hours has an invalid weekday: %w", err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeQuietHoursWeekday).
+				Context("weekday", day).
+				Build()
+		}
+	}
+	c := constraintFor("realtime.quiethours.suppress")
+	for _, effect := range settings.Suppress {
+		if !c.allows(effect) {
+			return errors.New(fmt.Errorf("quiet hours suppress must be %s, got %q", c, effect)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeQuietHoursSuppress).
+				Build()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInQuietHours(t *testing.T) {
+	t.Parallel()
+
+	// 2024-05-03 is a Friday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 5, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		weekdays []string
+		at       time.Time
+		want     bool
+	}{
+		{"same day inside", "12:00", "14:00", nil, at(3, 13, 0), true},
+		{"same day at end", "12:00", "14:00", nil, at(3, 14, 0), false},
+		{"same day before", "12:00", "14:00", nil, at(3, 11, 59), false},
+		{"crossing midnight evening", "22:00", "06:00", nil, at(3, 23, 30), true},
+		{"crossing midnight morning", "22:00", "06:00", nil, at(4, 3, 0), true},
+		{"crossing midnight daytime", "22:00", "06:00", nil, at(3, 12, 0), false},
+		{"whole day", "00:00", "00:00", nil, at(3, 12, 0), true},
+		{"weekday matches", "22:00", "06:00", []string{"friday"}, at(3, 23, 0), true},
+		{"morning belongs to start day", "22:00", "06:00", []string{"friday"}, at(4, 3, 0), true},
+		{"morning of other start day", "22:00", "06:00", []string{"friday"}, at(3, 3, 0), false},
+		{"numeric weekday", "12:00", "14:00", []string{"5"}, at(3, 13, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.Main.TimeZone = "UTC"
+			settings.Realtime.QuietHours = QuietHoursSettings{Enabled: true, Start: tt.start, End: tt.end, Weekdays: tt.weekdays}
+			if got := settings.InQuietHours(tt.at); got != tt.want {
+				t.Errorf("InQuietHours(%s) = %v, want %v", tt.at.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInQuietHoursTimeZone(t *testing.T) {
+	t.Parallel()
+
+	// Use a time zone with an offset other than the system time zone
+	zone := "Asia/Tokyo"
+	if _, offset := time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC).In(time.Local).Zone(); offset == 9*3600 {
+		zone = "Pacific/Honolulu"
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	settings := &Settings{}
+	settings.Main.TimeZone = zone
+	settings.Realtime.QuietHours = QuietHoursSettings{Enabled: true, Start: "22:00", End: "06:00"}
+
+	// Times are passed in the system time zone like time.Now
+	tests := []struct {
+		hour int
+		want bool
+	}{
+		{23, true},
+		{3, true},
+		{12, false},
+	}
+	for _, tt := range tests {
+		at := time.Date(2024, 5, 3, tt.hour, 0, 0, 0, loc).In(time.Local)
+		if got := settings.InQuietHours(at); got != tt.want {
+			t.Errorf("InQuietHours(%02d:00 %s) = %v, want %v", tt.hour, zone, got, tt.want)
+		}
+		if got := settings.QuietHoursSuppress(QuietHoursNotifications, at); got != tt.want {
+			t.Errorf("QuietHoursSuppress(%02d:00 %s) = %v, want %v", tt.hour, zone, got, tt.want)
+		}
+	}
+}
+
+func TestInQuietHoursDisabled(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Realtime.QuietHours = QuietHoursSettings{Start: "00:00", End: "00:00"}
+	if settings.InQuietHours(time.Now()) {
+		t.Error("InQuietHours() = true with quiet hours disabled")
+	}
+}
+
+func TestQuietHoursSuppress(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Realtime.QuietHours = QuietHoursSettings{Enabled: true, Start: "00:00", End: "00:00"}
+	now := time.Now()
+	for _, effect := range []string{QuietHoursActions, QuietHoursNotifications, QuietHoursMQTT} {
+		if !settings.QuietHoursSuppress(effect, now) {
+			t.Errorf("QuietHoursSuppress(%q) = false with an empty suppress list", effect)
+		}
+	}
+
+	settings.Realtime.QuietHours.Suppress = []string{QuietHoursMQTT}
+	if !settings.QuietHoursSuppress(QuietHoursMQTT, now) || settings.QuietHoursSuppress(QuietHoursActions, now) {
+		t.Error("QuietHoursSuppress() does not follow the suppress list")
+	}
+}
+
+func TestValidateQuietHours(t *testing.T) {
+	t.Parallel()
+
+	valid := QuietHoursSettings{Enabled: true, Start: "22:00", End: "06:30", Weekdays: []string{"Monday", "6"}, Suppress: []string{QuietHoursActions}}
+	tests := []struct {
+		name     string
+		modify   func(*QuietHoursSettings)
+		wantCode string
+	}{
+		{"valid", func(q *QuietHoursSettings) {}, ""},
+		{"disabled with invalid values", func(q *QuietHoursSettings) { q.Enabled = false; q.Start = "late" }, ""},
+		{"invalid start", func(q *QuietHoursSettings) { q.Start = "25:00" }, ErrCodeQuietHoursTime},
+		{"missing end", func(q *QuietHoursSettings) { q.End = "" }, ErrCodeQuietHoursTime},
+		{"invalid weekday", func(q *QuietHoursSettings) { q.Weekdays = []string{"someday"} }, ErrCodeQuietHoursWeekday},
+		{"invalid suppress", func(q *QuietHoursSettings) { q.Suppress = []string{"database"} }, ErrCodeQuietHoursSuppress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := valid
+			tt.modify(&settings)
+			err := validateQuietHours(&settings)
+			if (err != nil) != (tt.wantCode != "") {
+				t.Fatalf("validateQuietHours() error = %v, want code %q", err, tt.wantCode)
+			}
+			if err != nil && validationCode(err) != tt.wantCode {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), tt.wantCode)
+			}
+		})
+	}
+}
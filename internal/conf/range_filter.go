@@ -1,6 +1,8 @@
 package conf
 
 import (
+	"fmt"
+	"log"
 	"strings"
 	"sync"
 	"time"
@@ -10,6 +12,16 @@ var (
 	speciesListMutex sync.RWMutex
 )
 
+// Range filter models of RangeFilterSettings.Model
+const (
+	RangeFilterModelLatest = "latest" // current range filter model, the default
+	RangeFilterModelLegacy = "legacy" // previous range filter model
+)
+
+// DefaultRangeFilterUpdateInterval is how often the range filter species list
+// is recomputed when RangeFilterSettings.UpdateInterval is not set
+const DefaultRangeFilterUpdateInterval = 24 * time.Hour
+
 // UpdateIncludedSpecies updates the included species list in the RangeFilter
 func (s *Settings) UpdateIncludedSpecies(species []string) {
 	speciesListMutex.Lock()
@@ -19,6 +31,59 @@ func (s *Settings) UpdateIncludedSpecies(species []string) {
 	s.BirdNET.RangeFilter.LastUpdated = time.Now()
 }
 
+// normalizeRangeFilterModel lowercases the range filter model and returns a
+// warning when it is empty and falls back to the default model, an empty
+// string otherwise. Unknown models are errors of validateBirdNETSettings.
+func normalizeRangeFilterModel(rf *RangeFilterSettings, settings *Settings) string {
+	rf.Model = strings.ToLower(strings.TrimSpace(rf.Model))
+	if rf.Model != "" {
+		return ""
+	}
+	rf.Model = RangeFilterModelLatest
+
+	message := fmt.Sprintf("RangeFilter model is not set, using the %s model", RangeFilterModelLatest)
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeRangeFilterModel, "default-fallback")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-birdnet-validation: %s", message))
+	return message
+}
+
+// clampRangeFilterThreshold clamps the range filter threshold to its valid
+// range and returns a warning when it was outside, an empty string otherwise
+func clampRangeFilterThreshold(rf *RangeFilterSettings, settings *Settings) string {
+	c := constraintFor("birdnet.rangefilter.threshold")
+	if c.inRange(float64(rf.Threshold)) {
+		return ""
+	}
+	configured := rf.Threshold
+	rf.Threshold = float32(min(max(float64(rf.Threshold), *c.Minimum), *c.Maximum))
+
+	message := fmt.Sprintf("RangeFilter threshold %g must be %s, using %g", configured, c, rf.Threshold)
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeRangeFilterThreshold, "value-clamped")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-birdnet-validation: %s", message))
+	return message
+}
+
+// NeedsUpdate reports whether the species list should be recomputed at now.
+// A list that was never computed always needs an update, otherwise it is
+// recomputed every UpdateInterval when AutoUpdate is enabled.
+func (r RangeFilterSettings) NeedsUpdate(now time.Time) bool {
+	if r.LastUpdated.IsZero() {
+		return true
+	}
+	if !r.AutoUpdate {
+		return false
+	}
+	interval := r.UpdateInterval
+	if interval <= 0 {
+		interval = DefaultRangeFilterUpdateInterval
+	}
+	return !now.Before(r.LastUpdated.Add(interval))
+}
+
 // GetIncludedSpecies returns the current included species list from the RangeFilter
 func (s *Settings) GetIncludedSpecies() []string {
 	speciesListMutex.RLock()
@@ -0,0 +1,137 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRangeFilterNeedsUpdate(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2025, 5, 1, 6, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter RangeFilterSettings
+		now    time.Time
+		want   bool
+	}{
+		{"never updated", RangeFilterSettings{}, updated, true},
+		{"auto update disabled", RangeFilterSettings{LastUpdated: updated}, updated.Add(30 * 24 * time.Hour), false},
+		{"within interval", RangeFilterSettings{AutoUpdate: true, UpdateInterval: 6 * time.Hour, LastUpdated: updated}, updated.Add(6*time.Hour - time.Second), false},
+		{"interval passed", RangeFilterSettings{AutoUpdate: true, UpdateInterval: 6 * time.Hour, LastUpdated: updated}, updated.Add(6 * time.Hour), true},
+		{"default interval", RangeFilterSettings{AutoUpdate: true, LastUpdated: updated}, updated.Add(23 * time.Hour), false},
+		{"default interval passed", RangeFilterSettings{AutoUpdate: true, LastUpdated: updated}, updated.Add(24 * time.Hour), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.filter.NeedsUpdate(tt.now); got != tt.want {
+				t.Errorf("NeedsUpdate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRangeFilterUpdateInterval(t *testing.T) {
+	t.Parallel()
+
+	settings := &BirdNETConfig{RangeFilter: RangeFilterSettings{Model: "latest"}}
+	if err := validateBirdNETSettings(settings); err != nil {
+		t.Fatalf("validateBirdNETSettings() error = %v", err)
+	}
+	if settings.RangeFilter.UpdateInterval != DefaultRangeFilterUpdateInterval {
+		t.Errorf("UpdateInterval = %s, want the default %s", settings.RangeFilter.UpdateInterval, DefaultRangeFilterUpdateInterval)
+	}
+
+	settings.RangeFilter.UpdateInterval = -time.Hour
+	if err := validateBirdNETSettings(settings); err == nil {
+		t.Error("validateBirdNETSettings() of a negative update interval error = nil, want an error")
+	}
+}
+
+func TestNormalizeRangeFilterModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		model       string
+		wantModel   string
+		wantWarning bool
+	}{
+		{"latest", "latest", RangeFilterModelLatest, false},
+		{"legacy mixed case", " Legacy ", RangeFilterModelLegacy, false},
+		{"empty uses default", "", RangeFilterModelLatest, true},
+		{"unknown is kept for validation", "v3", "v3", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rf := &RangeFilterSettings{Model: tt.model}
+			warning := normalizeRangeFilterModel(rf, &Settings{})
+			if rf.Model != tt.wantModel {
+				t.Errorf("Model = %q, want %q", rf.Model, tt.wantModel)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("normalizeRangeFilterModel() warning = %q, wantWarning %v", warning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestValidateRangeFilterModel(t *testing.T) {
+	t.Parallel()
+
+	for _, model := range []string{RangeFilterModelLatest, RangeFilterModelLegacy} {
+		if err := validateBirdNETSettings(&BirdNETConfig{RangeFilter: RangeFilterSettings{Model: model}}); err != nil {
+			t.Errorf("validateBirdNETSettings() of model %q error = %v", model, err)
+		}
+	}
+	if err := validateBirdNETSettings(&BirdNETConfig{RangeFilter: RangeFilterSettings{Model: "v3"}}); err == nil {
+		t.Error("validateBirdNETSettings() of an unknown model error = nil, want an error")
+	}
+}
+
+func TestClampRangeFilterThreshold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		threshold   float32
+		want        float32
+		wantWarning bool
+	}{
+		{"in range", 0.01, 0.01, false},
+		{"lower bound", 0, 0, false},
+		{"upper bound", 1, 1, false},
+		{"negative", -0.5, 0, true},
+		{"above one", 1.5, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rf := &RangeFilterSettings{Threshold: tt.threshold}
+			warning := clampRangeFilterThreshold(rf, &Settings{})
+			if rf.Threshold != tt.want {
+				t.Errorf("Threshold = %g, want %g", rf.Threshold, tt.want)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("clampRangeFilterThreshold() warning = %q, wantWarning %v", warning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsRangeFilter(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.BirdNET.RangeFilter = RangeFilterSettings{Model: "V3", Threshold: 2}
+	err := ValidateSettings(settings)
+	if err == nil || !strings.Contains(err.Error(), "v3") {
+		t.Errorf("ValidateSettings() of an unknown model error = %v, want an error naming it", err)
+	}
+	if settings.BirdNET.RangeFilter.Threshold != 1 {
+		t.Errorf("Threshold = %g, want it clamped to 1", settings.BirdNET.RangeFilter.Threshold)
+	}
+}
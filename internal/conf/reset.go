@@ -0,0 +1,185 @@
+// conf/reset.go resetting configuration sections to their default values
+package conf
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// ResetToDefaults resets a configuration section such as "realtime.mqtt" of
+// the live settings to the defaults from the embedded config.yaml, validates
+// the result and saves it to the config file. Runtime-only values and per
+// installation secrets within the section are preserved.
+func ResetToDefaults(section string) error {
+	defaults, err := loadDefaultSettings()
+	if err != nil {
+		return err
+	}
+
+	settingsMutex.Lock()
+	if settingsInstance == nil {
+		settingsMutex.Unlock()
+		return errors.Newf("settings not loaded").
+			Component("conf").
+			Category(errors.CategoryConfiguration).
+			Context("operation", "reset-to-defaults").
+			Build()
+	}
+
+	// Apply and validate the reset on a copy so that a failed validation
+	// leaves the live settings untouched
+	candidate := *settingsInstance
+	candidate.ValidationWarnings = nil
+	if err := resetSection(&candidate, defaults, section); err != nil {
+		settingsMutex.Unlock()
+		return err
+	}
+	if err := ValidateSettings(&candidate); err != nil {
+		if err := checkValidationResult(err); err != nil {
+			settingsMutex.Unlock()
+			return err
+		}
+	}
+
+	// Copy the validated section into the live settings instance
+	if err := resetSection(settingsInstance, &candidate, section); err != nil {
+		settingsMutex.Unlock()
+		return err
+	}
+	settingsMutex.Unlock()
+
+	return SaveSettings()
+}
+
+// loadDefaultSettings builds a Settings struct from the embedded config.yaml
+// with the default overrides applied, layered over the built-in defaults
+func loadDefaultSettings() (*Settings, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	setDefaults(v)
+
+	defaultConfig, err := generateDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := v.ReadConfig(strings.NewReader(defaultConfig)); err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "read-default-config").
+			Build()
+	}
+
+	defaults := &Settings{}
+	if err := v.Unmarshal(defaults, viper.DecodeHook(settingsDecodeHook())); err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "unmarshal-default-config").
+			Build()
+	}
+	return defaults, nil
+}
+
+// resetSection overwrites the section of dst named by a dot separated path of
+// lowercase field names with the same section from src. Fields that are not
+// stored in the config file and empty secrets in src keep their dst values.
+func resetSection(dst, src *Settings, section string) error {
+	dstField, err := lookupSection(reflect.ValueOf(dst).Elem(), section)
+	if err != nil {
+		return err
+	}
+	srcField, err := lookupSection(reflect.ValueOf(src).Elem(), section)
+	if err != nil {
+		return err
+	}
+
+	reset := reflect.New(srcField.Type()).Elem()
+	reset.Set(srcField)
+	preserveRuntimeFields(reset, dstField)
+	dstField.Set(reset)
+	return nil
+}
+
+// lookupSection returns the settings field addressed by section
+func lookupSection(value reflect.Value, section string) (reflect.Value, error) {
+	invalidSection := func() error {
+		return errors.Newf("unknown configuration section %q", section).
+			Component("conf").
+			Category(errors.CategoryValidation).
+			Context("section", section).
+			Build()
+	}
+
+	if strings.TrimSpace(section) == "" {
+		return reflect.Value{}, invalidSection()
+	}
+
+	for _, name := range strings.Split(strings.ToLower(section), ".") {
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, invalidSection()
+		}
+		field, ok := findConfigField(value, name)
+		if !ok {
+			return reflect.Value{}, invalidSection()
+		}
+		value = field
+	}
+	return value, nil
+}
+
+// findConfigField finds a field of a struct value by its lowercase config key.
+// Fields that are not stored in the config file are not addressable as sections.
+func findConfigField(value reflect.Value, name string) (reflect.Value, bool) {
+	valueType := value.Type()
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() || field.Tag.Get("yaml") == "-" {
+			continue
+		}
+		if strings.ToLower(field.Name) == name {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// preserveRuntimeFields copies runtime-only fields, tagged yaml:"-", and
+// secrets that have no default value from current into reset
+func preserveRuntimeFields(reset, current reflect.Value) {
+	if reset.Kind() != reflect.Struct {
+		return
+	}
+
+	resetType := reset.Type()
+	for i := range resetType.NumField() {
+		field := resetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		switch {
+		case field.Tag.Get("yaml") == "-":
+			reset.Field(i).Set(current.Field(i))
+		case field.Type.Kind() == reflect.Struct:
+			preserveRuntimeFields(reset.Field(i), current.Field(i))
+		case isGeneratedSecret(resetType, field) && reset.Field(i).String() == "":
+			// Secrets such as the session secret are generated per installation
+			reset.Field(i).Set(current.Field(i))
+		}
+	}
+}
+
+// isGeneratedSecret reports whether a settings field holds a secret that is
+// generated for each installation rather than configured by the user
+func isGeneratedSecret(parent reflect.Type, field reflect.StructField) bool {
+	switch parent {
+	case reflect.TypeOf(Security{}):
+		return field.Name == "SessionSecret"
+	case reflect.TypeOf(BasicAuth{}):
+		return field.Name == "ClientSecret"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,117 @@
+package conf
+
+import (
+	"testing"
+)
+
+func TestLoadDefaultSettings(t *testing.T) {
+	t.Parallel()
+
+	defaults, err := loadDefaultSettings()
+	if err != nil {
+		t.Fatalf("loadDefaultSettings() error = %v", err)
+	}
+
+	if defaults.Realtime.MQTT.Enabled {
+		t.Error("expected MQTT to be disabled by default")
+	}
+	if defaults.WebServer.Port != "8080" {
+		t.Errorf("WebServer.Port = %q, want 8080", defaults.WebServer.Port)
+	}
+	// Value only defined in defaults.go, not in the embedded config.yaml
+	if defaults.Realtime.Monitoring.CriticalResendInterval != 30 {
+		t.Errorf("Monitoring.CriticalResendInterval = %d, want 30", defaults.Realtime.Monitoring.CriticalResendInterval)
+	}
+}
+
+func TestResetSection(t *testing.T) {
+	t.Parallel()
+
+	defaults, err := loadDefaultSettings()
+	if err != nil {
+		t.Fatalf("loadDefaultSettings() error = %v", err)
+	}
+
+	newCurrent := func() *Settings {
+		current := &Settings{}
+		current.Version = "1.2.3"
+		current.Main.Name = "garden-node"
+		current.Realtime.MQTT.Enabled = true
+		current.Realtime.MQTT.Broker = "tcp://mqtt.local:1883"
+		current.Realtime.MQTT.Password = "mqtt-password"
+		current.Realtime.Audio.SoxAudioTypes = []string{"wav", "flac"}
+		current.Realtime.Audio.Export.Type = "mp3"
+		current.Security.SessionSecret = "session-secret"
+		current.Security.BasicAuth.ClientSecret = "client-secret"
+		current.Security.BasicAuth.Password = "admin-password"
+		return current
+	}
+
+	t.Run("resets only the named section", func(t *testing.T) {
+		t.Parallel()
+		current := newCurrent()
+		if err := resetSection(current, defaults, "realtime.mqtt"); err != nil {
+			t.Fatalf("resetSection() error = %v", err)
+		}
+		if current.Realtime.MQTT.Enabled || current.Realtime.MQTT.Broker != defaults.Realtime.MQTT.Broker {
+			t.Errorf("MQTT not reset: %+v", current.Realtime.MQTT)
+		}
+		if current.Realtime.MQTT.Password != defaults.Realtime.MQTT.Password {
+			t.Error("expected MQTT password to be reset")
+		}
+		if current.Main.Name != "garden-node" || current.Realtime.Audio.Export.Type != "mp3" {
+			t.Error("settings outside the section were modified")
+		}
+	})
+
+	t.Run("section names are case-insensitive", func(t *testing.T) {
+		t.Parallel()
+		current := newCurrent()
+		if err := resetSection(current, defaults, "Realtime.Audio.Export"); err != nil {
+			t.Fatalf("resetSection() error = %v", err)
+		}
+		if current.Realtime.Audio.Export.Type != defaults.Realtime.Audio.Export.Type {
+			t.Errorf("Export.Type = %q, want %q", current.Realtime.Audio.Export.Type, defaults.Realtime.Audio.Export.Type)
+		}
+	})
+
+	t.Run("preserves runtime fields", func(t *testing.T) {
+		t.Parallel()
+		current := newCurrent()
+		if err := resetSection(current, defaults, "realtime"); err != nil {
+			t.Fatalf("resetSection() error = %v", err)
+		}
+		if len(current.Realtime.Audio.SoxAudioTypes) != 2 {
+			t.Errorf("SoxAudioTypes = %v, want runtime value preserved", current.Realtime.Audio.SoxAudioTypes)
+		}
+		if current.Version != "1.2.3" {
+			t.Error("top level runtime field modified")
+		}
+	})
+
+	t.Run("preserves generated secrets", func(t *testing.T) {
+		t.Parallel()
+		current := newCurrent()
+		if err := resetSection(current, defaults, "security"); err != nil {
+			t.Fatalf("resetSection() error = %v", err)
+		}
+		if current.Security.SessionSecret != "session-secret" {
+			t.Error("expected session secret to be preserved")
+		}
+		if current.Security.BasicAuth.ClientSecret != "client-secret" {
+			t.Error("expected basic auth client secret to be preserved")
+		}
+		if current.Security.BasicAuth.Password != defaults.Security.BasicAuth.Password {
+			t.Error("expected basic auth password to be reset")
+		}
+	})
+
+	for _, section := range []string{"", "realtime.nosuchsection", "input", "realtime.mqtt.broker.host", "."} {
+		t.Run("unknown section "+section, func(t *testing.T) {
+			t.Parallel()
+			if err := resetSection(newCurrent(), defaults, section); err == nil {
+				t.Errorf("resetSection(%q) expected error", section)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// conf/routes.go web routes requiring authentication
+package conf
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// RequiresAuth reports whether authentication is required for a request
+// path when authentication is enabled. Paths matching PublicRoutes never
+// require it. With no ProtectedRoutes every other path does, the web server
+// narrows this to its built-in protected routes, with ProtectedRoutes only the
+// matching paths do.
+//
+// Patterns are path.Match globs where "*" matches within one path segment, a
+// pattern ending in "/**" also matches every path below its prefix, e.g.
+// "/api/**" matches "/api" and "/api/v2/detections".
+func (s *Security) RequiresAuth(requestPath string) bool {
+	if matchesAnyRoute(s.PublicRoutes, requestPath) {
+		return false
+	}
+	if len(s.ProtectedRoutes) == 0 {
+		return true
+	}
+	return matchesAnyRoute(s.ProtectedRoutes, requestPath)
+}
+
+// HasProtectedRoutes reports whether ProtectedRoutes replaces the built-in
+// protected routes
+func (s *Security) HasProtectedRoutes() bool {
+	return len(s.ProtectedRoutes) > 0
+}
+
+// matchesAnyRoute reports whether a request path matches one of patterns
+func matchesAnyRoute(patterns []string, requestPath string) bool {
+	for _, pattern := range patterns {
+		if matchRoute(pattern, requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRoute reports whether a request path matches a route pattern, see
+// RequiresAuth for the pattern syntax
+func matchRoute(pattern, requestPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		if requestPath == prefix || strings.HasPrefix(requestPath, prefix+"/") {
+			return true
+		}
+	}
+	matched, err := path.Match(pattern, requestPath)
+	return err == nil && matched
+}
+
+// validateRoutePatterns checks that the route patterns are absolute paths
+// and valid globs
+func validateRoutePatterns(settings *Security) error {
+	for _, routes := range []struct {
+		key      string
+		patterns []string
+	}{
+		{"security.protectedroutes", settings.ProtectedRoutes},
+		{"security.publicroutes", settings.PublicRoutes},
+	} {
+		for _, pattern := range routes.patterns {
+			_, err := path.Match(strings.TrimSuffix(pattern, "/**"), "")
+			if err == nil && !strings.HasPrefix(pattern, "/") {
+				err = fmt.Errorf("pattern must start with /")
+			}
+			if err != nil {
+				return errors.New(fmt.Errorf("invalid route pattern %q in %s: %w", pattern, routes.key, err)).
+					Category(errors.CategoryValidation).
+					Context("validation_type", ErrCodeRoutePattern).
+					Build()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package conf
+
+import "testing"
+
+func TestSecurityRequiresAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		protected []string
+		public    []string
+		path      string
+		want      bool
+	}{
+		{"no routes protects everything", nil, nil, "/settings/main", true},
+		{"public route", nil, []string{"/api/v2/health"}, "/api/v2/health", false},
+		{"protected prefix", []string{"/settings/**"}, nil, "/settings/audio", true},
+		{"protected prefix itself", []string{"/settings/**"}, nil, "/settings", true},
+		{"not below protected prefix", []string{"/settings/**"}, nil, "/settingsx", false},
+		{"unprotected path", []string{"/settings/**"}, nil, "/dashboard", false},
+		{"single segment glob", []string{"/api/v2/*"}, nil, "/api/v2/detections", true},
+		{"glob does not cross segments", []string{"/api/v2/*"}, nil, "/api/v2/detections/1", false},
+		{"public wins over protected", []string{"/api/**"}, []string{"/api/v2/health"}, "/api/v2/health", false},
+		{"public glob", nil, []string{"/api/v2/*/public"}, "/api/v2/media/public", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := &Security{ProtectedRoutes: tt.protected, PublicRoutes: tt.public}
+			if got := s.RequiresAuth(tt.path); got != tt.want {
+				t.Errorf("RequiresAuth(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRoutePatterns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		protected []string
+		public    []string
+		wantErr   bool
+	}{
+		{"empty", nil, nil, false},
+		{"valid", []string{"/settings/**", "/api/v[12]/*"}, []string{"/api/v2/health"}, false},
+		{"malformed protected", []string{"/api/[v2"}, nil, true},
+		{"malformed public", nil, []string{"/api/\\"}, true},
+		{"relative", []string{"settings/**"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateRoutePatterns(&Security{ProtectedRoutes: tt.protected, PublicRoutes: tt.public})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateRoutePatterns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && validationCode(err) != ErrCodeRoutePattern {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), ErrCodeRoutePattern)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// conf/rtsp.go RTSP stream health monitoring and reconnection settings
+package conf
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Defaults of the RTSP health monitoring and reconnection settings
+const (
+	DefaultRTSPHealthyDataThreshold = 60 * time.Second // no data for this long marks a stream unhealthy
+	MaxRTSPHealthyDataThreshold     = 30 * time.Minute // longest accepted healthy data threshold
+	DefaultRTSPMonitoringInterval   = 30 * time.Second // interval of stream health checks
+	DefaultRTSPReconnectDelay       = 5 * time.Second  // delay before the first reconnection attempt
+	DefaultRTSPReconnectMaxDelay    = 2 * time.Minute  // longest delay between reconnection attempts
+	DefaultRTSPReconnectMultiplier  = 2.0              // growth of the delay per failed attempt
+)
+
+// EffectiveHealthyDataThreshold returns the time without data after which a
+// stream is unhealthy, the default when unset or above MaxRTSPHealthyDataThreshold
+func (h RTSPHealthSettings) EffectiveHealthyDataThreshold() time.Duration {
+	threshold := time.Duration(h.HealthyDataThreshold) * time.Second
+	if threshold <= 0 || threshold > MaxRTSPHealthyDataThreshold {
+		return DefaultRTSPHealthyDataThreshold
+	}
+	return threshold
+}
+
+// EffectiveMonitoringInterval returns the interval of stream health checks,
+// the default when unset
+func (h RTSPHealthSettings) EffectiveMonitoringInterval() time.Duration {
+	if h.MonitoringInterval <= 0 {
+		return DefaultRTSPMonitoringInterval
+	}
+	return time.Duration(h.MonitoringInterval) * time.Second
+}
+
+// EffectiveReconnectBackoff returns the initial delay, maximum delay and
+// multiplier of the backoff between reconnection attempts. The defaults are
+// used when ReconnectBackoff is disabled, streams always back off.
+func (r RTSPSettings) EffectiveReconnectBackoff() (initial, maxDelay time.Duration, multiplier float64) {
+	backoff := r.ReconnectBackoff
+	if !backoff.Enabled || backoff.InitialDelay <= 0 {
+		return DefaultRTSPReconnectDelay, DefaultRTSPReconnectMaxDelay, DefaultRTSPReconnectMultiplier
+	}
+
+	initial = time.Duration(backoff.InitialDelay) * time.Second
+	maxDelay = max(time.Duration(backoff.MaxDelay)*time.Second, initial)
+	multiplier = backoff.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = DefaultRTSPReconnectMultiplier
+	}
+	return initial, maxDelay, multiplier
+}
+
+// ReconnectDelay returns the delay before reconnection attempt n, starting at
+// 1, growing from the initial delay by the multiplier up to the maximum delay
+func (r RTSPSettings) ReconnectDelay(attempt int) time.Duration {
+	initial, maxDelay, multiplier := r.EffectiveReconnectBackoff()
+	if attempt <= 1 {
+		return initial
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay >= float64(maxDelay) {
+		return maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// validateRTSPSettings validates the RTSP health monitoring and reconnection
+// settings when RTSP streams are configured
+func validateRTSPSettings(settings *RTSPSettings) error {
+	if len(settings.URLs) == 0 {
+		return nil
+	}
+
+	health := settings.Health
+	if c := constraintFor("realtime.rtsp.health.healthydatathreshold"); !c.inRange(float64(health.HealthyDataThreshold)) {
+		return errors.New(fmt.Errorf("RTSP healthy data threshold must be %s seconds, got %d", c, health.HealthyDataThreshold)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRTSPHealthThreshold).
+			Build()
+	}
+	if c := constraintFor("realtime.rtsp.health.monitoringinterval"); !c.inRange(float64(health.MonitoringInterval)) {
+		return errors.New(fmt.Errorf("RTSP health monitoring interval must be %s seconds, got %d", c, health.MonitoringInterval)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRTSPHealthInterval).
+			Build()
+	}
+	if health.MonitoringInterval >= health.HealthyDataThreshold {
+		return errors.New(fmt.Errorf("RTSP health monitoring interval (%ds) must be shorter than the healthy data threshold (%ds), otherwise stalled streams are detected late",
+			health.MonitoringInterval, health.HealthyDataThreshold)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRTSPHealthInterval).
+			Build()
+	}
+
+	backoff := settings.ReconnectBackoff
+	if !backoff.Enabled {
+		return nil
+	}
+	if c := constraintFor("realtime.rtsp.reconnectbackoff.initialdelay"); !c.inRange(float64(backoff.InitialDelay)) {
+		return errors.New(fmt.Errorf("RTSP reconnect initial delay must be %s seconds, got %d", c, backoff.InitialDelay)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRTSPReconnectBackoff).
+			Build()
+	}
+	if backoff.MaxDelay < backoff.InitialDelay {
+		return errors.New(fmt.Errorf("RTSP reconnect max delay (%ds) must not be shorter than the initial delay (%ds)", backoff.MaxDelay, backoff.InitialDelay)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRTSPReconnectBackoff).
+			Build()
+	}
+	if c := constraintFor("realtime.rtsp.reconnectbackoff.backoffmultiplier"); !c.inRange(backoff.BackoffMultiplier) {
+		return errors.New(fmt.Errorf("RTSP reconnect backoff multiplier must be %s, got %v", c, backoff.BackoffMultiplier)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeRTSPReconnectBackoff).
+			Build()
+	}
+
+	return nil
+}
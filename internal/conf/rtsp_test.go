@@ -0,0 +1,96 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRTSPEffectiveValues(t *testing.T) {
+	t.Parallel()
+
+	health := RTSPHealthSettings{}
+	if got := health.EffectiveHealthyDataThreshold(); got != DefaultRTSPHealthyDataThreshold {
+		t.Errorf("EffectiveHealthyDataThreshold() = %v, want default %v", got, DefaultRTSPHealthyDataThreshold)
+	}
+	if got := health.EffectiveMonitoringInterval(); got != DefaultRTSPMonitoringInterval {
+		t.Errorf("EffectiveMonitoringInterval() = %v, want default %v", got, DefaultRTSPMonitoringInterval)
+	}
+
+	health = RTSPHealthSettings{HealthyDataThreshold: 90, MonitoringInterval: 15}
+	if got := health.EffectiveHealthyDataThreshold(); got != 90*time.Second {
+		t.Errorf("EffectiveHealthyDataThreshold() = %v, want 90s", got)
+	}
+	if got := health.EffectiveMonitoringInterval(); got != 15*time.Second {
+		t.Errorf("EffectiveMonitoringInterval() = %v, want 15s", got)
+	}
+}
+
+func TestRTSPReconnectDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		backoff RetrySettings
+		want    []time.Duration // delays of attempts 1, 2, ...
+	}{
+		{"disabled uses defaults", RetrySettings{}, []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second}},
+		{"configured", RetrySettings{Enabled: true, InitialDelay: 10, MaxDelay: 60, BackoffMultiplier: 3},
+			[]time.Duration{10 * time.Second, 30 * time.Second, 60 * time.Second, 60 * time.Second}},
+		{"large attempt is capped", RetrySettings{Enabled: true, InitialDelay: 1, MaxDelay: 300, BackoffMultiplier: 2},
+			[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := RTSPSettings{ReconnectBackoff: tt.backoff}
+			for i, want := range tt.want {
+				if got := settings.ReconnectDelay(i + 1); got != want {
+					t.Errorf("ReconnectDelay(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+			if got := settings.ReconnectDelay(10000); got <= 0 {
+				t.Errorf("ReconnectDelay(10000) = %v, want the maximum delay", got)
+			}
+		})
+	}
+}
+
+func TestValidateRTSPSettings(t *testing.T) {
+	t.Parallel()
+
+	valid := func() RTSPSettings {
+		return RTSPSettings{
+			URLs:             []string{"rtsp://camera/stream"},
+			Health:           RTSPHealthSettings{HealthyDataThreshold: 60, MonitoringInterval: 30},
+			ReconnectBackoff: RetrySettings{Enabled: true, InitialDelay: 5, MaxDelay: 120, BackoffMultiplier: 2},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(s *RTSPSettings)
+		wantErr bool
+	}{
+		{"valid", func(s *RTSPSettings) {}, false},
+		{"no streams", func(s *RTSPSettings) { s.URLs = nil; s.Health = RTSPHealthSettings{} }, false},
+		{"zero threshold", func(s *RTSPSettings) { s.Health.HealthyDataThreshold = 0 }, true},
+		{"zero interval", func(s *RTSPSettings) { s.Health.MonitoringInterval = 0 }, true},
+		{"interval not below threshold", func(s *RTSPSettings) { s.Health.MonitoringInterval = 60 }, true},
+		{"threshold above maximum", func(s *RTSPSettings) { s.Health.HealthyDataThreshold = 3600 }, true},
+		{"max delay below initial delay", func(s *RTSPSettings) { s.ReconnectBackoff.MaxDelay = 1 }, true},
+		{"multiplier below one", func(s *RTSPSettings) { s.ReconnectBackoff.BackoffMultiplier = 0.5 }, true},
+		{"disabled backoff is not checked", func(s *RTSPSettings) { s.ReconnectBackoff = RetrySettings{} }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := valid()
+			tt.modify(&settings)
+			if err := validateRTSPSettings(&settings); (err != nil) != tt.wantErr {
+				t.Errorf("validateRTSPSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
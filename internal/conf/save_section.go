@@ -0,0 +1,176 @@
+// conf/save_section.go saving a single settings section to the config file
+package conf
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// SaveSection writes only the settings section at a config key such as
+// "realtime.mqtt" to the config file. The config file is read again and only
+// the section is replaced with the current settings, so other sections keep
+// their content and comments even when another process changed them since the
+// settings were loaded. The settings are validated first, validation errors
+// leave the file unchanged.
+func SaveSection(section string) error {
+	// Serialize with SaveSettings writes
+	settingsWriter.writeMu.Lock()
+	defer settingsWriter.writeMu.Unlock()
+
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	if settingsInstance == nil {
+		return errors.Newf("settings are not loaded").
+			Component("conf").
+			Category(errors.CategoryConfiguration).
+			Context("operation", "save-section").
+			Build()
+	}
+
+	configPath, err := FindConfigFile()
+	if err != nil {
+		return errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "find-config-file").
+			Build()
+	}
+
+	if err := saveSectionFile(configPath, settingsInstance, section); err != nil {
+		return err
+	}
+	log.Printf("Settings section %s saved successfully to %s", section, configPath)
+	return nil
+}
+
+// saveSectionFile replaces the section of the config file at configPath with
+// the section of settings, the caller must hold settingsMutex
+func saveSectionFile(configPath string, settings *Settings, section string) error {
+	keys := strings.Split(strings.ToLower(strings.Trim(section, ".")), ".")
+	if keys[0] == "" {
+		return errors.Newf("empty settings section").
+			Component("conf").
+			Category(errors.CategoryValidation).
+			Context("operation", "save-section").
+			Build()
+	}
+
+	// Validate a copy so that normalization does not change the live settings
+	candidate := *settings
+	candidate.ValidationWarnings = nil
+	if err := ValidateSettings(&candidate); err != nil {
+		if err := checkValidationResult(err); err != nil {
+			return err
+		}
+	}
+
+	// Take the section from the settings as they would be saved
+	settingsCopy := *settings
+	speciesListMutex.RLock()
+	settingsCopy.BirdNET.RangeFilter.Species = append([]string(nil), settings.BirdNET.RangeFilter.Species...)
+	speciesListMutex.RUnlock()
+	restoreInlineSecrets(&settingsCopy)
+
+	var live yaml.Node
+	if err := live.Encode(&settingsCopy); err != nil {
+		return errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "yaml-marshal").
+			Build()
+	}
+	sectionNode := findYAMLPath(&live, keys)
+	if sectionNode == nil {
+		return errors.New(fmt.Errorf("unknown settings section %q", section)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeSettingsSection).
+			Build()
+	}
+
+	// Replace the section in the config file as it is on disk
+	data, err := readConfigFile(configPath)
+	if err != nil {
+		return errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-config-file").
+			Context("path", configPath).
+			Build()
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "yaml-unmarshal").
+			Context("path", configPath).
+			Build()
+	}
+	if doc.Kind == 0 {
+		// Empty config file
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if err := setYAMLPath(doc.Content[0], keys, sectionNode); err != nil {
+		return errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "set-config-section").
+			Context("section", section).
+			Build()
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&doc)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		return errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "encode-config-file").
+			Build()
+	}
+
+	yamlData := buf.Bytes()
+	if err := writeConfigFile(configPath, yamlData); err != nil {
+		return err
+	}
+
+	// Remember the written content so that the config watcher ignores our own
+	// write, unless other sections were changed externally and must be reloaded
+	lastChecksum.Lock()
+	changedExternally := contentFingerprint(data) != lastChecksum.fingerprint
+	lastChecksum.Unlock()
+	if !changedExternally {
+		recordChecksum(yamlData)
+	}
+	return nil
+}
+
+// findYAMLPath returns the value node at the keys below a mapping node,
+// matching keys case-insensitively, or nil when there is none
+func findYAMLPath(node *yaml.Node, keys []string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if strings.EqualFold(node.Content[i].Value, key) {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
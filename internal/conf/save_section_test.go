@@ -0,0 +1,84 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSaveSectionFile validates settings, which probes audio tools, and is not parallel
+func TestSaveSectionFile(t *testing.T) {
+	onDisk := `# node config
+main:
+  name: changed-externally # node name
+realtime:
+  mqtt:
+    enabled: false
+    topic: old
+  interval: 15
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(onDisk), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadFromReader(strings.NewReader("main:\n  name: live\n"))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	settings.Realtime.MQTT.Enabled = true
+	settings.Realtime.MQTT.Broker = "tcp://localhost:1883"
+	settings.Realtime.MQTT.Topic = "birdnet"
+
+	if err := saveSectionFile(configPath, settings, "Realtime.MQTT"); err != nil {
+		t.Fatalf("saveSectionFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := LoadFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("LoadFromReader(saved) error = %v", err)
+	}
+	if !saved.Realtime.MQTT.Enabled || saved.Realtime.MQTT.Topic != "birdnet" {
+		t.Errorf("saved MQTT = enabled %v topic %q, want the live section", saved.Realtime.MQTT.Enabled, saved.Realtime.MQTT.Topic)
+	}
+	if saved.Main.Name != "changed-externally" {
+		t.Errorf("saved Main.Name = %q, want the on-disk value of other sections", saved.Main.Name)
+	}
+	if !strings.Contains(string(data), "# node name") || !strings.Contains(string(data), "\n  interval: 15") {
+		t.Errorf("saved config lost other content of the file:\n%s", data)
+	}
+}
+
+// TestSaveSectionFileErrors validates settings, which probes audio tools, and is not parallel
+func TestSaveSectionFileErrors(t *testing.T) {
+	const onDisk = "main:\n  name: node\n"
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(onDisk), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadFromReader(strings.NewReader(onDisk))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+
+	for _, section := range []string{"", "realtime.nosuchsection"} {
+		if err := saveSectionFile(configPath, settings, section); err == nil {
+			t.Errorf("saveSectionFile(%q) error = nil, want error", section)
+		}
+	}
+
+	settings.BirdNET.Threshold = 2
+	if err := saveSectionFile(configPath, settings, "birdnet"); err == nil {
+		t.Error("saveSectionFile() with invalid settings error = nil, want validation error")
+	}
+
+	if data, err := os.ReadFile(configPath); err != nil || string(data) != onDisk {
+		t.Errorf("config file changed by failed saves: %q, %v", data, err)
+	}
+}
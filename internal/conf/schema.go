@@ -0,0 +1,163 @@
+// conf/schema.go JSON schema generation for settings forms
+package conf
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// jsonSchemaDialect is the JSON Schema version of generated schemas
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// sensitiveSettings lists settings holding credentials that are not read from
+// secret files, see secretFields for the others
+var sensitiveSettings = []string{
+	"security.sessionsecret",
+	"realtime.birdweather.id",
+	"backup.encryption_key",
+}
+
+// GenerateJSONSchema returns a JSON Schema describing Settings as exchanged by
+// the settings API, so that user interfaces can render settings forms. Enums
+// and ranges come from the constraints used by ValidateSettings, credentials
+// are marked with the non-standard "sensitive" keyword and runtime values that
+// are not stored in the config file are marked readOnly.
+func GenerateJSONSchema() ([]byte, error) {
+	sensitive := sensitiveKeys()
+
+	schema := typeSchema(reflect.TypeOf(Settings{}), "", sensitive)
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = "BirdNET-Go settings"
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, errors.New(err).
+			Component("conf").
+			Category(errors.CategoryConfiguration).
+			Context("operation", "generate-json-schema").
+			Build()
+	}
+	return data, nil
+}
+
+// sensitiveKeys returns the config keys of settings holding credentials
+func sensitiveKeys() map[string]bool {
+	sensitive := make(map[string]bool)
+	for _, field := range secretFields(&Settings{}) {
+		sensitive[field.key] = true
+	}
+	for _, key := range sensitiveSettings {
+		sensitive[key] = true
+	}
+	return sensitive
+}
+
+// typeSchema returns the schema of a Go type. key is the lowercase config key
+// of the value, used to look up constraints and sensitive markers.
+func typeSchema(t reflect.Type, key string, sensitive map[string]bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var schema map[string]any
+	switch {
+	case t == durationType:
+		schema = map[string]any{"type": "integer", "description": "duration in nanoseconds"}
+	case t == timeType:
+		schema = map[string]any{"type": "string", "format": "date-time"}
+	default:
+		schema = kindSchema(t, key, sensitive)
+	}
+
+	if constraint, ok := settingConstraints[key]; ok {
+		// Enums of lists constrain their items
+		if items, ok := schema["items"].(map[string]any); ok && len(constraint.Enum) > 0 {
+			items["enum"] = constraint.Enum
+			schema["uniqueItems"] = true
+		} else if len(constraint.Enum) > 0 {
+			schema["enum"] = constraint.Enum
+		}
+		if constraint.Minimum != nil {
+			schema["minimum"] = *constraint.Minimum
+		}
+		if constraint.Maximum != nil {
+			schema["maximum"] = *constraint.Maximum
+		}
+	}
+	if sensitive[key] {
+		schema["sensitive"] = true
+	}
+
+	return schema
+}
+
+// kindSchema returns the schema of a type based on its kind
+func kindSchema(t reflect.Type, key string, sensitive map[string]bool) map[string]any {
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), "", sensitive)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), "", sensitive)}
+	case reflect.Struct:
+		return structSchema(t, key, sensitive)
+	default:
+		// Interfaces accept any value
+		return map[string]any{}
+	}
+}
+
+// structSchema returns the object schema of a struct. Properties use the JSON
+// field names while constraints are looked up by config key.
+func structSchema(t reflect.Type, key string, sensitive map[string]bool) map[string]any {
+	properties := make(map[string]any)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+
+		configName := strings.ToLower(field.Name)
+		yamlTag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if yamlTag != "" && yamlTag != "-" {
+			configName = yamlTag
+		}
+		fieldKey := configName
+		if key != "" {
+			fieldKey = key + "." + configName
+		}
+
+		fieldSchema := typeSchema(field.Type, fieldKey, sensitive)
+		if yamlTag == "-" {
+			fieldSchema["readOnly"] = true
+		}
+		properties[name] = fieldSchema
+	}
+
+	return map[string]any{"type": "object", "properties": properties}
+}
@@ -0,0 +1,152 @@
+package conf
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// schemaProperty walks the properties of a generated schema by JSON field names
+func schemaProperty(t *testing.T, schema map[string]any, names ...string) map[string]any {
+	t.Helper()
+
+	current := schema
+	for _, name := range names {
+		properties, ok := current["properties"].(map[string]any)
+		if !ok {
+			t.Fatalf("schema has no properties at %q", name)
+		}
+		next, ok := properties[name].(map[string]any)
+		if !ok {
+			t.Fatalf("schema has no property %q", name)
+		}
+		current = next
+	}
+	return current
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := GenerateJSONSchema()
+	if err != nil {
+		t.Fatalf("GenerateJSONSchema() error = %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("generated schema is not valid JSON: %v", err)
+	}
+	if schema["$schema"] != jsonSchemaDialect || schema["type"] != "object" {
+		t.Errorf("unexpected schema root: $schema=%v type=%v", schema["$schema"], schema["type"])
+	}
+
+	tests := []struct {
+		name  string
+		path  []string
+		check func(t *testing.T, property map[string]any)
+	}{
+		{"range", []string{"BirdNET", "Sensitivity"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			if p["type"] != "number" || p["minimum"] != 0.0 || p["maximum"] != 1.5 {
+				t.Errorf("Sensitivity schema = %v, want number between 0 and 1.5", p)
+			}
+		}},
+		{"enum", []string{"Realtime", "Weather", "Provider"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			enum, _ := p["enum"].([]any)
+			if p["type"] != "string" || !slices.Contains(enum, any("openweather")) {
+				t.Errorf("Provider schema = %v, want string enum with openweather", p)
+			}
+		}},
+		{"list enum", []string{"Realtime", "Dashboard", "Thumbnails", "Providers"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			items, _ := p["items"].(map[string]any)
+			if _, ok := p["enum"]; ok || items["enum"] == nil || p["uniqueItems"] != true {
+				t.Errorf("Providers schema = %v, want unique items with enum", p)
+			}
+		}},
+		{"sensitive", []string{"Security", "BasicAuth", "Password"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			if p["sensitive"] != true {
+				t.Errorf("Password schema = %v, want sensitive", p)
+			}
+		}},
+		{"not sensitive", []string{"Security", "BasicAuth", "ClientID"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			if _, ok := p["sensitive"]; ok {
+				t.Errorf("ClientID schema = %v, want no sensitive marker", p)
+			}
+		}},
+		{"runtime value", []string{"Version"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			if p["readOnly"] != true {
+				t.Errorf("Version schema = %v, want readOnly", p)
+			}
+		}},
+		{"duration", []string{"Security", "BasicAuth", "AuthCodeExp"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			if p["type"] != "integer" {
+				t.Errorf("AuthCodeExp schema = %v, want integer", p)
+			}
+		}},
+		{"list", []string{"Realtime", "RTSP", "URLs"}, func(t *testing.T, p map[string]any) {
+			t.Helper()
+			items, _ := p["items"].(map[string]any)
+			if p["type"] != "array" || items["type"] != "string" {
+				t.Errorf("URLs schema = %v, want array of strings", p)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.check(t, schemaProperty(t, schema, tt.path...))
+		})
+	}
+}
+
+// TestSettingConstraintKeys ensures every constraint addresses an existing
+// setting, a stale key would silently drop it from the schema
+func TestSettingConstraintKeys(t *testing.T) {
+	t.Parallel()
+
+	settings := reflect.ValueOf(&Settings{}).Elem()
+	for key := range settingConstraints {
+		if _, err := lookupSection(settings, key); err != nil {
+			t.Errorf("constraint key %q does not match a setting: %v", key, err)
+		}
+	}
+}
+
+func TestSettingConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		constraint settingConstraint
+		value      float64
+		inRange    bool
+		text       string
+	}{
+		{"between inside", between(0, 1.5), 1.5, true, "between 0 and 1.5"},
+		{"between below", between(0, 1.5), -0.1, false, "between 0 and 1.5"},
+		{"at least above", atLeast(15), 60, true, "at least 15"},
+		{"at least below", atLeast(15), 5, false, "at least 15"},
+		{"enum has no bounds", oneOf("a", "b"), -1, true, "one of a, b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.constraint.inRange(tt.value); got != tt.inRange {
+				t.Errorf("inRange(%v) = %v, want %v", tt.value, got, tt.inRange)
+			}
+			if got := tt.constraint.String(); got != tt.text {
+				t.Errorf("String() = %q, want %q", got, tt.text)
+			}
+		})
+	}
+}
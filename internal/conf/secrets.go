@@ -0,0 +1,219 @@
+// conf/secrets.go reading secrets from files, e.g. Docker or Kubernetes secrets
+package conf
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// secretFileEnvPrefix is the prefix of environment variables pointing to secret
+// files, e.g. BIRDNET_SECURITY_BASICAUTH_PASSWORD_FILE
+const secretFileEnvPrefix = "BIRDNET_"
+
+// maxSecretFileSize limits how much is read from a secret file
+const maxSecretFileSize = 64 * 1024
+
+// backupTargetSecretKeys lists backup target settings holding credentials
+var backupTargetSecretKeys = []string{"password", "secretaccesskey", "privatekeypassphrase", "token", "accountkey", "sastoken"}
+
+// BackupTargetSecretKeys returns the backup target settings holding
+// credentials, which are removed from sanitized copies of the config
+func BackupTargetSecretKeys() []string {
+	return slices.Clone(backupTargetSecretKeys)
+}
+
+// notificationChannelSecretKeys lists notification channel settings holding
+// credentials
+var notificationChannelSecretKeys = []string{"bottoken", "webhookurl", "token"}
+
+// NotificationChannelSecretKeys returns the notification channel settings
+// holding credentials, which are removed from sanitized copies of the config
+func NotificationChannelSecretKeys() []string {
+	return slices.Clone(notificationChannelSecretKeys)
+}
+
+// secretField describes a configuration value that can be read from a file
+type secretField struct {
+	key     string             // config key, e.g. security.basicauth.password
+	fileRef string             // file reference from the config file
+	get     func() string      // returns the current value
+	set     func(value string) // replaces the value
+}
+
+// secretFields returns the secret-bearing fields of settings
+func secretFields(s *Settings) []secretField {
+	field := func(key string, value, fileRef *string) secretField {
+		return secretField{
+			key:     key,
+			fileRef: *fileRef,
+			get:     func() string { return *value },
+			set:     func(v string) { *value = v },
+		}
+	}
+
+	fields := []secretField{
+		field("security.basicauth.password", &s.Security.BasicAuth.Password, &s.Security.BasicAuth.PasswordFile),
+		field("security.basicauth.clientsecret", &s.Security.BasicAuth.ClientSecret, &s.Security.BasicAuth.ClientSecretFile),
+		field("security.googleauth.clientsecret", &s.Security.GoogleAuth.ClientSecret, &s.Security.GoogleAuth.ClientSecretFile),
+		field("security.githubauth.clientsecret", &s.Security.GithubAuth.ClientSecret, &s.Security.GithubAuth.ClientSecretFile),
+		field("realtime.mqtt.password", &s.Realtime.MQTT.Password, &s.Realtime.MQTT.PasswordFile),
+		field("realtime.weather.openweather.apikey", &s.Realtime.Weather.OpenWeather.APIKey, &s.Realtime.Weather.OpenWeather.APIKeyFile),
+		field("output.mysql.password", &s.Output.MySQL.Password, &s.Output.MySQL.PasswordFile),
+	}
+
+	// Backup target and notification channel settings are free-form maps,
+	// file references use a "file" suffix
+	for i, target := range s.Backup.Targets {
+		fields = append(fields, mapSecretFields(fmt.Sprintf("backup.targets.%d.settings", i), target.Settings, backupTargetSecretKeys)...)
+	}
+	for i, channel := range s.Notifications {
+		fields = append(fields, mapSecretFields(fmt.Sprintf("notifications.%d.settings", i), channel.Settings, notificationChannelSecretKeys)...)
+	}
+
+	return fields
+}
+
+// mapSecretFields returns the secret fields names of a free-form settings map
+// with config key prefix
+func mapSecretFields(prefix string, settings map[string]any, names []string) []secretField {
+	fields := make([]secretField, 0, len(names))
+	for _, name := range names {
+		fileRef, _ := settings[name+"file"].(string)
+		fields = append(fields, secretField{
+			key:     prefix + "." + name,
+			fileRef: fileRef,
+			get: func() string {
+				value, _ := settings[name].(string)
+				return value
+			},
+			set: func(v string) {
+				switch {
+				case settings == nil:
+				case v == "":
+					delete(settings, name)
+				default:
+					settings[name] = v
+				}
+			},
+		})
+	}
+	return fields
+}
+
+// secretFileEnvName returns the environment variable name for a config key
+func secretFileEnvName(key string) string {
+	return secretFileEnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_")) + "_FILE"
+}
+
+// resolveSecretFiles replaces secrets with the contents of their secret files.
+// A file named by the <KEY>_FILE environment variable takes precedence over a
+// file reference in the config file. The inline config values are remembered
+// so that secrets read from files are never written back to the config file.
+func resolveSecretFiles(settings *Settings) error {
+	for _, field := range secretFields(settings) {
+		path := os.Getenv(secretFileEnvName(field.key))
+		if path == "" {
+			path = field.fileRef
+		}
+		if path == "" {
+			continue
+		}
+
+		secret, err := readSecretFile(path, field.key, settings)
+		if err != nil {
+			return err
+		}
+
+		if settings.inlineSecrets == nil {
+			settings.inlineSecrets = make(map[string]string)
+		}
+		settings.inlineSecrets[field.key] = field.get()
+		field.set(secret)
+	}
+
+	return nil
+}
+
+// readSecretFile reads a secret from path, trimming the trailing newline. A
+// warning is recorded when the file is accessible by other users.
+func readSecretFile(path, key string, settings *Settings) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.New(fmt.Errorf("failed to open secret file for %s: %w", key, err)).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-secret-file").
+			Context("config_key", key).
+			Build()
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Failed to close secret file %s: %v", path, err)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", errors.New(fmt.Errorf("failed to stat secret file for %s: %w", key, err)).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-secret-file").
+			Context("config_key", key).
+			Build()
+	}
+	if info.IsDir() {
+		return "", errors.New(fmt.Errorf("secret file for %s is a directory: %s", key, path)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "read-secret-file").
+			Context("config_key", key).
+			Build()
+	}
+
+	// File permissions are not meaningful on Windows
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o007 != 0 {
+		message := fmt.Sprintf("secret file %s for %s is accessible by other users (mode %04o), use 0600 or 0640", path, key, info.Mode().Perm())
+		log.Printf("Configuration warning: %s", message)
+		settings.ValidationWarnings = append(settings.ValidationWarnings, fmt.Sprintf("config-secret-file: %s", message))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSecretFileSize))
+	if err != nil {
+		return "", errors.New(fmt.Errorf("failed to read secret file for %s: %w", key, err)).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-secret-file").
+			Context("config_key", key).
+			Build()
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// restoreInlineSecrets replaces secrets read from files with their original
+// inline values before settings are saved. Backup targets and notification
+// channels are cloned first so that the live settings keep the secrets.
+func restoreInlineSecrets(settings *Settings) {
+	if len(settings.inlineSecrets) == 0 {
+		return
+	}
+
+	settings.Backup.Targets = slices.Clone(settings.Backup.Targets)
+	for i := range settings.Backup.Targets {
+		settings.Backup.Targets[i].Settings = maps.Clone(settings.Backup.Targets[i].Settings)
+	}
+	settings.Notifications = slices.Clone(settings.Notifications)
+	for i := range settings.Notifications {
+		settings.Notifications[i].Settings = maps.Clone(settings.Notifications[i].Settings)
+	}
+
+	for _, field := range secretFields(settings) {
+		if inline, ok := settings.inlineSecrets[field.key]; ok {
+			field.set(inline)
+		}
+	}
+}
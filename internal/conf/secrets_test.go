@@ -0,0 +1,286 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeSecretFile writes a secret file with the given permissions
+func writeSecretFile(t *testing.T, content string, perm os.FileMode) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatalf("failed to chmod secret file: %v", err)
+	}
+	return path
+}
+
+func TestSecretFileEnvName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"security.basicauth.password", "BIRDNET_SECURITY_BASICAUTH_PASSWORD_FILE"},
+		{"realtime.mqtt.password", "BIRDNET_REALTIME_MQTT_PASSWORD_FILE"},
+		{"backup.targets.0.settings.password", "BIRDNET_BACKUP_TARGETS_0_SETTINGS_PASSWORD_FILE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+			if got := secretFileEnvName(tt.key); got != tt.want {
+				t.Errorf("secretFileEnvName(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSecretFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, s *Settings)
+		check func(t *testing.T, s *Settings)
+	}{
+		{
+			name: "basic auth password from file",
+			setup: func(t *testing.T, s *Settings) {
+				t.Helper()
+				s.Security.BasicAuth.Password = "inline"
+				s.Security.BasicAuth.PasswordFile = writeSecretFile(t, "s3cret\n", 0o600)
+			},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.Security.BasicAuth.Password != "s3cret" {
+					t.Errorf("Password = %q, want s3cret", s.Security.BasicAuth.Password)
+				}
+				if s.inlineSecrets["security.basicauth.password"] != "inline" {
+					t.Errorf("inline password not remembered, got %q", s.inlineSecrets["security.basicauth.password"])
+				}
+			},
+		},
+		{
+			name: "service secrets from files",
+			setup: func(t *testing.T, s *Settings) {
+				t.Helper()
+				s.Realtime.MQTT.PasswordFile = writeSecretFile(t, "mqtt-pass\r\n", 0o600)
+				s.Realtime.Weather.OpenWeather.APIKeyFile = writeSecretFile(t, "owm-key", 0o640)
+				s.Output.MySQL.PasswordFile = writeSecretFile(t, "db-pass", 0o400)
+				s.Security.GoogleAuth.ClientSecretFile = writeSecretFile(t, "google", 0o600)
+			},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.Realtime.MQTT.Password != "mqtt-pass" {
+					t.Errorf("MQTT password = %q, want mqtt-pass", s.Realtime.MQTT.Password)
+				}
+				if s.Realtime.Weather.OpenWeather.APIKey != "owm-key" {
+					t.Errorf("OpenWeather API key = %q, want owm-key", s.Realtime.Weather.OpenWeather.APIKey)
+				}
+				if s.Output.MySQL.Password != "db-pass" {
+					t.Errorf("MySQL password = %q, want db-pass", s.Output.MySQL.Password)
+				}
+				if s.Security.GoogleAuth.ClientSecret != "google" {
+					t.Errorf("Google client secret = %q, want google", s.Security.GoogleAuth.ClientSecret)
+				}
+				if len(s.ValidationWarnings) != 0 {
+					t.Errorf("unexpected warnings: %v", s.ValidationWarnings)
+				}
+			},
+		},
+		{
+			name: "backup target credentials from file",
+			setup: func(t *testing.T, s *Settings) {
+				t.Helper()
+				s.Backup.Targets = []BackupTarget{{
+					Type: "s3",
+					Settings: map[string]any{
+						"bucket":              "backups",
+						"secretaccesskeyfile": writeSecretFile(t, "aws-secret\n", 0o600),
+					},
+				}}
+			},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if got := s.Backup.Targets[0].Settings["secretaccesskey"]; got != "aws-secret" {
+					t.Errorf("secretaccesskey = %v, want aws-secret", got)
+				}
+			},
+		},
+		{
+			name: "no secret files configured",
+			setup: func(t *testing.T, s *Settings) {
+				t.Helper()
+				s.Security.BasicAuth.Password = "inline"
+			},
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.Security.BasicAuth.Password != "inline" {
+					t.Errorf("Password = %q, want inline", s.Security.BasicAuth.Password)
+				}
+				if len(s.inlineSecrets) != 0 {
+					t.Errorf("unexpected inline secrets: %v", s.inlineSecrets)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := &Settings{}
+			tt.setup(t, settings)
+			if err := resolveSecretFiles(settings); err != nil {
+				t.Fatalf("resolveSecretFiles() error = %v", err)
+			}
+			tt.check(t, settings)
+		})
+	}
+}
+
+func TestResolveSecretFilesErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		settings := &Settings{}
+		settings.Realtime.MQTT.PasswordFile = filepath.Join(t.TempDir(), "missing")
+		err := resolveSecretFiles(settings)
+		if err == nil {
+			t.Fatal("expected error for missing secret file")
+		}
+		if !strings.Contains(err.Error(), "realtime.mqtt.password") {
+			t.Errorf("error %q does not name the config key", err)
+		}
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		t.Parallel()
+
+		settings := &Settings{}
+		settings.Security.BasicAuth.PasswordFile = t.TempDir()
+		if err := resolveSecretFiles(settings); err == nil {
+			t.Fatal("expected error for secret file directory")
+		}
+	})
+}
+
+func TestResolveSecretFilesPermissionWarning(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not checked on Windows")
+	}
+
+	settings := &Settings{}
+	settings.Security.BasicAuth.PasswordFile = writeSecretFile(t, "s3cret", 0o644)
+	if err := resolveSecretFiles(settings); err != nil {
+		t.Fatalf("resolveSecretFiles() error = %v", err)
+	}
+
+	if settings.Security.BasicAuth.Password != "s3cret" {
+		t.Errorf("Password = %q, want s3cret", settings.Security.BasicAuth.Password)
+	}
+	if len(settings.ValidationWarnings) != 1 || !strings.HasPrefix(settings.ValidationWarnings[0], "config-secret-file:") {
+		t.Errorf("expected one secret file warning, got %v", settings.ValidationWarnings)
+	}
+}
+
+func TestResolveSecretFilesEnvPrecedence(t *testing.T) {
+	settings := &Settings{}
+	settings.Security.BasicAuth.PasswordFile = writeSecretFile(t, "from-yaml", 0o600)
+	t.Setenv("BIRDNET_SECURITY_BASICAUTH_PASSWORD_FILE", writeSecretFile(t, "from-env", 0o600))
+
+	if err := resolveSecretFiles(settings); err != nil {
+		t.Fatalf("resolveSecretFiles() error = %v", err)
+	}
+	if settings.Security.BasicAuth.Password != "from-env" {
+		t.Errorf("Password = %q, want from-env", settings.Security.BasicAuth.Password)
+	}
+}
+
+func TestRestoreInlineSecrets(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Security.BasicAuth.Password = "inline"
+	settings.Security.BasicAuth.PasswordFile = writeSecretFile(t, "s3cret", 0o600)
+	settings.Backup.Targets = []BackupTarget{{
+		Type: "ftp",
+		Settings: map[string]any{
+			"passwordfile": writeSecretFile(t, "ftp-pass", 0o600),
+		},
+	}}
+	if err := resolveSecretFiles(settings); err != nil {
+		t.Fatalf("resolveSecretFiles() error = %v", err)
+	}
+
+	settingsCopy := *settings
+	restoreInlineSecrets(&settingsCopy)
+
+	if settingsCopy.Security.BasicAuth.Password != "inline" {
+		t.Errorf("saved Password = %q, want inline", settingsCopy.Security.BasicAuth.Password)
+	}
+	if got, ok := settingsCopy.Backup.Targets[0].Settings["password"]; ok {
+		t.Errorf("saved backup password = %v, want unset", got)
+	}
+
+	// The live settings must keep the secrets read from files
+	if settings.Security.BasicAuth.Password != "s3cret" {
+		t.Errorf("live Password = %q, want s3cret", settings.Security.BasicAuth.Password)
+	}
+	if got := settings.Backup.Targets[0].Settings["password"]; got != "ftp-pass" {
+		t.Errorf("live backup password = %v, want ftp-pass", got)
+	}
+}
+
+// TestSaveKeepsNotificationSecrets validates settings, which probes audio
+// tools, and sets environment variables, it is not parallel
+func TestSaveKeepsNotificationSecrets(t *testing.T) {
+	t.Setenv("BIRDNET_NOTIFICATIONS_0_SETTINGS_BOTTOKEN_FILE", writeSecretFile(t, "bot-token\n", 0o600))
+
+	settings, err := LoadFromReader(strings.NewReader("main:\n  name: node\n"))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	settings.Notifications = []NotificationChannel{{
+		Type:     NotificationChannelTelegram,
+		Enabled:  true,
+		Settings: map[string]any{"chatid": "@birdfeed"},
+	}}
+	if err := resolveSecretFiles(settings); err != nil {
+		t.Fatalf("resolveSecretFiles() error = %v", err)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("main:\n  name: node\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveSectionFile(configPath, settings, "Notifications"); err != nil {
+		t.Fatalf("saveSectionFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "bot-token") {
+		t.Errorf("saved config contains the secret read from file:\n%s", data)
+	}
+
+	// The live settings must keep the secret read from the file
+	if got := settings.Notifications[0].Settings["bottoken"]; got != "bot-token" {
+		t.Errorf("live bottoken = %v, want bot-token", got)
+	}
+}
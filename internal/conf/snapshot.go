@@ -0,0 +1,113 @@
+// conf/snapshot.go in-memory snapshots of the live settings
+package conf
+
+import (
+	"reflect"
+)
+
+// SnapshotSettings returns a deep copy of the live settings, nil when the
+// settings are not loaded. Changes to the live settings do not affect the
+// snapshot, so that it can be passed to RestoreSnapshot to revert edits that
+// were not saved.
+func SnapshotSettings() *Settings {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	if settingsInstance == nil {
+		return nil
+	}
+
+	speciesListMutex.RLock()
+	defer speciesListMutex.RUnlock()
+	return cloneSettings(settingsInstance)
+}
+
+// RestoreSnapshot replaces the live settings with a snapshot taken with
+// SnapshotSettings without touching the config file. The live instance keeps
+// its address, so that components holding the pointer returned by Setting()
+// see the restored values. Runtime-only values such as the included species
+// list keep their current values. The snapshot is copied and can be restored
+// again, a nil snapshot is ignored.
+func RestoreSnapshot(snapshot *Settings) {
+	if snapshot == nil {
+		return
+	}
+	restored := cloneSettings(snapshot)
+
+	settingsMutex.Lock()
+	defer settingsMutex.Unlock()
+
+	if settingsInstance == nil {
+		settingsInstance = restored
+		return
+	}
+
+	speciesListMutex.Lock()
+	defer speciesListMutex.Unlock()
+	preserveRuntimeFields(reflect.ValueOf(restored).Elem(), reflect.ValueOf(settingsInstance).Elem())
+	*settingsInstance = *restored
+}
+
+// cloneSettings returns a deep copy of settings, the caller must hold the
+// locks guarding its fields
+func cloneSettings(settings *Settings) *Settings {
+	return cloneValue(reflect.ValueOf(settings)).Interface().(*Settings)
+}
+
+// cloneValue returns a deep copy of value. Pointers, slices, maps and
+// interfaces are copied recursively, unexported struct fields are copied as
+// they are.
+func cloneValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		clone := reflect.New(value.Type().Elem())
+		clone.Elem().Set(cloneValue(value.Elem()))
+		return clone
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		clone := reflect.New(value.Type()).Elem()
+		clone.Set(cloneValue(value.Elem()))
+		return clone
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		clone := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := range value.Len() {
+			clone.Index(i).Set(cloneValue(value.Index(i)))
+		}
+		return clone
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		clone := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			clone.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+		return clone
+	case reflect.Array:
+		clone := reflect.New(value.Type()).Elem()
+		for i := range value.Len() {
+			clone.Index(i).Set(cloneValue(value.Index(i)))
+		}
+		return clone
+	case reflect.Struct:
+		clone := reflect.New(value.Type()).Elem()
+		clone.Set(value)
+		for i := range value.NumField() {
+			if field := clone.Field(i); field.CanSet() {
+				field.Set(cloneValue(value.Field(i)))
+			}
+		}
+		return clone
+	default:
+		return value
+	}
+}
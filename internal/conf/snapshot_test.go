@@ -0,0 +1,89 @@
+package conf
+
+import (
+	"slices"
+	"testing"
+)
+
+// Not parallel, the tests replace the global settings
+
+func TestSnapshotSettings(t *testing.T) {
+	SetTestSettings(nil)
+	t.Cleanup(func() { SetTestSettings(nil) })
+
+	if snapshot := SnapshotSettings(); snapshot != nil {
+		t.Errorf("SnapshotSettings() without settings = %v, want nil", snapshot)
+	}
+
+	live := &Settings{}
+	live.Main.Name = "garden-node"
+	live.Realtime.RTSP.URLs = []string{"rtsp://camera.local/stream"}
+	live.Realtime.Species.Config = map[string]SpeciesConfig{
+		"eurasian blackbird": {Threshold: 0.7, Actions: []SpeciesAction{{Type: "ExecuteCommand", Parameters: []string{"CommonName"}}}},
+	}
+	SetTestSettings(live)
+
+	snapshot := SnapshotSettings()
+	live.Main.Name = "edited"
+	live.Realtime.RTSP.URLs[0] = "rtsp://edited.local/stream"
+	live.Realtime.Species.Config["eurasian blackbird"].Actions[0].Parameters[0] = "Edited"
+	live.Realtime.Species.Config["great tit"] = SpeciesConfig{Threshold: 0.5}
+
+	if snapshot.Main.Name != "garden-node" {
+		t.Errorf("snapshot Main.Name = %q, want garden-node", snapshot.Main.Name)
+	}
+	if snapshot.Realtime.RTSP.URLs[0] != "rtsp://camera.local/stream" {
+		t.Errorf("snapshot RTSP URL = %q, want the value at snapshot time", snapshot.Realtime.RTSP.URLs[0])
+	}
+	if got := snapshot.Realtime.Species.Config["eurasian blackbird"].Actions[0].Parameters[0]; got != "CommonName" {
+		t.Errorf("snapshot action parameter = %q, want CommonName", got)
+	}
+	if _, ok := snapshot.Realtime.Species.Config["great tit"]; ok {
+		t.Error("snapshot contains a species config added after the snapshot")
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	t.Cleanup(func() { SetTestSettings(nil) })
+
+	live := &Settings{}
+	live.Version = "1.2.3"
+	live.BirdNET.Threshold = 0.8
+	live.Realtime.Species.Include = []string{"Eurasian Blackbird"}
+	SetTestSettings(live)
+
+	snapshot := SnapshotSettings()
+	live.BirdNET.Threshold = 0.2
+	live.Realtime.Species.Include = append(live.Realtime.Species.Include, "Great Tit")
+	live.UpdateIncludedSpecies([]string{"Turdus merula_Eurasian Blackbird"})
+
+	RestoreSnapshot(snapshot)
+
+	if GetSettings() != live {
+		t.Fatal("RestoreSnapshot() replaced the live settings pointer")
+	}
+	if live.BirdNET.Threshold != 0.8 {
+		t.Errorf("BirdNET.Threshold = %v, want 0.8", live.BirdNET.Threshold)
+	}
+	if !slices.Equal(live.Realtime.Species.Include, []string{"Eurasian Blackbird"}) {
+		t.Errorf("Species.Include = %v, want the snapshot value", live.Realtime.Species.Include)
+	}
+	if got := live.GetIncludedSpecies(); !slices.Equal(got, []string{"Turdus merula_Eurasian Blackbird"}) {
+		t.Errorf("included species = %v, want the runtime value to be kept", got)
+	}
+	if live.Version != "1.2.3" {
+		t.Errorf("Version = %q, want 1.2.3", live.Version)
+	}
+
+	// The snapshot is not shared with the live settings and can be restored again
+	live.Realtime.Species.Include[0] = "Edited"
+	RestoreSnapshot(snapshot)
+	if live.Realtime.Species.Include[0] != "Eurasian Blackbird" {
+		t.Errorf("Species.Include after restoring again = %v, want the snapshot value", live.Realtime.Species.Include)
+	}
+
+	RestoreSnapshot(nil)
+	if GetSettings() != live {
+		t.Error("RestoreSnapshot(nil) changed the live settings")
+	}
+}
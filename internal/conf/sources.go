@@ -0,0 +1,90 @@
+// conf/sources.go where the value of each setting came from
+package conf
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// Sources of SettingSources
+const (
+	SourceFile     = "file"     // set in the config file
+	SourceEnv      = "env"      // read from a secret file named by an environment variable
+	SourceDefault  = "default"  // built-in default, embedded config.yaml or default override
+	SourceOverride = "override" // changed after loading without saving, e.g. by command line flags
+)
+
+// SettingSources returns where the live value of each setting came from, one
+// of the Source constants per config key such as "birdnet.threshold". Values
+// differing from the config file content last loaded or saved are overrides,
+// values missing from the config file are defaults. Nil is returned when the
+// settings are not loaded.
+func SettingSources() map[string]string {
+	inFile := configFileKeys()
+
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+	if settingsInstance == nil {
+		return nil
+	}
+
+	configChanges.mu.Lock()
+	saved := configChanges.saved
+	configChanges.mu.Unlock()
+
+	overridden := make(map[string]bool)
+	if saved != nil {
+		speciesListMutex.RLock()
+		for _, key := range Diff(saved, settingsInstance) {
+			overridden[key] = true
+		}
+		speciesListMutex.RUnlock()
+	}
+
+	fromEnv := make(map[string]bool)
+	for _, field := range secretFields(settingsInstance) {
+		if os.Getenv(secretFileEnvName(field.key)) != "" {
+			fromEnv[field.key] = true
+		}
+	}
+
+	settings := DescribeSettings()
+	sources := make(map[string]string, len(settings))
+	for i := range settings {
+		key := settings[i].Path
+		switch {
+		case overridden[key]:
+			sources[key] = SourceOverride
+		case fromEnv[key]:
+			sources[key] = SourceEnv
+		case inFile(key):
+			sources[key] = SourceFile
+		default:
+			sources[key] = SourceDefault
+		}
+	}
+	return sources
+}
+
+// configFileKeys returns a function reporting whether a config key is set in
+// the config file. The file is read again to see saves since loading, the
+// content viper loaded is used when it cannot be read.
+func configFileKeys() func(key string) bool {
+	configPath, err := configFilePath()
+	if err != nil {
+		return viper.InConfig
+	}
+	data, err := readConfigFile(configPath)
+	if err != nil {
+		return viper.InConfig
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return viper.InConfig
+	}
+	return v.InConfig
+}
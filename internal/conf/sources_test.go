@@ -0,0 +1,52 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// Not parallel, the test replaces the global settings and sets environment
+// variables
+
+func TestSettingSources(t *testing.T) {
+	SetTestSettings(nil)
+	t.Cleanup(func() { SetTestSettings(nil) })
+	t.Cleanup(viper.Reset)
+
+	if sources := SettingSources(); sources != nil {
+		t.Errorf("SettingSources() without settings = %v, want nil", sources)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("birdnet:\n  threshold: 0.6\n  sensitivity: 1.2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(configPath)
+	t.Setenv(secretFileEnvName("realtime.mqtt.password"), filepath.Join(t.TempDir(), "mqtt-password"))
+
+	live := &Settings{}
+	live.BirdNET.Threshold = 0.6
+	live.BirdNET.Sensitivity = 1.2
+	SetTestSettings(live)
+	// Set after loading, like a command line flag
+	live.BirdNET.Sensitivity = 1.4
+
+	sources := SettingSources()
+	want := map[string]string{
+		"birdnet.threshold":      SourceFile,
+		"birdnet.sensitivity":    SourceOverride,
+		"realtime.mqtt.password": SourceEnv,
+		"main.name":              SourceDefault,
+	}
+	for key, source := range want {
+		if sources[key] != source {
+			t.Errorf("SettingSources()[%q] = %q, want %q", key, sources[key], source)
+		}
+	}
+	if len(sources) != len(DescribeSettings()) {
+		t.Errorf("SettingSources() has %d keys, want one per setting (%d)", len(sources), len(DescribeSettings()))
+	}
+}
@@ -0,0 +1,211 @@
+// conf/species.go species name matching for include, exclude and per-species config
+package conf
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// NormalizeSpeciesName prepares a species name for case-insensitive comparison.
+// Surrounding whitespace is trimmed, internal whitespace and underscores are
+// collapsed into single spaces and the result is lowercased.
+func NormalizeSpeciesName(name string) string {
+	name = strings.ReplaceAll(name, "_", " ")
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// normalizeScientificName normalizes a scientific name and trims the subspecies
+// of a trinomial name so that "Parus major major" matches "Parus major". Only
+// scientific names are passed, trimming common names would match different
+// species sharing their first two words.
+func normalizeScientificName(name string) string {
+	fields := strings.Fields(NormalizeSpeciesName(name))
+	if len(fields) == 3 {
+		fields = fields[:2]
+	}
+	return strings.Join(fields, " ")
+}
+
+// Matches reports whether either the common or the scientific name of a species
+// matches an entry of list. Matching is case-insensitive and the subspecies of
+// a detected trinomial scientific name is ignored.
+func (s SpeciesSettings) Matches(list []string, common, scientific string) bool {
+	for _, entry := range list {
+		if speciesNameMatches(entry, common, scientific) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIncluded reports whether the species is in the Include list.
+func (s SpeciesSettings) IsIncluded(common, scientific string) bool {
+	return s.Matches(s.Include, common, scientific)
+}
+
+// IsExcluded reports whether the species is in the Exclude list.
+func (s SpeciesSettings) IsExcluded(common, scientific string) bool {
+	return s.Matches(s.Exclude, common, scientific)
+}
+
+// LookupConfig returns the per-species configuration for a species, matching
+// config keys against both its common and scientific name.
+func (s SpeciesSettings) LookupConfig(common, scientific string) (SpeciesConfig, bool) {
+	// Fast path, viper stores map keys in lowercase
+	if config, exists := s.Config[NormalizeSpeciesName(common)]; exists && common != "" {
+		return config, true
+	}
+
+	for name, config := range s.Config {
+		if speciesNameMatches(name, common, scientific) {
+			return config, true
+		}
+	}
+	return SpeciesConfig{}, false
+}
+
+// speciesNameMatches compares a configured species name against a common and
+// scientific name pair.
+func speciesNameMatches(entry, common, scientific string) bool {
+	normalizedEntry := NormalizeSpeciesName(entry)
+	if normalizedEntry == "" {
+		return false
+	}
+
+	if common != "" && normalizedEntry == NormalizeSpeciesName(common) {
+		return true
+	}
+
+	if scientific != "" && normalizedEntry == normalizeScientificName(scientific) {
+		return true
+	}
+
+	return false
+}
+
+// clone returns a deep copy of the species config
+func (c SpeciesConfig) clone() SpeciesConfig {
+	c.Actions = slices.Clone(c.Actions)
+	for i := range c.Actions {
+		c.Actions[i].Parameters = slices.Clone(c.Actions[i].Parameters)
+		c.Actions[i].Env = maps.Clone(c.Actions[i].Env)
+	}
+	if c.KeepSpectrograms != nil {
+		keep := *c.KeepSpectrograms
+		c.KeepSpectrograms = &keep
+	}
+	return c
+}
+
+// UpdateSpeciesConfig sets the per-species configuration of a species. The
+// config map is replaced with an updated copy while holding the settings
+// mutex, so that readers using SpeciesConfigs or LookupSpeciesConfig never
+// see a map being modified. Existing entries whose name only differs in case
+// or whitespace are replaced.
+func (s *Settings) UpdateSpeciesConfig(name string, cfg SpeciesConfig) {
+	settingsMutex.Lock()
+	defer settingsMutex.Unlock()
+
+	key := NormalizeSpeciesName(name)
+	configs := withoutSpecies(s.Realtime.Species.Config, key)
+	configs[key] = cfg.clone()
+	s.Realtime.Species.Config = configs
+}
+
+// RemoveSpeciesConfig removes the per-species configuration of a species, see
+// UpdateSpeciesConfig
+func (s *Settings) RemoveSpeciesConfig(name string) {
+	settingsMutex.Lock()
+	defer settingsMutex.Unlock()
+
+	s.Realtime.Species.Config = withoutSpecies(s.Realtime.Species.Config, NormalizeSpeciesName(name))
+}
+
+// SpeciesConfigs returns a deep copy of the per-species configurations that
+// the caller may keep and modify
+func (s *Settings) SpeciesConfigs() map[string]SpeciesConfig {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	configs := make(map[string]SpeciesConfig, len(s.Realtime.Species.Config))
+	for name, config := range s.Realtime.Species.Config {
+		configs[name] = config.clone()
+	}
+	return configs
+}
+
+// LookupSpeciesConfig is SpeciesSettings.LookupConfig holding the settings
+// mutex, for use while the config may be updated concurrently
+func (s *Settings) LookupSpeciesConfig(common, scientific string) (SpeciesConfig, bool) {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	config, ok := s.Realtime.Species.LookupConfig(common, scientific)
+	return config.clone(), ok
+}
+
+// DetectionInterval returns the minimum time between reported detections of a
+// species, given by its common name. Its per-species interval is used when
+// set, otherwise the global realtime interval.
+func (s *Settings) DetectionInterval(commonName string) time.Duration {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	if config, ok := s.Realtime.Species.LookupConfig(commonName, ""); ok && config.Interval > 0 {
+		return time.Duration(config.Interval) * time.Second
+	}
+	return time.Duration(s.Realtime.Interval) * time.Second
+}
+
+// KeepSpectrogram reports whether the spectrograms of a species, given by its
+// common and scientific name, are kept when retention deletes its audio clips.
+// Its per-species setting is used when set, otherwise the global retention
+// setting.
+func (s *Settings) KeepSpectrogram(common, scientific string) bool {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	if config, ok := s.Realtime.Species.LookupConfig(common, scientific); ok && config.KeepSpectrograms != nil {
+		return *config.KeepSpectrograms
+	}
+	return s.Realtime.Audio.Export.Retention.KeepSpectrograms
+}
+
+// withoutSpecies returns a copy of configs without the entries named key once
+// normalized
+func withoutSpecies(configs map[string]SpeciesConfig, key string) map[string]SpeciesConfig {
+	updated := maps.Clone(configs)
+	if updated == nil {
+		updated = make(map[string]SpeciesConfig)
+	}
+	maps.DeleteFunc(updated, func(name string, _ SpeciesConfig) bool {
+		return NormalizeSpeciesName(name) == key
+	})
+	return updated
+}
+
+// SpeciesNames maps the scientific names of species labels to their common
+// names, for species known only by their scientific name such as in audio
+// clip file names
+type SpeciesNames map[string]string
+
+// NewSpeciesNames returns the species names of labels in the
+// "Scientific name_Common name" format of BirdNET labels
+func NewSpeciesNames(labels []string) SpeciesNames {
+	names := make(SpeciesNames, len(labels))
+	for _, label := range labels {
+		if scientific, common, ok := strings.Cut(label, "_"); ok {
+			names[normalizeScientificName(scientific)] = common
+		}
+	}
+	return names
+}
+
+// CommonName returns the common name of a scientific name, matched like
+// species config keys, e.g. "Snowy Owl" for "bubo_scandiacus", or an empty
+// string for species without a label
+func (n SpeciesNames) CommonName(scientific string) string {
+	return n[normalizeScientificName(scientific)]
+}
@@ -0,0 +1,81 @@
+// conf/species_actions.go timeout and environment of species command actions
+package conf
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Timeouts of species command actions
+const (
+	DefaultActionTimeout = 30 * time.Second // used when TimeoutSeconds is not set
+	MaxActionTimeout     = time.Hour
+)
+
+// actionEnvNamePattern matches the environment variable names accepted in
+// SpeciesAction.Env
+var actionEnvNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Timeout returns the time a command action may run before it is killed,
+// DefaultActionTimeout when TimeoutSeconds is not set
+func (a SpeciesAction) Timeout() time.Duration {
+	if a.TimeoutSeconds <= 0 {
+		return DefaultActionTimeout
+	}
+	return time.Duration(a.TimeoutSeconds) * time.Second
+}
+
+// Environment returns base, a list of NAME=value entries, with Env merged
+// over it. Names are uppercased because config keys are case-insensitive and
+// read in lowercase. Entries are sorted for a stable result.
+func (a SpeciesAction) Environment(base []string) []string {
+	env := make(map[string]string, len(base)+len(a.Env))
+	for _, entry := range base {
+		if name, value, ok := strings.Cut(entry, "="); ok {
+			env[name] = value
+		}
+	}
+	for name, value := range a.Env {
+		env[strings.ToUpper(name)] = value
+	}
+
+	merged := make([]string, 0, len(env))
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		merged = append(merged, name+"="+env[name])
+	}
+	return merged
+}
+
+// validateSpeciesActions checks the timeouts and environment variables of
+// the per-species actions
+func validateSpeciesActions(settings *RealtimeSettings) error {
+	for _, species := range slices.Sorted(maps.Keys(settings.Species.Config)) {
+		for _, action := range settings.Species.Config[species].Actions {
+			timeout := time.Duration(action.TimeoutSeconds) * time.Second
+			if action.TimeoutSeconds < 0 || timeout > MaxActionTimeout {
+				return errors.New(fmt.Errorf("species %q action timeoutseconds must be between 0 and %d, got %d",
+					species, int(MaxActionTimeout.Seconds()), action.TimeoutSeconds)).
+					Category(errors.CategoryValidation).
+					Context("validation_type", ErrCodeActionTimeout).
+					Context("species", species).
+					Build()
+			}
+			for _, name := range slices.Sorted(maps.Keys(action.Env)) {
+				if !actionEnvNamePattern.MatchString(name) || strings.ContainsRune(action.Env[name], 0) {
+					return errors.New(fmt.Errorf("species %q action has an invalid environment variable %q, names must be letters, digits and underscores", species, name)).
+						Category(errors.CategoryValidation).
+						Context("validation_type", ErrCodeActionEnv).
+						Context("species", species).
+						Build()
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package conf
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSpeciesActionTimeout(t *testing.T) {
+	t.Parallel()
+
+	if got := (SpeciesAction{}).Timeout(); got != DefaultActionTimeout {
+		t.Errorf("Timeout() = %s, want %s", got, DefaultActionTimeout)
+	}
+	if got := (SpeciesAction{TimeoutSeconds: 5}).Timeout(); got != 5*time.Second {
+		t.Errorf("Timeout() = %s, want 5s", got)
+	}
+}
+
+func TestSpeciesActionEnvironment(t *testing.T) {
+	t.Parallel()
+
+	action := SpeciesAction{Env: map[string]string{"alert_level": "high", "path": "/opt/bin"}}
+	got := action.Environment([]string{"PATH=/usr/bin", "TMP=/tmp"})
+	want := []string{"ALERT_LEVEL=high", "PATH=/opt/bin", "TMP=/tmp"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Environment() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateSpeciesActions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		action   SpeciesAction
+		wantCode string
+	}{
+		{"defaults", SpeciesAction{Type: "ExecuteCommand"}, ""},
+		{"timeout and env", SpeciesAction{TimeoutSeconds: 60, Env: map[string]string{"ALERT_LEVEL": "high"}}, ""},
+		{"negative timeout", SpeciesAction{TimeoutSeconds: -1}, ErrCodeActionTimeout},
+		{"timeout too long", SpeciesAction{TimeoutSeconds: 7200}, ErrCodeActionTimeout},
+		{"invalid env name", SpeciesAction{Env: map[string]string{"ALERT-LEVEL": "high"}}, ErrCodeActionEnv},
+		{"env name starting with digit", SpeciesAction{Env: map[string]string{"1LEVEL": "high"}}, ErrCodeActionEnv},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &RealtimeSettings{}
+			settings.Species.Config = map[string]SpeciesConfig{"snowy owl": {Actions: []SpeciesAction{tt.action}}}
+			err := validateSpeciesActions(settings)
+			if (err != nil) != (tt.wantCode != "") {
+				t.Fatalf("validateSpeciesActions() error = %v, want code %q", err, tt.wantCode)
+			}
+			if err != nil && validationCode(err) != tt.wantCode {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// conf/species_occurrences.go minimum occurrences before a species is reported
+package conf
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// OccurrenceGate returns the number of detections of a species, given by its
+// common and scientific name, needed within window before it is reported. The
+// window is its per-species interval when set, otherwise the global realtime
+// interval, and zero means the detections never expire. A minimum of 1 or
+// less reports every detection.
+func (s *Settings) OccurrenceGate(common, scientific string) (minOccurrences int, window time.Duration) {
+	settingsMutex.RLock()
+	defer settingsMutex.RUnlock()
+
+	config, ok := s.Realtime.Species.LookupConfig(common, scientific)
+	if !ok || config.MinOccurrences <= 1 {
+		return 1, 0
+	}
+	interval := config.Interval
+	if interval <= 0 {
+		interval = s.Realtime.Interval
+	}
+	return config.MinOccurrences, time.Duration(interval) * time.Second
+}
+
+// OccurrenceTracker counts the recent detections of species for
+// OccurrenceGate, it is safe for concurrent use
+type OccurrenceTracker struct {
+	mu          sync.Mutex
+	occurrences map[string][]time.Time // detection times by normalized species name
+}
+
+// NewOccurrenceTracker returns an empty occurrence tracker
+func NewOccurrenceTracker() *OccurrenceTracker {
+	return &OccurrenceTracker{occurrences: make(map[string][]time.Time)}
+}
+
+// Record records a detection of species at time at and reports whether the
+// species has at least minOccurrences detections within window before at,
+// when it should be reported. Detections older than window are forgotten.
+func (t *OccurrenceTracker) Record(species string, at time.Time, minOccurrences int, window time.Duration) bool {
+	key := NormalizeSpeciesName(species)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if minOccurrences <= 1 {
+		delete(t.occurrences, key)
+		return true
+	}
+
+	times := t.occurrences[key]
+	if window > 0 {
+		times = slices.DeleteFunc(times, func(seen time.Time) bool {
+			return at.Sub(seen) >= window
+		})
+	}
+	times = append(times, at)
+	// Only the latest minOccurrences detections decide the outcome
+	if len(times) > minOccurrences {
+		times = times[len(times)-minOccurrences:]
+	}
+	t.occurrences[key] = times
+	return len(times) >= minOccurrences
+}
+
+// Count returns the number of detections of species recorded within window
+// before now
+func (t *OccurrenceTracker) Count(species string, now time.Time, window time.Duration) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := 0
+	for _, seen := range t.occurrences[NormalizeSpeciesName(species)] {
+		if window <= 0 || now.Sub(seen) < window {
+			count++
+		}
+	}
+	return count
+}
+
+// validateMinOccurrences checks that the per-species minimum occurrences are
+// not negative
+func validateMinOccurrences(settings *RealtimeSettings) error {
+	for _, name := range slices.Sorted(maps.Keys(settings.Species.Config)) {
+		if minOccurrences := settings.Species.Config[name].MinOccurrences; minOccurrences < 0 {
+			return errors.New(fmt.Errorf("species %q minoccurrences must be non-negative, got %d", name, minOccurrences)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeSpeciesMinOccurrences).
+				Context("species", name).
+				Build()
+		}
+	}
+	return nil
+}
+
+// warnOccurrencesWithoutInterval warns about species requiring several
+// occurrences without a per-species interval, their window falls back to the
+// global interval. It returns the warning, empty when there is none.
+func warnOccurrencesWithoutInterval(settings *Settings) string {
+	var species []string
+	for _, name := range slices.Sorted(maps.Keys(settings.Realtime.Species.Config)) {
+		if config := settings.Realtime.Species.Config[name]; config.MinOccurrences > 1 && config.Interval <= 0 {
+			species = append(species, name)
+		}
+	}
+	if len(species) == 0 {
+		return ""
+	}
+
+	window := fmt.Sprintf("the global interval of %d seconds", settings.Realtime.Interval)
+	if settings.Realtime.Interval <= 0 {
+		window = "no window, their detections never expire"
+	}
+	message := fmt.Sprintf("species %q set minoccurrences without an interval, counting occurrences within %s", species, window)
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeSpeciesMinOccurrences, "min-occurrences-interval")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-species-validation: %s", message))
+	return message
+}
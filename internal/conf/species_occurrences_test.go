@@ -0,0 +1,125 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccurrenceGate(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Realtime.Interval = 15
+	settings.Realtime.Species.Config = map[string]SpeciesConfig{
+		"snowy owl":            {MinOccurrences: 3, Interval: 600},
+		"eurasian lynx":        {MinOccurrences: 2},
+		"great tit":            {MinOccurrences: 1, Interval: 60},
+		"tyto alba":            {MinOccurrences: 2},
+		"lesser spotted eagle": {MinOccurrences: 4},
+	}
+
+	tests := []struct {
+		common     string
+		scientific string
+		wantMin    int
+		wantWindow time.Duration
+	}{
+		{"Snowy Owl", "Bubo scandiacus", 3, 10 * time.Minute},
+		{"eurasian lynx", "Lynx lynx", 2, 15 * time.Second},
+		{"great tit", "Parus major", 1, 0},
+		{"Barn Owl", "Tyto alba", 2, 15 * time.Second},
+		{"Lesser Spotted Woodpecker", "Dryobates minor", 1, 0},
+		{"not configured", "", 1, 0},
+	}
+	for _, tt := range tests {
+		if gotMin, gotWindow := settings.OccurrenceGate(tt.common, tt.scientific); gotMin != tt.wantMin || gotWindow != tt.wantWindow {
+			t.Errorf("OccurrenceGate(%q, %q) = %d, %s, want %d, %s", tt.common, tt.scientific, gotMin, gotWindow, tt.wantMin, tt.wantWindow)
+		}
+	}
+}
+
+func TestOccurrenceTrackerRecord(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewOccurrenceTracker()
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+	window := 10 * time.Minute
+
+	steps := []struct {
+		offset time.Duration
+		want   bool
+	}{
+		{0, false},
+		{time.Minute, false},
+		{2 * time.Minute, true},
+		{3 * time.Minute, true},
+		// The earlier detections have expired
+		{20 * time.Minute, false},
+		{21 * time.Minute, false},
+		{22 * time.Minute, true},
+	}
+	for _, step := range steps {
+		if got := tracker.Record("Snowy Owl", start.Add(step.offset), 3, window); got != step.want {
+			t.Errorf("Record() at +%s = %v, want %v", step.offset, got, step.want)
+		}
+	}
+	if got := tracker.Count("snowy owl", start.Add(22*time.Minute), window); got != 3 {
+		t.Errorf("Count() = %d, want 3", got)
+	}
+	if !tracker.Record("great tit", start, 1, window) {
+		t.Error("Record() with minimum 1 = false, want true")
+	}
+}
+
+func TestOccurrenceTrackerWithoutWindow(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewOccurrenceTracker()
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+	if tracker.Record("eurasian lynx", start, 2, 0) {
+		t.Error("first Record() = true, want false")
+	}
+	if !tracker.Record("eurasian lynx", start.Add(48*time.Hour), 2, 0) {
+		t.Error("second Record() = false, want true")
+	}
+}
+
+func TestValidateMinOccurrences(t *testing.T) {
+	t.Parallel()
+
+	settings := &RealtimeSettings{}
+	settings.Species.Config = map[string]SpeciesConfig{"snowy owl": {MinOccurrences: 3}}
+	if err := validateMinOccurrences(settings); err != nil {
+		t.Fatalf("validateMinOccurrences() error = %v", err)
+	}
+
+	settings.Species.Config["eurasian lynx"] = SpeciesConfig{MinOccurrences: -1}
+	err := validateMinOccurrences(settings)
+	if err == nil {
+		t.Fatal("validateMinOccurrences() error = nil, want error")
+	}
+	if validationCode(err) != ErrCodeSpeciesMinOccurrences {
+		t.Errorf("validationCode() = %q, want %q", validationCode(err), ErrCodeSpeciesMinOccurrences)
+	}
+}
+
+func TestWarnOccurrencesWithoutInterval(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Realtime.Interval = 15
+	settings.Realtime.Species.Config = map[string]SpeciesConfig{
+		"snowy owl": {MinOccurrences: 3, Interval: 600},
+	}
+	if warning := warnOccurrencesWithoutInterval(settings); warning != "" {
+		t.Errorf("warnOccurrencesWithoutInterval() = %q, want no warning", warning)
+	}
+
+	settings.Realtime.Species.Config["eurasian lynx"] = SpeciesConfig{MinOccurrences: 2}
+	if warning := warnOccurrencesWithoutInterval(settings); warning == "" {
+		t.Error("warnOccurrencesWithoutInterval() = no warning, want warning")
+	}
+	if len(settings.ValidationWarnings) != 1 {
+		t.Errorf("ValidationWarnings = %v, want one warning", settings.ValidationWarnings)
+	}
+}
@@ -0,0 +1,300 @@
+package conf
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSpeciesSettingsMatches(t *testing.T) {
+	t.Parallel()
+
+	settings := SpeciesSettings{}
+
+	tests := []struct {
+		name       string
+		list       []string
+		common     string
+		scientific string
+		want       bool
+	}{
+		{"exact common name", []string{"Great Tit"}, "Great Tit", "Parus major", true},
+		{"mixed case common name", []string{"great TIT"}, "Great Tit", "Parus major", true},
+		{"scientific name in list, common detection", []string{"Parus major"}, "Great Tit", "Parus major", true},
+		{"scientific name mixed case", []string{"PARUS MAJOR"}, "Great Tit", "Parus major", true},
+		{"scientific only detection", []string{"Parus major"}, "", "parus major", true},
+		{"subspecies in list does not match species", []string{"Parus major major"}, "Great Tit", "Parus major", false},
+		{"subspecies in detection", []string{"Parus major"}, "Great Tit", "Parus major newtoni", true},
+		{"extra whitespace", []string{"  Great   Tit "}, "Great Tit", "", true},
+		{"underscore separated", []string{"Parus_major"}, "", "Parus major", true},
+		{"common name does not match scientific", []string{"Great Tit"}, "", "Parus major", false},
+		{"different species", []string{"Blue Tit"}, "Great Tit", "Parus major", false},
+		{"same genus different species", []string{"Parus minor"}, "Great Tit", "Parus major", false},
+		{"common names sharing first two words", []string{"Lesser Spotted Eagle"}, "Lesser Spotted Woodpecker", "Dryobates minor", false},
+		{"common name as scientific name", []string{"Lesser Spotted Eagle"}, "Lesser Spotted Woodpecker", "Lesser Spotted Woodpecker", false},
+		{"empty entry", []string{""}, "", "", false},
+		{"empty list", nil, "Great Tit", "Parus major", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := settings.Matches(tt.list, tt.common, tt.scientific); got != tt.want {
+				t.Errorf("Matches(%v, %q, %q) = %v, want %v", tt.list, tt.common, tt.scientific, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeciesSettingsIncludeExclude(t *testing.T) {
+	t.Parallel()
+
+	settings := SpeciesSettings{
+		Include: []string{"eurasian blue tit"},
+		Exclude: []string{"Corvus Corax", "House Sparrow"},
+	}
+
+	if !settings.IsIncluded("Eurasian Blue Tit", "Cyanistes caeruleus") {
+		t.Error("expected Eurasian Blue Tit to be included")
+	}
+	if settings.IsIncluded("Great Tit", "Parus major") {
+		t.Error("expected Great Tit not to be included")
+	}
+	if !settings.IsExcluded("Common Raven", "Corvus corax") {
+		t.Error("expected Common Raven to be excluded by scientific name")
+	}
+	if !settings.IsExcluded("house sparrow", "Passer domesticus") {
+		t.Error("expected house sparrow to be excluded by common name")
+	}
+	if settings.IsExcluded("Eurasian Blue Tit", "Cyanistes caeruleus") {
+		t.Error("expected Eurasian Blue Tit not to be excluded")
+	}
+}
+
+func TestSpeciesSettingsLookupConfig(t *testing.T) {
+	t.Parallel()
+
+	settings := SpeciesSettings{
+		Config: map[string]SpeciesConfig{
+			"great tit":           {Threshold: 0.5},
+			"cyanistes caeruleus": {Threshold: 0.6},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		common        string
+		scientific    string
+		wantThreshold float64
+		wantFound     bool
+	}{
+		{"common name key", "Great Tit", "Parus major", 0.5, true},
+		{"common name key uppercase", "GREAT TIT", "", 0.5, true},
+		{"scientific name key", "Eurasian Blue Tit", "Cyanistes caeruleus", 0.6, true},
+		{"scientific name key with subspecies", "", "Cyanistes caeruleus ogliastrae", 0.6, true},
+		{"not configured", "House Sparrow", "Passer domesticus", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config, found := settings.LookupConfig(tt.common, tt.scientific)
+			if found != tt.wantFound {
+				t.Fatalf("LookupConfig(%q, %q) found = %v, want %v", tt.common, tt.scientific, found, tt.wantFound)
+			}
+			if config.Threshold != tt.wantThreshold {
+				t.Errorf("LookupConfig(%q, %q) threshold = %v, want %v", tt.common, tt.scientific, config.Threshold, tt.wantThreshold)
+			}
+		})
+	}
+}
+
+func TestDetectionInterval(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Realtime.Interval = 30
+	settings.Realtime.Species.Config = map[string]SpeciesConfig{
+		"house sparrow":        {Interval: 300},
+		"barn owl":             {Threshold: 0.5},
+		"lesser spotted eagle": {Interval: 300},
+	}
+
+	tests := []struct {
+		name    string
+		species string
+		want    time.Duration
+	}{
+		{"override by common name", "House Sparrow", 5 * time.Minute},
+		{"config without interval", "Barn Owl", 30 * time.Second},
+		{"common name sharing first two words", "lesser spotted woodpecker", 30 * time.Second},
+		{"not configured", "Bubo bubo", 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := settings.DetectionInterval(tt.species); got != tt.want {
+				t.Errorf("DetectionInterval(%q) = %v, want %v", tt.species, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeepSpectrogram(t *testing.T) {
+	t.Parallel()
+
+	keep, discard := true, false
+	settings := &Settings{}
+	settings.Realtime.Audio.Export.Retention.KeepSpectrograms = false
+	settings.Realtime.Species.Config = map[string]SpeciesConfig{
+		"snowy owl":            {KeepSpectrograms: &keep},
+		"house sparrow":        {KeepSpectrograms: &discard},
+		"tyto alba":            {Threshold: 0.5},
+		"lesser spotted eagle": {KeepSpectrograms: &keep},
+	}
+
+	tests := []struct {
+		name       string
+		global     bool
+		common     string
+		scientific string
+		want       bool
+	}{
+		{"kept by common name", false, "Snowy Owl", "Bubo scandiacus", true},
+		{"discarded by common name", true, "House Sparrow", "Passer domesticus", false},
+		{"config without override", true, "Barn Owl", "Tyto alba", true},
+		{"scientific name key", false, "", "tyto_alba", false},
+		{"not configured", false, "Eurasian Eagle-Owl", "Bubo bubo", false},
+		{"not configured global", true, "Eurasian Eagle-Owl", "Bubo bubo", true},
+		{"common name sharing first two words", false, "Lesser Spotted Woodpecker", "Dryobates minor", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := cloneSettings(settings)
+			s.Realtime.Audio.Export.Retention.KeepSpectrograms = tt.global
+			if got := s.KeepSpectrogram(tt.common, tt.scientific); got != tt.want {
+				t.Errorf("KeepSpectrogram(%q, %q) = %v, want %v", tt.common, tt.scientific, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeciesNames(t *testing.T) {
+	t.Parallel()
+
+	names := NewSpeciesNames([]string{"Bubo scandiacus_Snowy Owl", "Parus major_Great Tit", "Noise"})
+	tests := []struct {
+		scientific string
+		want       string
+	}{
+		{"bubo_scandiacus", "Snowy Owl"},
+		{"Parus major major", "Great Tit"},
+		{"tyto_alba", ""},
+	}
+	for _, tt := range tests {
+		if got := names.CommonName(tt.scientific); got != tt.want {
+			t.Errorf("CommonName(%q) = %q, want %q", tt.scientific, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSpeciesInterval(t *testing.T) {
+	t.Parallel()
+
+	settings := &RealtimeSettings{}
+	settings.Species.Config = map[string]SpeciesConfig{"house sparrow": {Interval: -1}}
+	if err := validateRealtimeSettings(settings); err == nil {
+		t.Error("validateRealtimeSettings() accepted a negative species interval")
+	}
+
+	settings.Species.Config = map[string]SpeciesConfig{"house sparrow": {Interval: 0}}
+	if err := validateRealtimeSettings(settings); err != nil {
+		t.Errorf("validateRealtimeSettings() error = %v for a zero species interval", err)
+	}
+}
+
+func TestUpdateSpeciesConfig(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Realtime.Species.Config = map[string]SpeciesConfig{
+		"Great Tit": {Threshold: 0.5},
+		"blue tit":  {Threshold: 0.6},
+	}
+	original := settings.Realtime.Species.Config
+
+	settings.UpdateSpeciesConfig("great tit", SpeciesConfig{Threshold: 0.8})
+	if len(settings.Realtime.Species.Config) != 2 {
+		t.Fatalf("Config = %v, want the great tit entry replaced", settings.Realtime.Species.Config)
+	}
+	if got := settings.Realtime.Species.Config["great tit"].Threshold; got != 0.8 {
+		t.Errorf("great tit threshold = %v, want 0.8", got)
+	}
+	if got := original["Great Tit"].Threshold; got != 0.5 {
+		t.Errorf("previous map was modified, threshold = %v, want 0.5", got)
+	}
+
+	settings.RemoveSpeciesConfig("Blue  Tit")
+	if _, ok := settings.Realtime.Species.Config["blue tit"]; ok {
+		t.Errorf("blue tit config not removed: %v", settings.Realtime.Species.Config)
+	}
+	if _, ok := original["blue tit"]; !ok {
+		t.Error("previous map was modified by RemoveSpeciesConfig")
+	}
+}
+
+func TestSpeciesConfigsDefensiveCopy(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.UpdateSpeciesConfig("Great Tit", SpeciesConfig{
+		Threshold: 0.5,
+		Actions:   []SpeciesAction{{Type: "ExecuteCommand", Parameters: []string{"CommonName"}}},
+	})
+
+	configs := settings.SpeciesConfigs()
+	config := configs["great tit"]
+	config.Actions[0].Parameters[0] = "modified"
+	configs["blue tit"] = SpeciesConfig{}
+
+	if got := settings.Realtime.Species.Config["great tit"].Actions[0].Parameters[0]; got != "CommonName" {
+		t.Errorf("action parameter = %q, copy modified the settings", got)
+	}
+	if _, ok := settings.Realtime.Species.Config["blue tit"]; ok {
+		t.Error("adding to the copy modified the settings")
+	}
+}
+
+// TestSpeciesConfigConcurrentAccess is meant to be run with the race detector
+func TestSpeciesConfigConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				name := fmt.Sprintf("species %d", (i+j)%5)
+				if j%3 == 0 {
+					settings.RemoveSpeciesConfig(name)
+				} else {
+					settings.UpdateSpeciesConfig(name, SpeciesConfig{Threshold: float64(j) / 100})
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				settings.LookupSpeciesConfig(fmt.Sprintf("species %d", j%5), "")
+				_ = settings.SpeciesConfigs()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,129 @@
+// conf/spectrogram.go spectrogram image size and style of exported clips
+package conf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Color schemes of SpectrogramSettings.ColorScheme
+const (
+	SpectrogramColorDefault    = "default"    // SoX default palette
+	SpectrogramColorMonochrome = "monochrome" // grayscale
+	SpectrogramColorLight      = "light"      // light background
+	SpectrogramColorHigh       = "highcolor"  // high contrast palette
+)
+
+// Default spectrogram size, images requested without a width use it
+const (
+	DefaultSpectrogramWidth  = 800
+	DefaultSpectrogramHeight = 400
+)
+
+// Spectrogram size limits in pixels
+const (
+	MinSpectrogramSize = 16
+	MaxSpectrogramSize = 4096
+)
+
+// SpectrogramRawMaxWidth is the width below which spectrograms are always
+// generated raw, axes and legends do not fit thumbnails
+const SpectrogramRawMaxWidth = 800
+
+// SpectrogramParams are the resolved parameters of one spectrogram image
+type SpectrogramParams struct {
+	Width       int    // image width in pixels
+	Height      int    // image height in pixels
+	ColorScheme string // one of the SpectrogramColor constants
+	Raw         bool   // true to leave out axes and legend
+}
+
+// Params returns the parameters of a spectrogram of width pixels, width 0 or
+// less uses the configured width. The height keeps the configured aspect
+// ratio and images narrower than SpectrogramRawMaxWidth are always raw.
+func (s SpectrogramSettings) Params(width int) SpectrogramParams {
+	configured := s.Width
+	if configured <= 0 {
+		configured = DefaultSpectrogramWidth
+	}
+	height := s.Height
+	if height <= 0 {
+		height = DefaultSpectrogramHeight
+	}
+	if width <= 0 {
+		width = configured
+	}
+	scheme := strings.ToLower(s.ColorScheme)
+	if scheme == "" {
+		scheme = SpectrogramColorDefault
+	}
+
+	return SpectrogramParams{
+		Width:       width,
+		Height:      max(height*width/configured, 1),
+		ColorScheme: scheme,
+		Raw:         s.Raw || width < SpectrogramRawMaxWidth,
+	}
+}
+
+// SoxColorArgs returns the SoX spectrogram options of the color scheme
+func (p SpectrogramParams) SoxColorArgs() []string {
+	switch p.ColorScheme {
+	case SpectrogramColorMonochrome:
+		return []string{"-m"}
+	case SpectrogramColorLight:
+		return []string{"-l"}
+	case SpectrogramColorHigh:
+		return []string{"-h"}
+	default:
+		return nil
+	}
+}
+
+// FFmpegColor returns the showspectrumpic color of the color scheme, empty
+// for the FFmpeg default. FFmpeg has no monochrome or light palette, they use
+// the default.
+func (p SpectrogramParams) FFmpegColor() string {
+	if p.ColorScheme == SpectrogramColorHigh {
+		return "rainbow"
+	}
+	return ""
+}
+
+// validateSpectrogramSettings checks the spectrogram size and color scheme.
+// Zero sizes and an empty color scheme are set to the defaults, the color
+// scheme is lowercased.
+func validateSpectrogramSettings(s *SpectrogramSettings) error {
+	for _, dimension := range []struct {
+		key          string
+		value        *int
+		defaultValue int
+	}{
+		{"realtime.audio.export.spectrogram.width", &s.Width, DefaultSpectrogramWidth},
+		{"realtime.audio.export.spectrogram.height", &s.Height, DefaultSpectrogramHeight},
+	} {
+		if *dimension.value == 0 {
+			*dimension.value = dimension.defaultValue
+		}
+		if c := constraintFor(dimension.key); !c.inRange(float64(*dimension.value)) {
+			return errors.New(fmt.Errorf("%s must be %s, got %d", dimension.key, c, *dimension.value)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeSpectrogramSize).
+				Build()
+		}
+	}
+
+	s.ColorScheme = strings.ToLower(strings.TrimSpace(s.ColorScheme))
+	if s.ColorScheme == "" {
+		s.ColorScheme = SpectrogramColorDefault
+	}
+	if c := constraintFor("realtime.audio.export.spectrogram.colorscheme"); !c.allows(s.ColorScheme) {
+		return errors.New(fmt.Errorf("unknown spectrogram color scheme %q, must be %s", s.ColorScheme, c)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeSpectrogramColorScheme).
+			Build()
+	}
+	return nil
+}
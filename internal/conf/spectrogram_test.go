@@ -0,0 +1,96 @@
+package conf
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpectrogramParams(t *testing.T) {
+	t.Parallel()
+
+	defaults := SpectrogramSettings{Width: 800, Height: 400, ColorScheme: SpectrogramColorDefault}
+	tests := []struct {
+		name     string
+		settings SpectrogramSettings
+		width    int
+		want     SpectrogramParams
+	}{
+		{"configured width", defaults, 0, SpectrogramParams{800, 400, SpectrogramColorDefault, false}},
+		{"thumbnail is raw", defaults, 400, SpectrogramParams{400, 200, SpectrogramColorDefault, true}},
+		{"wide keeps aspect ratio", defaults, 1000, SpectrogramParams{1000, 500, SpectrogramColorDefault, false}},
+		{"zero settings use defaults", SpectrogramSettings{}, 0, SpectrogramParams{800, 400, SpectrogramColorDefault, false}},
+		{"custom size and style", SpectrogramSettings{Width: 1200, Height: 300, ColorScheme: "Monochrome", Raw: true}, 0,
+			SpectrogramParams{1200, 300, SpectrogramColorMonochrome, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.settings.Params(tt.width); got != tt.want {
+				t.Errorf("Params(%d) = %+v, want %+v", tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpectrogramColorArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scheme     string
+		wantSox    []string
+		wantFFmpeg string
+	}{
+		{SpectrogramColorDefault, nil, ""},
+		{SpectrogramColorMonochrome, []string{"-m"}, ""},
+		{SpectrogramColorLight, []string{"-l"}, ""},
+		{SpectrogramColorHigh, []string{"-h"}, "rainbow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			t.Parallel()
+			p := SpectrogramParams{ColorScheme: tt.scheme}
+			if got := p.SoxColorArgs(); !slices.Equal(got, tt.wantSox) {
+				t.Errorf("SoxColorArgs() = %v, want %v", got, tt.wantSox)
+			}
+			if got := p.FFmpegColor(); got != tt.wantFFmpeg {
+				t.Errorf("FFmpegColor() = %q, want %q", got, tt.wantFFmpeg)
+			}
+		})
+	}
+}
+
+func TestValidateSpectrogramSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings SpectrogramSettings
+		want     SpectrogramSettings
+		wantCode string
+	}{
+		{"defaults", SpectrogramSettings{}, SpectrogramSettings{Width: 800, Height: 400, ColorScheme: SpectrogramColorDefault}, ""},
+		{"color scheme lowercased", SpectrogramSettings{Width: 640, Height: 240, ColorScheme: " HighColor "},
+			SpectrogramSettings{Width: 640, Height: 240, ColorScheme: SpectrogramColorHigh}, ""},
+		{"negative width", SpectrogramSettings{Width: -1, Height: 400}, SpectrogramSettings{Width: -1, Height: 400}, ErrCodeSpectrogramSize},
+		{"height too large", SpectrogramSettings{Width: 800, Height: 10000}, SpectrogramSettings{Width: 800, Height: 10000}, ErrCodeSpectrogramSize},
+		{"unknown color scheme", SpectrogramSettings{Width: 800, Height: 400, ColorScheme: "purple"},
+			SpectrogramSettings{Width: 800, Height: 400, ColorScheme: "purple"}, ErrCodeSpectrogramColorScheme},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := tt.settings
+			err := validateSpectrogramSettings(&s)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("validateSpectrogramSettings() error = %v", err)
+				}
+			} else if code := validationCode(err); err == nil || code != tt.wantCode {
+				t.Fatalf("validateSpectrogramSettings() error = %v with code %q, want code %q", err, code, tt.wantCode)
+			}
+			if s != tt.want {
+				t.Errorf("settings = %+v, want %+v", s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// conf/ssh_key.go passphrase-protected SSH private keys of SFTP and rsync backup targets
+package conf
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// ParseSSHPrivateKey parses a PEM encoded SSH private key and returns the raw
+// key, e.g. *ed25519.PrivateKey. Encrypted keys are decrypted with
+// passphrase, which is ignored for unencrypted keys.
+func ParseSSHPrivateKey(pemBytes []byte, passphrase string) (any, error) {
+	key, err := ssh.ParseRawPrivateKey(pemBytes)
+	var missing *ssh.PassphraseMissingError
+	if !errors.As(err, &missing) {
+		return key, err
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("private key is encrypted, a passphrase is required")
+	}
+	key, err = ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, []byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+	return key, nil
+}
+
+// validateSSHKeyPassphrase checks that an encrypted private key at keyPath
+// can be decrypted with passphrase. Keys that cannot be read are not checked,
+// the backup target reports them when it connects.
+func validateSSHKeyPassphrase(keyPath, passphrase string) error {
+	if keyPath == "" {
+		return nil
+	}
+	pemBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil
+	}
+	if _, err := ParseSSHPrivateKey(pemBytes, passphrase); err != nil {
+		return fmt.Errorf("private key %s: %w", keyPath, err)
+	}
+	return nil
+}
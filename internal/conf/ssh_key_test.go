@@ -0,0 +1,132 @@
+package conf
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// writeSSHKey writes a new ed25519 private key, encrypted when passphrase is set
+func writeSSHKey(t *testing.T, passphrase string) string {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var block *pem.Block
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(key, "")
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte(passphrase))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseSSHPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	plain, err := os.ReadFile(writeSSHKey(t, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := os.ReadFile(writeSSHKey(t, "correct horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		pemBytes   []byte
+		passphrase string
+		wantErr    bool
+	}{
+		{"unencrypted key", plain, "", false},
+		{"unencrypted key ignores passphrase", plain, "unused", false},
+		{"encrypted key with passphrase", encrypted, "correct horse", false},
+		{"encrypted key without passphrase", encrypted, "", true},
+		{"encrypted key with wrong passphrase", encrypted, "wrong", true},
+		{"not a key", []byte("not a key"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			key, err := ParseSSHPrivateKey(tt.pemBytes, tt.passphrase)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSSHPrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if _, err := ssh.NewSignerFromKey(key); err != nil {
+					t.Errorf("ParseSSHPrivateKey() returned a key without a signer: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestBackupSettingsValidateKeyPassphrase(t *testing.T) {
+	t.Parallel()
+
+	encrypted := writeSSHKey(t, "correct horse")
+	sftp := func(passphrase string) error {
+		s := SFTPBackupSettings{Host: "nas", Username: "birdnet", InsecureIgnoreHostKey: true,
+			PrivateKeyPath: encrypted, PrivateKeyPassphrase: passphrase}
+		return s.Validate()
+	}
+	rsync := func(passphrase string) error {
+		s := RsyncBackupSettings{Path: "/backups", SSHKeyPath: encrypted, PrivateKeyPassphrase: passphrase}
+		return s.Validate()
+	}
+
+	if err := sftp("correct horse"); err != nil {
+		t.Errorf("SFTP Validate() with passphrase error = %v", err)
+	}
+	if err := sftp(""); err == nil {
+		t.Error("SFTP Validate() of an encrypted key without passphrase error = nil, want an error")
+	}
+	if err := rsync("correct horse"); err != nil {
+		t.Errorf("rsync Validate() with passphrase error = %v", err)
+	}
+	if err := rsync("wrong"); err == nil {
+		t.Error("rsync Validate() with a wrong passphrase error = nil, want an error")
+	}
+
+	// Keys that cannot be read are reported when connecting
+	missing := RsyncBackupSettings{Path: "/backups", SSHKeyPath: filepath.Join(t.TempDir(), "missing")}
+	if err := missing.Validate(); err != nil {
+		t.Errorf("rsync Validate() of a missing key error = %v, want nil", err)
+	}
+}
+
+func TestLoadBackupTargetKeyPassphrase(t *testing.T) {
+	t.Parallel()
+
+	encrypted := writeSSHKey(t, "correct horse")
+	load := func(targetType, passphrase string) error {
+		_, err := LoadFromReader(strings.NewReader("backup:\n  enabled: true\n  targets:\n" +
+			"    - type: " + targetType + "\n      enabled: true\n      settings:\n" +
+			"        host: nas.local\n        username: birdnet\n        path: /backups\n        insecure_ignore_host_key: true\n" +
+			"        key_file: " + encrypted + "\n        privatekeypassphrase: " + passphrase + "\n"))
+		return err
+	}
+	for _, targetType := range []string{"sftp", "rsync"} {
+		if err := load(targetType, "correct horse"); err != nil {
+			t.Errorf("LoadFromReader() of a %s target with the passphrase error = %v", targetType, err)
+		}
+		if err := load(targetType, "wrong"); err == nil {
+			t.Errorf("LoadFromReader() of a %s target with a wrong passphrase error = nil, want an error", targetType)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// conf/systemid.go persistent system identifier for telemetry
+package conf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"github.com/tphakala/birdnet-go/internal/privacy"
+)
+
+// systemIDFileName is the sidecar file next to the config file holding the
+// system ID, it is kept out of the config file so that saving settings does
+// not rewrite it
+const systemIDFileName = ".system_id"
+
+// systemIDMutex serializes reading and creating the system ID file
+var systemIDMutex sync.Mutex
+
+// EnsureSystemID returns the system ID stored next to the config file,
+// creating and storing a new one on first use. Calls return the same ID for as
+// long as the file exists, keeping telemetry continuous across restarts. The
+// ID is also set as Settings.SystemID of the loaded settings.
+func EnsureSystemID() (string, error) {
+	configDir, err := systemIDDir()
+	if err != nil {
+		return "", err
+	}
+
+	id, err := ensureSystemID(configDir)
+	if err != nil {
+		return "", err
+	}
+
+	if settings := GetSettings(); settings != nil {
+		settings.SystemID = id
+	}
+	return id, nil
+}
+
+// systemIDDir returns the directory of the config file in use, or the default
+// config directory when no config file has been read
+func systemIDDir() (string, error) {
+	if configFile := viper.ConfigFileUsed(); configFile != "" {
+		return filepath.Dir(configFile), nil
+	}
+
+	configPaths, err := GetDefaultConfigPaths()
+	if err != nil {
+		return "", err
+	}
+	if len(configPaths) == 0 {
+		return "", errors.Newf("no config directory available for system ID").
+			Category(errors.CategoryConfiguration).
+			Context("operation", "ensure-system-id").
+			Build()
+	}
+	return configPaths[0], nil
+}
+
+// ensureSystemID reads the system ID file in configDir, replacing a missing or
+// malformed ID with a newly generated one
+func ensureSystemID(configDir string) (string, error) {
+	systemIDMutex.Lock()
+	defer systemIDMutex.Unlock()
+
+	idFile := filepath.Join(configDir, systemIDFileName)
+	if data, err := os.ReadFile(idFile); err == nil {
+		if id := strings.TrimSpace(string(data)); privacy.IsValidSystemID(id) {
+			return id, nil
+		}
+	}
+
+	id, err := privacy.GenerateSystemID()
+	if err != nil {
+		return "", errors.New(err).
+			Category(errors.CategorySystem).
+			Context("operation", "generate-system-id").
+			Build()
+	}
+
+	if err := writeSystemIDFile(idFile, id); err != nil {
+		return "", errors.New(fmt.Errorf("failed to save system ID: %w", err)).
+			Category(errors.CategoryFileIO).
+			Context("operation", "ensure-system-id").
+			Build()
+	}
+	return id, nil
+}
+
+// writeSystemIDFile writes the system ID through a temporary file so that a
+// crash cannot leave a truncated ID behind
+func writeSystemIDFile(idFile, id string) error {
+	if err := os.MkdirAll(filepath.Dir(idFile), 0o755); err != nil {
+		return err
+	}
+
+	return AtomicWriteFile(idFile, []byte(id), 0o600)
+}
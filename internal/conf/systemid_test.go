@@ -0,0 +1,76 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/privacy"
+)
+
+func TestEnsureSystemIDIsStable(t *testing.T) {
+	t.Parallel()
+
+	configDir := t.TempDir()
+
+	first, err := ensureSystemID(configDir)
+	if err != nil {
+		t.Fatalf("ensureSystemID() error = %v", err)
+	}
+	if !privacy.IsValidSystemID(first) {
+		t.Fatalf("ensureSystemID() = %q, not a valid system ID", first)
+	}
+
+	second, err := ensureSystemID(configDir)
+	if err != nil {
+		t.Fatalf("second ensureSystemID() error = %v", err)
+	}
+	if second != first {
+		t.Errorf("second ensureSystemID() = %q, want %q", second, first)
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, systemIDFileName))
+	if err != nil {
+		t.Fatalf("system ID file not written: %v", err)
+	}
+	if string(data) != first {
+		t.Errorf("system ID file contains %q, want %q", data, first)
+	}
+}
+
+func TestEnsureSystemIDReplacesMalformedID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		keep    bool
+	}{
+		{"existing id", "ABCD-1234-EF56\n", true},
+		{"empty file", "", false},
+		{"malformed id", "not-an-id", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configDir := t.TempDir()
+			idFile := filepath.Join(configDir, systemIDFileName)
+			if err := os.WriteFile(idFile, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write system ID file: %v", err)
+			}
+
+			id, err := ensureSystemID(configDir)
+			if err != nil {
+				t.Fatalf("ensureSystemID() error = %v", err)
+			}
+			if tt.keep && id != "ABCD-1234-EF56" {
+				t.Errorf("ensureSystemID() = %q, want existing ID", id)
+			}
+			if !tt.keep && !privacy.IsValidSystemID(id) {
+				t.Errorf("ensureSystemID() = %q, want a newly generated ID", id)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// conf/telemetry.go helpers for Prometheus telemetry settings
+package conf
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PromLabels returns the configured constant labels as Prometheus labels.
+// The returned map is a copy and safe to modify.
+func (t TelemetrySettings) PromLabels() prometheus.Labels {
+	labels := make(prometheus.Labels, len(t.Labels))
+	for name, value := range t.Labels {
+		labels[name] = value
+	}
+	return labels
+}
@@ -0,0 +1,73 @@
+// conf/testing.go in-memory settings for tests of code using Setting()
+package conf
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// LoadFromReader reads settings from a YAML config in r layered over the
+// built-in defaults, without touching the filesystem or the global settings.
+// Settings are migrated and validated like Load does, secret files are not
+// read and the configured time zone is not applied to the process.
+func LoadFromReader(r io.Reader) (*Settings, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	setDefaults(v)
+
+	if err := v.ReadConfig(r); err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "read-config-reader").
+			Build()
+	}
+
+	settings := &Settings{}
+	if _, err := decodeSettings(v, settings); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateSettings(settings); err != nil {
+		if err := checkValidationResult(err); err != nil {
+			return nil, err
+		}
+	}
+
+	return settings, nil
+}
+
+// LoadForTest reads settings from an in-memory YAML config with LoadFromReader
+// and installs them as the global settings with SetTestSettings
+func LoadForTest(yamlConfig string) (*Settings, error) {
+	settings, err := LoadFromReader(strings.NewReader(yamlConfig))
+	if err != nil {
+		return nil, err
+	}
+	SetTestSettings(settings)
+	return settings, nil
+}
+
+// SetTestSettings replaces the global settings returned by Setting() and
+// GetSettings(), so that Setting() does not load the config file. Passing nil
+// resets the global settings, the next Setting() call loads the config file
+// again. It must not be called concurrently with Setting(), tests using it
+// cannot run in parallel with other tests using the global settings.
+func SetTestSettings(settings *Settings) {
+	settingsMutex.Lock()
+	defer settingsMutex.Unlock()
+
+	settingsInstance = settings
+	settingsLoadErr = nil
+	speciesListMutex.RLock()
+	configChanges.setSaved(settings)
+	speciesListMutex.RUnlock()
+	once = sync.Once{}
+	if settings != nil {
+		// Mark initialization as done so that Setting() returns settings
+		once.Do(func() {})
+	}
+}
@@ -0,0 +1,132 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadFromReader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr bool
+		check   func(t *testing.T, s *Settings)
+	}{
+		{
+			name: "defaults",
+			yaml: "",
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.Main.Name != "BirdNET-Go" {
+					t.Errorf("Main.Name = %q, want default BirdNET-Go", s.Main.Name)
+				}
+			},
+		},
+		{
+			name: "overrides",
+			yaml: "main:\n  name: test-node\nbirdnet:\n  threshold: 0.5\n",
+			check: func(t *testing.T, s *Settings) {
+				t.Helper()
+				if s.Main.Name != "test-node" {
+					t.Errorf("Main.Name = %q, want test-node", s.Main.Name)
+				}
+				if s.BirdNET.Threshold != 0.5 {
+					t.Errorf("BirdNET.Threshold = %v, want 0.5", s.BirdNET.Threshold)
+				}
+			},
+		},
+		{
+			name:    "invalid yaml",
+			yaml:    "main: [",
+			wantErr: true,
+		},
+		{
+			name:    "invalid settings",
+			yaml:    "birdnet:\n  threshold: 2\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings, err := LoadFromReader(strings.NewReader(tt.yaml))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadFromReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, settings)
+			}
+		})
+	}
+}
+
+// TestSetTestSettings replaces the global settings and cannot run in parallel
+func TestSetTestSettings(t *testing.T) {
+	previous := GetSettings()
+	t.Cleanup(func() { SetTestSettings(previous) })
+
+	settings, err := LoadForTest("main:\n  name: injected\n")
+	if err != nil {
+		t.Fatalf("LoadForTest() error = %v", err)
+	}
+	if got := Setting(); got != settings {
+		t.Fatalf("Setting() = %p, want injected settings %p", got, settings)
+	}
+	if got := Setting().Main.Name; got != "injected" {
+		t.Errorf("Setting().Main.Name = %q, want injected", got)
+	}
+
+	replacement := &Settings{}
+	SetTestSettings(replacement)
+	if got := Setting(); got != replacement {
+		t.Errorf("Setting() after SetTestSettings = %p, want %p", got, replacement)
+	}
+
+	SetTestSettings(nil)
+	if got := GetSettings(); got != nil {
+		t.Errorf("GetSettings() after reset = %p, want nil", got)
+	}
+}
+
+// TestSettingOK loads settings through the global viper instance and cannot run in parallel
+func TestSettingOK(t *testing.T) {
+	previous := GetSettings()
+	t.Cleanup(func() {
+		viper.Reset()
+		SetTestSettings(previous)
+	})
+
+	configDir := t.TempDir()
+	t.Setenv(ConfigDirEnv, configDir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte("main: [unterminated\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	SetTestSettings(nil)
+	settings, err := SettingOK()
+	if err == nil || settings != nil {
+		t.Fatalf("SettingOK() = %p, %v, want the load error", settings, err)
+	}
+	if _, again := SettingOK(); again == nil {
+		t.Error("SettingOK() after a failed load error = nil, want the first load error")
+	}
+
+	injected := &Settings{}
+	SetTestSettings(injected)
+	if settings, err := SettingOK(); err != nil || settings != injected {
+		t.Errorf("SettingOK() = %p, %v, want injected settings %p", settings, err, injected)
+	}
+	if got := MustSetting(); got != injected {
+		t.Errorf("MustSetting() = %p, want injected settings %p", got, injected)
+	}
+}
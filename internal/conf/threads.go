@@ -0,0 +1,36 @@
+// conf/threads.go number of CPU threads used for analysis
+package conf
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+)
+
+// EffectiveThreads returns the number of CPU threads used for analysis. 0
+// Threads means all CPUs, and more threads than CPUs are limited to the CPU
+// count to avoid thrashing.
+func (b BirdNETConfig) EffectiveThreads() int {
+	cpus := runtime.NumCPU()
+	if b.Threads <= 0 {
+		return cpus
+	}
+	return min(b.Threads, cpus)
+}
+
+// warnExcessThreads returns a warning when more threads than CPUs are
+// configured, an empty string otherwise
+func warnExcessThreads(birdnetSettings *BirdNETConfig, settings *Settings) string {
+	cpus := runtime.NumCPU()
+	if birdnetSettings.Threads <= cpus {
+		return ""
+	}
+
+	message := fmt.Sprintf("BirdNET threads %d exceeds the %d available CPUs, %d threads are used",
+		birdnetSettings.Threads, cpus, birdnetSettings.EffectiveThreads())
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeBirdNETThreads, "exceeds-cpu-count")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-birdnet-validation: %s", message))
+	return message
+}
@@ -0,0 +1,47 @@
+package conf
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestEffectiveThreads(t *testing.T) {
+	t.Parallel()
+
+	cpus := runtime.NumCPU()
+	tests := []struct {
+		name    string
+		threads int
+		want    int
+	}{
+		{"auto", 0, cpus},
+		{"one", 1, 1},
+		{"all CPUs", cpus, cpus},
+		{"above CPU count", cpus + 4, cpus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := (BirdNETConfig{Threads: tt.threads}).EffectiveThreads(); got != tt.want {
+				t.Errorf("EffectiveThreads() of %d threads = %d, want %d", tt.threads, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarnExcessThreads(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	for _, threads := range []int{0, 1, runtime.NumCPU()} {
+		if warning := warnExcessThreads(&BirdNETConfig{Threads: threads}, settings); warning != "" {
+			t.Errorf("warnExcessThreads() of %d threads = %q, want no warning", threads, warning)
+		}
+	}
+	if warning := warnExcessThreads(&BirdNETConfig{Threads: runtime.NumCPU() + 1}, settings); warning == "" {
+		t.Error("warnExcessThreads() above the CPU count = no warning, want a warning")
+	}
+	if len(settings.ValidationWarnings) != 1 {
+		t.Errorf("ValidationWarnings = %q, want 1 warning", settings.ValidationWarnings)
+	}
+}
@@ -0,0 +1,91 @@
+// conf/thumbnails.go thumbnail image provider preferences
+package conf
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Thumbnail image provider and fallback policy values
+const (
+	ImageProviderAuto       = "auto"
+	ImageProviderWikimedia  = "wikimedia"
+	ImageProviderAvicommons = "avicommons"
+
+	FallbackPolicyNone = "none"
+	FallbackPolicyAll  = "all"
+)
+
+// imageProviders lists the image providers in their default preference order
+var imageProviders = []string{ImageProviderWikimedia, ImageProviderAvicommons}
+
+// ProviderOrder returns the image providers to try, in order. An explicit
+// Providers list is returned as is. Otherwise the order is derived from the
+// legacy settings: in auto mode all providers are tried in their default
+// order, a specific provider is tried first and followed by the remaining
+// providers only when the fallback policy is "all". Unknown values resolve
+// like ValidateSettings does, to auto and none.
+func (t Thumbnails) ProviderOrder() []string {
+	if len(t.Providers) > 0 {
+		return slices.Clone(t.Providers)
+	}
+
+	provider := t.ImageProvider
+	if !slices.Contains(imageProviders, provider) {
+		return slices.Clone(imageProviders)
+	}
+
+	order := []string{provider}
+	if t.FallbackPolicy == FallbackPolicyAll {
+		for _, name := range imageProviders {
+			if name != provider {
+				order = append(order, name)
+			}
+		}
+	}
+	return order
+}
+
+// Shown reports whether thumbnails are shown on the summary or recent table
+func (t Thumbnails) Shown() bool {
+	return t.Summary || t.Recent
+}
+
+// AvailabilityWarning returns a warning when thumbnails are shown but none
+// of the providers of ProviderOrder is available, an empty string otherwise.
+// available reports whether an image provider could be initialized.
+func (t Thumbnails) AvailabilityWarning(available func(provider string) bool) string {
+	if !t.Shown() {
+		return ""
+	}
+	order := t.ProviderOrder()
+	if slices.ContainsFunc(order, available) {
+		return ""
+	}
+	return fmt.Sprintf("thumbnails are shown on the dashboard but none of the image providers %v is available", order)
+}
+
+// validateProviders checks that Providers only lists known image providers
+// and lists each of them at most once
+func (t Thumbnails) validateProviders() error {
+	c := constraintFor("realtime.dashboard.thumbnails.providers")
+	for i, provider := range t.Providers {
+		if !c.allows(provider) {
+			return errors.New(fmt.Errorf("unknown thumbnail image provider %q, must be %s", provider, c)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeThumbnailProviders).
+				Context("provider", provider).
+				Build()
+		}
+		if slices.Contains(t.Providers[:i], provider) {
+			return errors.New(fmt.Errorf("thumbnail image provider %q is listed more than once", provider)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeThumbnailProviders).
+				Context("provider", provider).
+				Build()
+		}
+	}
+	return nil
+}
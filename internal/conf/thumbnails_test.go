@@ -0,0 +1,139 @@
+package conf
+
+import (
+	stderrors "errors"
+	"slices"
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestThumbnailsProviderOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		thumbnails Thumbnails
+		want       []string
+	}{
+		{"auto", Thumbnails{ImageProvider: "auto", FallbackPolicy: "none"}, []string{"wikimedia", "avicommons"}},
+		{"explicit providers", Thumbnails{ImageProvider: "wikimedia", FallbackPolicy: "none", Providers: []string{"avicommons", "wikimedia"}}, []string{"avicommons", "wikimedia"}},
+		{"preferred without fallback", Thumbnails{ImageProvider: "avicommons", FallbackPolicy: "none"}, []string{"avicommons"}},
+		{"preferred with fallback", Thumbnails{ImageProvider: "avicommons", FallbackPolicy: "all"}, []string{"avicommons", "wikimedia"}},
+		{"unknown provider resolves to auto", Thumbnails{ImageProvider: "wikimedai", FallbackPolicy: "all"}, []string{"wikimedia", "avicommons"}},
+		{"unknown policy resolves to none", Thumbnails{ImageProvider: "wikimedia", FallbackPolicy: "any"}, []string{"wikimedia"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.thumbnails.ProviderOrder(); !slices.Equal(got, tt.want) {
+				t.Errorf("ProviderOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateThumbnailSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		thumbnails   Thumbnails
+		wantProvider string
+		wantPolicy   string
+		wantWarnings int
+	}{
+		{"valid values", Thumbnails{ImageProvider: "avicommons", FallbackPolicy: "all"}, "avicommons", "all", 0},
+		{"empty values", Thumbnails{}, "auto", "none", 0},
+		{"unknown provider", Thumbnails{ImageProvider: "avicomons", FallbackPolicy: "all"}, "auto", "all", 1},
+		{"unknown policy", Thumbnails{ImageProvider: "wikimedia", FallbackPolicy: "some"}, "wikimedia", "none", 1},
+		{"both unknown", Thumbnails{ImageProvider: "Wikimedia", FallbackPolicy: "yes"}, "auto", "none", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := &Settings{}
+			thumbnails := tt.thumbnails
+			validateThumbnailSettings(&thumbnails, settings)
+
+			if thumbnails.ImageProvider != tt.wantProvider {
+				t.Errorf("ImageProvider = %q, want %q", thumbnails.ImageProvider, tt.wantProvider)
+			}
+			if thumbnails.FallbackPolicy != tt.wantPolicy {
+				t.Errorf("FallbackPolicy = %q, want %q", thumbnails.FallbackPolicy, tt.wantPolicy)
+			}
+			if len(settings.ValidationWarnings) != tt.wantWarnings {
+				t.Errorf("got %d warnings, want %d: %v", len(settings.ValidationWarnings), tt.wantWarnings, settings.ValidationWarnings)
+			}
+		})
+	}
+}
+
+func TestThumbnailsValidateProviders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		providers []string
+		wantErr   bool
+	}{
+		{"not set", nil, false},
+		{"single provider", []string{"avicommons"}, false},
+		{"all providers", []string{"avicommons", "wikimedia"}, false},
+		{"unknown provider", []string{"wikimedia", "flickr"}, true},
+		{"auto is not a provider", []string{"auto"}, true},
+		{"duplicate provider", []string{"wikimedia", "avicommons", "wikimedia"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Thumbnails{Providers: tt.providers}.validateProviders()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateProviders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError type, got %T", err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != "dashboard-thumbnails-providers" {
+				t.Errorf("expected validation_type = dashboard-thumbnails-providers, got %v", ctx)
+			}
+		})
+	}
+}
+
+func TestThumbnailsAvailabilityWarning(t *testing.T) {
+	t.Parallel()
+
+	onlyWikimedia := func(provider string) bool { return provider == "wikimedia" }
+	none := func(string) bool { return false }
+
+	tests := []struct {
+		name        string
+		thumbnails  Thumbnails
+		available   func(string) bool
+		wantWarning bool
+	}{
+		{"hidden", Thumbnails{}, none, false},
+		{"shown and available", Thumbnails{Summary: true, ImageProvider: "wikimedia"}, onlyWikimedia, false},
+		{"shown with fallback", Thumbnails{Recent: true, Providers: []string{"avicommons", "wikimedia"}}, onlyWikimedia, false},
+		{"shown and unavailable", Thumbnails{Summary: true, ImageProvider: "avicommons"}, onlyWikimedia, true},
+		{"no provider available", Thumbnails{Summary: true, Recent: true}, none, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if warning := tt.thumbnails.AvailabilityWarning(tt.available); (warning != "") != tt.wantWarning {
+				t.Errorf("AvailabilityWarning() = %q, wantWarning %v", warning, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// conf/timezone.go configurable time zone for schedules, timestamps and display
+package conf
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// locationCache caches loaded time zones by name, loading a location reads the
+// time zone database
+var locationCache sync.Map // map[string]*time.Location
+
+// loadLocation returns the location of an IANA time zone name, empty names and
+// "Local" return the system time zone
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return time.Local, nil
+	}
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
+
+// validateTimeZone validates the configured time zone name
+func validateTimeZone(name string) error {
+	if _, err := loadLocation(name); err != nil {
+		return errors.New(fmt.Errorf("invalid time zone %q, use an IANA name such as Europe/Helsinki: %w", name, err)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeTimeZone).
+			Build()
+	}
+	return nil
+}
+
+// Location returns the configured time zone, or the system time zone when
+// Main.TimeZone is empty or cannot be loaded. The process local time zone is
+// left unchanged, components that use the configured zone convert times with
+// the returned location.
+func (s *Settings) Location() *time.Location {
+	loc, err := loadLocation(s.Main.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,91 @@
+package conf
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestValidateTimeZone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		zone    string
+		wantErr bool
+	}{
+		{"empty uses system time zone", "", false},
+		{"local", "Local", false},
+		{"utc", "UTC", false},
+		{"iana name", "Europe/Helsinki", false},
+		{"unknown name", "Mars/Olympus_Mons", true},
+		{"offset is not a zone name", "+02:00", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateTimeZone(tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTimeZone(%q) error = %v, wantErr %v", tt.zone, err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected *errors.EnhancedError, got %T", err)
+			}
+			if got := enhancedErr.Context["validation_type"]; got != "main-timezone" {
+				t.Errorf("validation_type = %v, want main-timezone", got)
+			}
+		})
+	}
+}
+
+func TestSettingsLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		zone string
+		want string
+	}{
+		{"empty", "", time.Local.String()},
+		{"iana name", "Europe/Helsinki", "Europe/Helsinki"},
+		{"invalid falls back to system time zone", "Not/AZone", time.Local.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := &Settings{}
+			settings.Main.TimeZone = tt.zone
+			if got := settings.Location().String(); got != tt.want {
+				t.Errorf("Location() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackupScheduleNextRunInConfiguredZone(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Main.TimeZone = "Europe/Helsinki"
+	loc := settings.Location()
+
+	schedule := BackupScheduleConfig{Enabled: true, Hour: 3, Minute: 0}
+	from := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	next := schedule.NextRun(from, loc)
+	want := time.Date(2026, time.June, 2, 3, 0, 0, 0, loc)
+	if !next.Equal(want) {
+		t.Errorf("NextRun() = %v, want %v", next, want)
+	}
+}
@@ -149,7 +149,7 @@ func (tm *TLSManager) SaveCertificate(service string, certType TLSCertificateTyp
 	}
 
 	// Write file with appropriate permissions
-	if err := os.WriteFile(filePath, []byte(content), perm); err != nil {
+	if err := AtomicWriteFile(filePath, []byte(content), perm); err != nil {
 		return "", errors.New(err).
 			Component("tls-manager").
 			Category(errors.CategoryFileIO).
@@ -0,0 +1,25 @@
+// conf/transport.go audio streaming transport negotiation
+package conf
+
+// Audio streaming transports
+const (
+	StreamTransportAuto = "auto"
+	StreamTransportSSE  = "sse"
+	StreamTransportWS   = "ws"
+)
+
+// ResolveTransport returns the transport to use for a client, either "ws" or
+// "sse". Auto mode uses WebSockets when the client supports them. Server-sent
+// events work with every client and are also used when WebSockets are
+// configured but not supported by the client.
+func (a AudioSettings) ResolveTransport(clientSupportsWS bool) string {
+	switch a.StreamTransport {
+	case StreamTransportSSE:
+		return StreamTransportSSE
+	default:
+		if clientSupportsWS {
+			return StreamTransportWS
+		}
+		return StreamTransportSSE
+	}
+}
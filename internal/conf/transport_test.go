@@ -0,0 +1,52 @@
+package conf
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestAudioSettingsResolveTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		transport        string
+		clientSupportsWS bool
+		want             string
+	}{
+		{"auto with websocket client", "auto", true, "ws"},
+		{"auto without websocket client", "auto", false, "sse"},
+		{"empty treated as auto", "", true, "ws"},
+		{"sse with websocket client", "sse", true, "sse"},
+		{"ws with websocket client", "ws", true, "ws"},
+		{"ws without websocket client", "ws", false, "sse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := AudioSettings{StreamTransport: tt.transport}
+			if got := settings.ResolveTransport(tt.clientSupportsWS); got != tt.want {
+				t.Errorf("ResolveTransport(%v) = %q, want %q", tt.clientSupportsWS, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAudioSettingsStreamTransport(t *testing.T) {
+	t.Parallel()
+
+	err := validateAudioSettings(&AudioSettings{StreamTransport: "websocket"})
+	if err == nil {
+		t.Fatal("expected error for unknown stream transport")
+	}
+	var enhancedErr *errors.EnhancedError
+	if !stderrors.As(err, &enhancedErr) {
+		t.Fatalf("expected EnhancedError type, got %T", err)
+	}
+	if ctx := enhancedErr.Context["validation_type"]; ctx != "audio-stream-transport" {
+		t.Errorf("expected validation_type = audio-stream-transport, got %v", ctx)
+	}
+}
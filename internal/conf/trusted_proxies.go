@@ -0,0 +1,101 @@
+// conf/trusted_proxies.go client addresses of requests passing reverse proxies
+package conf
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// DefaultTrustedProxies trusts forwarded headers from proxies on the same host
+var DefaultTrustedProxies = []string{"127.0.0.1/32", "::1/128"}
+
+// RealClientIP returns the address of the client that sent a request. The
+// X-Forwarded-For and X-Real-IP headers are honored only when the request
+// comes from one of the trusted proxy CIDRs, otherwise anyone could claim an
+// address in a bypass subnet. X-Forwarded-For is read from the right,
+// skipping trusted proxies, so entries prepended by the client are ignored.
+// It returns nil when the request address cannot be parsed.
+func RealClientIP(r *http.Request, trusted []string) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remote := net.ParseIP(host)
+	networks := parseTrustedProxies(trusted)
+	if remote == nil || !ipInNetworks(remote, networks) {
+		return remote
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		var client net.IP
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(hops[i]))
+			if ip == nil {
+				break
+			}
+			client = ip
+			if !ipInNetworks(ip, networks) {
+				return client
+			}
+		}
+		// Every hop was a trusted proxy, the first one is the client
+		if client != nil {
+			return client
+		}
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip
+	}
+	return remote
+}
+
+// IsTrustedProxy reports whether a request comes directly from one of the
+// trusted proxy CIDRs
+func IsTrustedProxy(r *http.Request, trusted []string) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ipInNetworks(ip, parseTrustedProxies(trusted))
+}
+
+// parseTrustedProxies returns the networks of the trusted proxy CIDRs,
+// skipping invalid entries rejected by validation
+func parseTrustedProxies(trusted []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(trusted))
+	for _, cidr := range trusted {
+		if _, network, err := net.ParseCIDR(strings.TrimSpace(cidr)); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+// ipInNetworks reports whether ip is in one of networks
+func ipInNetworks(ip net.IP, networks []*net.IPNet) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateTrustedProxies checks that the trusted proxies are CIDRs
+func validateTrustedProxies(settings *Security) error {
+	for _, cidr := range settings.TrustedProxies {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return errors.New(fmt.Errorf("invalid trusted proxy %q, must be a CIDR such as 172.16.0.0/12 or 10.0.0.1/32: %w", cidr, err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeTrustedProxy).
+				Build()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package conf
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealClientIP(t *testing.T) {
+	t.Parallel()
+
+	trusted := []string{"10.0.0.0/8", "::1/128"}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        string
+		xri        string
+		want       string
+	}{
+		{"direct client", "192.168.1.20:5000", "", "", "192.168.1.20"},
+		{"untrusted forwarded header ignored", "203.0.113.5:5000", "192.168.1.20", "192.168.1.21", "203.0.113.5"},
+		{"trusted proxy", "10.0.0.2:5000", "192.168.1.20", "", "192.168.1.20"},
+		{"spoofed entry before proxy entry", "10.0.0.2:5000", "192.168.1.99, 203.0.113.5", "", "203.0.113.5"},
+		{"chain of trusted proxies", "10.0.0.2:5000", "192.168.1.20, 10.0.0.3", "", "192.168.1.20"},
+		{"all hops trusted", "10.0.0.2:5000", "10.0.0.4, 10.0.0.3", "", "10.0.0.4"},
+		{"real ip header", "[::1]:5000", "", "192.168.1.20", "192.168.1.20"},
+		{"invalid headers", "10.0.0.2:5000", "unknown", "bogus", "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.xff != "" {
+				r.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.xri != "" {
+				r.Header.Set("X-Real-IP", tt.xri)
+			}
+			if got := RealClientIP(r, trusted); got.String() != tt.want {
+				t.Errorf("RealClientIP() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRealClientIPWithoutTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "127.0.0.1:5000"
+	r.Header.Set("X-Forwarded-For", "192.168.1.20")
+	if got := RealClientIP(r, nil); got.String() != "127.0.0.1" {
+		t.Errorf("RealClientIP() = %v, want 127.0.0.1", got)
+	}
+	if got := RealClientIP(r, DefaultTrustedProxies); got.String() != "192.168.1.20" {
+		t.Errorf("RealClientIP() with default proxies = %v, want 192.168.1.20", got)
+	}
+}
+
+func TestValidateTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		proxies []string
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"defaults", DefaultTrustedProxies, false},
+		{"networks", []string{"172.16.0.0/12", "fd00::/8"}, false},
+		{"bare address", []string{"10.0.0.1"}, true},
+		{"invalid", []string{"10.0.0.0/33"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateTrustedProxies(&Security{TrustedProxies: tt.proxies})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTrustedProxies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && validationCode(err) != ErrCodeTrustedProxy {
+				t.Errorf("validationCode() = %q, want %q", validationCode(err), ErrCodeTrustedProxy)
+			}
+		})
+	}
+}
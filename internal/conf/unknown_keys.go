@@ -0,0 +1,119 @@
+// conf/unknown_keys.go detection of config file keys no setting consumes
+package conf
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DetectUnknownKeys returns the sorted config keys of the config file at path
+// that no setting consumes, such as typos or keys removed in an upgrade. Keys
+// are matched like viper does, case-insensitively to the lowercase field names
+// of Settings. Lists and maps are not looked into, only the keys of sections.
+func DetectUnknownKeys(path string) ([]string, error) {
+	data, err := readConfigFile(path)
+	if err != nil {
+		return nil, errors.New(err).
+			Category(errors.CategoryFileIO).
+			Context("operation", "read-config-file").
+			Context("path", path).
+			Build()
+	}
+
+	var content map[string]any
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, errors.New(fmt.Errorf("cannot parse config file %s: %w", path, err)).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "parse-config-file").
+			Build()
+	}
+
+	sections := make(map[string]bool)
+	collectConfigKeys(reflect.TypeOf(Settings{}), "", sections)
+
+	var unknown []string
+	findUnknownKeys(content, "", sections, &unknown)
+	slices.Sort(unknown)
+	return unknown, nil
+}
+
+// collectConfigKeys adds the config keys decoded into the fields of a struct
+// type to keys, true for sections holding further settings
+func collectConfigKeys(t reflect.Type, key string, keys map[string]bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		configName := strings.ToLower(field.Name)
+		tag, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if tag == "-" {
+			continue
+		} else if tag != "" {
+			configName = strings.ToLower(tag)
+		}
+		fieldKey := configName
+		if key != "" {
+			fieldKey = key + "." + configName
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		isSection := fieldType.Kind() == reflect.Struct && fieldType != timeType
+		keys[fieldKey] = isSection
+		if isSection {
+			collectConfigKeys(fieldType, fieldKey, keys)
+		}
+	}
+}
+
+// findUnknownKeys appends the keys of a parsed YAML mapping missing from
+// keys, sections are searched recursively
+func findUnknownKeys(content map[string]any, key string, keys map[string]bool, unknown *[]string) {
+	for name, value := range content {
+		fieldKey := strings.ToLower(name)
+		if key != "" {
+			fieldKey = key + "." + fieldKey
+		}
+
+		isSection, known := keys[fieldKey]
+		switch {
+		case !known:
+			*unknown = append(*unknown, fieldKey)
+		case isSection:
+			if section, ok := value.(map[string]any); ok {
+				findUnknownKeys(section, fieldKey, keys, unknown)
+			}
+		}
+	}
+}
+
+// warnUnknownKeys logs a warning for each key of the config file at path no
+// setting consumes. A config file that cannot be read is not reported, it
+// already failed to load.
+func warnUnknownKeys(path string, settings *Settings) {
+	if path == "" {
+		return
+	}
+	unknown, err := DetectUnknownKeys(path)
+	if err != nil {
+		return
+	}
+
+	for _, key := range unknown {
+		message := fmt.Sprintf("config key %s is not a known setting and is ignored, check it for typos or remove it if it was dropped in an upgrade", key)
+		log.Printf("Configuration warning: %s", message)
+		logValidationWarning(fmt.Errorf("%s", message), ErrCodeUnknownKey, "unknown-key")
+		settings.ValidationWarnings = append(settings.ValidationWarnings,
+			fmt.Sprintf("config-unknown-key-validation: %s", message))
+	}
+}
@@ -0,0 +1,101 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDetectUnknownKeysDefaultConfig(t *testing.T) {
+	t.Parallel()
+
+	data, err := configFiles.ReadFile("config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	unknown, err := DetectUnknownKeys(path)
+	if err != nil {
+		t.Fatalf("DetectUnknownKeys() error = %v", err)
+	}
+	if len(unknown) > 0 {
+		t.Errorf("DetectUnknownKeys() of the default config = %v, want none", unknown)
+	}
+}
+
+func TestDetectUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+main:
+  name: site-a
+  nmae: typo
+BirdNET:
+  Threshold: 0.8
+  oldsetting: true
+realtime:
+  audio:
+    soundlevel:
+      debug_realtime_logging: true
+  species:
+    config:
+      Any Species:
+        threshold: 0.5
+  legacysection:
+    enabled: true
+  openweather:
+    apikey: legacy
+removedtoplevel: 1
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	unknown, err := DetectUnknownKeys(path)
+	if err != nil {
+		t.Fatalf("DetectUnknownKeys() error = %v", err)
+	}
+	want := []string{"birdnet.oldsetting", "main.nmae", "realtime.legacysection", "removedtoplevel"}
+	if !slices.Equal(unknown, want) {
+		t.Errorf("DetectUnknownKeys() = %v, want %v", unknown, want)
+	}
+}
+
+func TestDetectUnknownKeysErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if _, err := DetectUnknownKeys(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("DetectUnknownKeys() of a missing file succeeded, want error")
+	}
+
+	path := filepath.Join(dir, "broken.yaml")
+	if err := os.WriteFile(path, []byte("main: [unclosed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DetectUnknownKeys(path); err == nil {
+		t.Error("DetectUnknownKeys() of invalid YAML succeeded, want error")
+	}
+}
+
+func TestWarnUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("main:\n  nmae: typo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := &Settings{}
+	warnUnknownKeys(path, settings)
+	if len(settings.ValidationWarnings) != 1 || !strings.Contains(settings.ValidationWarnings[0], "main.nmae") {
+		t.Errorf("ValidationWarnings = %v, want one warning about main.nmae", settings.ValidationWarnings)
+	}
+}
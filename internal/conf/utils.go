@@ -11,7 +11,9 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,12 +22,20 @@ import (
 	"github.com/tphakala/birdnet-go/internal/privacy"
 )
 
-// getDefaultConfigPaths returns a list of default configuration paths for the current operating system.
+// ConfigDirEnv is the environment variable naming the configuration directory
+// searched before all default directories, e.g. set by distribution packages
+const ConfigDirEnv = "BIRDNET_GO_CONFIG_DIR"
+
+// GetDefaultConfigPaths returns a list of default configuration paths for the current operating system.
 // It determines paths based on standard conventions for storing application configuration files.
 // If a config.yaml file is found in any of the paths, it returns that path as the default.
+//
+// The paths are searched in this order, the first one is where a new default
+// config file is created:
+//   - $BIRDNET_GO_CONFIG_DIR
+//   - Windows: the executable directory and ~\AppData\Roaming\birdnet-go
+//   - Linux and macOS: $XDG_CONFIG_HOME/birdnet-go, ~/.config/birdnet-go and /etc/birdnet-go
 func GetDefaultConfigPaths() ([]string, error) {
-	var configPaths []string
-
 	// Fetch the directory of the executable.
 	exePath, err := os.Executable()
 	if err != nil {
@@ -45,21 +55,7 @@ func GetDefaultConfigPaths() ([]string, error) {
 			Build()
 	}
 
-	// Define default paths based on the operating system.
-	switch runtime.GOOS {
-	case "windows":
-		// For Windows, use the executable directory and the AppData Roaming directory.
-		configPaths = []string{
-			exeDir,
-			filepath.Join(homeDir, "AppData", "Roaming", "birdnet-go"),
-		}
-	default:
-		// For Linux and macOS, use a hidden directory in the home directory and a system-wide configuration directory.
-		configPaths = []string{
-			filepath.Join(homeDir, ".config", "birdnet-go"),
-			"/etc/birdnet-go",
-		}
-	}
+	configPaths := configSearchPaths(runtime.GOOS, exeDir, homeDir, os.Getenv)
 
 	// Check if config.yaml exists in any of the paths
 	for _, path := range configPaths {
@@ -74,6 +70,42 @@ func GetDefaultConfigPaths() ([]string, error) {
 	return configPaths, nil
 }
 
+// configSearchPaths returns the configuration directories searched on goos in
+// order of priority, getenv reads the environment
+func configSearchPaths(goos, exeDir, homeDir string, getenv func(string) string) []string {
+	var configPaths []string
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if !slices.Contains(configPaths, path) {
+			configPaths = append(configPaths, path)
+		}
+	}
+
+	// An explicitly configured directory takes precedence over all defaults
+	if dir := getenv(ConfigDirEnv); dir != "" {
+		add(dir)
+	}
+
+	// Define default paths based on the operating system.
+	switch goos {
+	case "windows":
+		// For Windows, use the executable directory and the AppData Roaming directory.
+		add(exeDir)
+		add(filepath.Join(homeDir, "AppData", "Roaming", "birdnet-go"))
+	default:
+		// For Linux and macOS, use the XDG config directory, which defaults to
+		// ~/.config, and a system-wide configuration directory. Relative
+		// XDG_CONFIG_HOME values are invalid by the XDG spec and ignored.
+		if xdgConfigHome := getenv("XDG_CONFIG_HOME"); filepath.IsAbs(xdgConfigHome) {
+			add(filepath.Join(xdgConfigHome, "birdnet-go"))
+		}
+		add(filepath.Join(homeDir, ".config", "birdnet-go"))
+		add("/etc/birdnet-go")
+	}
+
+	return configPaths
+}
+
 // findConfigFile locates the configuration file.
 func FindConfigFile() (string, error) {
 	configPaths, err := GetDefaultConfigPaths()
@@ -270,20 +302,71 @@ func GetBoardModel() string {
 	return model
 }
 
-// ParsePercentage converts a percentage string (e.g., "80%") to a float64
-func ParsePercentage(percentage string) (float64, error) {
-	if strings.HasSuffix(percentage, "%") {
-		value, err := strconv.ParseFloat(strings.TrimSuffix(percentage, "%"), 64)
+// diskLimitPattern matches absolute disk sizes such as "50GB", "500 MiB" or "1.5TB"
+var diskLimitPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]+)$`)
+
+// diskSizeUnits maps lowercase size units to their size in bytes
+var diskSizeUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// ParseDiskLimit converts a disk limit into bytes. The limit is either a
+// percentage of totalBytes such as "80%", clamped to 1-100, or an absolute size
+// such as "50GB" or "500MiB". A number without a unit such as "80" is a
+// percentage, like earlier versions read it. isPercent reports which form was
+// used.
+func ParseDiskLimit(s string, totalBytes uint64) (limitBytes uint64, isPercent bool, err error) {
+	s = strings.TrimSpace(s)
+
+	if _, numErr := strconv.ParseFloat(s, 64); strings.HasSuffix(s, "%") || numErr == nil {
+		percent, err := ParsePercentage(s)
 		if err != nil {
-			return 0, err
+			return 0, true, errors.Newf("invalid disk limit percentage %q", s).
+				Component("conf").
+				Category(errors.CategoryValidation).
+				Context("input", s).
+				Build()
 		}
-		return value, nil
+		percent = max(1, min(100, percent))
+		return uint64(float64(totalBytes) * percent / 100), true, nil
 	}
-	return 0, errors.Newf("invalid percentage format").
-		Component("conf").
-		Category(errors.CategoryValidation).
-		Context("input", percentage).
-		Build()
+
+	matches := diskLimitPattern.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, false, errors.Newf("invalid disk limit %q, expected a percentage like \"80%%\" or a size like \"50GB\"", s).
+			Component("conf").
+			Category(errors.CategoryValidation).
+			Context("input", s).
+			Build()
+	}
+
+	unitSize, ok := diskSizeUnits[strings.ToLower(matches[2])]
+	if !ok {
+		return 0, false, errors.Newf("unknown disk size unit %q in %q", matches[2], s).
+			Component("conf").
+			Category(errors.CategoryValidation).
+			Context("input", s).
+			Build()
+	}
+
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil || value <= 0 {
+		return 0, false, errors.Newf("disk limit %q must be a positive size", s).
+			Component("conf").
+			Category(errors.CategoryValidation).
+			Context("input", s).
+			Build()
+	}
+
+	return uint64(value * unitSize), false, nil
 }
 
 // ParseRetentionPeriod converts a string like "24h", "7d", "1w", "3m", "1y" to hours.
@@ -326,8 +409,16 @@ func ParseRetentionPeriod(retention string) (int, error) {
 	}
 }
 
-// ParseWeekday converts a string to time.Weekday
+// ParseWeekday converts a string to time.Weekday. It accepts weekday names,
+// case-insensitively, and numbers from "0" (Sunday) to "6" (Saturday).
 func ParseWeekday(day string) (time.Weekday, error) {
+	if n, err := strconv.Atoi(day); err == nil {
+		if n < int(time.Sunday) || n > int(time.Saturday) {
+			return time.Sunday, fmt.Errorf("invalid weekday: %s", day)
+		}
+		return time.Weekday(n), nil
+	}
+
 	switch strings.ToLower(day) {
 	case "sunday":
 		return time.Sunday, nil
@@ -353,8 +444,12 @@ func (lc *LogConfig) GetRotationDay() (time.Weekday, error) {
 	return ParseWeekday(lc.RotationDay)
 }
 
-// GetLocalTimezone returns the local time zone of the system.
+// GetLocalTimezone returns the configured time zone, or the local time zone of
+// the system when none is configured.
 func GetLocalTimezone() (*time.Location, error) {
+	if settings := GetSettings(); settings != nil {
+		return settings.Location(), nil
+	}
 	return time.Local, nil
 }
 
@@ -398,29 +493,11 @@ func IsSoxAvailable() (isAvailable bool, formats []string) {
 		return false, nil // SoX is not available
 	}
 
-	// Execute SoX with the help flag to get its output
-	cmd := exec.Command(soxPath, "-h")
-	output, err := cmd.CombinedOutput()
+	formats, err = probeSoxFormats(soxPath)
 	if err != nil {
 		return false, nil // Failed to execute SoX
 	}
-
-	// Convert the output to a string and split it into lines
-	outputStr := string(output)
-	lines := strings.Split(outputStr, "\n")
-
-	var audioFormats []string
-	// Iterate through the lines to find the supported audio formats
-	for _, line := range lines {
-		if formats, found := strings.CutPrefix(line, "AUDIO FILE FORMATS:"); found {
-			// Extract and process the list of audio formats
-			formats = strings.TrimSpace(formats)
-			audioFormats = strings.Fields(formats)
-			break
-		}
-	}
-
-	return true, audioFormats // SoX is available, return the list of supported formats
+	return true, formats // SoX is available, return the list of supported formats
 }
 
 // ValidateToolPath checks if a tool is available, either at an explicit path or in the system PATH.
@@ -454,7 +531,7 @@ func ValidateToolPath(configuredPath, toolName string) (string, error) {
 // moveFile moves a file from src to dst, working across devices
 func moveFile(src, dst string) error {
 	// Try to rename the file first (this works for moves within the same filesystem)
-	if err := os.Rename(src, dst); err == nil {
+	if err := renameFile(src, dst); err == nil {
 		return nil // If rename succeeds, we're done
 	}
 
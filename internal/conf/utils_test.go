@@ -0,0 +1,80 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfigSearchPaths(t *testing.T) {
+	t.Parallel()
+
+	home := filepath.FromSlash("/home/bird")
+	exe := filepath.FromSlash("/opt/birdnet-go")
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want []string
+	}{
+		{"linux defaults", "linux", nil,
+			[]string{"/home/bird/.config/birdnet-go", "/etc/birdnet-go"}},
+		{"xdg config home", "linux", map[string]string{"XDG_CONFIG_HOME": "/home/bird/xdg"},
+			[]string{"/home/bird/xdg/birdnet-go", "/home/bird/.config/birdnet-go", "/etc/birdnet-go"}},
+		{"xdg config home is the default", "darwin", map[string]string{"XDG_CONFIG_HOME": "/home/bird/.config"},
+			[]string{"/home/bird/.config/birdnet-go", "/etc/birdnet-go"}},
+		{"relative xdg config home is ignored", "linux", map[string]string{"XDG_CONFIG_HOME": "xdg"},
+			[]string{"/home/bird/.config/birdnet-go", "/etc/birdnet-go"}},
+		{"config dir env first", "linux", map[string]string{ConfigDirEnv: "/srv/birdnet/", "XDG_CONFIG_HOME": "/home/bird/xdg"},
+			[]string{"/srv/birdnet", "/home/bird/xdg/birdnet-go", "/home/bird/.config/birdnet-go", "/etc/birdnet-go"}},
+		{"windows defaults", "windows", map[string]string{"XDG_CONFIG_HOME": "/home/bird/xdg"},
+			[]string{"/opt/birdnet-go", "/home/bird/AppData/Roaming/birdnet-go"}},
+		{"windows config dir env", "windows", map[string]string{ConfigDirEnv: "/srv/birdnet"},
+			[]string{"/srv/birdnet", "/opt/birdnet-go", "/home/bird/AppData/Roaming/birdnet-go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string { return tt.env[key] }
+			want := make([]string, len(tt.want))
+			for i, path := range tt.want {
+				want[i] = filepath.FromSlash(path)
+			}
+			if got := configSearchPaths(tt.goos, exe, home, getenv); !slices.Equal(got, want) {
+				t.Errorf("configSearchPaths() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestGetDefaultConfigPathsConfigDirEnv sets environment variables and cannot run in parallel
+func TestGetDefaultConfigPathsConfigDirEnv(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv(ConfigDirEnv, configDir)
+	// Keep config files of the user running the tests out of the search
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// Without a config file the configured directory is where one is created
+	paths, err := GetDefaultConfigPaths()
+	if err != nil {
+		t.Fatalf("GetDefaultConfigPaths() error = %v", err)
+	}
+	if len(paths) == 0 || paths[0] != configDir {
+		t.Fatalf("GetDefaultConfigPaths() = %v, want %s first", paths, configDir)
+	}
+
+	// A config file in the configured directory is the only path returned
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte("main:\n  name: node\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	paths, err = GetDefaultConfigPaths()
+	if err != nil {
+		t.Fatalf("GetDefaultConfigPaths() error = %v", err)
+	}
+	if !slices.Equal(paths, []string{configDir}) {
+		t.Errorf("GetDefaultConfigPaths() = %v, want [%s]", paths, configDir)
+	}
+}
@@ -5,10 +5,10 @@ package conf
 import (
 	"fmt"
 	"log"
+	"maps"
 	"net"
-	"os/exec"
 	"regexp"
-	"strconv"
+	"slices"
 	"strings"
 
 	"github.com/tphakala/birdnet-go/internal/errors"
@@ -17,14 +17,69 @@ import (
 // MinSoundLevelInterval is the minimum sound level interval in seconds to prevent excessive CPU usage
 const MinSoundLevelInterval = 5
 
-// ValidationError represents a collection of validation errors
+// MinMonitoringCheckInterval is the minimum system monitoring check interval in seconds
+const MinMonitoringCheckInterval = 5
+
+// prometheusNamePattern matches valid Prometheus label names and metric name prefixes
+var prometheusNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Severity tells whether a validation issue prevents the settings from being used
+type Severity string
+
+const (
+	// SeverityWarning marks an issue the settings were corrected for, e.g. a fallback to a default
+	SeverityWarning Severity = "warning"
+	// SeverityError marks an issue that makes the settings unusable
+	SeverityError Severity = "error"
+)
+
+// ValidationIssue is a single problem found by ValidateSettings or Lint
+type ValidationIssue struct {
+	Field    string   `json:"field"`          // config key or section of the issue, e.g. birdnet.locale
+	Code     string   `json:"code,omitempty"` // machine readable code, one of the ErrCode constants
+	Message  string   `json:"message"`        // human readable description
+	Severity Severity `json:"severity"`       // SeverityWarning or SeverityError
+}
+
+// ValidationError represents a collection of validation issues
 type ValidationError struct {
-	Errors []string
+	Issues []ValidationIssue
 }
 
 // Error returns a string representation of the validation errors
 func (ve ValidationError) Error() string {
-	return fmt.Sprintf("Validation errors: %v", ve.Errors)
+	return fmt.Sprintf("Validation errors: %v", ve.Errors())
+}
+
+// Errors returns the messages of all issues, warnings included, for callers
+// that predate ValidationIssue
+func (ve ValidationError) Errors() []string {
+	messages := make([]string, 0, len(ve.Issues))
+	for _, issue := range ve.Issues {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
+// HasErrors reports whether any issue has SeverityError
+func (ve ValidationError) HasErrors() bool {
+	for _, issue := range ve.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// addError records an error issue for a config key or section, the code is
+// the validation_type of err
+func (ve *ValidationError) addError(field string, err error) {
+	ve.Issues = append(ve.Issues, ValidationIssue{Field: field, Code: validationCode(err), Message: err.Error(), Severity: SeverityError})
+}
+
+// addWarning records a warning issue with code for a config key or section
+func (ve *ValidationError) addWarning(field, code, message string) {
+	ve.Issues = append(ve.Issues, ValidationIssue{Field: field, Code: code, Message: message, Severity: SeverityWarning})
 }
 
 // logValidationWarning logs a validation warning for telemetry purposes without returning an error
@@ -41,129 +96,330 @@ func logValidationWarning(err error, validationType, warningType string) {
 func ValidateSettings(settings *Settings) error {
 	ve := ValidationError{}
 
+	// Validate time zone
+	if err := validateTimeZone(settings.Main.TimeZone); err != nil {
+		ve.addError("main.timezone", err)
+	}
+
+	// Validate log outputs
+	for key, lc := range map[string]*LogConfig{
+		"main.log":      &settings.Main.Log,
+		"webserver.log": &settings.WebServer.Log,
+	} {
+		if err := validateLogConfig(lc, key); err != nil {
+			ve.addError(key, err)
+		}
+	}
+
 	// Validate BirdNET settings
-	if err := validateBirdNETSettings(&settings.BirdNET, settings); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+	if warning := normalizeRangeFilterModel(&settings.BirdNET.RangeFilter, settings); warning != "" {
+		ve.addWarning("birdnet.rangefilter.model", ErrCodeRangeFilterModel, warning)
+	}
+	if warning := clampRangeFilterThreshold(&settings.BirdNET.RangeFilter, settings); warning != "" {
+		ve.addWarning("birdnet.rangefilter.threshold", ErrCodeRangeFilterThreshold, warning)
+	}
+	if err := validateBirdNETSettings(&settings.BirdNET); err != nil {
+		ve.addError("birdnet", err)
+	}
+	if warning := validateLocale(&settings.BirdNET, settings); warning != "" {
+		ve.addWarning("birdnet.locale", ErrCodeLocale, warning)
+	}
+	if warning := validateSecondaryLocale(&settings.BirdNET, settings); warning != "" {
+		ve.addWarning("birdnet.secondarylocale", ErrCodeLocale, warning)
+	}
+	if warning := warnExcessThreads(&settings.BirdNET, settings); warning != "" {
+		ve.addWarning("birdnet.threads", ErrCodeBirdNETThreads, warning)
+	}
+	configDir := ConfigDir()
+	if warning := warnMissingModelFile("model", settings.BirdNET.ResolveModelPath(configDir), settings); warning != "" {
+		ve.addWarning("birdnet.modelpath", ErrCodeBirdNETModelPath, warning)
+	}
+	if warning := warnMissingModelFile("label", settings.BirdNET.ResolveLabelPath(configDir), settings); warning != "" {
+		ve.addWarning("birdnet.labelpath", ErrCodeBirdNETModelPath, warning)
+	}
+
+	// Validate file and directory analysis options
+	if err := settings.Input.Validate(); err != nil {
+		ve.addError("input", err)
 	}
 
 	// Validate WebServer settings
 	if err := validateWebServerSettings(&settings.WebServer); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+		ve.addError("webserver", err)
 	}
 
 	// Validate Security settings
 	if err := validateSecuritySettings(&settings.Security); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+		ve.addError("security", err)
 	}
 
+	// Validate authentication token and session lifetimes
+	if err := validateAuthDurations(settings); err != nil {
+		ve.addError("security", err)
+	}
+
+	// Warn about OAuth redirect URIs that differ from the ones derived from the host
+	validateRedirectURIs(settings)
+
 	// Validate Realtime settings
 	if err := validateRealtimeSettings(&settings.Realtime); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+		ve.addError("realtime", err)
+	} else {
+		if warning := warnInsecureMQTT(&settings.Realtime.MQTT, settings); warning != "" {
+			ve.addWarning("realtime.mqtt.tls.insecureskipverify", ErrCodeMQTTTLSVerification, warning)
+		}
+		if warning := warnOccurrencesWithoutInterval(settings); warning != "" {
+			ve.addWarning("realtime.species.config", ErrCodeSpeciesMinOccurrences, warning)
+		}
+	}
+
+	// Validate privacy and dog bark filter settings
+	if err := validateDetectionFilters(settings); err != nil {
+		ve.addError("realtime", err)
 	}
 
 	// Validate Birdweather settings
 	if err := validateBirdweatherSettings(&settings.Realtime.Birdweather); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+		ve.addError("realtime.birdweather", err)
 	}
 
 	// Validate Audio settings
 	if err := validateAudioSettings(&settings.Realtime.Audio); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+		ve.addError("realtime.audio", err)
+	} else if warning := validateExportTools(&settings.Realtime.Audio, settings); warning != "" {
+		ve.addWarning("realtime.audio.export.type", ErrCodeExportType, warning)
 	}
 
 	// Validate Dashboard settings
 	if err := validateDashboardSettings(&settings.Realtime.Dashboard); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+		ve.addError("realtime.dashboard", err)
+	} else if warning := capSummaryLimit(&settings.Realtime.Dashboard, settings); warning != "" {
+		ve.addWarning("realtime.dashboard.summarylimit", ErrCodeDashboardSummaryLimit, warning)
+	}
+
+	// Validate thumbnail image provider settings, unknown values fall back to defaults
+	validateThumbnailSettings(&settings.Realtime.Dashboard.Thumbnails, settings)
+	if err := settings.Realtime.Dashboard.Thumbnails.validateProviders(); err != nil {
+		ve.addError("realtime.dashboard.thumbnails", err)
 	}
 
 	// Validate Weather settings
 	if err := validateWeatherSettings(&settings.Realtime.Weather); err != nil {
-		ve.Errors = append(ve.Errors, err.Error())
+		ve.addError("realtime.weather", err)
+	} else {
+		// Respect the poll interval limits of the weather provider
+		validateWeatherProviderInterval(&settings.Realtime.Weather, settings)
+	}
+
+	// Validate Telemetry settings
+	if err := validateTelemetrySettings(&settings.Realtime.Telemetry); err != nil {
+		ve.addError("realtime.telemetry", err)
+	} else if err := validateListenConflicts(settings); err != nil {
+		ve.addError("realtime.telemetry.listen", err)
+	}
+
+	// Validate system monitoring settings
+	if err := validateMonitoringSettings(&settings.Realtime.Monitoring, settings); err != nil {
+		ve.addError("realtime.monitoring", err)
+	}
+
+	// Validate backup retention policies, targets may override the global policy
+	if settings.Backup.Enabled {
+		if err := settings.Backup.ValidateCompression(); err != nil {
+			ve.addError("backup.compression", errors.New(fmt.Errorf("backup compression: %w", err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeBackupCompression).
+				Build())
+		} else if warning := settings.Backup.CompressionWarning(); warning != "" {
+			log.Printf("Configuration warning: %s", warning)
+			logValidationWarning(fmt.Errorf("%s", warning), ErrCodeBackupCompression, "compression-level-extreme")
+			settings.ValidationWarnings = append(settings.ValidationWarnings,
+				fmt.Sprintf("config-backup-validation: %s", warning))
+			ve.addWarning("backup.compression_level", ErrCodeBackupCompression, warning)
+		}
+		if settings.Backup.MaxConcurrentUploads < 0 {
+			ve.addError("backup.max_concurrent_uploads", errors.New(fmt.Errorf("backup max concurrent uploads must not be negative, got %d", settings.Backup.MaxConcurrentUploads)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeBackupConcurrentUploads).
+				Build())
+		}
+		if settings.Backup.BandwidthLimitKBps < 0 {
+			ve.addError("backup.bandwidth_limit_kbps", errors.New(fmt.Errorf("backup bandwidth limit must not be negative, got %d KB/s", settings.Backup.BandwidthLimitKBps)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeBackupBandwidthLimit).
+				Build())
+		}
+		if err := settings.Backup.Retention.Validate(); err != nil {
+			ve.addError("backup.retention", fmt.Errorf("backup retention: %w", err))
+		}
+		for i, target := range settings.Backup.Targets {
+			if !target.Enabled {
+				continue
+			}
+			if err := validateBackupTarget(target); err != nil {
+				ve.addError(fmt.Sprintf("backup.targets.%d.settings", i), fmt.Errorf("backup target %d (%s): %w", i, target.Type, err))
+			} else if warning := knownHostsDefaultWarning(target); warning != "" {
+				log.Printf("Configuration warning: %s", warning)
+				logValidationWarning(fmt.Errorf("%s", warning), ErrCodeBackupTargetHostKey, "default-known-hosts")
+				settings.ValidationWarnings = append(settings.ValidationWarnings,
+					fmt.Sprintf("config-backup-validation: %s", warning))
+				ve.addWarning(fmt.Sprintf("backup.targets.%d.settings", i), ErrCodeBackupTargetHostKey, warning)
+			}
+			if target.Retention == nil {
+				continue
+			}
+			if err := target.Retention.Validate(); err != nil {
+				ve.addError(fmt.Sprintf("backup.targets.%d.retention", i), fmt.Errorf("backup target %d (%s) retention: %w", i, target.Type, err))
+			}
+		}
+	}
+
+	// Validate backup schedules
+	if settings.Backup.Enabled {
+		for i, schedule := range settings.Backup.Schedules {
+			if !schedule.Enabled {
+				continue
+			}
+			if err := schedule.Validate(); err != nil {
+				ve.addError(fmt.Sprintf("backup.schedules.%d", i), fmt.Errorf("backup schedule %d: %w", i, err))
+			}
+		}
+	}
+
+	// Validate detection notification channels
+	for i, channel := range settings.Notifications {
+		if !channel.Enabled {
+			continue
+		}
+		if err := channel.Validate(); err != nil {
+			ve.addError(fmt.Sprintf("notifications.%d", i), fmt.Errorf("notification channel %d (%s): %w", i, channel.Type, err))
+		}
 	}
 
-	// If there are any errors, return the ValidationError
-	if len(ve.Errors) > 0 {
+	// Normalize the analysis output type, unknown types fall back to table
+	validateOutputFileType(settings)
+
+	// Validate the MySQL connection pool
+	if err := validateMySQLPool(settings); err != nil {
+		ve.addError("output.mysql", err)
+	}
+
+	// Warn when both databases are enabled, the datastore uses SQLite
+	if warning := databaseConflictWarning(settings); warning != "" {
+		log.Printf("Configuration warning: %s", warning)
+		logValidationWarning(fmt.Errorf("%s", warning), ErrCodeDatabaseConflict, "database-both-enabled")
+		settings.ValidationWarnings = append(settings.ValidationWarnings,
+			fmt.Sprintf("config-database-validation: %s", warning))
+		ve.addWarning("output", ErrCodeDatabaseConflict, warning)
+	}
+
+	// Run validators registered by other packages
+	runRegisteredValidators(settings, &ve)
+
+	// If there are any issues, return the ValidationError
+	if len(ve.Issues) > 0 {
 		return ve
 	}
 	return nil
 }
 
 // validateBirdNETSettings validates the BirdNET-specific settings
-func validateBirdNETSettings(birdnetSettings *BirdNETConfig, settings *Settings) error {
+func validateBirdNETSettings(birdnetSettings *BirdNETConfig) error {
 	var errs []string
 
 	// Check if sensitivity is within valid range
-	if birdnetSettings.Sensitivity < 0 || birdnetSettings.Sensitivity > 1.5 {
-		errs = append(errs, "BirdNET sensitivity must be between 0 and 1.5")
+	if c := constraintFor("birdnet.sensitivity"); !c.inRange(birdnetSettings.Sensitivity) {
+		errs = append(errs, fmt.Sprintf("BirdNET sensitivity must be %s", c))
 	}
 
 	// Check if threshold is within valid range
-	if birdnetSettings.Threshold < 0 || birdnetSettings.Threshold > 1 {
-		errs = append(errs, "BirdNET threshold must be between 0 and 1")
+	if c := constraintFor("birdnet.threshold"); !c.inRange(float64(birdnetSettings.Threshold)) {
+		errs = append(errs, fmt.Sprintf("BirdNET threshold must be %s", c))
 	}
 
 	// Check if overlap is within valid range
-	if birdnetSettings.Overlap < 0 || birdnetSettings.Overlap > 2.99 {
-		errs = append(errs, "BirdNET overlap value must be between 0 and 2.99 seconds")
+	if c := constraintFor("birdnet.overlap"); !c.inRange(birdnetSettings.Overlap) {
+		errs = append(errs, fmt.Sprintf("BirdNET overlap value must be %s seconds", c))
 	}
 
 	// Check if longitude is within valid range
-	if birdnetSettings.Longitude < -180 || birdnetSettings.Longitude > 180 {
-		errs = append(errs, "BirdNET longitude must be between -180 and 180")
+	if c := constraintFor("birdnet.longitude"); !c.inRange(birdnetSettings.Longitude) {
+		errs = append(errs, fmt.Sprintf("BirdNET longitude must be %s", c))
 	}
 
 	// Check if latitude is within valid range
-	if birdnetSettings.Latitude < -90 || birdnetSettings.Latitude > 90 {
-		errs = append(errs, "BirdNET latitude must be between -90 and 90")
-	}
-
-	// Check if threads is non-negative
-	if birdnetSettings.Threads < 0 {
-		errs = append(errs, "BirdNET threads must be at least 0")
+	if c := constraintFor("birdnet.latitude"); !c.inRange(birdnetSettings.Latitude) {
+		errs = append(errs, fmt.Sprintf("BirdNET latitude must be %s", c))
 	}
 
-	// Validate RangeFilter settings
-	if birdnetSettings.RangeFilter.Model == "" {
-		errs = append(errs, "RangeFilter model must not be empty")
+	// Check if threads is non-negative, 0 uses all CPUs
+	if c := constraintFor("birdnet.threads"); !c.inRange(float64(birdnetSettings.Threads)) {
+		errs = append(errs, fmt.Sprintf("BirdNET threads must be %s", c))
 	}
 
-	// Check if RangeFilter threshold is within valid range
-	if birdnetSettings.RangeFilter.Threshold < 0 || birdnetSettings.RangeFilter.Threshold > 1 {
-		errs = append(errs, "RangeFilter threshold must be between 0 and 1")
+	// Check if RangeFilter model is known, an empty model uses the default
+	if c := constraintFor("birdnet.rangefilter.model"); birdnetSettings.RangeFilter.Model != "" && !c.allows(birdnetSettings.RangeFilter.Model) {
+		errs = append(errs, fmt.Sprintf("RangeFilter model %q is not supported, it must be %s", birdnetSettings.RangeFilter.Model, c))
 	}
 
-	// Validate locale setting
-	if birdnetSettings.Locale != "" {
-		normalizedLocale, err := NormalizeLocale(birdnetSettings.Locale)
-		if err != nil {
-			// This means locale normalization fell back to default
-			message := fmt.Sprintf("BirdNET locale '%s' is not supported, will use fallback '%s'", birdnetSettings.Locale, normalizedLocale)
-			errs = append(errs, message)
-
-			// Store the validation warning for telemetry reporting
-			// We can't call telemetry directly here due to import cycles
-			// This will be handled by the calling code in main.go
-			if settings.ValidationWarnings == nil {
-				settings.ValidationWarnings = make([]string, 0)
-			}
-			settings.ValidationWarnings = append(settings.ValidationWarnings,
-				fmt.Sprintf("config-locale-validation: %s", message))
-		}
-		// Update the settings with the normalized locale
-		birdnetSettings.Locale = normalizedLocale
+	// Check the RangeFilter update interval, 0 uses the default
+	switch {
+	case birdnetSettings.RangeFilter.UpdateInterval < 0:
+		errs = append(errs, fmt.Sprintf("RangeFilter update interval must not be negative, got %s", birdnetSettings.RangeFilter.UpdateInterval))
+	case birdnetSettings.RangeFilter.UpdateInterval == 0:
+		birdnetSettings.RangeFilter.UpdateInterval = DefaultRangeFilterUpdateInterval
 	}
 
 	// If there are any errors, return them as a single error
 	if len(errs) > 0 {
 		return errors.New(fmt.Errorf("birdnet settings errors: %v", errs)).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "birdnet-settings-collection").
+			Context("validation_type", ErrCodeBirdNETSettings).
 			Build()
 	}
 
 	return nil
 }
 
+// validateLocale normalizes the BirdNET locale and returns a warning message
+// when the configured locale is not supported and falls back to a default
+func validateLocale(birdnetSettings *BirdNETConfig, settings *Settings) string {
+	return normalizeLocaleSetting("BirdNET locale", &birdnetSettings.Locale, settings)
+}
+
+// validateSecondaryLocale normalizes the optional BirdNET secondary locale
+// and returns a warning message when it falls back to a default
+func validateSecondaryLocale(birdnetSettings *BirdNETConfig, settings *Settings) string {
+	return normalizeLocaleSetting("BirdNET secondary locale", &birdnetSettings.SecondaryLocale, settings)
+}
+
+// normalizeLocaleSetting replaces a set locale with its normalized code and
+// returns a warning message when the locale is not supported
+func normalizeLocaleSetting(name string, locale *string, settings *Settings) string {
+	if *locale == "" {
+		return ""
+	}
+
+	configured := *locale
+	normalizedLocale, matched := NormalizeLocale(configured)
+	// Update the settings with the normalized locale
+	*locale = normalizedLocale
+	if matched {
+		return ""
+	}
+
+	// This means locale normalization fell back to default
+	message := fmt.Sprintf("%s '%s' is not supported, will use fallback '%s', supported locales are %s",
+		name, configured, normalizedLocale, strings.Join(SupportedLocales(), ", "))
+
+	// Store the validation warning for telemetry reporting
+	// We can't call telemetry directly here due to import cycles
+	// This will be handled by the calling code in main.go
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-locale-validation: %s", message))
+	return message
+}
+
 // validateWebServerSettings validates the WebServer-specific settings
 func validateWebServerSettings(settings *WebServerSettings) error {
 	if settings.Enabled {
@@ -171,30 +427,61 @@ func validateWebServerSettings(settings *WebServerSettings) error {
 		if settings.Port == "" {
 			return errors.New(fmt.Errorf("WebServer port is required when enabled")).
 				Category(errors.CategoryValidation).
-				Context("validation_type", "webserver-port-required").
+				Context("validation_type", ErrCodeWebServerPortRequired).
 				Build()
 		}
-		// You might want to add more specific port validation here
 	}
+	if settings.Port != "" {
+		if err := validateWebServerPort(settings.Port); err != nil {
+			return err
+		}
+	}
+
+	// Validate LiveStream quality preset and expand it into unset encoding settings
+	if c := constraintFor("webserver.livestream.quality"); settings.LiveStream.Quality != "" && !c.allows(settings.LiveStream.Quality) {
+		return errors.New(fmt.Errorf("LiveStream quality must be %s, got %q", c, settings.LiveStream.Quality)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeLivestreamQuality).
+			Context("quality", settings.LiveStream.Quality).
+			Build()
+	}
+	settings.LiveStream.ApplyQualityPreset()
 
 	// Validate LiveStream settings
-	if settings.LiveStream.BitRate < 16 || settings.LiveStream.BitRate > 320 {
-		return errors.New(fmt.Errorf("LiveStream bitrate must be between 16 and 320 kbps, got %d", settings.LiveStream.BitRate)).
+	if c := constraintFor("webserver.livestream.bitrate"); !c.inRange(float64(settings.LiveStream.BitRate)) {
+		return errors.New(fmt.Errorf("LiveStream bitrate must be %s kbps, got %d", c, settings.LiveStream.BitRate)).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "livestream-bitrate").
+			Context("validation_type", ErrCodeLivestreamBitrate).
 			Context("bitrate", settings.LiveStream.BitRate).
 			Build()
 	}
 
-	if settings.LiveStream.SegmentLength < 1 || settings.LiveStream.SegmentLength > 30 {
-		return errors.New(fmt.Errorf("LiveStream segment length must be between 1 and 30 seconds, got %d", settings.LiveStream.SegmentLength)).
+	if c := constraintFor("webserver.livestream.segmentlength"); !c.inRange(float64(settings.LiveStream.SegmentLength)) {
+		return errors.New(fmt.Errorf("LiveStream segment length must be %s seconds, got %d", c, settings.LiveStream.SegmentLength)).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "livestream-segment-length").
+			Context("validation_type", ErrCodeLivestreamSegmentLength).
 			Context("segment_length", settings.LiveStream.SegmentLength).
 			Build()
 	}
 
-	return nil
+	if c := constraintFor("webserver.livestream.samplerate"); !c.allows(settings.LiveStream.SampleRate) {
+		return errors.New(fmt.Errorf("LiveStream sample rate must be %s Hz, got %d", c, settings.LiveStream.SampleRate)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeLivestreamSampleRate).
+			Context("sample_rate", settings.LiveStream.SampleRate).
+			Build()
+	}
+
+	// Empty log level leaves the ffmpeg default
+	if c := constraintFor("webserver.livestream.ffmpegloglevel"); settings.LiveStream.FfmpegLogLevel != "" && !c.allows(settings.LiveStream.FfmpegLogLevel) {
+		return errors.New(fmt.Errorf("LiveStream ffmpeg log level must be %s, got %q", c, settings.LiveStream.FfmpegLogLevel)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeLivestreamLogLevel).
+			Context("ffmpeg_log_level", settings.LiveStream.FfmpegLogLevel).
+			Build()
+	}
+
+	return validateCORSSettings(&settings.CORS)
 }
 
 // validateSecuritySettings validates the security-specific settings
@@ -203,17 +490,36 @@ func validateSecuritySettings(settings *Security) error {
 	if (settings.BasicAuth.Enabled || settings.GoogleAuth.Enabled || settings.GithubAuth.Enabled) && settings.Host == "" {
 		return errors.New(fmt.Errorf("security.host must be set when using authentication providers")).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "security-authentication-host").
+			Context("validation_type", ErrCodeAuthenticationHost).
 			Build()
 	}
 
+	// OAuth redirect URIs are derived from the host and must be valid URLs, the
+	// web server port does not affect whether they are
+	if settings.BasicAuth.Enabled || settings.GoogleAuth.Enabled || settings.GithubAuth.Enabled {
+		if _, err := settings.RedirectBaseURL(""); err != nil {
+			return errors.New(fmt.Errorf("security.host cannot be used for authentication redirect URIs: %w, use a hostname or address such as birdnet.example.com or 192.168.1.10:8080", err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeAuthenticationHost).
+				Build()
+		}
+	}
+
 	// AutoTLS validation
 	if settings.AutoTLS {
 		// Host is required for AutoTLS
 		if settings.Host == "" {
 			return errors.New(fmt.Errorf("security.host must be set when AutoTLS is enabled")).
 				Category(errors.CategoryValidation).
-				Context("validation_type", "security-autotls-host").
+				Context("validation_type", ErrCodeAutoTLSHost).
+				Build()
+		}
+
+		// Let's Encrypt only issues certificates for public DNS names
+		if err := validateTLSHostname(settings.Host); err != nil {
+			return errors.New(fmt.Errorf("%w, AutoTLS requires a DNS name that resolves to this system", err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeAutoTLSHost).
 				Build()
 		}
 
@@ -228,6 +534,16 @@ func validateSecuritySettings(settings *Security) error {
 		}
 	}
 
+	// Validate the route patterns of authentication
+	if err := validateRoutePatterns(settings); err != nil {
+		return err
+	}
+
+	// Validate the reverse proxies trusted for client addresses
+	if err := validateTrustedProxies(settings); err != nil {
+		return err
+	}
+
 	// Validate the subnet bypass setting against the allowed pattern
 	if settings.AllowSubnetBypass.Enabled {
 		subnets := strings.Split(settings.AllowSubnetBypass.Subnet, ",")
@@ -236,7 +552,7 @@ func validateSecuritySettings(settings *Security) error {
 			if err != nil {
 				return errors.New(err).
 					Category(errors.CategoryValidation).
-					Context("validation_type", "security-subnet-format").
+					Context("validation_type", ErrCodeSubnetFormat).
 					Context("subnet", subnet).
 					Build()
 			}
@@ -247,7 +563,7 @@ func validateSecuritySettings(settings *Security) error {
 	if settings.SessionDuration <= 0 {
 		return errors.New(fmt.Errorf("security.sessionduration must be a positive duration")).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "security-session-duration").
+			Context("validation_type", ErrCodeSessionDuration).
 			Build()
 	}
 
@@ -257,18 +573,64 @@ func validateSecuritySettings(settings *Security) error {
 // validateRealtimeSettings validates the Realtime-specific settings
 func validateRealtimeSettings(settings *RealtimeSettings) error {
 	// Check if interval is non-negative
-	if settings.Interval < 0 {
+	if !constraintFor("realtime.interval").inRange(float64(settings.Interval)) {
 		return errors.New(fmt.Errorf("realtime interval must be non-negative")).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "realtime-interval").
+			Context("validation_type", ErrCodeRealtimeInterval).
 			Build()
 	}
 
+	// Check that per-species intervals are non-negative, zero uses the global interval
+	for _, name := range slices.Sorted(maps.Keys(settings.Species.Config)) {
+		if interval := settings.Species.Config[name].Interval; interval < 0 {
+			return errors.New(fmt.Errorf("species %q interval must be non-negative, got %d", name, interval)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeSpeciesInterval).
+				Context("species", name).
+				Build()
+		}
+	}
+
+	// Check that per-species minimum occurrences are non-negative
+	if err := validateMinOccurrences(settings); err != nil {
+		return err
+	}
+
+	// Validate the quiet hours schedule
+	if err := validateQuietHours(&settings.QuietHours); err != nil {
+		return err
+	}
+
+	// Validate the command action concurrency limit
+	if err := validateActionLimit(settings); err != nil {
+		return err
+	}
+
+	// Validate the timeouts and environment of species command actions
+	if err := validateSpeciesActions(settings); err != nil {
+		return err
+	}
+
+	// Validate detection deduplication settings
+	if err := validateDedupSettings(settings); err != nil {
+		return err
+	}
+
+	// Validate the reported confidence format, empty uses fractions
+	if err := validateConfidenceFormat(settings); err != nil {
+		return err
+	}
+
 	// Validate MQTT settings
 	if err := validateMQTTSettings(&settings.MQTT); err != nil {
 		return err
 	}
 
+	// Validate RTSP health monitoring and reconnection settings
+	if err := validateRTSPSettings(&settings.RTSP); err != nil {
+		return err
+	}
+
 	// Validate sound level settings
 	if err := validateSoundLevelSettings(&settings.Audio.SoundLevel); err != nil {
 		return err
@@ -282,18 +644,29 @@ func validateRealtimeSettings(settings *RealtimeSettings) error {
 func validateMQTTSettings(settings *MQTTSettings) error {
 	if settings.Enabled {
 		// Check if broker is provided when enabled
-		if settings.Broker == "" {
+		if len(settings.BrokerList()) == 0 {
 			return errors.New(fmt.Errorf("MQTT broker URL is required when MQTT is enabled")).
 				Category(errors.CategoryValidation).
-				Context("validation_type", "mqtt-broker-required").
+				Context("validation_type", ErrCodeMQTTBrokerRequired).
 				Build()
 		}
+		if err := settings.validateBrokers(); err != nil {
+			return err
+		}
+
+		// Validate the certificate files of TLS connections
+		settings.enableTLSFromBrokers()
+		if settings.TLS.Enabled {
+			if err := settings.TLS.validateFiles(); err != nil {
+				return err
+			}
+		}
 
 		// Check if topic is provided when enabled
 		if settings.Topic == "" {
 			return errors.New(fmt.Errorf("MQTT topic is required when MQTT is enabled")).
 				Category(errors.CategoryValidation).
-				Context("validation_type", "mqtt-topic-required").
+				Context("validation_type", ErrCodeMQTTTopicRequired).
 				Build()
 		}
 
@@ -302,28 +675,28 @@ func validateMQTTSettings(settings *MQTTSettings) error {
 
 		// Validate retry settings if enabled
 		if settings.RetrySettings.Enabled {
-			if settings.RetrySettings.MaxRetries < 0 {
+			if !constraintFor("realtime.mqtt.retrysettings.maxretries").inRange(float64(settings.RetrySettings.MaxRetries)) {
 				return errors.New(fmt.Errorf("MQTT max retries must be non-negative")).
 					Category(errors.CategoryValidation).
-					Context("validation_type", "mqtt-max-retries").
+					Context("validation_type", ErrCodeMQTTMaxRetries).
 					Build()
 			}
 			if settings.RetrySettings.InitialDelay < 0 {
 				return errors.New(fmt.Errorf("MQTT initial delay must be non-negative")).
 					Category(errors.CategoryValidation).
-					Context("validation_type", "mqtt-initial-delay").
+					Context("validation_type", ErrCodeMQTTInitialDelay).
 					Build()
 			}
 			if settings.RetrySettings.MaxDelay < settings.RetrySettings.InitialDelay {
 				return errors.New(fmt.Errorf("MQTT max delay must be greater than or equal to initial delay")).
 					Category(errors.CategoryValidation).
-					Context("validation_type", "mqtt-max-delay").
+					Context("validation_type", ErrCodeMQTTMaxDelay).
 					Build()
 			}
 			if settings.RetrySettings.BackoffMultiplier <= 0 {
 				return errors.New(fmt.Errorf("MQTT backoff multiplier must be positive")).
 					Category(errors.CategoryValidation).
-					Context("validation_type", "mqtt-backoff-multiplier").
+					Context("validation_type", ErrCodeMQTTBackoffMultiplier).
 					Build()
 			}
 		}
@@ -336,10 +709,10 @@ func validateSoundLevelSettings(settings *SoundLevelSettings) error {
 	// Sound level settings are optional, only validate if enabled
 	if settings.Enabled {
 		// Check if interval is at least the minimum to avoid excessive CPU usage
-		if settings.Interval < MinSoundLevelInterval {
+		if !constraintFor("realtime.audio.soundlevel.interval").inRange(float64(settings.Interval)) {
 			return errors.New(fmt.Errorf("sound level interval must be at least %d seconds to avoid excessive CPU usage, got %d", MinSoundLevelInterval, settings.Interval)).
 				Category(errors.CategoryValidation).
-				Context("validation_type", "sound-level-interval").
+				Context("validation_type", ErrCodeSoundLevelInterval).
 				Context("interval", settings.Interval).
 				Context("minimum_interval", MinSoundLevelInterval).
 				Build()
@@ -367,93 +740,139 @@ func validateBirdweatherSettings(settings *BirdweatherSettings) error {
 		}
 
 		// Check if threshold is within valid range
-		if settings.Threshold < 0 || settings.Threshold > 1 {
-			return errors.New(fmt.Errorf("birdweather threshold must be between 0 and 1")).
+		if c := constraintFor("realtime.birdweather.threshold"); !c.inRange(settings.Threshold) {
+			return errors.New(fmt.Errorf("birdweather threshold must be %s", c)).
 				Category(errors.CategoryValidation).
-				Context("validation_type", "birdweather-threshold").
+				Context("validation_type", ErrCodeBirdweatherThreshold).
 				Build()
 		}
 
 		// Check if location accuracy is non-negative
-		if settings.LocationAccuracy < 0 {
+		if !constraintFor("realtime.birdweather.locationaccuracy").inRange(settings.LocationAccuracy) {
 			return errors.New(fmt.Errorf("birdweather location accuracy must be non-negative")).
 				Category(errors.CategoryValidation).
-				Context("validation_type", "birdweather-location-accuracy").
+				Context("validation_type", ErrCodeBirdweatherLocationAccuracy).
+				Build()
+		}
+
+		// Check if rate limit settings are non-negative
+		if settings.RateLimit.MaxPerMinute < 0 {
+			return errors.New(fmt.Errorf("birdweather rate limit max per minute must be non-negative, got %d", settings.RateLimit.MaxPerMinute)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeBirdweatherRateLimit).
+				Context("max_per_minute", settings.RateLimit.MaxPerMinute).
 				Build()
 		}
+		if settings.RateLimit.BurstSize < 0 {
+			return errors.New(fmt.Errorf("birdweather rate limit burst size must be non-negative, got %d", settings.RateLimit.BurstSize)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeBirdweatherBurstSize).
+				Context("burst_size", settings.RateLimit.BurstSize).
+				Build()
+		}
+
+		// Validate the upload mode and soundscape interval
+		if err := validateBirdweatherUpload(settings); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // validateAudioSettings validates the audio settings and sets ffmpeg and sox paths
 func validateAudioSettings(settings *AudioSettings) error {
-	// Validate and determine the effective FFmpeg path
-	validatedFfmpegPath, ffmpegErr := ValidateToolPath(settings.FfmpegPath, GetFfmpegBinaryName())
-	if ffmpegErr != nil {
-		log.Printf("FFmpeg validation failed: %v. Audio export/conversion requiring FFmpeg might be disabled or use defaults.", ffmpegErr)
-		// Log validation warning for telemetry
-		logValidationWarning(ffmpegErr, "audio-tool-ffmpeg", "ffmpeg-not-available")
-		settings.FfmpegPath = "" // Ensure path is empty if validation failed
-	} else {
-		settings.FfmpegPath = validatedFfmpegPath // Store the validated path (explicit or from PATH)
+	// Validate streaming transport, empty transport is treated as auto
+	if c := constraintFor("realtime.audio.streamtransport"); settings.StreamTransport != "" && !c.allows(settings.StreamTransport) {
+		return errors.New(fmt.Errorf("audio stream transport must be %s, got %q", c, settings.StreamTransport)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeAudioTransport).
+			Context("stream_transport", settings.StreamTransport).
+			Build()
 	}
 
-	// Validate and determine the effective SoX path
-	// We only need to know if it's available and its formats, so LookPath is sufficient here.
-	soxPath, soxLookPathErr := exec.LookPath(GetSoxBinaryName())
-	if soxLookPathErr != nil {
-		settings.SoxPath = ""
-		settings.SoxAudioTypes = nil
-		log.Println("SoX not found in system PATH. Audio source processing requiring SoX might be disabled.")
-	} else {
-		settings.SoxPath = soxPath
-		// Get supported formats if SoX is found
-		_, formats := IsSoxAvailable() // We already know it's available from LookPath
-		settings.SoxAudioTypes = formats
+	// Validate input gain
+	if c := constraintFor("realtime.audio.gain"); !c.inRange(settings.Gain) {
+		return errors.New(fmt.Errorf("audio gain must be %s dB, got %v", c, settings.Gain)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeAudioGain).
+			Build()
+	}
+
+	// Detect ffmpeg and sox and the formats they support, failed probes only
+	// leave the supported formats unknown
+	if err := probeAudioTools(settings); err != nil {
+		log.Printf("Audio tool probe failed: %v", err)
+	}
+
+	// Validate audio export filename template
+	if err := ValidateFilenameTemplate(settings.Export.FilenameTemplate); err != nil {
+		return err
+	}
+
+	// Validate spectrogram settings
+	if err := validateSpectrogramSettings(&settings.Export.Spectrogram); err != nil {
+		return err
 	}
 
 	// Validate audio export settings
 	if settings.Export.Enabled {
+		// Validate clip retention settings
+		if err := settings.Export.Retention.Validate(); err != nil {
+			return err
+		}
+
 		if settings.FfmpegPath == "" {
 			settings.Export.Type = "wav"
 			log.Printf("FFmpeg not available, using WAV format for audio export")
 		} else {
 			// Validate audio type and bitrate
-			switch settings.Export.Type {
-			case "aac", "opus", "mp3":
-				if !strings.HasSuffix(settings.Export.Bitrate, "k") {
-					return errors.New(fmt.Errorf("invalid bitrate format for %s: %s. Must end with 'k' (e.g., '64k')", settings.Export.Type, settings.Export.Bitrate)).
-						Category(errors.CategoryValidation).
-						Context("validation_type", "audio-export-bitrate-format").
-						Context("export_type", settings.Export.Type).
-						Context("bitrate", settings.Export.Bitrate).
-						Build()
-				}
-				bitrateValue, err := strconv.Atoi(strings.TrimSuffix(settings.Export.Bitrate, "k"))
-				if err != nil {
-					return errors.New(fmt.Errorf("invalid bitrate value for %s: %s", settings.Export.Type, settings.Export.Bitrate)).
-						Category(errors.CategoryValidation).
-						Context("validation_type", "audio-export-bitrate-value").
-						Context("export_type", settings.Export.Type).
-						Context("bitrate", settings.Export.Bitrate).
-						Build()
-				}
-				if bitrateValue < 32 || bitrateValue > 320 {
-					return errors.New(fmt.Errorf("bitrate for %s must be between 32k and 320k", settings.Export.Type)).
-						Category(errors.CategoryValidation).
-						Context("validation_type", "audio-export-bitrate-range").
-						Context("export_type", settings.Export.Type).
-						Build()
-				}
-			case "wav", "flac":
-				// These formats don't use bitrate, so we'll ignore the bitrate setting
-			default:
-				return errors.New(fmt.Errorf("unsupported audio export type: %s", settings.Export.Type)).
-					Category(errors.CategoryValidation).
-					Context("validation_type", "audio-export-type").
-					Context("export_type", settings.Export.Type).
-					Build()
+			if err := validateExportFormat(&settings.Export); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateExportFormat validates the audio export type and its bitrate. Lossy
+// formats require a bitrate within limits, lossless formats ignore it.
+func validateExportFormat(settings *ExportSettings) error {
+	if c := constraintFor("realtime.audio.export.type"); !c.allows(settings.Type) {
+		return errors.New(fmt.Errorf("unsupported audio export type: %s", settings.Type)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeExportType).
+			Context("export_type", settings.Type).
+			Build()
+	}
+
+	switch {
+	case IsLossyExportType(settings.Type):
+		bitrateValue, err := parseBitrate(settings.Bitrate)
+		if err != nil {
+			validationType := "audio-export-bitrate-value"
+			if !strings.HasSuffix(settings.Bitrate, "k") {
+				validationType = "audio-export-bitrate-format"
 			}
+			return errors.New(fmt.Errorf("invalid bitrate for %s: %w", settings.Type, err)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", validationType).
+				Context("export_type", settings.Type).
+				Context("bitrate", settings.Bitrate).
+				Build()
+		}
+		if bitrateValue < MinExportBitrate || bitrateValue > MaxExportBitrate {
+			return errors.New(fmt.Errorf("bitrate for %s must be between %dk and %dk", settings.Type, MinExportBitrate, MaxExportBitrate)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeExportBitrateRange).
+				Context("export_type", settings.Type).
+				Build()
+		}
+	default:
+		// These formats don't use bitrate, warn so that users know the setting has no effect
+		if message := exportBitrateWarning(settings); message != "" {
+			log.Printf("Configuration warning: %s", message)
+			logValidationWarning(fmt.Errorf("%s", message), ErrCodeExportBitrate, "bitrate-ignored")
 		}
 	}
 
@@ -462,11 +881,11 @@ func validateAudioSettings(settings *AudioSettings) error {
 
 // Add this new function
 func validateDashboardSettings(settings *Dashboard) error {
-	// Validate SummaryLimit
-	if settings.SummaryLimit < 10 || settings.SummaryLimit > 1000 {
-		return errors.New(fmt.Errorf("Dashboard SummaryLimit must be between 10 and 1000")).
+	// Validate SummaryLimit, limits above the maximum are capped by capSummaryLimit
+	if c := constraintFor("realtime.dashboard.summarylimit"); float64(settings.SummaryLimit) < *c.Minimum {
+		return errors.New(fmt.Errorf("Dashboard SummaryLimit must be %s", c)).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "dashboard-summary-limit").
+			Context("validation_type", ErrCodeDashboardSummaryLimit).
 			Context("summary_limit", settings.SummaryLimit).
 			Build()
 	}
@@ -474,15 +893,161 @@ func validateDashboardSettings(settings *Dashboard) error {
 	return nil
 }
 
+// validateThumbnailSettings checks the thumbnail image provider and fallback
+// policy. Unknown values are replaced with "auto" and "none" and reported as
+// warnings so that a typo does not disable thumbnails.
+func validateThumbnailSettings(thumbnails *Thumbnails, settings *Settings) {
+	fallback := func(name, key string, value *string, defaultValue string) {
+		c := constraintFor(key)
+		if *value == "" {
+			*value = defaultValue
+			return
+		}
+		if c.allows(*value) {
+			return
+		}
+
+		message := fmt.Sprintf("thumbnail %s %q is not %s, using %q", name, *value, c, defaultValue)
+		log.Printf("Configuration warning: %s", message)
+		logValidationWarning(fmt.Errorf("%s", message), ErrCodeDashboardThumbnails, "invalid-"+strings.ReplaceAll(name, " ", "-"))
+		settings.ValidationWarnings = append(settings.ValidationWarnings,
+			fmt.Sprintf("config-thumbnails-validation: %s", message))
+		*value = defaultValue
+	}
+
+	fallback("image provider", "realtime.dashboard.thumbnails.imageprovider", &thumbnails.ImageProvider, ImageProviderAuto)
+	fallback("fallback policy", "realtime.dashboard.thumbnails.fallbackpolicy", &thumbnails.FallbackPolicy, FallbackPolicyNone)
+}
+
 // validateWeatherSettings validates weather-specific settings
 func validateWeatherSettings(settings *WeatherSettings) error {
 	// Validate poll interval (minimum 15 minutes)
-	if settings.PollInterval < 15 {
-		return errors.New(fmt.Errorf("weather poll interval must be at least 15 minutes, got %d", settings.PollInterval)).
+	if c := constraintFor("realtime.weather.pollinterval"); !c.inRange(float64(settings.PollInterval)) {
+		return errors.New(fmt.Errorf("weather poll interval must be %s minutes, got %d", c, settings.PollInterval)).
 			Category(errors.CategoryValidation).
-			Context("validation_type", "weather-poll-interval").
+			Context("validation_type", ErrCodeWeatherPollInterval).
 			Context("poll_interval", settings.PollInterval).
 			Build()
 	}
+
+	// Validate poll jitter
+	if c := constraintFor("realtime.weather.polljitter"); !c.inRange(float64(settings.PollJitter)) {
+		return errors.New(fmt.Errorf("weather poll jitter must be %s minutes, got %d", c, settings.PollJitter)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeWeatherPollJitter).
+			Build()
+	}
+
+	// Validate provider, empty provider falls back to the legacy OpenWeather settings
+	if c := constraintFor("realtime.weather.provider"); settings.Provider != "" && !c.allows(settings.Provider) {
+		return errors.New(fmt.Errorf("weather provider must be %s, got %q", c, settings.Provider)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeWeatherProvider).
+			Context("provider", settings.Provider).
+			Build()
+	}
+	return nil
+}
+
+// validateTelemetrySettings validates the listen address, Prometheus namespace
+// and constant labels
+func validateTelemetrySettings(settings *TelemetrySettings) error {
+	if err := validateTelemetryListen(settings); err != nil {
+		return err
+	}
+
+	if settings.Namespace != "" && !prometheusNamePattern.MatchString(settings.Namespace) {
+		return errors.New(fmt.Errorf("telemetry namespace %q is not a valid Prometheus metric name prefix", settings.Namespace)).
+			Category(errors.CategoryValidation).
+			Context("validation_type", ErrCodeTelemetryNamespace).
+			Context("namespace", settings.Namespace).
+			Build()
+	}
+
+	for name := range settings.Labels {
+		if !prometheusNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return errors.New(fmt.Errorf("telemetry label name %q is not a valid Prometheus label name", name)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeTelemetryLabelName).
+				Context("label_name", name).
+				Build()
+		}
+	}
+
+	return nil
+}
+
+// validateMonitoringSettings validates system monitoring settings. A check
+// interval below the minimum is clamped with a warning instead of failing.
+func validateMonitoringSettings(monitoringSettings *MonitoringSettings, settings *Settings) error {
+	if !monitoringSettings.Enabled {
+		return nil
+	}
+
+	if monitoringSettings.CheckInterval < MinMonitoringCheckInterval {
+		message := fmt.Sprintf("monitoring check interval %d is below minimum, using %d seconds",
+			monitoringSettings.CheckInterval, MinMonitoringCheckInterval)
+		log.Printf("Configuration warning: %s", message)
+		logValidationWarning(fmt.Errorf("%s", message), ErrCodeMonitoringCheckInterval, "check-interval-clamped")
+		settings.ValidationWarnings = append(settings.ValidationWarnings,
+			fmt.Sprintf("config-monitoring-validation: %s", message))
+		monitoringSettings.CheckInterval = MinMonitoringCheckInterval
+	}
+
+	if err := ValidatePercentRange(monitoringSettings.HysteresisPercent, "realtime.monitoring.hysteresispercent"); err != nil {
+		return err
+	}
+
+	// Temperature and network thresholds are not percentages
+	percentThresholds := []struct {
+		key      string
+		enabled  bool
+		warning  float64
+		critical float64
+	}{
+		{"cpu", monitoringSettings.CPU.Enabled, monitoringSettings.CPU.Warning, monitoringSettings.CPU.Critical},
+		{"memory", monitoringSettings.Memory.Enabled, monitoringSettings.Memory.Warning, monitoringSettings.Memory.Critical},
+		{"disk", monitoringSettings.Disk.Enabled, monitoringSettings.Disk.Warning, monitoringSettings.Disk.Critical},
+	}
+	for _, t := range percentThresholds {
+		if !t.enabled {
+			continue
+		}
+		if err := ValidatePercentRange(t.warning, "realtime.monitoring."+t.key+".warning"); err != nil {
+			return err
+		}
+		if err := ValidatePercentRange(t.critical, "realtime.monitoring."+t.key+".critical"); err != nil {
+			return err
+		}
+	}
+
+	thresholds := []struct {
+		resource string
+		enabled  bool
+		warning  float64
+		critical float64
+	}{
+		{"cpu", monitoringSettings.CPU.Enabled, monitoringSettings.CPU.Warning, monitoringSettings.CPU.Critical},
+		{"memory", monitoringSettings.Memory.Enabled, monitoringSettings.Memory.Warning, monitoringSettings.Memory.Critical},
+		{"disk", monitoringSettings.Disk.Enabled, monitoringSettings.Disk.Warning, monitoringSettings.Disk.Critical},
+		{"temperature", monitoringSettings.Temperature.Enabled, monitoringSettings.Temperature.Warning, monitoringSettings.Temperature.Critical},
+		{"network errors", monitoringSettings.Network.Enabled && monitoringSettings.Network.Errors.Enabled,
+			monitoringSettings.Network.Errors.Warning, monitoringSettings.Network.Errors.Critical},
+		{"network throughput", monitoringSettings.Network.Enabled && monitoringSettings.Network.Throughput.Enabled,
+			monitoringSettings.Network.Throughput.Warning, monitoringSettings.Network.Throughput.Critical},
+	}
+
+	for _, t := range thresholds {
+		if t.enabled && t.warning >= t.critical {
+			return errors.New(fmt.Errorf("monitoring %s warning threshold (%.1f) must be less than critical threshold (%.1f)", t.resource, t.warning, t.critical)).
+				Category(errors.CategoryValidation).
+				Context("validation_type", ErrCodeMonitoringThresholds).
+				Context("resource", t.resource).
+				Context("warning", t.warning).
+				Context("critical", t.critical).
+				Build()
+		}
+	}
+
 	return nil
 }
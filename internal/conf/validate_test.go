@@ -2,7 +2,9 @@ package conf
 
 import (
 	stderrors "errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/tphakala/birdnet-go/internal/errors"
 )
@@ -220,4 +222,288 @@ func BenchmarkValidateSoundLevelSettingsWithError(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = validateSoundLevelSettings(settings)
 	}
-}
\ No newline at end of file
+}
+func TestValidateTelemetrySettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings TelemetrySettings
+		wantErr  bool
+		errType  string
+	}{
+		{"empty namespace and labels", TelemetrySettings{}, false, ""},
+		{"valid namespace", TelemetrySettings{Namespace: "backyard_node1"}, false, ""},
+		{"valid labels", TelemetrySettings{Labels: map[string]string{"node": "garden", "site_id": "Äpple 1"}}, false, ""},
+		{"namespace with dash", TelemetrySettings{Namespace: "back-yard"}, true, "telemetry-namespace"},
+		{"namespace starting with digit", TelemetrySettings{Namespace: "1node"}, true, "telemetry-namespace"},
+		{"label name with dot", TelemetrySettings{Labels: map[string]string{"node.name": "a"}}, true, "telemetry-label-name"},
+		{"reserved label name", TelemetrySettings{Labels: map[string]string{"__name__": "a"}}, true, "telemetry-label-name"},
+		{"empty label name", TelemetrySettings{Labels: map[string]string{"": "a"}}, true, "telemetry-label-name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateTelemetrySettings(&tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTelemetrySettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError type, got %T", err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("expected validation_type = %s, got %v", tt.errType, ctx)
+			}
+		})
+	}
+}
+
+func TestTelemetrySettingsPromLabels(t *testing.T) {
+	t.Parallel()
+
+	settings := TelemetrySettings{Labels: map[string]string{"node": "garden"}}
+	labels := settings.PromLabels()
+	if labels["node"] != "garden" {
+		t.Fatalf("PromLabels()[node] = %q, want garden", labels["node"])
+	}
+
+	// Modifying the returned labels must not affect the settings
+	labels["node"] = "changed"
+	if settings.Labels["node"] != "garden" {
+		t.Error("PromLabels() returned map shares storage with settings")
+	}
+
+	if got := (TelemetrySettings{}).PromLabels(); got == nil || len(got) != 0 {
+		t.Errorf("PromLabels() on empty settings = %v, want empty non-nil map", got)
+	}
+}
+
+func TestValidateMonitoringSettings(t *testing.T) {
+	t.Parallel()
+
+	validThresholds := ThresholdSettings{Enabled: true, Warning: 80, Critical: 90}
+
+	tests := []struct {
+		name         string
+		monitoring   MonitoringSettings
+		wantErr      bool
+		wantInterval int
+		wantWarning  bool
+	}{
+		{
+			name:       "disabled monitoring is not validated",
+			monitoring: MonitoringSettings{Enabled: false, CheckInterval: 0, CPU: ThresholdSettings{Enabled: true, Warning: 90, Critical: 80}},
+		},
+		{
+			name:         "valid settings",
+			monitoring:   MonitoringSettings{Enabled: true, CheckInterval: 60, CPU: validThresholds, Memory: validThresholds},
+			wantInterval: 60,
+		},
+		{
+			name:         "zero interval clamped to minimum",
+			monitoring:   MonitoringSettings{Enabled: true, CheckInterval: 0},
+			wantInterval: MinMonitoringCheckInterval,
+			wantWarning:  true,
+		},
+		{
+			name:       "cpu warning above critical",
+			monitoring: MonitoringSettings{Enabled: true, CheckInterval: 60, CPU: ThresholdSettings{Enabled: true, Warning: 95, Critical: 85}},
+			wantErr:    true,
+		},
+		{
+			name:         "disabled resource with invalid thresholds ignored",
+			monitoring:   MonitoringSettings{Enabled: true, CheckInterval: 60, Temperature: ThresholdSettings{Warning: 80, Critical: 80}},
+			wantInterval: 60,
+		},
+		{
+			name:       "temperature warning equal to critical",
+			monitoring: MonitoringSettings{Enabled: true, CheckInterval: 60, Temperature: ThresholdSettings{Enabled: true, Warning: 80, Critical: 80}},
+			wantErr:    true,
+		},
+		{
+			name: "network throughput warning above critical",
+			monitoring: MonitoringSettings{Enabled: true, CheckInterval: 60, Network: NetworkThresholdSettings{
+				Enabled:    true,
+				Errors:     ThresholdSettings{Enabled: true, Warning: 1, Critical: 5},
+				Throughput: ThresholdSettings{Enabled: true, Warning: 100, Critical: 50},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "network thresholds ignored when network disabled",
+			monitoring: MonitoringSettings{Enabled: true, CheckInterval: 60, Network: NetworkThresholdSettings{
+				Errors: ThresholdSettings{Enabled: true, Warning: 5, Critical: 1},
+			}},
+			wantInterval: 60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings := &Settings{}
+			settings.Realtime.Monitoring = tt.monitoring
+
+			err := validateMonitoringSettings(&settings.Realtime.Monitoring, settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateMonitoringSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.monitoring.Enabled && settings.Realtime.Monitoring.CheckInterval != tt.wantInterval {
+				t.Errorf("CheckInterval = %d, want %d", settings.Realtime.Monitoring.CheckInterval, tt.wantInterval)
+			}
+			if got := len(settings.ValidationWarnings) > 0; got != tt.wantWarning {
+				t.Errorf("validation warning recorded = %v, want %v", got, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestValidateWeatherSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings WeatherSettings
+		wantErr  bool
+		errType  string
+	}{
+		{"yrno provider", WeatherSettings{Provider: "yrno", PollInterval: 60}, false, ""},
+		{"legacy empty provider", WeatherSettings{PollInterval: 60}, false, ""},
+		{"unknown provider", WeatherSettings{Provider: "metoffice", PollInterval: 60}, true, "weather-provider"},
+		{"poll interval too short", WeatherSettings{Provider: "none", PollInterval: 5}, true, "weather-poll-interval"},
+		{"negative poll jitter", WeatherSettings{Provider: "yrno", PollInterval: 60, PollJitter: -1}, true, "weather-poll-jitter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateWeatherSettings(&tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateWeatherSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError type, got %T", err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("expected validation_type = %s, got %v", tt.errType, ctx)
+			}
+		})
+	}
+}
+
+func TestValidateSecurityHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		security Security
+		wantErr  bool
+		errType  string
+	}{
+		{"autotls with dns name", Security{AutoTLS: true, Host: "birdnet.example.com"}, false, ""},
+		{"autotls with trailing dot", Security{AutoTLS: true, Host: "birdnet.example.com."}, false, ""},
+		{"autotls without host", Security{AutoTLS: true}, true, "security-autotls-host"},
+		{"autotls with ip address", Security{AutoTLS: true, Host: "192.168.1.10"}, true, "security-autotls-host"},
+		{"autotls with localhost", Security{AutoTLS: true, Host: "localhost"}, true, "security-autotls-host"},
+		{"autotls with scheme", Security{AutoTLS: true, Host: "https://birdnet.example.com"}, true, "security-autotls-host"},
+		{"autotls with port", Security{AutoTLS: true, Host: "birdnet.example.com:8080"}, true, "security-autotls-host"},
+		{"autotls with single label", Security{AutoTLS: true, Host: "birdnet"}, true, "security-autotls-host"},
+		{"autotls with invalid label", Security{AutoTLS: true, Host: "bird_net.example.com"}, true, "security-autotls-host"},
+		{"auth with ip address and port", Security{BasicAuth: BasicAuth{Enabled: true}, Host: "192.168.1.10:8080"}, false, ""},
+		{"auth with url", Security{GoogleAuth: SocialProvider{Enabled: true}, Host: "https://birdnet.example.com/"}, false, ""},
+		{"auth without host", Security{GithubAuth: SocialProvider{Enabled: true}}, true, "security-authentication-host"},
+		{"auth with query", Security{BasicAuth: BasicAuth{Enabled: true}, Host: "birdnet.example.com/?next=1"}, true, "security-authentication-host"},
+		{"auth with invalid url", Security{BasicAuth: BasicAuth{Enabled: true}, Host: "http://bird net"}, true, "security-authentication-host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.security.SessionDuration = time.Hour
+			err := validateSecuritySettings(&tt.security)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSecuritySettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var enhancedErr *errors.EnhancedError
+			if !stderrors.As(err, &enhancedErr) {
+				t.Fatalf("expected EnhancedError type, got %T", err)
+			}
+			if ctx := enhancedErr.Context["validation_type"]; ctx != tt.errType {
+				t.Errorf("expected validation_type = %s, got %v", tt.errType, ctx)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsIssueSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		modify       func(s *Settings)
+		wantWarnings []string
+		wantErrors   []string
+	}{
+		{"valid", func(s *Settings) {}, nil, nil},
+		{"unsupported locale", func(s *Settings) { s.BirdNET.Locale = "xx-invalid" }, []string{"birdnet.locale"}, nil},
+		{"invalid threshold", func(s *Settings) { s.BirdNET.Threshold = 2 }, nil, []string{"birdnet"}},
+		{"unsupported locale and invalid threshold", func(s *Settings) {
+			s.BirdNET.Locale = "xx-invalid"
+			s.BirdNET.Threshold = 2
+		}, []string{"birdnet.locale"}, []string{"birdnet"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings, err := loadDefaultSettings()
+			if err != nil {
+				t.Fatalf("loadDefaultSettings() error = %v", err)
+			}
+			tt.modify(settings)
+
+			err = ValidateSettings(settings)
+			var warnings, errs []string
+			var ve ValidationError
+			if stderrors.As(err, &ve) {
+				for _, issue := range ve.Issues {
+					if issue.Severity == SeverityWarning {
+						warnings = append(warnings, issue.Field)
+					} else {
+						errs = append(errs, issue.Field)
+					}
+				}
+				if len(ve.Errors()) != len(ve.Issues) {
+					t.Errorf("Errors() has %d messages, want %d", len(ve.Errors()), len(ve.Issues))
+				}
+			} else if err != nil {
+				t.Fatalf("ValidateSettings() error = %v, want ValidationError", err)
+			}
+
+			if fmt.Sprint(warnings) != fmt.Sprint(tt.wantWarnings) {
+				t.Errorf("warning fields = %v, want %v", warnings, tt.wantWarnings)
+			}
+			if fmt.Sprint(errs) != fmt.Sprint(tt.wantErrors) {
+				t.Errorf("error fields = %v, want %v", errs, tt.wantErrors)
+			}
+			if loadErr := checkValidationResult(err); err != nil && (loadErr != nil) != (len(tt.wantErrors) > 0) {
+				t.Errorf("checkValidationResult() error = %v, want error %v", loadErr, len(tt.wantErrors) > 0)
+			}
+		})
+	}
+}
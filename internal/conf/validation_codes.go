@@ -0,0 +1,174 @@
+// conf/validation_codes.go machine readable codes of validation issues
+package conf
+
+import (
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+// Codes of ValidationIssue, they are also the validation_type context of the
+// validation errors so that a code names the same check in the UI and in
+// telemetry. The web UI maps them to the form fields of the issues.
+const (
+	// ErrCodeInvalidSetting is the code of issues without a more specific code
+	ErrCodeInvalidSetting = "invalid-setting"
+
+	// Main settings and logs
+	ErrCodeTimeZone  = "main-timezone"
+	ErrCodeLogOutput = "log-output"
+	ErrCodeLogFormat = "log-format"
+	ErrCodeLogPath   = "log-path"
+
+	// BirdNET settings
+	ErrCodeBirdNETSettings      = "birdnet-settings-collection"
+	ErrCodeBirdNETThreads       = "birdnet-threads"
+	ErrCodeBirdNETModelPath     = "birdnet-model-path"
+	ErrCodeRangeFilterModel     = "birdnet-rangefilter-model"
+	ErrCodeRangeFilterThreshold = "birdnet-rangefilter-threshold"
+	ErrCodeLocale               = "locale-code-support"
+	ErrCodeModelVersion         = "model-version-support"
+
+	// File and directory analysis options
+	ErrCodeInputWorkers     = "input-workers"
+	ErrCodeInputFilePattern = "input-file-pattern"
+
+	// Web server and live stream settings
+	ErrCodeWebServerPort           = "webserver-port"
+	ErrCodeWebServerPortRequired   = "webserver-port-required"
+	ErrCodeLivestreamBitrate       = "livestream-bitrate"
+	ErrCodeLivestreamSegmentLength = "livestream-segment-length"
+	ErrCodeLivestreamSampleRate    = "livestream-sample-rate"
+	ErrCodeLivestreamQuality       = "livestream-quality"
+	ErrCodeLivestreamLogLevel      = "livestream-ffmpeg-log-level"
+	ErrCodeCORSOrigin              = "webserver-cors-origin"
+	ErrCodeCORSMethod              = "webserver-cors-method"
+
+	// Security settings
+	ErrCodeAuthenticationHost = "security-authentication-host"
+	ErrCodeAutoTLSHost        = "security-autotls-host"
+	ErrCodeSessionDuration    = "security-session-duration"
+	ErrCodeAuthDuration       = "security-auth-duration"
+	ErrCodeSubnetFormat       = "security-subnet-format"
+	ErrCodeRedirectURI        = "security-redirect-uri"
+	ErrCodeRoutePattern       = "security-route-pattern"
+	ErrCodeTrustedProxy       = "security-trusted-proxy"
+
+	// Realtime detection settings
+	ErrCodeRealtimeInterval      = "realtime-interval"
+	ErrCodeSpeciesInterval       = "realtime-species-interval"
+	ErrCodeSpeciesMinOccurrences = "realtime-species-min-occurrences"
+	ErrCodeDedupStrategy         = "dedup-strategy"
+	ErrCodeDedupWindow           = "dedup-window"
+	ErrCodeConfidenceFormat      = "confidence-format"
+	ErrCodeFilterConfidence      = "filter-confidence"
+	ErrCodeDogBarkFilterRemember = "dogbarkfilter-remember"
+	ErrCodeDogBarkFilterSpecies  = "dogbarkfilter-species"
+	ErrCodePrivacyRoundTimestamp = "privacy-round-timestamp"
+	ErrCodePercentRange          = "percent-range"
+	ErrCodeQuietHoursTime        = "quiethours-time"
+	ErrCodeQuietHoursWeekday     = "quiethours-weekday"
+	ErrCodeQuietHoursSuppress    = "quiethours-suppress"
+	ErrCodeMaxConcurrentActions  = "realtime-max-concurrent-actions"
+	ErrCodeActionOverflow        = "realtime-action-overflow"
+	ErrCodeActionTimeout         = "realtime-action-timeout"
+	ErrCodeActionEnv             = "realtime-action-env"
+
+	// MQTT settings
+	ErrCodeMQTTBrokerRequired    = "mqtt-broker-required"
+	ErrCodeMQTTBrokerURL         = "mqtt-broker-url"
+	ErrCodeMQTTTopicRequired     = "mqtt-topic-required"
+	ErrCodeMQTTMaxRetries        = "mqtt-max-retries"
+	ErrCodeMQTTInitialDelay      = "mqtt-initial-delay"
+	ErrCodeMQTTMaxDelay          = "mqtt-max-delay"
+	ErrCodeMQTTBackoffMultiplier = "mqtt-backoff-multiplier"
+	ErrCodeMQTTTLSFile           = "mqtt-tls-file"
+	ErrCodeMQTTTLSClientPair     = "mqtt-tls-client-pair"
+	ErrCodeMQTTTLSVerification   = "mqtt-tls-verification"
+
+	// RTSP stream settings
+	ErrCodeRTSPHealthThreshold  = "rtsp-health-threshold"
+	ErrCodeRTSPHealthInterval   = "rtsp-health-interval"
+	ErrCodeRTSPReconnectBackoff = "rtsp-reconnect-backoff"
+
+	// BirdWeather, weather and telemetry settings
+	ErrCodeBirdweatherThreshold        = "birdweather-threshold"
+	ErrCodeBirdweatherLocationAccuracy = "birdweather-location-accuracy"
+	ErrCodeBirdweatherRateLimit        = "birdweather-ratelimit-max-per-minute"
+	ErrCodeBirdweatherBurstSize        = "birdweather-ratelimit-burst-size"
+	ErrCodeBirdweatherUploadMode       = "birdweather-upload-mode"
+	ErrCodeBirdweatherSoundscape       = "birdweather-soundscape-interval"
+	ErrCodeWeatherProvider             = "weather-provider"
+	ErrCodeWeatherPollInterval         = "weather-poll-interval"
+	ErrCodeWeatherPollJitter           = "weather-poll-jitter"
+	ErrCodeTelemetryListen             = "telemetry-listen"
+	ErrCodeTelemetryNamespace          = "telemetry-namespace"
+	ErrCodeTelemetryLabelName          = "telemetry-label-name"
+	ErrCodeListenConflict              = "listen-conflict"
+
+	// System monitoring and dashboard settings
+	ErrCodeMonitoringThresholds    = "monitoring-thresholds"
+	ErrCodeMonitoringCheckInterval = "monitoring-check-interval"
+	ErrCodeSoundLevelInterval      = "sound-level-interval"
+	ErrCodeDashboardSummaryLimit   = "dashboard-summary-limit"
+	ErrCodeDashboardThumbnails     = "dashboard-thumbnails"
+	ErrCodeThumbnailProviders      = "dashboard-thumbnails-providers"
+
+	// Audio and audio export settings
+	ErrCodeAudioGain              = "audio-gain"
+	ErrCodeAudioTransport         = "audio-stream-transport"
+	ErrCodeFFmpeg                 = "audio-tool-ffmpeg"
+	ErrCodeExportType             = "audio-export-type"
+	ErrCodeExportBitrate          = "audio-export-bitrate"
+	ErrCodeExportBitrateRange     = "audio-export-bitrate-range"
+	ErrCodeExportFilenameTemplate = "audio-export-filename-template"
+	ErrCodeSpectrogramSize        = "audio-export-spectrogram-size"
+	ErrCodeSpectrogramColorScheme = "audio-export-spectrogram-colorscheme"
+	ErrCodeRetentionPolicy        = "retention-policy"
+	ErrCodeRetentionMaxAge        = "retention-max-age"
+	ErrCodeRetentionMaxUsage      = "retention-max-usage"
+	ErrCodeRetentionMinClips      = "retention-min-clips"
+
+	// Backup settings
+	ErrCodeBackupCompression       = "backup-compression"
+	ErrCodeBackupConcurrentUploads = "backup-max-concurrent-uploads"
+	ErrCodeBackupBandwidthLimit    = "backup-bandwidth-limit"
+	ErrCodeBackupRetentionCount    = "backup-retention-count"
+	ErrCodeBackupRetentionMaxAge   = "backup-retention-max-age"
+	ErrCodeBackupScheduleHour      = "backup-schedule-hour"
+	ErrCodeBackupScheduleMinute    = "backup-schedule-minute"
+	ErrCodeBackupScheduleWeekday   = "backup-schedule-weekday"
+	ErrCodeBackupTargetType        = "backup-target-type"
+	ErrCodeBackupTargetSettings    = "backup-target-settings"
+	ErrCodeBackupTargetHostKey     = "backup-target-host-key"
+
+	// Notification channel settings
+	ErrCodeNotificationType        = "notification-channel-type"
+	ErrCodeNotificationThreshold   = "notification-channel-threshold"
+	ErrCodeNotificationSettings    = "notification-channel-settings"
+	ErrCodeNotificationBatchWindow = "notification-channel-batch-window"
+	ErrCodeNotificationRateLimit   = "notification-channel-rate-limit"
+
+	// Database settings
+	ErrCodeMySQLPool        = "mysql-connection-pool"
+	ErrCodeDatabaseConflict = "database-conflict"
+
+	// Other settings
+	ErrCodeOutputFileType  = "output-file-type"
+	ErrCodeSettingsSection = "settings-section"
+	ErrCodeUnknownKey      = "unknown-config-key"
+)
+
+// validationCode returns the validation_type context of the first validation
+// error in the chain of err, ErrCodeInvalidSetting when there is none
+func validationCode(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		var enhanced *errors.EnhancedError
+		if !errors.As(err, &enhanced) {
+			break
+		}
+		if code, ok := enhanced.GetContext()["validation_type"].(string); ok && code != "" {
+			return code
+		}
+		err = enhanced
+	}
+	return ErrCodeInvalidSetting
+}
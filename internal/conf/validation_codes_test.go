@@ -0,0 +1,84 @@
+package conf
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+)
+
+func TestValidationCode(t *testing.T) {
+	t.Parallel()
+
+	coded := errors.New(fmt.Errorf("MQTT broker URL is required")).
+		Category(errors.CategoryValidation).
+		Context("validation_type", ErrCodeMQTTBrokerRequired).
+		Build()
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"coded", coded, ErrCodeMQTTBrokerRequired},
+		{"wrapped", fmt.Errorf("realtime: %w", coded), ErrCodeMQTTBrokerRequired},
+		{"enhanced wrapper without code", errors.New(coded).Category(errors.CategoryConfiguration).Build(), ErrCodeMQTTBrokerRequired},
+		{"plain", fmt.Errorf("broken"), ErrCodeInvalidSetting},
+		{"enhanced without code", errors.New(fmt.Errorf("broken")).Category(errors.CategoryValidation).Build(), ErrCodeInvalidSetting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := validationCode(tt.err); got != tt.want {
+				t.Errorf("validationCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsIssueCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		modify   func(s *Settings)
+		field    string
+		wantCode string
+	}{
+		{"unsupported locale", func(s *Settings) { s.BirdNET.Locale = "xx-invalid" }, "birdnet.locale", ErrCodeLocale},
+		{"invalid threshold", func(s *Settings) { s.BirdNET.Threshold = 2 }, "birdnet", ErrCodeBirdNETSettings},
+		{"invalid mqtt broker", func(s *Settings) {
+			s.Realtime.MQTT.Enabled = true
+			s.Realtime.MQTT.Broker = "localhost:1883"
+			s.Realtime.MQTT.Topic = "birdnet"
+		}, "realtime", ErrCodeMQTTBrokerURL},
+		{"negative backup uploads", func(s *Settings) {
+			s.Backup.Enabled = true
+			s.Backup.MaxConcurrentUploads = -1
+		}, "backup.max_concurrent_uploads", ErrCodeBackupConcurrentUploads},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			settings, err := loadDefaultSettings()
+			if err != nil {
+				t.Fatalf("loadDefaultSettings() error = %v", err)
+			}
+			tt.modify(settings)
+
+			var ve ValidationError
+			if err := ValidateSettings(settings); !stderrors.As(err, &ve) {
+				t.Fatalf("ValidateSettings() error = %v, want ValidationError", err)
+			}
+			for _, issue := range ve.Issues {
+				if issue.Field == tt.field {
+					if issue.Code != tt.wantCode {
+						t.Errorf("issue %s code = %q, want %q", issue.Field, issue.Code, tt.wantCode)
+					}
+					return
+				}
+			}
+			t.Errorf("ValidateSettings() issues = %v, want an issue of %s", ve.Issues, tt.field)
+		})
+	}
+}
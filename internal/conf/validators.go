@@ -0,0 +1,63 @@
+// conf/validators.go validators registered by other packages
+package conf
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// registeredValidator is a validator contributed by another package
+type registeredValidator struct {
+	name string
+	fn   func(*Settings) []ValidationIssue
+}
+
+var (
+	validators      []registeredValidator // sorted by name
+	validatorsMutex sync.RWMutex
+)
+
+// RegisterValidator adds a validator that ValidateSettings runs after its own
+// checks, so that packages such as mqtt or backup can validate their settings
+// without the conf package importing them. Validators run in name order,
+// registering a name again replaces its validator. Issues without a severity
+// are errors and issues without a code have the validator name as code,
+// warnings are also recorded in Settings.ValidationWarnings.
+// Validators must not keep the settings, they may be a candidate copy.
+func RegisterValidator(name string, fn func(*Settings) []ValidationIssue) {
+	validatorsMutex.Lock()
+	defer validatorsMutex.Unlock()
+
+	validators = slices.DeleteFunc(validators, func(v registeredValidator) bool { return v.name == name })
+	if fn == nil {
+		return
+	}
+	validators = append(validators, registeredValidator{name: name, fn: fn})
+	slices.SortFunc(validators, func(a, b registeredValidator) int { return cmp.Compare(a.name, b.name) })
+}
+
+// runRegisteredValidators runs the registered validators and records their issues
+func runRegisteredValidators(settings *Settings, ve *ValidationError) {
+	validatorsMutex.RLock()
+	registered := slices.Clone(validators)
+	validatorsMutex.RUnlock()
+
+	for _, v := range registered {
+		for _, issue := range v.fn(settings) {
+			if issue.Severity == "" {
+				issue.Severity = SeverityError
+			}
+			if issue.Code == "" {
+				issue.Code = v.name
+			}
+			if issue.Severity == SeverityWarning {
+				logValidationWarning(fmt.Errorf("%s", issue.Message), v.name, issue.Field)
+				settings.ValidationWarnings = append(settings.ValidationWarnings,
+					fmt.Sprintf("config-%s-validation: %s", v.name, issue.Message))
+			}
+			ve.Issues = append(ve.Issues, issue)
+		}
+	}
+}
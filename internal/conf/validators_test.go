@@ -0,0 +1,68 @@
+package conf
+
+import (
+	stderrors "errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestRegisterValidator changes the global validator registry and cannot run in parallel
+func TestRegisterValidator(t *testing.T) {
+	t.Cleanup(func() {
+		validatorsMutex.Lock()
+		validators = nil
+		validatorsMutex.Unlock()
+	})
+
+	settings, err := LoadFromReader(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	settings.ValidationWarnings = nil
+
+	var order []string
+	RegisterValidator("zeta", func(*Settings) []ValidationIssue {
+		order = append(order, "zeta")
+		return []ValidationIssue{{Field: "realtime.mqtt.broker", Message: "broker unreachable"}}
+	})
+	RegisterValidator("alpha", func(*Settings) []ValidationIssue {
+		order = append(order, "alpha-old")
+		return nil
+	})
+	RegisterValidator("alpha", func(*Settings) []ValidationIssue {
+		order = append(order, "alpha")
+		return []ValidationIssue{{Field: "backup.targets", Code: "backup-target-slow", Message: "target slow", Severity: SeverityWarning}}
+	})
+
+	err = ValidateSettings(settings)
+	if !slices.Equal(order, []string{"alpha", "zeta"}) {
+		t.Errorf("validators ran in order %v, want [alpha zeta]", order)
+	}
+
+	var ve ValidationError
+	if !stderrors.As(err, &ve) {
+		t.Fatalf("ValidateSettings() error = %v, want ValidationError", err)
+	}
+	want := []ValidationIssue{
+		{Field: "backup.targets", Code: "backup-target-slow", Message: "target slow", Severity: SeverityWarning},
+		{Field: "realtime.mqtt.broker", Code: "zeta", Message: "broker unreachable", Severity: SeverityError},
+	}
+	if len(ve.Issues) < 2 || !slices.Equal(ve.Issues[len(ve.Issues)-2:], want) {
+		t.Errorf("registered validator issues = %v, want %v last", ve.Issues, want)
+	}
+	if !slices.Contains(settings.ValidationWarnings, "config-alpha-validation: target slow") {
+		t.Errorf("ValidationWarnings = %v, want the registered warning", settings.ValidationWarnings)
+	}
+
+	// Registering nil removes a validator
+	RegisterValidator("zeta", nil)
+	RegisterValidator("alpha", nil)
+	order = nil
+	if err := ValidateSettings(settings); stderrors.As(err, &ve) && ve.HasErrors() {
+		t.Errorf("ValidateSettings() after removing validators error = %v", err)
+	}
+	if len(order) != 0 {
+		t.Errorf("removed validators ran: %v", order)
+	}
+}
@@ -0,0 +1,217 @@
+// conf/watch.go debounced config saves and config file watching
+package conf
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// DefaultSaveDebounce is the window in which SaveSettings calls are coalesced
+// into a single write
+const DefaultSaveDebounce = 500 * time.Millisecond
+
+// settingsWriter coalesces SaveSettings calls
+var settingsWriter = &debouncedWriter{window: DefaultSaveDebounce, write: writeSettings}
+
+// afterFunc calls f in its own goroutine after d like time.AfterFunc and
+// returns a function that stops the timer, tests replace it to fire timers
+// on demand
+type afterFunc func(d time.Duration, f func()) (stop func() bool)
+
+// timeAfterFunc is the afterFunc of the wall clock
+func timeAfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// lastChecksum holds the fingerprint of the config file content last written
+// by SaveYAMLConfig or loaded, used to ignore our own writes
+var lastChecksum struct {
+	sync.Mutex
+	fingerprint string
+}
+
+// SetSaveDebounce sets the window in which SaveSettings calls are coalesced,
+// zero or a negative window writes immediately
+func SetSaveDebounce(window time.Duration) {
+	settingsWriter.mu.Lock()
+	defer settingsWriter.mu.Unlock()
+	settingsWriter.window = window
+}
+
+// pendingWrite is a write waiting for its debounce window to pass
+type pendingWrite struct {
+	done chan struct{}
+	err  error
+}
+
+// debouncedWriter coalesces calls to write. The first call schedules a write
+// after the debounce window, calls within the window share the same write
+// and all of them receive its result.
+type debouncedWriter struct {
+	mu        sync.Mutex
+	window    time.Duration
+	pending   *pendingWrite
+	writeMu   sync.Mutex // serializes writes
+	write     func() error
+	afterFunc afterFunc // schedules the write, timeAfterFunc when nil
+}
+
+// Do requests a write and waits until it has completed
+func (d *debouncedWriter) Do() error {
+	return <-d.Request()
+}
+
+// Request requests a write without waiting for it, the returned channel
+// receives the result of the write once it has completed
+func (d *debouncedWriter) Request() <-chan error {
+	result := make(chan error, 1)
+
+	d.mu.Lock()
+	if d.window <= 0 {
+		d.mu.Unlock()
+		result <- d.writeNow()
+		return result
+	}
+
+	pending := d.pending
+	if pending == nil {
+		pending = &pendingWrite{done: make(chan struct{})}
+		d.pending = pending
+		schedule := d.afterFunc
+		if schedule == nil {
+			schedule = timeAfterFunc
+		}
+		schedule(d.window, func() {
+			// Calls from now on schedule a new write that includes their changes
+			d.mu.Lock()
+			d.pending = nil
+			d.mu.Unlock()
+
+			pending.err = d.writeNow()
+			close(pending.done)
+		})
+	}
+	d.mu.Unlock()
+
+	go func() {
+		<-pending.done
+		result <- pending.err
+	}()
+	return result
+}
+
+// writeNow writes, waiting for an earlier write to complete first
+func (d *debouncedWriter) writeNow() error {
+	d.writeMu.Lock()
+	defer d.writeMu.Unlock()
+	return d.write()
+}
+
+// recordChecksum remembers the fingerprint of config file content, it reports
+// whether the content differs from the previously recorded content. Changes
+// of comments, formatting or key order are not differences.
+func recordChecksum(data []byte) bool {
+	fingerprint := contentFingerprint(data)
+
+	lastChecksum.Lock()
+	defer lastChecksum.Unlock()
+	if fingerprint == lastChecksum.fingerprint {
+		return false
+	}
+	lastChecksum.fingerprint = fingerprint
+	return true
+}
+
+// Watch watches the config file and reloads the settings when another process
+// changes it, calling onChange with the reloaded settings. The reloaded values
+// are copied into the live settings instance, so that components holding the
+// pointer returned by Setting() see them. Bursts of file events are debounced,
+// and changes written by SaveSettings or that leave the settings unchanged,
+// such as edited comments or values equal to the live settings, are ignored
+// to avoid reload loops. Nothing is watched without a config file.
+func Watch(onChange func(*Settings)) {
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		return
+	}
+	if data, err := readConfigFile(configPath); err == nil {
+		recordChecksum(data)
+	}
+
+	handleEvent := newConfigChangeHandler(configPath, DefaultSaveDebounce, timeAfterFunc, func() {
+		previous := SnapshotSettings()
+		settings, err := reloadSettings()
+		if err != nil {
+			log.Printf("Failed to reload changed config file %s: %v", configPath, err)
+			return
+		}
+		if previous.Equal(settings) {
+			return
+		}
+		log.Printf("Reloaded settings from changed config file %s", configPath)
+		if onChange != nil {
+			onChange(settings)
+		}
+	})
+
+	viper.OnConfigChange(func(fsnotify.Event) { handleEvent() })
+	viper.WatchConfig()
+}
+
+// reloadSettings loads the config file and copies the loaded values into the
+// live settings instance, which keeps its address and runtime-only values
+func reloadSettings() (*Settings, error) {
+	live := GetSettings()
+	loaded, err := Load()
+	if err != nil || live == nil {
+		return loaded, err
+	}
+
+	// Load replaced the instance, put the live one back with the loaded values
+	settingsMutex.Lock()
+	settingsInstance = live
+	settingsMutex.Unlock()
+	RestoreSnapshot(loaded)
+
+	// The restore keeps runtime fields such as the fingerprint of the config
+	// file, take the fingerprint of the reloaded file
+	settingsMutex.Lock()
+	settingsInstance.ConfigFingerprint = loaded.ConfigFingerprint
+	settingsMutex.Unlock()
+	return live, nil
+}
+
+// newConfigChangeHandler returns a function to call for each config file event.
+// reload is called once the events have settled for window, and only when the
+// file content differs from the content last written or loaded.
+func newConfigChangeHandler(configPath string, window time.Duration, schedule afterFunc, reload func()) func() {
+	var (
+		mu   sync.Mutex
+		stop func() bool
+	)
+
+	check := func() {
+		data, err := readConfigFile(configPath)
+		if err != nil {
+			log.Printf("Failed to read changed config file %s: %v", configPath, err)
+			return
+		}
+		if !recordChecksum(data) {
+			return
+		}
+		reload()
+	}
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stop != nil {
+			stop()
+		}
+		stop = schedule(window, check)
+	}
+}
@@ -0,0 +1,206 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeTimers is an afterFunc whose timers fire when the test calls fire
+type fakeTimers struct {
+	mu     sync.Mutex
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	f       func()
+	stopped bool
+}
+
+func (c *fakeTimers) afterFunc(_ time.Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &fakeTimer{f: f}
+	c.timers = append(c.timers, timer)
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		wasActive := !timer.stopped
+		timer.stopped = true
+		return wasActive
+	}
+}
+
+// fire runs the functions of the scheduled timers that were not stopped
+func (c *fakeTimers) fire() {
+	c.mu.Lock()
+	var due []func()
+	for _, timer := range c.timers {
+		if !timer.stopped {
+			timer.stopped = true
+			due = append(due, timer.f)
+		}
+	}
+	c.timers = nil
+	c.mu.Unlock()
+	for _, f := range due {
+		f()
+	}
+}
+
+func TestDebouncedWriterCoalescesRapidSaves(t *testing.T) {
+	t.Parallel()
+
+	var writes atomic.Int32
+	var clock fakeTimers
+	writer := &debouncedWriter{
+		window: DefaultSaveDebounce,
+		write: func() error {
+			writes.Add(1)
+			return nil
+		},
+		afterFunc: clock.afterFunc,
+	}
+
+	// Simulate an autosaving UI saving on every keystroke
+	results := make([]<-chan error, 0, 10)
+	for range 10 {
+		results = append(results, writer.Request())
+	}
+	if got := writes.Load(); got != 0 {
+		t.Errorf("got %d writes before the debounce window passed, want 0", got)
+	}
+	clock.fire()
+	for _, result := range results {
+		if err := <-result; err != nil {
+			t.Errorf("Request() error = %v", err)
+		}
+	}
+	if got := writes.Load(); got != 1 {
+		t.Errorf("got %d writes for rapid saves, want 1", got)
+	}
+
+	// A save after the window has passed is written separately
+	result := writer.Request()
+	clock.fire()
+	if err := <-result; err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if got := writes.Load(); got != 2 {
+		t.Errorf("got %d writes, want 2", got)
+	}
+}
+
+func TestDebouncedWriterWithoutWindow(t *testing.T) {
+	t.Parallel()
+
+	var writes atomic.Int32
+	writer := &debouncedWriter{write: func() error {
+		writes.Add(1)
+		return nil
+	}}
+
+	for range 3 {
+		if err := writer.Do(); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+	if got := writes.Load(); got != 3 {
+		t.Errorf("got %d writes without debounce window, want 3", got)
+	}
+}
+
+// The config change tests share the recorded checksum and do not run in parallel
+func TestConfigChangeHandlerIgnoresOwnSaves(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	settings := &Settings{}
+	settings.Main.Name = "own-save"
+	if err := SaveYAMLConfig(configPath, settings); err != nil {
+		t.Fatalf("SaveYAMLConfig() error = %v", err)
+	}
+
+	var reloads atomic.Int32
+	var clock fakeTimers
+	handleEvent := newConfigChangeHandler(configPath, DefaultSaveDebounce, clock.afterFunc, func() { reloads.Add(1) })
+	for range 5 {
+		handleEvent()
+	}
+	clock.fire()
+
+	if got := reloads.Load(); got != 0 {
+		t.Errorf("got %d reloads after own save, want 0", got)
+	}
+}
+
+func TestConfigChangeHandlerReloadsExternalChanges(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := SaveYAMLConfig(configPath, &Settings{}); err != nil {
+		t.Fatalf("SaveYAMLConfig() error = %v", err)
+	}
+
+	var reloads atomic.Int32
+	var clock fakeTimers
+	handleEvent := newConfigChangeHandler(configPath, DefaultSaveDebounce, clock.afterFunc, func() { reloads.Add(1) })
+
+	// An editor typically triggers several events for one save
+	if err := os.WriteFile(configPath, []byte("main:\n  name: external\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	for range 3 {
+		handleEvent()
+	}
+	clock.fire()
+
+	if got := reloads.Load(); got != 1 {
+		t.Errorf("got %d reloads after external change, want 1", got)
+	}
+
+	// Touching the file without changing its content does not reload again
+	handleEvent()
+	clock.fire()
+	if got := reloads.Load(); got != 1 {
+		t.Errorf("got %d reloads after unchanged write, want 1", got)
+	}
+}
+
+// TestReloadSettingsFingerprint loads settings through the global viper
+// instance and is not parallel
+func TestReloadSettingsFingerprint(t *testing.T) {
+	configDir := isolateConfigSearch(t)
+	t.Cleanup(func() { SetTestSettings(nil) })
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("main:\n  name: before\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	live, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	loadedFingerprint := live.ConfigFingerprint
+
+	// Edit the file externally and reload it like the watcher does
+	edited := []byte("main:\n  name: after\n")
+	if err := os.WriteFile(configPath, edited, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := reloadSettings()
+	if err != nil {
+		t.Fatalf("reloadSettings() error = %v", err)
+	}
+
+	if reloaded != live || live.Main.Name != "after" {
+		t.Errorf("reloadSettings() = %p with name %q, want the live instance %p with the edited name", reloaded, reloaded.Main.Name, live)
+	}
+	// Load may write the migrated config back, compare with the file on disk
+	want, err := ConfigFingerprint()
+	if err != nil {
+		t.Fatalf("ConfigFingerprint() error = %v", err)
+	}
+	if live.ConfigFingerprint != want || live.ConfigFingerprint == loadedFingerprint {
+		t.Errorf("ConfigFingerprint after reload = %q, want %q of the edited file", live.ConfigFingerprint, want)
+	}
+}
@@ -0,0 +1,73 @@
+// conf/weather.go weather polling intervals respecting provider limits
+package conf
+
+import (
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultWeatherPollInterval is the poll interval in minutes used when none is set
+const DefaultWeatherPollInterval = 60
+
+// weatherProviderMinPollInterval holds the shortest poll interval in minutes
+// each provider is polled with. yr.no asks clients not to poll more often than
+// its forecasts change, OpenWeather updates current weather about every 10
+// minutes. Providers without an entry use the realtime.weather.pollinterval
+// minimum.
+var weatherProviderMinPollInterval = map[string]int{
+	"yrno":        30,
+	"openweather": 15,
+}
+
+// MinPollInterval returns the shortest poll interval in minutes accepted for
+// the weather provider, an empty provider is the legacy OpenWeather setting
+func (w WeatherSettings) MinPollInterval() int {
+	provider := w.Provider
+	if provider == "" {
+		provider = "openweather"
+	}
+	if minimum, ok := weatherProviderMinPollInterval[provider]; ok {
+		return minimum
+	}
+	return int(*constraintFor("realtime.weather.pollinterval").Minimum)
+}
+
+// EffectivePollInterval returns the interval between weather polls, the
+// default when unset and at least the minimum of the provider
+func (w WeatherSettings) EffectivePollInterval() time.Duration {
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = DefaultWeatherPollInterval
+	}
+	return time.Duration(max(interval, w.MinPollInterval())) * time.Minute
+}
+
+// NextPollDelay returns the delay until the next weather poll, the effective
+// poll interval plus a random jitter of up to PollJitter minutes so that
+// nodes started together do not poll in lockstep
+func (w WeatherSettings) NextPollDelay() time.Duration {
+	delay := w.EffectivePollInterval()
+	if w.PollJitter > 0 {
+		delay += rand.N(time.Duration(w.PollJitter) * time.Minute)
+	}
+	return delay
+}
+
+// validateWeatherProviderInterval raises poll intervals below the minimum of
+// the weather provider to that minimum and reports a warning
+func validateWeatherProviderInterval(weather *WeatherSettings, settings *Settings) {
+	minimum := weather.MinPollInterval()
+	if weather.PollInterval <= 0 || weather.PollInterval >= minimum {
+		return
+	}
+
+	message := fmt.Sprintf("weather poll interval %d minutes is below the %d minutes minimum of provider %q, using %d minutes",
+		weather.PollInterval, minimum, weather.Provider, minimum)
+	log.Printf("Configuration warning: %s", message)
+	logValidationWarning(fmt.Errorf("%s", message), ErrCodeWeatherPollInterval, "poll-interval-clamped")
+	settings.ValidationWarnings = append(settings.ValidationWarnings,
+		fmt.Sprintf("config-weather-validation: %s", message))
+	weather.PollInterval = minimum
+}
@@ -0,0 +1,69 @@
+package conf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeatherEffectivePollInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings WeatherSettings
+		want     time.Duration
+	}{
+		{"configured", WeatherSettings{Provider: "yrno", PollInterval: 90}, 90 * time.Minute},
+		{"unset uses default", WeatherSettings{Provider: "yrno"}, DefaultWeatherPollInterval * time.Minute},
+		{"yrno minimum", WeatherSettings{Provider: "yrno", PollInterval: 1}, 30 * time.Minute},
+		{"openweather minimum", WeatherSettings{Provider: "openweather", PollInterval: 10}, 15 * time.Minute},
+		{"legacy provider minimum", WeatherSettings{PollInterval: 10}, 15 * time.Minute},
+		{"no provider", WeatherSettings{Provider: "none", PollInterval: 20}, 20 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.settings.EffectivePollInterval(); got != tt.want {
+				t.Errorf("EffectivePollInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeatherNextPollDelay(t *testing.T) {
+	t.Parallel()
+
+	settings := WeatherSettings{Provider: "yrno", PollInterval: 60}
+	if got := settings.NextPollDelay(); got != time.Hour {
+		t.Errorf("NextPollDelay() without jitter = %v, want 1h", got)
+	}
+
+	settings.PollJitter = 5
+	for range 100 {
+		if got := settings.NextPollDelay(); got < time.Hour || got >= time.Hour+5*time.Minute {
+			t.Fatalf("NextPollDelay() = %v, want within [1h, 1h5m)", got)
+		}
+	}
+}
+
+func TestValidateWeatherProviderInterval(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Realtime.Weather = WeatherSettings{Provider: "yrno", PollInterval: 20}
+	validateWeatherProviderInterval(&settings.Realtime.Weather, settings)
+	if got := settings.Realtime.Weather.PollInterval; got != 30 {
+		t.Errorf("PollInterval = %d, want clamped to 30", got)
+	}
+	if len(settings.ValidationWarnings) != 1 {
+		t.Errorf("ValidationWarnings = %v, want one warning", settings.ValidationWarnings)
+	}
+
+	settings = &Settings{}
+	settings.Realtime.Weather = WeatherSettings{Provider: "yrno", PollInterval: 45}
+	validateWeatherProviderInterval(&settings.Realtime.Weather, settings)
+	if got := settings.Realtime.Weather.PollInterval; got != 45 || len(settings.ValidationWarnings) != 0 {
+		t.Errorf("PollInterval = %d with warnings %v, want 45 unchanged", got, settings.ValidationWarnings)
+	}
+}
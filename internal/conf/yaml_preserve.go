@@ -0,0 +1,143 @@
+// conf/yaml_preserve.go saving settings while keeping comments of the config file
+package conf
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/tphakala/birdnet-go/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// SaveYAMLConfigPreservingComments updates the YAML configuration file with
+// new settings like SaveYAMLConfig, but keeps the comments and key order of
+// the existing file. Only values that changed are replaced, settings missing
+// from the file are appended to their section and settings no longer present
+// are removed. A file that does not exist or cannot be parsed is overwritten
+// with SaveYAMLConfig.
+func SaveYAMLConfigPreservingComments(configPath string, settings *Settings) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return SaveYAMLConfig(configPath, settings)
+	}
+	// An encrypted file that cannot be decrypted must not be overwritten
+	if data, err = decryptConfigData(data); err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || doc.Kind != yaml.DocumentNode ||
+		len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		log.Printf("Config file %s cannot be updated in place, rewriting it without comments", configPath)
+		return SaveYAMLConfig(configPath, settings)
+	}
+
+	var updated yaml.Node
+	if err := updated.Encode(settings); err != nil {
+		return errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "yaml-marshal").
+			Build()
+	}
+	mergeYAMLNode(doc.Content[0], &updated)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&doc)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		return errors.New(err).
+			Category(errors.CategoryConfiguration).
+			Context("operation", "encode-config-file").
+			Build()
+	}
+
+	// Write the config atomically, encrypted when a config key is set
+	yamlData := buf.Bytes()
+	if err := writeConfigFile(configPath, yamlData); err != nil {
+		return err
+	}
+
+	// Remember the written content so that the config watcher ignores our own write
+	recordChecksum(yamlData)
+	return nil
+}
+
+// mergeYAMLNode updates dst in place with the values of src. Mappings are
+// merged key by key, matching keys case-insensitively: keys missing from src
+// are removed, such as deleted map entries and empty omitempty fields, and
+// keys missing from dst are appended. Other values are replaced when they
+// differ. Comments of the kept keys of dst are kept.
+func mergeYAMLNode(dst, src *yaml.Node) {
+	if dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode {
+		kept := dst.Content[:0]
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			key, value := dst.Content[i], dst.Content[i+1]
+			if updated := mappingValue(src, key.Value); updated != nil {
+				mergeYAMLNode(value, updated)
+				kept = append(kept, key, value)
+			}
+		}
+		dst.Content = kept
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key, value := src.Content[i], src.Content[i+1]
+			if mappingValue(dst, key.Value) == nil {
+				dst.Content = append(dst.Content, key, value)
+			}
+		}
+		return
+	}
+
+	if yamlValuesEqual(dst, src) {
+		return
+	}
+	headComment, lineComment, footComment := dst.HeadComment, dst.LineComment, dst.FootComment
+	*dst = *src
+	dst.HeadComment, dst.LineComment, dst.FootComment = headComment, lineComment, footComment
+}
+
+// mappingValue returns the value of a key of a mapping node, matching the key
+// case-insensitively, or nil when the key is missing
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlValuesEqual reports whether two nodes hold the same value, regardless
+// of formatting such as quoting or "00.000" for 0
+func yamlValuesEqual(a, b *yaml.Node) bool {
+	var av, bv any
+	if a.Decode(&av) != nil || b.Decode(&bv) != nil {
+		return false
+	}
+	if af, ok := yamlNumber(av); ok {
+		bf, ok := yamlNumber(bv)
+		return ok && af == bf
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// yamlNumber returns a decoded YAML number as float64
+func yamlNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,165 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSaveYAMLConfigPreservingComments(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(getDefaultConfig()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadFromReader(strings.NewReader(getDefaultConfig()))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	settings.BirdNET.Threshold = 0.65
+	settings.Realtime.MQTT.Topic = "garden/birds"
+
+	if err := SaveYAMLConfigPreservingComments(configPath, settings); err != nil {
+		t.Fatalf("SaveYAMLConfigPreservingComments() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+	for _, want := range []string{
+		"threshold: 0.65 # threshold for prediction confidence to report",
+		"# true to enable mysql output",
+		"latitude: 00.000", // unchanged values keep their formatting
+	} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved config does not contain %q", want)
+		}
+	}
+	if strings.Index(saved, "\nmain:") > strings.Index(saved, "\nbirdnet:") {
+		t.Error("saved config changed the key order of the file")
+	}
+
+	reloaded, err := LoadFromReader(strings.NewReader(saved))
+	if err != nil {
+		t.Fatalf("LoadFromReader(saved) error = %v", err)
+	}
+	if reloaded.BirdNET.Threshold != 0.65 || reloaded.Realtime.MQTT.Topic != "garden/birds" {
+		t.Errorf("reloaded threshold %v topic %q, want the saved values", reloaded.BirdNET.Threshold, reloaded.Realtime.MQTT.Topic)
+	}
+}
+
+func TestSaveYAMLConfigPreservingCommentsFallback(t *testing.T) {
+	t.Parallel()
+
+	settings := &Settings{}
+	settings.Main.Name = "node"
+
+	for name, content := range map[string]string{
+		"unparsable": "main: [unclosed\n",
+		"not a map":  "- item\n",
+		"missing":    "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if content != "" {
+				if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := SaveYAMLConfigPreservingComments(configPath, settings); err != nil {
+				t.Fatalf("SaveYAMLConfigPreservingComments() error = %v", err)
+			}
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var saved Settings
+			if err := yaml.Unmarshal(data, &saved); err != nil || saved.Main.Name != "node" {
+				t.Errorf("saved config has Main.Name %q, %v, want the full settings", saved.Main.Name, err)
+			}
+		})
+	}
+}
+
+func TestMergeYAMLNode(t *testing.T) {
+	t.Parallel()
+
+	var dst, src yaml.Node
+	if err := yaml.Unmarshal([]byte("# head\nA: 1 # one\nb: \"text\"\nremoved: 1 # gone\nlist: [1, 2]\n"), &dst); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal([]byte("a: 2\nb: text\nlist: [1, 3]\nc: new\n"), &src); err != nil {
+		t.Fatal(err)
+	}
+	mergeYAMLNode(dst.Content[0], src.Content[0])
+
+	out, err := yaml.Marshal(&dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# head\nA: 2 # one\nb: \"text\"\nlist: [1, 3]\nc: new\n"
+	if string(out) != want {
+		t.Errorf("merged YAML =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestSaveYAMLConfigPreservingCommentsRemovesDeleted(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(getDefaultConfig()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadFromReader(strings.NewReader(getDefaultConfig()))
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	settings.Realtime.Species.Config = map[string]SpeciesConfig{
+		"robin":    {Threshold: 0.6},
+		"blue tit": {Threshold: 0.7},
+	}
+	settings.Backup.Targets = []BackupTarget{{
+		Type:     "local",
+		Enabled:  true,
+		Settings: map[string]any{"path": "/backups", "note": "garage"},
+	}}
+	if err := SaveYAMLConfigPreservingComments(configPath, settings); err != nil {
+		t.Fatalf("SaveYAMLConfigPreservingComments() error = %v", err)
+	}
+
+	delete(settings.Realtime.Species.Config, "robin")
+	delete(settings.Backup.Targets[0].Settings, "note")
+	if err := SaveYAMLConfigPreservingComments(configPath, settings); err != nil {
+		t.Fatalf("SaveYAMLConfigPreservingComments() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("LoadFromReader(saved) error = %v", err)
+	}
+	if _, ok := reloaded.Realtime.Species.Config["robin"]; ok {
+		t.Error("deleted species config robin is still in the saved config")
+	}
+	if _, ok := reloaded.Realtime.Species.Config["blue tit"]; !ok {
+		t.Error("species config blue tit is missing from the saved config")
+	}
+	if len(reloaded.Backup.Targets) != 1 {
+		t.Fatalf("saved config has %d backup targets, want 1", len(reloaded.Backup.Targets))
+	}
+	if _, ok := reloaded.Backup.Targets[0].Settings["note"]; ok {
+		t.Error("deleted backup target setting note is still in the saved config")
+	}
+	if !strings.Contains(string(data), "# true to enable mysql output") {
+		t.Error("saved config lost the comments of the file")
+	}
+}
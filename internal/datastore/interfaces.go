@@ -155,6 +155,16 @@ func (ds *DataStore) Save(note *Note, results []Results) error {
 	txID := fmt.Sprintf("tx-%s", uuid.New().String()[:8])
 	txStart := time.Now()
 	txLogger := getLogger().With("tx_id", txID, "operation", "save_note")
+
+	// Store an anonymized copy when the privacy settings require it, the ID
+	// of the stored note is copied back to the caller's note
+	if settings := conf.GetSettings(); settings != nil {
+		if stored := anonymizeNote(note, &settings.Realtime.PrivacyFilter.Privacy); stored != note {
+			original := note
+			defer func() { original.ID = stored.ID }()
+			note = stored
+		}
+	}
 	
 	txLogger.Debug("Starting transaction",
 		"note_scientific_name", note.ScientificName,
@@ -378,7 +388,7 @@ func (ds *DataStore) GetTopBirdsData(selectedDate string, minConfidenceNormalize
 	var results []SpeciesCount
 
 	// Get the number of species to report from the dashboard settings
-	reportCount := conf.Setting().Realtime.Dashboard.SummaryLimit
+	reportCount := conf.Setting().Realtime.Dashboard.EffectiveSummaryLimit()
 
 	// First, get the count and common names
 	query := ds.DB.Table("notes").
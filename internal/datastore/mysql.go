@@ -46,10 +46,10 @@ func (store *MySQLStore) Open() error {
 		return err // validateMySQLConfig returns a properly formatted error
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		store.Settings.Output.MySQL.Username, store.Settings.Output.MySQL.Password,
-		store.Settings.Output.MySQL.Host, store.Settings.Output.MySQL.Port,
-		store.Settings.Output.MySQL.Database)
+	dsn, err := store.Settings.MySQLDSN()
+	if err != nil {
+		return err
+	}
 	
 	// Log database opening (with sanitized DSN)
 	sanitizedDSN := fmt.Sprintf("%s:***@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
@@ -77,13 +77,26 @@ func (store *MySQLStore) Open() error {
 		return fmt.Errorf("failed to open MySQL database: %w", err)
 	}
 
+	// Apply the configured connection pool limits
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get MySQL connection pool: %w", err)
+	}
+	pool := store.Settings.MySQLPoolConfig()
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+
 	store.DB = db
 	
 	// Log successful connection
 	getLogger().Info("MySQL database opened successfully",
 		"host", store.Settings.Output.MySQL.Host,
 		"port", store.Settings.Output.MySQL.Port,
-		"database", store.Settings.Output.MySQL.Database)
+		"database", store.Settings.Output.MySQL.Database,
+		"max_open_conns", pool.MaxOpenConns,
+		"max_idle_conns", pool.MaxIdleConns,
+		"conn_max_lifetime", pool.ConnMaxLifetime)
 	
 	if err := performAutoMigration(db, store.Settings.Debug, "MySQL", dsn); err != nil {
 		return err
@@ -0,0 +1,33 @@
+// internal/datastore/privacy.go
+package datastore
+
+import (
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// noteDateTimeLayout is the layout of the combined Date and Time fields of a note
+const noteDateTimeLayout = "2006-01-02 15:04:05"
+
+// anonymizeNote returns the note to store with the privacy settings applied.
+// Timestamps are rounded down and the location is removed as configured on
+// a copy, so that callers keep the precise note, e.g. to export its audio
+// clip. The note itself is returned when the settings keep full-fidelity
+// records.
+func anonymizeNote(note *Note, privacy *conf.DetectionPrivacySettings) *Note {
+	if !privacy.Anonymizes() {
+		return note
+	}
+
+	stored := *note
+	stored.BeginTime = privacy.AnonymizeTime(note.BeginTime)
+	stored.EndTime = privacy.AnonymizeTime(note.EndTime)
+	if detected, err := time.ParseInLocation(noteDateTimeLayout, note.Date+" "+note.Time, time.Local); err == nil {
+		detected = privacy.AnonymizeTime(detected)
+		stored.Date = detected.Format("2006-01-02")
+		stored.Time = detected.Format("15:04:05")
+	}
+	stored.Latitude, stored.Longitude = privacy.AnonymizeLocation(note.Latitude, note.Longitude)
+	return &stored
+}
@@ -0,0 +1,49 @@
+// privacy_test.go: Tests for anonymizing notes before they are stored
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+func TestAnonymizeNote(t *testing.T) {
+	t.Parallel()
+
+	begin := time.Date(2026, 5, 17, 6, 47, 31, 0, time.Local)
+	note := &Note{
+		Date:      "2026-05-17",
+		Time:      "06:47:33",
+		BeginTime: begin,
+		EndTime:   begin.Add(3 * time.Second),
+		Latitude:  60.17,
+		Longitude: 24.94,
+	}
+
+	t.Run("full fidelity", func(t *testing.T) {
+		t.Parallel()
+		privacy := &conf.DetectionPrivacySettings{StoreLocation: true}
+		assert.Same(t, note, anonymizeNote(note, privacy))
+	})
+
+	t.Run("rounded without location", func(t *testing.T) {
+		t.Parallel()
+		privacy := &conf.DetectionPrivacySettings{RoundTimestampTo: 15 * time.Minute}
+		stored := anonymizeNote(note, privacy)
+
+		assert.NotSame(t, note, stored)
+		assert.Equal(t, "2026-05-17", stored.Date)
+		assert.Equal(t, "06:45:00", stored.Time)
+		assert.True(t, stored.BeginTime.Equal(time.Date(2026, 5, 17, 6, 45, 0, 0, time.Local)))
+		assert.True(t, stored.EndTime.Equal(time.Date(2026, 5, 17, 6, 45, 0, 0, time.Local)))
+		assert.Zero(t, stored.Latitude)
+		assert.Zero(t, stored.Longitude)
+
+		// The caller's note keeps its precise values
+		assert.Equal(t, "06:47:33", note.Time)
+		assert.True(t, note.BeginTime.Equal(begin))
+		assert.InDelta(t, 60.17, note.Latitude, 1e-9)
+	})
+}
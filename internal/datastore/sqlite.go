@@ -170,7 +170,10 @@ func (s *SQLiteStore) createBackup(dbPath string) error {
 // Open initializes the SQLite database connection
 func (s *SQLiteStore) Open() error {
 	// Get database path from settings
-	dbPath := s.Settings.Output.SQLite.Path
+	dbPath, err := s.Settings.SQLiteDSN()
+	if err != nil {
+		return err
+	}
 	
 	// Log database opening
 	getLogger().Info("Opening SQLite database",
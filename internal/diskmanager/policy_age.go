@@ -97,7 +97,6 @@ func AgeBasedCleanup(quit <-chan struct{}, db Interface) CleanupResult {
 
 	startTime := time.Now() // Track cleanup duration
 	debug := retention.Debug
-	keepSpectrograms := retention.KeepSpectrograms
 	minClipsPerSpecies := retention.MinClips
 	retentionPeriodSetting := retention.MaxAge
 
@@ -155,7 +154,7 @@ func AgeBasedCleanup(quit <-chan struct{}, db Interface) CleanupResult {
 
 	// Call the helper function to process files
 	deletedCount, loopErr := processAgeBasedDeletionLoop(files, speciesTotalCount,
-		minClipsPerSpecies, maxDeletions, debug, keepSpectrograms,
+		minClipsPerSpecies, maxDeletions, debug,
 		quit, retentionCutoffUnix)
 
 	// Get final disk utilization
@@ -197,11 +196,13 @@ func AgeBasedCleanup(quit <-chan struct{}, db Interface) CleanupResult {
 // 2. Maximum deletion count is reached
 // 3. A quit signal is received
 func processAgeBasedDeletionLoop(files []FileInfo, speciesTotalCount map[string]int,
-	minClipsPerSpecies int, maxDeletions int, debug bool, keepSpectrograms bool,
+	minClipsPerSpecies int, maxDeletions int, debug bool,
 	quit <-chan struct{}, retentionCutoffUnix int64) (deletedCount int, loopErr error) {
 
 	deletedCount = 0
 	errorCount := 0
+	// Clip file names hold scientific names, species configs may be keyed by common name
+	speciesNames := conf.NewSpeciesNames(conf.Setting().BirdNET.Labels)
 
 	for i := range files {
 		select {
@@ -229,8 +230,10 @@ func processAgeBasedDeletionLoop(files []FileInfo, speciesTotalCount map[string]
 				continue
 			}
 
-			// 2. Perform deletion using the common helper
-			if delErr := deleteFileAndOptionalSpectrogram(file, reason, keepSpectrograms, debug, "age"); delErr != nil {
+			// 2. Perform deletion using the common helper, spectrograms are kept
+			// as configured for the species
+			keepSpectrogram := conf.Setting().KeepSpectrogram(speciesNames.CommonName(file.Species), file.Species)
+			if delErr := deleteFileAndOptionalSpectrogram(file, reason, keepSpectrogram, debug, "age"); delErr != nil {
 				// Use the common error handler
 				shouldStop, loopErrTmp := handleDeletionErrorInLoop(file.Path, delErr, &errorCount, 10, "age")
 				if shouldStop {
@@ -631,3 +631,35 @@ func (m *mockFileInfo) Mode() os.FileMode  { return m.mode }
 func (m *mockFileInfo) ModTime() time.Time { return m.modTime }
 func (m *mockFileInfo) IsDir() bool        { return m.isDir }
 func (m *mockFileInfo) Sys() interface{}   { return nil }
+
+// TestAgeBasedDeletionKeepsSpectrogramsByCommonName tests that per-species
+// keepspectrograms settings keyed by common name apply to clip files, whose
+// names hold the scientific name.
+func TestAgeBasedDeletionKeepsSpectrogramsByCommonName(t *testing.T) {
+	keep := true
+	settings := &conf.Settings{}
+	settings.BirdNET.Labels = []string{"Bubo scandiacus_Snowy Owl", "Anas platyrhynchos_Mallard"}
+	settings.Realtime.Species.Config = map[string]conf.SpeciesConfig{"snowy owl": {KeepSpectrograms: &keep}}
+	conf.SetTestSettings(settings)
+	t.Cleanup(func() { conf.SetTestSettings(nil) })
+
+	testDir := t.TempDir()
+	oldTime := time.Now().Add(-720 * time.Hour)
+	var files []FileInfo
+	pngPaths := map[string]string{}
+	for _, species := range []string{"bubo_scandiacus", "anas_platyrhynchos"} {
+		baseName := fmt.Sprintf("%s_80p_%s", species, oldTime.UTC().Format("20060102T150405Z"))
+		audioPath := filepath.Join(testDir, baseName+".wav")
+		pngPaths[species] = filepath.Join(testDir, baseName+".png")
+		require.NoError(t, os.WriteFile(audioPath, []byte("audio"), 0o600))
+		require.NoError(t, os.WriteFile(pngPaths[species], []byte("png"), 0o600))
+		files = append(files, FileInfo{Path: audioPath, Species: species, Timestamp: oldTime, Size: 5})
+	}
+
+	deleted, err := processAgeBasedDeletionLoop(files, map[string]int{"bubo_scandiacus": 1, "anas_platyrhynchos": 1},
+		0, 10, false, make(chan struct{}), time.Now().Unix())
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted, "both old clips should be deleted")
+	assert.FileExists(t, pngPaths["bubo_scandiacus"], "spectrogram of the snowy owl should be kept")
+	assert.NoFileExists(t, pngPaths["anas_platyrhynchos"], "spectrogram of the mallard should be deleted")
+}
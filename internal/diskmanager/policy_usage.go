@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/tphakala/birdnet-go/internal/conf"
@@ -32,6 +33,26 @@ func UsageBasedCleanup(quit <-chan struct{}, db Interface) CleanupResult {
 		"policy", "usage",
 	)
 
+	// Skip listing the clips while the disk usage is within the budget, errors
+	// are reported by the full check below
+	export := conf.Setting().Realtime.Audio.Export
+	if usedBytes, limitBytes, err := export.DiskBudget(); err == nil && usedBytes < limitBytes {
+		utilization := 0
+		if usage, err := GetDiskUsage(export.Path); err == nil {
+			utilization = int(usage)
+		}
+		serviceLogger.Info("Usage-based cleanup run completed",
+			"policy", "usage",
+			"result", "usage within budget",
+			"files_removed", 0,
+			"used_bytes", usedBytes,
+			"limit_bytes", limitBytes,
+			"disk_utilization", utilization,
+			"timestamp", time.Now().Format(time.RFC3339),
+			"duration_ms", 0)
+		return CleanupResult{DiskUtilization: utilization}
+	}
+
 	// Perform initial setup (get files, settings, check if proceed)
 	files, baseDir, retention, proceed, initialResult := prepareInitialCleanup(db)
 	if !proceed {
@@ -47,13 +68,12 @@ func UsageBasedCleanup(quit <-chan struct{}, db Interface) CleanupResult {
 
 	startTime := time.Now() // Track cleanup duration
 	debug := retention.Debug
-	keepSpectrograms := retention.KeepSpectrograms
 	minClipsPerSpecies := retention.MinClips
 	usageThresholdSetting := retention.MaxUsage
 
-	// Convert usage threshold string (e.g., "80%") to float64
+	// Convert usage threshold string (e.g., "80%" or "50GB") to a usage percentage
 	// This determines at what disk usage percentage the cleanup should activate
-	usageThresholdFloat, err := conf.ParsePercentage(usageThresholdSetting)
+	usageThresholdFloat, err := resolveUsageThreshold(usageThresholdSetting, baseDir)
 	if err != nil {
 		// Use the utilization from the initial result if available
 		serviceLogger.Error("Usage-based cleanup failed",
@@ -113,7 +133,6 @@ func UsageBasedCleanup(quit <-chan struct{}, db Interface) CleanupResult {
 		minClipsPerSpecies:  minClipsPerSpecies,
 		maxDeletions:        1000, // Maximum number of files to delete in one run
 		refreshInterval:     50,   // Refresh actual disk usage every N deletions
-		keepSpectrograms:    keepSpectrograms,
 		debug:               debug,
 	}
 	deletedCount, lastKnownGoodUsagePercent, loopErr := processUsageDeletionLoop(files, speciesMonthCount,
@@ -144,6 +163,40 @@ func UsageBasedCleanup(quit <-chan struct{}, db Interface) CleanupResult {
 	return CleanupResult{Err: loopErr, ClipsRemoved: deletedCount, DiskUtilization: finalUsagePercent}
 }
 
+// resolveUsageThreshold converts the MaxUsage retention setting into a disk
+// usage percentage for the filesystem holding baseDir. Absolute sizes such as
+// "50GB" are converted using the filesystem's total size.
+func resolveUsageThreshold(setting, baseDir string) (float64, error) {
+	// Percentages don't depend on the filesystem size, resolve them against a
+	// fixed total to avoid an unnecessary statfs call
+	if strings.HasSuffix(strings.TrimSpace(setting), "%") {
+		return usageThresholdPercent(setting, percentResolution)
+	}
+
+	diskInfo, err := GetDetailedDiskUsage(baseDir)
+	if err != nil {
+		return 0, err
+	}
+	return usageThresholdPercent(setting, diskInfo.TotalBytes)
+}
+
+// percentResolution is the total used to resolve percentage limits, it keeps
+// two decimals of precision
+const percentResolution = 10_000
+
+// usageThresholdPercent converts a disk limit into a percentage of totalBytes,
+// capped at 100%
+func usageThresholdPercent(setting string, totalBytes uint64) (float64, error) {
+	if totalBytes == 0 {
+		return 0, fmt.Errorf("cannot resolve disk limit %q, filesystem reports zero total bytes", setting)
+	}
+	limitBytes, _, err := conf.ParseDiskLimit(setting, totalBytes)
+	if err != nil {
+		return 0, err
+	}
+	return min(100, float64(limitBytes)*100/float64(totalBytes)), nil
+}
+
 // usageLoopParams holds the parameters for the usage-based deletion loop.
 // This struct helps organize parameters and makes function signatures cleaner.
 type usageLoopParams struct {
@@ -153,7 +206,6 @@ type usageLoopParams struct {
 	minClipsPerSpecies  int           // Minimum number of clips to preserve per species per directory
 	maxDeletions        int           // Maximum number of files to delete in one run
 	refreshInterval     int           // How often to refresh actual disk usage (every N deletions)
-	keepSpectrograms    bool          // Whether to keep spectrograms when deleting audio files
 	debug               bool          // Enable verbose logging
 }
 
@@ -173,6 +225,8 @@ func processUsageDeletionLoop(files []FileInfo, speciesMonthCount map[string]map
 	// This is updated after each deletion based on file size
 	estimatedUsedBytes := params.diskInfo.UsedBytes
 	lastKnownGoodUsagePercent = params.initialUsagePercent
+	// Clip file names hold scientific names, species configs may be keyed by common name
+	speciesNames := conf.NewSpeciesNames(conf.Setting().BirdNET.Labels)
 
 	for i := range files {
 		select {
@@ -201,7 +255,7 @@ func processUsageDeletionLoop(files []FileInfo, speciesMonthCount map[string]map
 			file := &files[i]
 
 			// Handle eligibility checks and deletion for this file
-			deleted, deletionErr := handleUsageDeletionIteration(file, speciesMonthCount, params.minClipsPerSpecies, params.keepSpectrograms, currentUsagePercent, params.usageThreshold, params.debug)
+			deleted, deletionErr := handleUsageDeletionIteration(file, speciesMonthCount, speciesNames, params.minClipsPerSpecies, currentUsagePercent, params.usageThreshold, params.debug)
 
 			if deletionErr != nil {
 				// Use the common error handler
@@ -307,7 +361,7 @@ func checkInitialUsage(baseDir string, usageThreshold int, debug bool) (initialU
 
 // handleUsageDeletionIteration processes a single file for potential deletion based on usage policy rules.
 // It returns whether the file was deleted and any critical error encountered during deletion.
-func handleUsageDeletionIteration(file *FileInfo, speciesMonthCount map[string]map[string]int, minClipsPerSpecies int, keepSpectrograms bool, currentUsagePercent, usageThreshold int, debug bool) (deleted bool, deletionErr error) {
+func handleUsageDeletionIteration(file *FileInfo, speciesMonthCount map[string]map[string]int, speciesNames conf.SpeciesNames, minClipsPerSpecies int, currentUsagePercent, usageThreshold int, debug bool) (deleted bool, deletionErr error) {
 	// Check if locked
 	if checkLocked(file, debug) {
 		return false, nil
@@ -324,7 +378,9 @@ func handleUsageDeletionIteration(file *FileInfo, speciesMonthCount map[string]m
 	reason := fmt.Sprintf("usage %d%% >= threshold %d%%", currentUsagePercent, usageThreshold)
 
 	// Call the common deletion function
-	if delErr := deleteFileAndOptionalSpectrogram(file, reason, keepSpectrograms, debug, "usage"); delErr != nil {
+	// Spectrograms are kept as configured for the species
+	keepSpectrogram := conf.Setting().KeepSpectrogram(speciesNames.CommonName(file.Species), file.Species)
+	if delErr := deleteFileAndOptionalSpectrogram(file, reason, keepSpectrogram, debug, "usage"); delErr != nil {
 		// Return the error to be handled by the main loop (e.g., increment error count)
 		return false, delErr
 	}
@@ -1070,3 +1070,39 @@ func TestUsageBasedCleanupLockedFiles(t *testing.T) {
 
 // Define a variable for os.Remove to allow mocking in tests
 var osRemove = os.Remove
+
+// TestUsageThresholdPercent tests conversion of percentage and absolute disk limits
+func TestUsageThresholdPercent(t *testing.T) {
+	t.Parallel()
+
+	const totalBytes = 100 * 1000 * 1000 * 1000 // 100GB
+
+	tests := []struct {
+		name       string
+		setting    string
+		totalBytes uint64
+		want       float64
+		wantErr    bool
+	}{
+		{"percentage", "80%", totalBytes, 80, false},
+		{"fractional percentage", "80.5%", percentResolution, 80.5, false},
+		{"absolute size", "50GB", totalBytes, 50, false},
+		{"binary size", "10GiB", totalBytes, 10.737418240, false},
+		{"absolute size larger than disk", "500GB", totalBytes, 100, false},
+		{"invalid setting", "lots", totalBytes, 0, true},
+		{"zero total bytes", "50GB", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := usageThresholdPercent(tt.setting, tt.totalBytes)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
@@ -228,6 +228,7 @@ func (s *Server) handleLoginPage(c echo.Context) error {
 		Page:     "dashboard",
 		Title:    "Dashboard",
 		Settings: s.Settings,
+		Public:   s.Settings.PublicView(),
 	})
 }
 
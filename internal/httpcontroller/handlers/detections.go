@@ -647,10 +647,8 @@ func (h *Handlers) handleSpeciesExclusion(note *datastore.Note, verified, ignore
 
 	if verified == "false_positive" && ignoreSpecies != "" {
 		// Check if species is already excluded
-		for _, s := range settings.Realtime.Species.Exclude {
-			if s == ignoreSpecies {
-				return nil
-			}
+		if settings.Realtime.Species.IsExcluded(ignoreSpecies, "") {
+			return nil
 		}
 
 		// Add to excluded list
@@ -665,14 +663,11 @@ func (h *Handlers) handleSpeciesExclusion(note *datastore.Note, verified, ignore
 		})
 	} else if verified == "correct" {
 		// Check if species is in exclude list
-		for _, s := range settings.Realtime.Species.Exclude {
-			if s == note.CommonName {
-				h.SSE.SendNotification(Notification{
-					Message: fmt.Sprintf("%s is currently in ignore list. You may want to remove it from Settings.", note.CommonName),
-					Type:    "warning",
-				})
-				break
-			}
+		if settings.Realtime.Species.IsExcluded(note.CommonName, note.ScientificName) {
+			h.SSE.SendNotification(Notification{
+				Message: fmt.Sprintf("%s is currently in ignore list. You may want to remove it from Settings.", note.CommonName),
+				Type:    "warning",
+			})
 		}
 	}
 	return nil
@@ -178,101 +178,46 @@ func (h *Handlers) selectBirdImage(scientificName string) (*imageprovider.BirdIm
 		return nil, fmt.Errorf("scientific name cannot be empty")
 	}
 
-	// Get user's preferred image provider from settings
-	settings := conf.Setting()
-	preferredProvider := settings.Realtime.Dashboard.Thumbnails.ImageProvider
-	fallbackPolicy := settings.Realtime.Dashboard.Thumbnails.FallbackPolicy
+	// Resolve the provider preference order from settings
+	thumbnails := conf.Setting().Realtime.Dashboard.Thumbnails
+	providerOrder := thumbnails.ProviderOrder()
 
-	h.Debug("Image request for %s - Preferred provider: %s, Fallback policy: %s",
-		scientificName, preferredProvider, fallbackPolicy)
+	h.Debug("Image request for %s - Preferred provider: %s, Fallback policy: %s, Provider order: %v",
+		scientificName, thumbnails.ImageProvider, thumbnails.FallbackPolicy, providerOrder)
 
 	// If the BirdImageCache is nil, return early
 	if h.BirdImageCache == nil {
 		return nil, fmt.Errorf("bird image cache not available")
 	}
 
-	// If we have access to a registry and a specific provider is requested
-	if preferredProvider != "auto" && h.BirdImageCache.GetRegistry() != nil {
-		registry := h.BirdImageCache.GetRegistry()
-
-		// Log available providers
-		var providers []string
-		registry.RangeProviders(func(name string, cache *imageprovider.BirdImageCache) bool {
-			providers = append(providers, name)
-			return true
-		})
-		h.Debug("Available providers: %v", providers)
-
-		if cache, ok := registry.GetCache(preferredProvider); ok {
-			h.Debug("Found preferred provider cache: %s", preferredProvider)
-
-			// Try to get the image from the preferred provider
-			if birdImage, err := cache.Get(scientificName); err == nil {
-				h.Debug("Successfully got image from %s for %s: %s",
-					preferredProvider, scientificName, birdImage.URL)
-				return &birdImage, nil
-			} else {
-				h.Debug("Failed to get image from %s: %v", preferredProvider, err)
-			}
-
-			// If preferred provider fails and fallback is disabled, return empty
-			if fallbackPolicy != "all" {
-				h.Debug("No fallback allowed (policy: %s), returning empty", fallbackPolicy)
-				return nil, fmt.Errorf("preferred provider failed and fallback is disabled")
-			}
-		} else {
-			h.Debug("Preferred provider '%s' not found in registry", preferredProvider)
-			// If preferred provider doesn't exist and fallback is disabled, return empty
-			if fallbackPolicy != "all" {
-				h.Debug("No fallback allowed (policy: %s) and provider not found, returning empty", fallbackPolicy)
-				return nil, fmt.Errorf("preferred provider not found and fallback is disabled")
-			}
-		}
-	} else {
-		h.Debug("Using default provider - Provider set to auto: %v, Registry available: %v",
-			preferredProvider == "auto", h.BirdImageCache.GetRegistry() != nil)
+	registry := h.BirdImageCache.GetRegistry()
+	if registry == nil {
+		h.Debug("No image provider registry available")
+		return nil, fmt.Errorf("no image provider registry available")
 	}
 
-	// At this point, either we're using "auto" mode or fallback is allowed
-	// Try all available providers
-	if registry := h.BirdImageCache.GetRegistry(); registry != nil {
-		var lastError error
-		var foundImage *imageprovider.BirdImage
-
-		registry.RangeProviders(func(name string, cache *imageprovider.BirdImageCache) bool {
-			// Skip if this is the preferred provider that already failed
-			if name == preferredProvider && preferredProvider != "auto" {
-				return true // Continue to next provider
-			}
-
-			birdImage, err := cache.Get(scientificName)
-			if err == nil {
-				h.Debug("Successfully got image from fallback provider %s for %s: %s",
-					name, scientificName, birdImage.URL)
-				// Found a valid image, store and stop iteration
-				// Copy the value to avoid capturing loop variable address
-				imgCopy := birdImage
-				foundImage = &imgCopy
-				lastError = nil
-				return false // Stop iteration
-			}
-
-			h.Debug("Fallback provider %s failed for %s: %v", name, scientificName, err)
-			lastError = err
-			return true // Continue to next provider
-		})
-
-		if foundImage != nil {
-			return foundImage, nil
+	// Try providers in order until one returns an image
+	var lastError error
+	for _, name := range providerOrder {
+		cache, ok := registry.GetCache(name)
+		if !ok {
+			h.Debug("Provider '%s' not found in registry", name)
+			continue
 		}
 
-		if lastError != nil {
-			h.Debug("All providers failed for %s", scientificName)
-			return nil, lastError
+		birdImage, err := cache.Get(scientificName)
+		if err == nil {
+			h.Debug("Successfully got image from %s for %s: %s", name, scientificName, birdImage.URL)
+			return &birdImage, nil
 		}
-	} else {
-		h.Debug("No image provider registry available")
-		return nil, fmt.Errorf("no image provider registry available")
+
+		h.Debug("Provider %s failed for %s: %v", name, scientificName, err)
+		lastError = err
+	}
+
+	if lastError != nil {
+		h.Debug("All providers failed for %s", scientificName)
+		return nil, lastError
 	}
 
 	return nil, fmt.Errorf("no image found for %s", scientificName)
@@ -393,7 +338,8 @@ func (h *Handlers) ServeSpectrogram(c echo.Context) error {
 		}()
 
 		// Try to create the spectrogram
-		if err := createSpectrogramWithSoX(fullPath, spectrogramPath, 400); err != nil {
+		params := conf.Setting().Realtime.Audio.Export.Spectrogram.Params(400)
+		if err := createSpectrogramWithSoX(fullPath, spectrogramPath, params); err != nil {
 			h.Debug("ServeSpectrogram: Failed to create spectrogram: %v", err)
 			c.Response().Header().Set(echo.HeaderContentType, "image/svg+xml")
 			return c.File("assets/images/spectrogram-placeholder.svg")
@@ -492,7 +438,7 @@ func fileExists(filename string) (bool, error) {
 
 // createSpectrogramWithSoX generates a spectrogram for an audio file using ffmpeg and SoX.
 // It supports various audio formats by using ffmpeg to pipe the audio to SoX when necessary.
-func createSpectrogramWithSoX(audioClipPath, spectrogramPath string, width int) error {
+func createSpectrogramWithSoX(audioClipPath, spectrogramPath string, params conf.SpectrogramParams) error {
 	// Get ffmpeg and sox paths from settings
 	ffmpegBinary := conf.Setting().Realtime.Audio.FfmpegPath
 	soxBinary := conf.Setting().Realtime.Audio.SoxPath
@@ -505,10 +451,6 @@ func createSpectrogramWithSoX(audioClipPath, spectrogramPath string, width int)
 		return fmt.Errorf("SoX path not set in settings")
 	}
 
-	// Set height based on width
-	heightStr := strconv.Itoa(width / 2)
-	widthStr := strconv.Itoa(width)
-
 	// Determine if we need to use ffmpeg based on file extension
 	ext := strings.ToLower(filepath.Ext(audioClipPath))
 	// remove prefix dot
@@ -530,7 +472,7 @@ func createSpectrogramWithSoX(audioClipPath, spectrogramPath string, width int)
 		ffmpegArgs := []string{"-hide_banner", "-i", audioClipPath, "-f", "sox", "-"}
 
 		// Build SoX command arguments
-		soxArgs := append([]string{"-t", "sox", "-"}, getSoxSpectrogramArgs(widthStr, heightStr, spectrogramPath)...)
+		soxArgs := append([]string{"-t", "sox", "-"}, getSoxSpectrogramArgs(params, spectrogramPath)...)
 
 		// Set up commands
 		if runtime.GOOS == "windows" {
@@ -597,7 +539,7 @@ func createSpectrogramWithSoX(audioClipPath, spectrogramPath string, width int)
 		runtime.Gosched()
 	} else {
 		// Use SoX directly for supported formats
-		soxArgs := append([]string{audioClipPath}, getSoxSpectrogramArgs(widthStr, heightStr, spectrogramPath)...)
+		soxArgs := append([]string{audioClipPath}, getSoxSpectrogramArgs(params, spectrogramPath)...)
 
 		if runtime.GOOS == "windows" {
 			soxCmd = exec.Command(soxBinary, soxArgs...) // #nosec G204 -- soxBinary validated via ValidateToolPath
@@ -626,14 +568,15 @@ func createSpectrogramWithSoX(audioClipPath, spectrogramPath string, width int)
 }
 
 // getSoxSpectrogramArgs returns the common SoX arguments for generating a spectrogram
-func getSoxSpectrogramArgs(widthStr, heightStr, spectrogramPath string) []string {
+func getSoxSpectrogramArgs(params conf.SpectrogramParams, spectrogramPath string) []string {
 	// TODO: make these dynamic based on audio length and gain
 	const audioLength = "15"
 	const dynamicRange = "100"
 
-	args := []string{"-n", "rate", "24k", "spectrogram", "-x", widthStr, "-y", heightStr, "-d", audioLength, "-z", dynamicRange, "-o", spectrogramPath}
-	width, _ := strconv.Atoi(widthStr)
-	if width < 800 {
+	args := []string{"-n", "rate", "24k", "spectrogram", "-x", strconv.Itoa(params.Width), "-y", strconv.Itoa(params.Height), "-d", audioLength, "-z", dynamicRange}
+	args = append(args, params.SoxColorArgs()...)
+	args = append(args, "-o", spectrogramPath)
+	if params.Raw {
 		args = append(args, "-r")
 	}
 	return args
@@ -641,7 +584,7 @@ func getSoxSpectrogramArgs(widthStr, heightStr, spectrogramPath string) []string
 
 // createSpectrogramWithFFmpeg generates a spectrogram for an audio file using only ffmpeg.
 // It supports various audio formats and applies the same practices as createSpectrogramWithSoX.
-func createSpectrogramWithFFmpeg(audioClipPath, spectrogramPath string, width int) error {
+func createSpectrogramWithFFmpeg(audioClipPath, spectrogramPath string, params conf.SpectrogramParams) error {
 	// Get ffmpeg path from settings
 	ffmpegBinary := conf.Setting().Realtime.Audio.FfmpegPath
 
@@ -650,17 +593,18 @@ func createSpectrogramWithFFmpeg(audioClipPath, spectrogramPath string, width in
 		return fmt.Errorf("ffmpeg path not set in settings")
 	}
 
-	// Set height based on width
-	height := width / 2
-	heightStr := strconv.Itoa(height)
-	widthStr := strconv.Itoa(width)
+	// Build the spectrum filter from the spectrogram parameters
+	filter := fmt.Sprintf("showspectrumpic=s=%dx%d:legend=0:gain=3:drange=100", params.Width, params.Height)
+	if color := params.FFmpegColor(); color != "" {
+		filter += ":color=" + color
+	}
 
 	// Build ffmpeg command arguments
 	ffmpegArgs := []string{
 		"-hide_banner",
 		"-y", // answer yes to overwriting the output file if it already exists
 		"-i", audioClipPath,
-		"-lavfi", fmt.Sprintf("showspectrumpic=s=%sx%s:legend=0:gain=3:drange=100", widthStr, heightStr),
+		"-lavfi", filter,
 		"-frames:v", "1", // Generate only one frame instead of animation
 		spectrogramPath,
 	}
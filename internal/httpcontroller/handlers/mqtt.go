@@ -64,6 +64,7 @@ func (h *Handlers) TestMQTT(c echo.Context) error {
 			Main: struct {
 				Name      string
 				TimeAs24h bool
+				TimeZone  string
 				Log       conf.LogConfig
 			}{
 				Name: "BirdNET-Go-Test", // Test client ID
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/tphakala/birdnet-go/internal/conf"
@@ -29,8 +28,12 @@ var fieldsToSkip = map[string]bool{
 	"output.file.enabled":             true,
 	"output.file.path":                true,
 	"output.file.type":                true,
+	speciesConfigFormKey:              true, // applied by updateSpeciesConfigsFromForm
 }
 
+// speciesConfigFormKey is the form field holding the per-species configs as JSON
+const speciesConfigFormKey = "realtime.species.config"
+
 // GetAudioDevices handles the request to list available audio devices
 // API: GET /api/v1/settings/audio/get
 func (h *Handlers) GetAudioDevices(c echo.Context) error {
@@ -189,25 +192,6 @@ func (h *Handlers) SaveSettings(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
-func formatAndValidateHost(host string, useHTTPS bool) (string, error) {
-	protocol := "http"
-	if useHTTPS {
-		protocol = "https"
-	}
-
-	host = strings.TrimRight(host, "/")
-	if !strings.HasPrefix(host, "http") {
-		host = fmt.Sprintf("%s://%s", protocol, host)
-	}
-
-	parsedHost, err := url.Parse(host)
-	if err != nil || parsedHost.Host == "" {
-		return "", fmt.Errorf("invalid host address")
-	}
-
-	return host, nil
-}
-
 func (h *Handlers) updateAuthenticationSettings(settings *conf.Settings) {
 	basicAuth := &settings.Security.BasicAuth
 
@@ -217,7 +201,8 @@ func (h *Handlers) updateAuthenticationSettings(settings *conf.Settings) {
 	}
 
 	// Format and validate the host address
-	host, err := formatAndValidateHost(settings.Security.Host, settings.Security.RedirectToHTTPS)
+	webServerPort := settings.WebServer.PortNumber()
+	host, err := settings.Security.RedirectBaseURL(webServerPort)
 	if err != nil {
 		h.SSE.SendNotification(Notification{
 			Message: err.Error(),
@@ -227,8 +212,8 @@ func (h *Handlers) updateAuthenticationSettings(settings *conf.Settings) {
 	}
 
 	settings.Security.BasicAuth.RedirectURI = host
-	settings.Security.GoogleAuth.RedirectURI = fmt.Sprintf("%s/auth/google/callback", host)
-	settings.Security.GithubAuth.RedirectURI = fmt.Sprintf("%s/auth/github/callback", host)
+	settings.Security.GoogleAuth.RedirectURI = settings.Security.RedirectURI("google", "", webServerPort)
+	settings.Security.GithubAuth.RedirectURI = settings.Security.RedirectURI("github", "", webServerPort)
 
 	// Generate secrets if they are empty
 	if basicAuth.Enabled {
@@ -238,12 +223,8 @@ func (h *Handlers) updateAuthenticationSettings(settings *conf.Settings) {
 		if basicAuth.ClientSecret == "" {
 			basicAuth.ClientSecret = conf.GenerateRandomSecret()
 		}
-		if basicAuth.AuthCodeExp == 0 {
-			basicAuth.AuthCodeExp = 10 * time.Minute
-		}
-		if basicAuth.AccessTokenExp == 0 {
-			basicAuth.AccessTokenExp = 1 * time.Hour
-		}
+		basicAuth.AuthCodeExp = basicAuth.EffectiveAuthCodeExp()
+		basicAuth.AccessTokenExp = basicAuth.EffectiveAccessTokenExp()
 	}
 
 	// Generate a random session secret for Gothic
@@ -330,10 +311,48 @@ func updateSettingsFromForm(settings *conf.Settings, formValues map[string][]str
 		}
 	}
 
+	// Per-species configs go through the settings API, which replaces the
+	// config map while holding the settings mutex
+	if configJSON, exists := formValues[speciesConfigFormKey]; exists && len(configJSON) > 0 {
+		if err := updateSpeciesConfigsFromForm(settings, configJSON[0]); err != nil {
+			return err
+		}
+	}
+
 	// Delegate the update process to updateStructFromForm
 	return updateStructFromForm(reflect.ValueOf(settings).Elem(), formValues, "")
 }
 
+// updateSpeciesConfigsFromForm replaces the per-species configs with the
+// configs of the JSON form value, species missing from it are removed
+func updateSpeciesConfigsFromForm(settings *conf.Settings, configJSON string) error {
+	var configs map[string]conf.SpeciesConfig
+	if err := json.Unmarshal([]byte(configJSON), &configs); err != nil {
+		return fmt.Errorf("error unmarshaling species configs for %s: %w", speciesConfigFormKey, err)
+	}
+
+	submitted := make(map[string]bool, len(configs))
+	for species := range configs {
+		submitted[conf.NormalizeSpeciesName(species)] = true
+	}
+	for species := range settings.SpeciesConfigs() {
+		if !submitted[conf.NormalizeSpeciesName(species)] {
+			settings.RemoveSpeciesConfig(species)
+		}
+	}
+
+	for species, config := range configs {
+		// Ensure Parameters is properly initialized as a string slice
+		for i := range config.Actions {
+			if config.Actions[i].Parameters == nil {
+				config.Actions[i].Parameters = []string{}
+			}
+		}
+		settings.UpdateSpeciesConfig(species, config)
+	}
+	return nil
+}
+
 // updateStructFromForm recursively updates a struct's fields from form values
 func updateStructFromForm(v reflect.Value, formValues map[string][]string, prefix string) error { //nolint:gocognit,gocyclo // ignore warnings for this function, going to be obsoleted soon
 	t := v.Type()
@@ -466,33 +485,8 @@ func updateStructFromForm(v reflect.Value, formValues map[string][]string, prefi
 				}
 			}
 		case reflect.Map:
-			// Handle map fields
-			if fieldType.Type == reflect.TypeOf(map[string]conf.SpeciesConfig{}) {
-				// Special handling for species config map
-				if configJSON, exists := formValues[fullName]; exists && len(configJSON) > 0 {
-					var configs map[string]conf.SpeciesConfig
-					if err := json.Unmarshal([]byte(configJSON[0]), &configs); err != nil {
-						// Add more detailed error logging
-						//log.Printf("Debug: Failed to unmarshal species config JSON: %s", configJSON[0])
-						return fmt.Errorf("error unmarshaling species configs for %s: %w", fullName, err)
-					}
-
-					// Clean up the Actions data before setting
-					for species, config := range configs {
-						for i := range config.Actions {
-							// Ensure Parameters is properly initialized as a string slice
-							if config.Actions[i].Parameters == nil {
-								config.Actions[i].Parameters = []string{}
-							}
-						}
-						configs[species] = config
-					}
-
-					field.Set(reflect.ValueOf(configs))
-				}
-			} else {
-				return fmt.Errorf("unsupported map type for %s", fullName)
-			}
+			// The species config map is updated by updateSpeciesConfigsFromForm
+			return fmt.Errorf("unsupported map type for %s", fullName)
 		default:
 			// Return error for unsupported field types
 			return fmt.Errorf("unsupported field type for %s", fullName)
@@ -815,7 +809,7 @@ func hasRTSPSettings(formParams map[string][]string) bool {
 // Check if MQTT settings have changed
 func mqttSettingsChanged(oldSettings, currentSettings *conf.Settings) bool {
 	return oldSettings.Realtime.MQTT.Enabled != currentSettings.Realtime.MQTT.Enabled ||
-		oldSettings.Realtime.MQTT.Broker != currentSettings.Realtime.MQTT.Broker ||
+		!slices.Equal(oldSettings.Realtime.MQTT.BrokerList(), currentSettings.Realtime.MQTT.BrokerList()) ||
 		oldSettings.Realtime.MQTT.Topic != currentSettings.Realtime.MQTT.Topic ||
 		oldSettings.Realtime.MQTT.Username != currentSettings.Realtime.MQTT.Username ||
 		oldSettings.Realtime.MQTT.Password != currentSettings.Realtime.MQTT.Password ||
@@ -885,7 +879,7 @@ func telemetrySettingsChanged(oldSettings, currentSettings *conf.Settings) bool
 func speciesIntervalSettingsChanged(oldSettings, currentSettings *conf.Settings) bool {
 	// Get the old and new species configs
 	oldSpeciesConfigs := oldSettings.Realtime.Species.Config
-	newSpeciesConfigs := currentSettings.Realtime.Species.Config
+	newSpeciesConfigs := currentSettings.SpeciesConfigs()
 
 	// Create a set of all species keys from both old and new configs for efficient iteration
 	allSpecies := make(map[string]bool)
@@ -942,24 +936,7 @@ func deepCopySettings(settings *conf.Settings) conf.Settings {
 
 	// Copy Species configs
 	if settings.Realtime.Species.Config != nil {
-		settingsCopy.Realtime.Species.Config = make(map[string]conf.SpeciesConfig)
-		for k, v := range settings.Realtime.Species.Config {
-			// Deep copy of SpeciesConfig (including Actions slice if present)
-			speciesConfig := v
-			if v.Actions != nil {
-				speciesConfig.Actions = make([]conf.SpeciesAction, len(v.Actions))
-				for i, action := range v.Actions {
-					actionCopy := action
-					if action.Parameters != nil {
-						actionCopy.Parameters = make([]string, len(action.Parameters))
-						// Use built-in copy function to copy slice
-						copy(actionCopy.Parameters, action.Parameters)
-					}
-					speciesConfig.Actions[i] = actionCopy
-				}
-			}
-			settingsCopy.Realtime.Species.Config[k] = speciesConfig
-		}
+		settingsCopy.Realtime.Species.Config = settings.SpeciesConfigs()
 	}
 
 	// Deep copy Include and Exclude species lists
@@ -23,19 +23,13 @@ func (h *Handlers) IgnoreSpecies(c echo.Context) error {
 	settings := conf.Setting()
 
 	// Check if species is already in the excluded list
-	isExcluded := false
-	for _, s := range settings.Realtime.Species.Exclude {
-		if s == commonName {
-			isExcluded = true
-			break
-		}
-	}
+	isExcluded := settings.Realtime.Species.IsExcluded(commonName, "")
 
 	if isExcluded {
 		// Remove from excluded list
 		newExcludeList := make([]string, 0)
 		for _, s := range settings.Realtime.Species.Exclude {
-			if s != commonName {
+			if !settings.Realtime.Species.Matches([]string{s}, commonName, "") {
 				newExcludeList = append(newExcludeList, s)
 			}
 		}
@@ -45,21 +39,23 @@ func (h *Handlers) IgnoreSpecies(c echo.Context) error {
 		settings.Realtime.Species.Exclude = append(settings.Realtime.Species.Exclude, commonName)
 	}
 
-	// Save the settings
-	if err := conf.SaveSettings(); err != nil {
+	// Save the settings without waiting for the save debounce window, the
+	// result is reported with a notification
+	saved := conf.SaveSettingsAsync()
+	message := fmt.Sprintf("%s %s excluded species list", commonName, map[bool]string{true: "removed from", false: "added to"}[isExcluded])
+	go func() {
+		if err := <-saved; err != nil {
+			h.SSE.SendNotification(Notification{
+				Message: fmt.Sprintf("Failed to save settings: %v", err),
+				Type:    "error",
+			})
+			return
+		}
 		h.SSE.SendNotification(Notification{
-			Message: fmt.Sprintf("Failed to save settings: %v", err),
-			Type:    "error",
+			Message: message,
+			Type:    "success",
 		})
-		return h.NewHandlerError(err, "Failed to save settings", http.StatusInternalServerError)
-	}
-
-	// Send success notification
-	message := fmt.Sprintf("%s %s excluded species list", commonName, map[bool]string{true: "removed from", false: "added to"}[isExcluded])
-	h.SSE.SendNotification(Notification{
-		Message: message,
-		Type:    "success",
-	})
+	}()
 
 	return c.NoContent(http.StatusOK)
 }
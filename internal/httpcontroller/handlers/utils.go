@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/tphakala/birdnet-go/internal/conf"
 	"github.com/tphakala/birdnet-go/internal/datastore"
 )
 
@@ -17,9 +18,11 @@ type NoteWithIndex struct {
 	Index           int     // Index in a list for rendering purposes
 }
 
-// getCurrentDate returns the current date in YYYY-MM-DD format.
+// getCurrentDate returns the current date in YYYY-MM-DD format in the
+// configured time zone.
 func getCurrentDate() string {
-	return time.Now().Format("2006-01-02")
+	loc, _ := conf.GetLocalTimezone()
+	return time.Now().In(loc).Format("2006-01-02")
 }
 
 // sumHourlyCounts calculates the total counts from hourly counts.
@@ -50,6 +50,7 @@ type RenderData struct {
 	Page            string
 	Title           string
 	Settings        *conf.Settings
+	Public          conf.PublicSettings
 	Locales         []LocaleData
 	Charts          template.HTML
 	ContentTemplate string
@@ -325,6 +326,7 @@ func (s *Server) handlePageRequest(c echo.Context) error {
 		Page:     pageRoute.TemplateName,
 		Title:    pageRoute.Title,
 		Settings: s.Settings,
+		Public:   s.Settings.PublicView(),
 		Security: &Security{
 			Enabled:       s.isAuthenticationEnabled(c),
 			AccessAllowed: s.IsAccessAllowed(c),
@@ -221,8 +221,10 @@ func (s *Server) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		path := c.Request().URL.Path
 
-		// Skip check for non-protected routes
-		if !isProtectedRoute(path) {
+		// Skip check for non-protected routes, configured protected routes
+		// replace the built-in ones and public routes are never protected
+		authSettings := &s.Settings.Security
+		if !authSettings.RequiresAuth(path) || (!authSettings.HasProtectedRoutes() && !isProtectedRoute(path)) {
 			return next(c)
 		}
 
@@ -7,8 +7,8 @@ import (
 	"log"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -76,8 +76,14 @@ func New(settings *conf.Settings, dataStore datastore.Interface, birdImageCache
 		metrics:           observabilityMetrics,
 	}
 
-	// Configure an IP extractor
-	s.Echo.IPExtractor = echo.ExtractIPFromXFFHeader()
+	// Configure an IP extractor honoring forwarded headers from trusted proxies
+	s.Echo.IPExtractor = func(r *http.Request) string {
+		if clientIP := conf.RealClientIP(r, s.Settings.Security.TrustedProxies); clientIP != nil {
+			return clientIP.String()
+		}
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		return host
+	}
 
 	// Initialize SunCalc for calculating sun event times
 	s.SunCalc = suncalc.NewSunCalc(settings.BirdNET.Latitude, settings.BirdNET.Longitude)
@@ -115,7 +121,7 @@ func (s *Server) Start() {
 				log.Printf("AutoTLS validation failed: %v", validationErr)
 				log.Println("AutoTLS has been disabled. Starting HTTP server on configured port.")
 				s.Settings.Security.AutoTLS = false
-				err = s.Echo.Start(":" + s.Settings.WebServer.Port)
+				err = s.Echo.Start(s.Settings.WebServer.ListenAddress())
 			} else {
 				// AutoTLS requires standard HTTPS ports
 				configPaths, configErr := conf.GetDefaultConfigPaths()
@@ -148,7 +154,7 @@ func (s *Server) Start() {
 				err = s.Echo.StartAutoTLS(":443")
 			}
 		} else {
-			err = s.Echo.Start(":" + s.Settings.WebServer.Port)
+			err = s.Echo.Start(s.Settings.WebServer.ListenAddress())
 		}
 
 		if err != nil {
@@ -162,7 +168,7 @@ func (s *Server) Start() {
 		fmt.Printf("HTTPS server started with AutoTLS on ports 80 (redirect) and 443 (secure)\n")
 		fmt.Printf("Domain: %s\n", s.Settings.Security.Host)
 	} else {
-		fmt.Printf("HTTP server started on port %s\n", s.Settings.WebServer.Port)
+		fmt.Printf("HTTP server started on %s\n", s.Settings.WebServer.ListenAddress())
 	}
 }
 
@@ -176,18 +182,12 @@ func (s *Server) IsAccessAllowed(c echo.Context) bool {
 }
 
 func (s *Server) RealIP(c echo.Context) string {
-	// Get the X-Forwarded-For header
-	if xff := c.Request().Header.Get("X-Forwarded-For"); xff != "" {
-		// Split and get the first IP in the chain
-		ips := strings.Split(xff, ",")
-		if len(ips) > 0 {
-			// Take the last IP which should be the original client IP
-			return strings.TrimSpace(ips[len(ips)-1])
-		}
-	}
-
-	// Fallback to direct RemoteAddr
+	// Forwarded headers are honored only from trusted proxies, fall back to
+	// the direct RemoteAddr
 	ip, _, _ := net.SplitHostPort(c.Request().RemoteAddr)
+	if clientIP := conf.RealClientIP(c.Request(), s.Settings.Security.TrustedProxies); clientIP != nil {
+		ip = clientIP.String()
+	}
 
 	// If we're running in a container and the client appears to be localhost,
 	// try to resolve the actual host IP
@@ -60,13 +60,7 @@ func (s *Server) GetTemplateFunctions() template.FuncMap {
 		"getAllSpecies":         s.GetAllSpecies,
 		"getIncludedSpecies":    s.GetIncludedSpecies,
 		"isSpeciesExcluded": func(commonName string) bool {
-			settings := conf.Setting()
-			for _, s := range settings.Realtime.Species.Exclude {
-				if s == commonName {
-					return true
-				}
-			}
-			return false
+			return conf.Setting().Realtime.Species.IsExcluded(commonName, "")
 		},
 		"includeTemplate": func(name string, data interface{}) (template.HTML, error) {
 			var buf bytes.Buffer
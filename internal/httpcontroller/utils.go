@@ -10,9 +10,10 @@ import (
 // prepareLocalesData returns sorted locale data to be used for select menu on the main settings page
 func (s *Server) prepareLocalesData() []LocaleData {
 	// Pre-allocate slice with capacity for all locale codes
-	locales := make([]LocaleData, 0, len(conf.LocaleCodes))
-	for code, name := range conf.LocaleCodes {
-		locales = append(locales, LocaleData{Code: code, Name: name})
+	supported := conf.SupportedLocales()
+	locales := make([]LocaleData, 0, len(supported))
+	for _, code := range supported {
+		locales = append(locales, LocaleData{Code: code, Name: conf.LocaleCodes[code]})
 	}
 	sort.Slice(locales, func(i, j int) bool {
 		return locales[i].Name < locales[j].Name
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"runtime"
+)
+
+// journaldSocket is the socket of the native systemd journal protocol
+const journaldSocket = "/run/systemd/journal/socket"
+
+// journaldWriter sends each written log record to the systemd journal as
+// one entry, with the journal's default priority. The level is part of the
+// message.
+type journaldWriter struct {
+	conn *net.UnixConn
+}
+
+// newJournaldWriter connects to the systemd journal, it fails on systems
+// other than Linux and when journald is not running
+func newJournaldWriter() (*journaldWriter, error) {
+	if runtime.GOOS != "linux" {
+		return nil, errors.New("journald output is only supported on Linux")
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journaldSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return &journaldWriter{conn: conn}, nil
+}
+
+// Write sends one log record as a journal entry
+func (w *journaldWriter) Write(p []byte) (int, error) {
+	if _, err := w.conn.Write(journaldEntry(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to the journal
+func (w *journaldWriter) Close() error {
+	return w.conn.Close()
+}
+
+// journaldEntry encodes a log record as a journal entry in the native
+// protocol, with the record as its MESSAGE
+func journaldEntry(record []byte) []byte {
+	var entry bytes.Buffer
+	writeJournaldField(&entry, "SYSLOG_IDENTIFIER", []byte("birdnet-go"))
+	writeJournaldField(&entry, "MESSAGE", bytes.TrimSuffix(record, []byte("\n")))
+	return entry.Bytes()
+}
+
+// writeJournaldField appends a field to a journal entry. Values containing
+// newlines are length prefixed, the others are written as KEY=value lines.
+func writeJournaldField(entry *bytes.Buffer, key string, value []byte) {
+	entry.WriteString(key)
+	if !bytes.ContainsRune(value, '\n') {
+		entry.WriteByte('=')
+		entry.Write(value)
+		entry.WriteByte('\n')
+		return
+	}
+	entry.WriteByte('\n')
+	_ = binary.Write(entry, binary.LittleEndian, uint64(len(value)))
+	entry.Write(value)
+	entry.WriteByte('\n')
+}
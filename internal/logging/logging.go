@@ -47,9 +47,14 @@ var levelNames = map[slog.Leveler]string{
 // defaultReplaceAttr provides common attribute formatting for all loggers.
 // It formats time, customizes level names, and truncates floats to 2 decimal places.
 func defaultReplaceAttr(groups []string, a slog.Attr) slog.Attr {
-	// Format time to second precision (RFC3339 without sub-seconds)
+	// Format time to second precision (RFC3339 without sub-seconds) in the
+	// configured time zone
 	if a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
-		a.Value = slog.StringValue(a.Value.Time().Format("2006-01-02T15:04:05Z07:00"))
+		t := a.Value.Time()
+		if settings := conf.GetSettings(); settings != nil {
+			t = t.In(settings.Location())
+		}
+		a.Value = slog.StringValue(t.Format("2006-01-02T15:04:05Z07:00"))
 	}
 	// Customize level names
 	if a.Key == slog.LevelKey {
@@ -264,11 +269,68 @@ func Trace(msg string, args ...any) {
 	slog.Log(context.TODO(), LevelTrace, msg, args...)
 }
 
-// NewFileLogger creates a new slog.Logger instance configured to write JSON logs
+// NewFileLogger creates a new slog.Logger instance configured to write logs
 // to the specified file path using lumberjack for rotation based on global config.
+// The Main.Log output and format settings apply to all file loggers, with
+// output other than file the path is ignored and the logs of all services are
+// written to the same target, told apart by their service attribute.
 // It includes a 'service' attribute in all logs.
 // It returns the logger, a function to close the underlying log writer, and an error if setup fails.
 func NewFileLogger(filePath, serviceName string, levelVar *slog.LevelVar) (*slog.Logger, func() error, error) {
+	// Using Main.Log settings as the default for all file loggers created via this func
+	mainLogConf := conf.Setting().Main.Log
+
+	writer, closeFunc, err := newLogWriter(&mainLogConf, filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handlerOptions := &slog.HandlerOptions{
+		AddSource:   false, // Keep this false unless specifically needed for debugging
+		Level:       levelVar,
+		ReplaceAttr: defaultReplaceAttr,
+	}
+	var handler slog.Handler
+	if mainLogConf.FormatOrDefault() == conf.LogFormatText {
+		handler = slog.NewTextHandler(writer, handlerOptions)
+	} else {
+		handler = slog.NewJSONHandler(writer, handlerOptions)
+	}
+
+	// Create the logger and add the service attribute
+	logger := slog.New(handler).With("service", serviceName)
+
+	return logger, closeFunc, nil
+}
+
+// newLogWriter returns the writer of the log output configured in logConf
+// and a function to close it. Log files at filePath are rotated with
+// lumberjack, standard output and standard error are never closed.
+func newLogWriter(logConf *conf.LogConfig, filePath string) (io.Writer, func() error, error) {
+	noClose := func() error { return nil }
+	switch output := logConf.OutputOrDefault(); output {
+	case conf.LogOutputFile:
+		// Handled below
+	case conf.LogOutputStdout:
+		return os.Stdout, noClose, nil
+	case conf.LogOutputStderr:
+		return os.Stderr, noClose, nil
+	case conf.LogOutputSyslog:
+		w, err := newSyslogWriter()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		return w, w.Close, nil
+	case conf.LogOutputJournald:
+		w, err := newJournaldWriter()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to the systemd journal: %w", err)
+		}
+		return w, w.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown log output %q", output)
+	}
+
 	// Ensure the directory exists (lumberjack doesn't create directories)
 	logDir := filepath.Dir(filePath)
 	if logDir != "." { // Avoid trying to create the current directory if filePath is just a filename
@@ -277,10 +339,6 @@ func NewFileLogger(filePath, serviceName string, levelVar *slog.LevelVar) (*slog
 		}
 	}
 
-	// Configure lumberjack logger based on global config settings
-	// Using Main.Log settings as the default for all file loggers created via this func
-	mainLogConf := conf.Setting().Main.Log
-
 	lj := &lumberjack.Logger{
 		Filename: filePath,
 		Compress: false, // Compression can be added later if needed
@@ -292,12 +350,12 @@ func NewFileLogger(filePath, serviceName string, levelVar *slog.LevelVar) (*slog
 	maxBackups := 3
 	maxAge := 28 // days
 
-	configMaxSizeMB := int(mainLogConf.MaxSize / (1024 * 1024))
+	configMaxSizeMB := int(logConf.MaxSize / (1024 * 1024))
 	if configMaxSizeMB > 0 {
 		maxSizeMB = configMaxSizeMB
 	}
 
-	switch mainLogConf.Rotation {
+	switch logConf.Rotation {
 	case conf.RotationDaily:
 		maxAge = 1
 		maxBackups = 30
@@ -307,31 +365,17 @@ func NewFileLogger(filePath, serviceName string, levelVar *slog.LevelVar) (*slog
 	case conf.RotationSize:
 		// Size-based rotation uses maxSizeMB derived from config (or default)
 	default:
-		slog.Warn("Unknown log rotation type in config, using size-based defaults", "configuredType", mainLogConf.Rotation)
+		slog.Warn("Unknown log rotation type in config, using size-based defaults", "configuredType", logConf.Rotation)
 	}
 
 	lj.MaxSize = maxSizeMB
 	lj.MaxBackups = maxBackups
 	lj.MaxAge = maxAge
 
-	// Create the slog handler using the lumberjack writer
-	handler := slog.NewJSONHandler(lj, &slog.HandlerOptions{
-		AddSource:   false, // Keep this false unless specifically needed for debugging
-		Level:       levelVar,
-		ReplaceAttr: defaultReplaceAttr,
-	})
-
-	// Create the logger and add the service attribute
-	logger := slog.New(handler).With("service", serviceName)
-
-	// Return the logger and the lumberjack closer function
+	// Return the writer and the lumberjack closer function
 	// Note: lumberjack.Logger.Close() doesn't actually close the file handle
 	// immediately in the typical sense, it's more for resource cleanup related
 	// to its internal state if needed. The actual file handle management
 	// happens internally based on rotation.
-	closeFunc := func() error {
-		return lj.Close() // Call lumberjack's Close method
-	}
-
-	return logger, closeFunc, nil
+	return lj, lj.Close, nil
 }
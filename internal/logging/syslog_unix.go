@@ -0,0 +1,14 @@
+//go:build !windows
+
+package logging
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// newSyslogWriter connects to the local syslog daemon, records are sent with
+// the daemon facility and info priority, their level is part of the message
+func newSyslogWriter() (io.WriteCloser, error) {
+	return syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "birdnet-go")
+}
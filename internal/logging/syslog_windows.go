@@ -0,0 +1,13 @@
+//go:build windows
+
+package logging
+
+import (
+	"errors"
+	"io"
+)
+
+// newSyslogWriter fails on Windows, which has no syslog daemon
+func newSyslogWriter() (io.WriteCloser, error) {
+	return nil, errors.New("syslog output is not supported on Windows")
+}
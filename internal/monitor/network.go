@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"slices"
+	"time"
+
+	psnet "github.com/shirou/gopsutil/v3/net"
+)
+
+// networkSample holds interface counters from a single network check
+type networkSample struct {
+	counters map[string]psnet.IOCountersStat
+	time     time.Time
+}
+
+// networkRates calculates the packet error rate in percent and the combined
+// throughput in Mbit/s between two counter samples. It returns false when the
+// counters went backwards, e.g. after an interface reset.
+func networkRates(prev, curr psnet.IOCountersStat, elapsed time.Duration) (errorPercent, mbps float64, ok bool) {
+	if elapsed <= 0 ||
+		curr.BytesSent < prev.BytesSent || curr.BytesRecv < prev.BytesRecv ||
+		curr.PacketsSent < prev.PacketsSent || curr.PacketsRecv < prev.PacketsRecv ||
+		curr.Errin < prev.Errin || curr.Errout < prev.Errout {
+		return 0, 0, false
+	}
+
+	packets := (curr.PacketsSent - prev.PacketsSent) + (curr.PacketsRecv - prev.PacketsRecv)
+	errs := (curr.Errin - prev.Errin) + (curr.Errout - prev.Errout)
+	if packets+errs > 0 {
+		errorPercent = float64(errs) / float64(packets+errs) * 100
+	}
+
+	bytes := (curr.BytesSent - prev.BytesSent) + (curr.BytesRecv - prev.BytesRecv)
+	mbps = float64(bytes) * 8 / elapsed.Seconds() / 1e6
+
+	return errorPercent, mbps, true
+}
+
+// shouldMonitorInterface reports whether a network interface is selected for monitoring
+func (m *SystemMonitor) shouldMonitorInterface(name string) bool {
+	interfaces := m.config.Realtime.Monitoring.Network.Interfaces
+	if len(interfaces) > 0 {
+		return slices.Contains(interfaces, name)
+	}
+	return name != "lo"
+}
+
+// checkNetwork monitors network interface error rate and throughput. Rates are
+// calculated from the counter change since the previous check, so the first
+// check only records a baseline.
+func (m *SystemMonitor) checkNetwork() {
+	counters, err := psnet.IOCounters(true)
+	if err != nil {
+		m.logger.Error("Failed to get network interface counters", "error", err)
+		return
+	}
+
+	current := networkSample{
+		counters: make(map[string]psnet.IOCountersStat, len(counters)),
+		time:     time.Now(),
+	}
+	for _, c := range counters {
+		if m.shouldMonitorInterface(c.Name) {
+			current.counters[c.Name] = c
+		}
+	}
+
+	m.mu.Lock()
+	previous := m.lastNetworkSample
+	m.lastNetworkSample = &current
+	m.mu.Unlock()
+
+	if previous == nil {
+		m.logger.Debug("Recorded network baseline", "interfaces", len(current.counters))
+		return
+	}
+
+	network := m.config.Realtime.Monitoring.Network
+	elapsed := current.time.Sub(previous.time)
+	for name, curr := range current.counters {
+		prev, exists := previous.counters[name]
+		if !exists {
+			continue
+		}
+
+		errorPercent, mbps, ok := networkRates(prev, curr, elapsed)
+		if !ok {
+			m.logger.Debug("Network counters reset, skipping interface", "interface", name)
+			continue
+		}
+
+		if network.Errors.Enabled {
+			m.checkThresholdsWithPath(ResourceNetworkErrors, errorPercent,
+				network.Errors.Warning, network.Errors.Critical, name)
+		}
+		if network.Throughput.Enabled {
+			m.checkThresholdsWithPath(ResourceNetworkThroughput, mbps,
+				network.Throughput.Warning, network.Throughput.Critical, name)
+		}
+	}
+}
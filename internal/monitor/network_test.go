@@ -0,0 +1,88 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	psnet "github.com/shirou/gopsutil/v3/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+func TestNetworkRates(t *testing.T) {
+	t.Parallel()
+
+	prev := psnet.IOCountersStat{
+		Name:        "eth0",
+		BytesSent:   1_000_000,
+		BytesRecv:   2_000_000,
+		PacketsSent: 1000,
+		PacketsRecv: 2000,
+	}
+
+	tests := []struct {
+		name       string
+		curr       psnet.IOCountersStat
+		elapsed    time.Duration
+		wantErrors float64
+		wantMbps   float64
+		wantOK     bool
+	}{
+		{
+			name: "throughput without errors",
+			curr: psnet.IOCountersStat{
+				BytesSent: 1_000_000 + 5_000_000, BytesRecv: 2_000_000 + 7_500_000,
+				PacketsSent: 1500, PacketsRecv: 2500,
+			},
+			elapsed:  10 * time.Second,
+			wantMbps: 10.0,
+			wantOK:   true,
+		},
+		{
+			name: "error rate",
+			curr: psnet.IOCountersStat{
+				BytesSent: 1_000_000, BytesRecv: 2_000_000,
+				PacketsSent: 1045, PacketsRecv: 2050,
+				Errin: 3, Errout: 2,
+			},
+			elapsed:    time.Second,
+			wantErrors: 5.0,
+			wantOK:     true,
+		},
+		{
+			name:    "counter reset",
+			curr:    psnet.IOCountersStat{BytesSent: 10, BytesRecv: 10},
+			elapsed: time.Second,
+			wantOK:  false,
+		},
+		{
+			name:    "zero elapsed",
+			curr:    prev,
+			elapsed: 0,
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errorPercent, mbps, ok := networkRates(prev, tt.curr, tt.elapsed)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.InDelta(t, tt.wantErrors, errorPercent, 0.001)
+			assert.InDelta(t, tt.wantMbps, mbps, 0.001)
+		})
+	}
+}
+
+func TestShouldMonitorInterface(t *testing.T) {
+	t.Parallel()
+
+	config := &conf.Settings{}
+	monitor := NewSystemMonitor(config)
+	assert.True(t, monitor.shouldMonitorInterface("eth0"))
+	assert.False(t, monitor.shouldMonitorInterface("lo"), "loopback should be skipped by default")
+
+	config.Realtime.Monitoring.Network.Interfaces = []string{"wlan0"}
+	assert.True(t, monitor.shouldMonitorInterface("wlan0"))
+	assert.False(t, monitor.shouldMonitorInterface("eth0"), "only configured interfaces should be monitored")
+}
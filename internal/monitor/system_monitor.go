@@ -46,11 +46,32 @@ func init() {
 type ResourceType string
 
 const (
-	ResourceCPU    ResourceType = "cpu"
-	ResourceMemory ResourceType = "memory"
-	ResourceDisk   ResourceType = "disk"
+	ResourceCPU               ResourceType = "cpu"
+	ResourceMemory            ResourceType = "memory"
+	ResourceDisk              ResourceType = "disk"
+	ResourceTemperature       ResourceType = "temperature"
+	ResourceNetworkErrors     ResourceType = "network_errors"
+	ResourceNetworkThroughput ResourceType = "network_throughput"
 )
 
+// hasInstances reports whether a resource type is tracked per instance, such
+// as per disk path or per network interface
+func (r ResourceType) hasInstances() bool {
+	return r == ResourceDisk || r == ResourceNetworkErrors || r == ResourceNetworkThroughput
+}
+
+// unit returns the unit used for the resource's values in notifications
+func (r ResourceType) unit() string {
+	switch r {
+	case ResourceTemperature:
+		return "°C"
+	case ResourceNetworkThroughput:
+		return " Mbit/s"
+	default:
+		return "%"
+	}
+}
+
 // AlertState tracks the current alert state for a resource
 type AlertState struct {
 	InWarning           bool
@@ -64,15 +85,17 @@ type AlertState struct {
 
 // SystemMonitor monitors system resources and sends notifications when thresholds are exceeded
 type SystemMonitor struct {
-	config         *conf.Settings
-	interval       time.Duration
-	alertStates    map[string]*AlertState
-	validatedPaths map[string]bool // Cache for validated disk paths
-	mu             sync.RWMutex
-	ctx            context.Context
-	cancel         context.CancelFunc
-	wg             sync.WaitGroup
-	logger         *slog.Logger
+	config                 *conf.Settings
+	interval               time.Duration
+	alertStates            map[string]*AlertState
+	validatedPaths         map[string]bool // Cache for validated disk paths
+	temperatureUnavailable bool            // Set once reading thermal zones has failed
+	lastNetworkSample      *networkSample  // Interface counters from the previous network check
+	mu                     sync.RWMutex
+	ctx                    context.Context
+	cancel                 context.CancelFunc
+	wg                     sync.WaitGroup
+	logger                 *slog.Logger
 }
 
 // NewSystemMonitor creates a new system monitor instance
@@ -84,6 +107,10 @@ func NewSystemMonitor(config *conf.Settings) *SystemMonitor {
 	if config.Realtime.Monitoring.CheckInterval > 0 {
 		interval = time.Duration(config.Realtime.Monitoring.CheckInterval) * time.Second
 	}
+	// Enforce the minimum interval to avoid busy-looping on misconfiguration
+	if minInterval := conf.MinMonitoringCheckInterval * time.Second; interval < minInterval {
+		interval = minInterval
+	}
 
 	// Auto-append critical paths if disk monitoring is enabled
 	if config.Realtime.Monitoring.Disk.Enabled {
@@ -125,6 +152,8 @@ func NewSystemMonitor(config *conf.Settings) *SystemMonitor {
 		"cpu_enabled", config.Realtime.Monitoring.CPU.Enabled,
 		"memory_enabled", config.Realtime.Monitoring.Memory.Enabled,
 		"disk_enabled", config.Realtime.Monitoring.Disk.Enabled,
+		"temperature_enabled", config.Realtime.Monitoring.Temperature.Enabled,
+		"network_enabled", config.Realtime.Monitoring.Network.Enabled,
 		"disk_paths", config.Realtime.Monitoring.Disk.Paths,
 		"disk_warning", config.Realtime.Monitoring.Disk.Warning,
 		"disk_critical", config.Realtime.Monitoring.Disk.Critical,
@@ -200,6 +229,8 @@ func (m *SystemMonitor) checkAllResources() {
 		"cpu_enabled", m.config.Realtime.Monitoring.CPU.Enabled,
 		"memory_enabled", m.config.Realtime.Monitoring.Memory.Enabled,
 		"disk_enabled", m.config.Realtime.Monitoring.Disk.Enabled,
+		"temperature_enabled", m.config.Realtime.Monitoring.Temperature.Enabled,
+		"network_enabled", m.config.Realtime.Monitoring.Network.Enabled,
 	)
 
 	// Check CPU usage
@@ -219,6 +250,16 @@ func (m *SystemMonitor) checkAllResources() {
 		m.logger.Debug("Disk monitoring is disabled")
 	}
 
+	// Check CPU temperature
+	if m.config.Realtime.Monitoring.Temperature.Enabled {
+		m.checkTemperature()
+	}
+
+	// Check network interfaces
+	if m.config.Realtime.Monitoring.Network.Enabled {
+		m.checkNetwork()
+	}
+
 	m.logger.Debug("Completed resource checks")
 }
 
@@ -333,10 +374,10 @@ func (m *SystemMonitor) checkThresholds(resource ResourceType, current, warningT
 
 // checkThresholdsWithPath evaluates resource usage against configured thresholds with optional path
 func (m *SystemMonitor) checkThresholdsWithPath(resource ResourceType, current, warningThreshold, criticalThreshold float64, path string) {
-	// Create state key that includes path for disk resources
+	// Create state key that includes path for disk resources and interface for network resources
 	// Use "|" as separator since it cannot appear in file paths
 	stateKey := string(resource)
-	if resource == ResourceDisk && path != "" {
+	if resource.hasInstances() && path != "" {
 		stateKey = fmt.Sprintf("%s|%s", resource, path)
 	}
 
@@ -456,7 +497,7 @@ func (m *SystemMonitor) sendNotificationWithPath(resource ResourceType, current,
 	// Try to publish via event bus first
 	if eventBus := events.GetEventBus(); eventBus != nil {
 		var event events.ResourceEvent
-		if resource == ResourceDisk && path != "" {
+		if resource.hasInstances() && path != "" {
 			event = events.NewResourceEventWithPath(string(resource), current, threshold, severity, path)
 		} else {
 			event = events.NewResourceEvent(string(resource), current, threshold, severity)
@@ -493,7 +534,7 @@ func (m *SystemMonitor) sendNotificationWithPath(resource ResourceType, current,
 		return
 	}
 
-	notification.NotifyResourceAlert(string(resource), current, threshold, "%")
+	notification.NotifyResourceAlert(string(resource), current, threshold, resource.unit())
 
 	m.logger.Warn("Resource threshold exceeded",
 		"resource", resource,
@@ -520,7 +561,7 @@ func (m *SystemMonitor) sendRecoveryNotificationWithPath(resource ResourceType,
 	if eventBus := events.GetEventBus(); eventBus != nil {
 		// For recovery, threshold is not applicable, use 0
 		var event events.ResourceEvent
-		if resource == ResourceDisk && path != "" {
+		if resource.hasInstances() && path != "" {
 			event = events.NewResourceEventWithPath(string(resource), current, 0, events.SeverityRecovery, path)
 		} else {
 			event = events.NewResourceEvent(string(resource), current, 0, events.SeverityRecovery)
@@ -561,12 +602,18 @@ func (m *SystemMonitor) sendRecoveryNotificationWithPath(resource ResourceType,
 		resourceName = "Memory"
 	case ResourceDisk:
 		resourceName = "Disk"
+	case ResourceTemperature:
+		resourceName = "Temperature"
+	case ResourceNetworkErrors:
+		resourceName = "Network error rate"
+	case ResourceNetworkThroughput:
+		resourceName = "Network throughput"
 	default:
 		resourceName = string(resource)
 	}
 
 	title := fmt.Sprintf("%s Usage Recovered", resourceName)
-	message := fmt.Sprintf("%s usage has returned to normal (%.1f%%)", resourceName, current)
+	message := fmt.Sprintf("%s usage has returned to normal (%.1f%s)", resourceName, current, resource.unit())
 	
 	// Add duration info if available
 	if duration > 0 {
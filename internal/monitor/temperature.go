@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// thermalZonePattern matches the Linux sysfs thermal zone temperature files
+var thermalZonePattern = "/sys/class/thermal/thermal_zone*/temp"
+
+// readThermalZoneTemperature returns the highest temperature in degrees Celsius
+// reported by the thermal zones matching pattern. Zone files contain the
+// temperature in millidegrees Celsius.
+func readThermalZoneTemperature(pattern string) (float64, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return 0, fmt.Errorf("invalid thermal zone pattern %q: %w", pattern, err)
+	}
+
+	var highest float64
+	found := false
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			// Some zones are not readable, e.g. disabled sensors
+			continue
+		}
+		milliCelsius, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			continue
+		}
+		celsius := float64(milliCelsius) / 1000.0
+		if !found || celsius > highest {
+			highest = celsius
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, fmt.Errorf("no readable thermal zones found matching %s", pattern)
+	}
+	return highest, nil
+}
+
+// checkTemperature monitors CPU temperature from thermal zones
+func (m *SystemMonitor) checkTemperature() {
+	temperature, err := readThermalZoneTemperature(thermalZonePattern)
+	if err != nil {
+		// Report missing sensors only once to avoid flooding the log on every check
+		m.mu.Lock()
+		alreadyReported := m.temperatureUnavailable
+		m.temperatureUnavailable = true
+		m.mu.Unlock()
+		if !alreadyReported {
+			m.logger.Error("Failed to read CPU temperature", "error", err)
+		}
+		return
+	}
+
+	m.checkThresholds(ResourceTemperature, temperature,
+		m.config.Realtime.Monitoring.Temperature.Warning,
+		m.config.Realtime.Monitoring.Temperature.Critical)
+}
@@ -0,0 +1,63 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadThermalZoneTemperature(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		zones   map[string]string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:  "single zone",
+			zones: map[string]string{"thermal_zone0": "48312\n"},
+			want:  48.312,
+		},
+		{
+			name:  "highest zone wins",
+			zones: map[string]string{"thermal_zone0": "41000", "thermal_zone1": "67500"},
+			want:  67.5,
+		},
+		{
+			name:  "unparseable zone ignored",
+			zones: map[string]string{"thermal_zone0": "invalid", "thermal_zone1": "52000"},
+			want:  52.0,
+		},
+		{
+			name:    "no zones",
+			zones:   map[string]string{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for zone, content := range tt.zones {
+				zoneDir := filepath.Join(dir, zone)
+				require.NoError(t, os.MkdirAll(zoneDir, 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(zoneDir, "temp"), []byte(content), 0o644))
+			}
+
+			got, err := readThermalZoneTemperature(filepath.Join(dir, "thermal_zone*", "temp"))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.001)
+		})
+	}
+}
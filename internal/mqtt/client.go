@@ -9,7 +9,7 @@ import (
 	"net"
 	"net/url"
 	"os"
-	"strings"
+	"slices"
 	"sync"
 	"time"
 
@@ -42,7 +42,10 @@ type client struct {
 func NewClient(settings *conf.Settings, observabilityMetrics *observability.Metrics) (Client, error) {
 	mqttLogger.Info("Creating new MQTT client")
 	config := DefaultConfig()
-	config.Broker = settings.Realtime.MQTT.Broker
+	if brokers := settings.Realtime.MQTT.BrokerList(); len(brokers) > 0 {
+		config.Broker = brokers[0]
+		config.Brokers = brokers
+	}
 	config.ClientID = settings.Main.Name
 	config.Username = settings.Realtime.MQTT.Username
 	config.Password = settings.Realtime.MQTT.Password // Keep password in config, but don't log it
@@ -57,8 +60,9 @@ func NewClient(settings *conf.Settings, observabilityMetrics *observability.Metr
 	config.TLS.ClientCert = settings.Realtime.MQTT.TLS.ClientCert
 	config.TLS.ClientKey = settings.Realtime.MQTT.TLS.ClientKey
 
-	// Auto-detect TLS from broker URL scheme
-	if strings.HasPrefix(config.Broker, "ssl://") || strings.HasPrefix(config.Broker, "tls://") || strings.HasPrefix(config.Broker, "mqtts://") {
+	// Auto-detect TLS from the broker URL schemes, the TLS configuration is
+	// only used for brokers with a TLS scheme
+	if slices.ContainsFunc(config.brokerList(), conf.BrokerUsesTLS) {
 		config.TLS.Enabled = true
 		mqttLogger.Info("TLS enabled based on broker URL scheme")
 	}
@@ -74,6 +78,7 @@ func NewClient(settings *conf.Settings, observabilityMetrics *observability.Metr
 	// Log config details without sensitive info
 	mqttLogger.Info("MQTT configuration loaded",
 		"broker", config.Broker,
+		"failover_brokers", len(config.brokerList())-1,
 		"client_id", config.ClientID,
 		"username", config.Username, // Log username, usually not sensitive
 		"topic", config.Topic,
@@ -174,7 +179,9 @@ func (c *client) Connect(ctx context.Context) error {
 
 	// Create connection options - can be outside lock, but simpler here
 	opts := mqtt.NewClientOptions()
-	opts.AddBroker(c.config.Broker)
+	for _, broker := range c.config.brokerList() {
+		opts.AddBroker(broker)
+	}
 	opts.SetClientID(c.config.ClientID)
 	opts.SetUsername(c.config.Username)
 	opts.SetPassword(c.config.Password) // Do not log the password
@@ -245,7 +252,8 @@ func (c *client) Connect(ctx context.Context) error {
 	dnsCtx, dnsCancel := context.WithTimeout(ctx, 5*time.Second)
 	defer dnsCancel()
 	host := u.Hostname()
-	if net.ParseIP(host) == nil {
+	// With failover brokers an unresolvable primary broker is skipped when connecting
+	if net.ParseIP(host) == nil && len(c.config.brokerList()) == 1 {
 		logger.Debug("Resolving broker hostname", "host", host)
 		_, err := net.DefaultResolver.LookupHost(dnsCtx, host)
 		if err != nil {
@@ -726,6 +726,7 @@ func createTestClient(t *testing.T, broker string) (Client, *observability.Metri
 		Main: struct {
 			Name      string
 			TimeAs24h bool
+			TimeZone  string
 			Log       conf.LogConfig
 		}{
 			Name: clientID,
@@ -84,6 +84,7 @@ func TestTLSFileExistenceChecks(t *testing.T) {
 				Main: struct {
 					Name      string
 					TimeAs24h bool
+					TimeZone  string
 					Log       conf.LogConfig
 				}{
 					Name: "TestNode-FileCheck",
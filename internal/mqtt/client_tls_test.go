@@ -56,6 +56,7 @@ func testMosquittoTLSConnection(t *testing.T) {
 		Main: struct {
 			Name      string
 			TimeAs24h bool
+			TimeZone  string
 			Log       conf.LogConfig
 		}{
 			Name: "TestNode-TLS-Mosquitto", //nolint:misspell // Mosquitto is the correct name of the MQTT broker
@@ -114,6 +115,7 @@ func testHiveMQTLSConnection(t *testing.T) {
 		Main: struct {
 			Name      string
 			TimeAs24h bool
+			TimeZone  string
 			Log       conf.LogConfig
 		}{
 			Name: "TestNode-TLS-HiveMQ",
@@ -177,6 +179,7 @@ func testSelfSignedCertificate(t *testing.T) {
 		Main: struct {
 			Name      string
 			TimeAs24h bool
+			TimeZone  string
 			Log       conf.LogConfig
 		}{
 			Name: "TestNode-TLS-SelfSigned",
@@ -264,6 +267,7 @@ func testTLSAutoDetection(t *testing.T) {
 				Main: struct {
 					Name      string
 					TimeAs24h bool
+					TimeZone  string
 					Log       conf.LogConfig
 				}{
 					Name: "TestNode-AutoDetect",
@@ -302,6 +306,7 @@ func testTLSConnectionTest(t *testing.T) {
 		Main: struct {
 			Name      string
 			TimeAs24h bool
+			TimeZone  string
 			Log       conf.LogConfig
 		}{
 			Name: "TestNode-TLS-ConnTest",
@@ -411,6 +416,7 @@ func TestTLSConfigValidation(t *testing.T) {
 			Main: struct {
 				Name      string
 				TimeAs24h bool
+				TimeZone  string
 				Log       conf.LogConfig
 			}{
 				Name: "TestNode-InvalidCA",
@@ -458,6 +464,7 @@ func TestTLSConfigValidation(t *testing.T) {
 			Main: struct {
 				Name      string
 				TimeAs24h bool
+				TimeZone  string
 				Log       conf.LogConfig
 			}{
 				Name: "TestNode-InvalidClientCert",
@@ -531,6 +538,7 @@ func BenchmarkTLSConnection(b *testing.B) {
 			Main: struct {
 				Name      string
 				TimeAs24h bool
+				TimeZone  string
 				Log       conf.LogConfig
 			}{
 				Name: "BenchNode-TLS",
@@ -576,6 +584,7 @@ func BenchmarkTLSConnection(b *testing.B) {
 			Main: struct {
 				Name      string
 				TimeAs24h bool
+				TimeZone  string
 				Log       conf.LogConfig
 			}{
 				Name: "BenchNode-TCP",
@@ -40,6 +40,7 @@ type Client interface {
 // Config holds the configuration for the MQTT client.
 type Config struct {
 	Broker            string
+	Brokers           []string // brokers tried in order for failover, Broker is the first of them, empty when Broker is the only broker
 	Debug             bool
 	ClientID          string
 	Username          string
@@ -59,7 +60,7 @@ type Config struct {
 
 // TLSConfig holds TLS/SSL configuration for secure MQTT connections
 type TLSConfig struct {
-	Enabled            bool   // true to enable TLS (auto-detected from broker URL)
+	Enabled            bool   // true to enable TLS (auto-detected from the broker URLs)
 	InsecureSkipVerify bool   // true to skip certificate verification
 	CACert             string // path to CA certificate file
 	ClientCert         string // path to client certificate file
@@ -128,3 +129,12 @@ func DefaultConfig() Config {
 		DisconnectTimeout: 250 * time.Millisecond,
 	}
 }
+
+// brokerList returns the brokers to connect to in order, Brokers when set and
+// otherwise Broker alone
+func (c *Config) brokerList() []string {
+	if len(c.Brokers) > 0 {
+		return c.Brokers
+	}
+	return []string{c.Broker}
+}
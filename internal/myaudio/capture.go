@@ -568,6 +568,9 @@ func processAudioFrame(
 	}
 	// --- End Buffer Safety Handling ---
 
+	// Apply input gain or normalization if configured (use the safe bufferToUse)
+	applyInputGain(bufferToUse, &settings.Realtime.Audio)
+
 	// Apply audio EQ filters if enabled (use the safe bufferToUse)
 	if settings.Realtime.Audio.Equalizer.Enabled {
 		if eqErr := ApplyFilters(bufferToUse); eqErr != nil {
@@ -4,6 +4,8 @@ package myaudio
 import (
 	"fmt"
 	"log"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -268,6 +270,13 @@ func HasCaptureBuffer(sourceID string) bool {
 	return exists
 }
 
+// CaptureBufferSources returns the sorted source IDs of the capture buffers
+func CaptureBufferSources() []string {
+	cbMutex.RLock()
+	defer cbMutex.RUnlock()
+	return slices.Sorted(maps.Keys(captureBuffers))
+}
+
 // InitCaptureBuffers initializes the capture buffers for each capture source.
 // It returns an error if initialization fails for any source.
 func InitCaptureBuffers(durationSeconds, sampleRate, bytesPerSample int, sources []string) error {
@@ -353,19 +353,25 @@ func buildFFmpegArgs(tempFilePath string, settings *conf.AudioSettings) []string
 
 	outputEncoder := getEncoder(settings.Export.Type)
 	outputFormat := getOutputFormat(settings.Export.Type)
-	outputBitrate := getMaxBitrate(settings.Export.Type, settings.Export.Bitrate)
+	outputBitrate := settings.Export.EffectiveBitrate()
 
-	return []string{
+	args := []string{
 		"-f", ffmpegFormat, // Input format based on bit depth
 		"-ar", ffmpegSampleRate, // Sample rate
 		"-ac", ffmpegNumChannels, // Number of channels
 		"-i", "-", // Read from stdin
 		"-c:a", outputEncoder,
-		"-b:a", outputBitrate,
+	}
+	// Lossless formats have no bitrate
+	if outputBitrate != "" {
+		args = append(args, "-b:a", outputBitrate)
+	}
+
+	return append(args,
 		"-f", outputFormat, // Specify the output format
 		"-y",         // Overwrite output file if it exists
 		tempFilePath, // Write to the temporary file
-	}
+	)
 }
 
 // getCodec returns the appropriate codec to use with FFmpeg based on the format
@@ -404,21 +410,6 @@ func getOutputFormat(exportType string) string {
 	}
 }
 
-// getMaxBitrate limits the bitrate to the maximum allowed by the format
-func getMaxBitrate(format, requestedBitrate string) string {
-	switch format {
-	case "opus":
-		if requestedBitrate > "256k" {
-			return "256k"
-		}
-	case "mp3":
-		if requestedBitrate > "320k" {
-			return "320k"
-		}
-	}
-	return requestedBitrate
-}
-
 // ExportAudioWithCustomFFmpegArgs exports PCM data using FFmpeg with custom arguments directly to a memory buffer.
 // This avoids writing temporary files to disk.
 // ffmpegPath is the path to the FFmpeg executable.
@@ -5,7 +5,6 @@ import (
 	"log/slog"
 	"path/filepath"
 	"sync"
-	"time"
 
 	"github.com/tphakala/birdnet-go/internal/conf"
 	"github.com/tphakala/birdnet-go/internal/errors"
@@ -73,11 +72,7 @@ func getGlobalManager() *FFmpegManager {
 		globalManager = NewFFmpegManager()
 		// Start monitoring with configurable interval
 		settings := conf.Setting()
-		monitoringInterval := time.Duration(settings.Realtime.RTSP.Health.MonitoringInterval) * time.Second
-		if monitoringInterval == 0 {
-			monitoringInterval = 30 * time.Second // default fallback
-		}
-		globalManager.StartMonitoring(monitoringInterval)
+		globalManager.StartMonitoring(settings.Realtime.RTSP.Health.EffectiveMonitoringInterval())
 	})
 	
 	managerMutex.RLock()
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log"
 	"log/slog"
+	"math"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -40,8 +41,7 @@ const (
 	defaultBackoffDuration = 5 * time.Second
 	maxBackoffDuration     = 2 * time.Minute
 
-	// Health check thresholds (defaults, can be overridden by config)
-	defaultHealthyDataThreshold   = 60 * time.Second
+	// Health check thresholds, the healthy data threshold is configurable
 	defaultReceivingDataThreshold = 5 * time.Second
 
 	// Circuit breaker settings
@@ -207,8 +207,9 @@ type FFmpegStream struct {
 	processStartTime time.Time
 
 	// Backoff for restarts
-	backoffDuration time.Duration
-	maxBackoff      time.Duration
+	backoffDuration   time.Duration
+	maxBackoff        time.Duration
+	backoffMultiplier float64
 
 	// Circuit breaker state
 	consecutiveFailures int
@@ -237,17 +238,24 @@ func (w *threadSafeWriter) Write(p []byte) (n int, err error) {
 // The url parameter specifies the RTSP stream URL, transport specifies the RTSP transport protocol,
 // and audioChan is the channel where processed audio data will be sent.
 func NewFFmpegStream(url, transport string, audioChan chan UnifiedAudioData) *FFmpegStream {
+	// Use the configured reconnection backoff when settings are loaded
+	backoffDuration, maxBackoff, backoffMultiplier := defaultBackoffDuration, maxBackoffDuration, conf.DefaultRTSPReconnectMultiplier
+	if settings := conf.GetSettings(); settings != nil {
+		backoffDuration, maxBackoff, backoffMultiplier = settings.Realtime.RTSP.EffectiveReconnectBackoff()
+	}
+
 	return &FFmpegStream{
-		url:             url,
-		transport:       transport,
-		audioChan:       audioChan,
-		restartChan:     make(chan struct{}, 1),
-		stopChan:        make(chan struct{}),
-		backoffDuration: defaultBackoffDuration,
-		maxBackoff:      maxBackoffDuration,
-		lastDataTime:    time.Now(),
-		dataRateCalc:    newDataRateCalculator(dataRateWindowSize),
-		lastDropLogTime: time.Now(),
+		url:               url,
+		transport:         transport,
+		audioChan:         audioChan,
+		restartChan:       make(chan struct{}, 1),
+		stopChan:          make(chan struct{}),
+		backoffDuration:   backoffDuration,
+		maxBackoff:        maxBackoff,
+		backoffMultiplier: backoffMultiplier,
+		lastDataTime:      time.Now(),
+		dataRateCalc:      newDataRateCalculator(dataRateWindowSize),
+		lastDropLogTime:   time.Now(),
 	}
 }
 
@@ -795,9 +803,9 @@ func (s *FFmpegStream) handleRestartBackoff() {
 		exponent = maxBackoffExponent
 	}
 
-	backoff := s.backoffDuration * time.Duration(1<<uint(exponent))
-	if backoff > s.maxBackoff {
-		backoff = s.maxBackoff
+	backoff := s.maxBackoff
+	if scaled := float64(s.backoffDuration) * math.Pow(s.backoffMultiplier, float64(exponent)); scaled < float64(s.maxBackoff) {
+		backoff = time.Duration(scaled)
 	}
 	s.restartCountMu.Unlock()
 
@@ -889,15 +897,9 @@ func (s *FFmpegStream) GetHealth() StreamHealth {
 		dataRate = 0
 	}
 
-	// Get configurable thresholds
+	// Get configurable threshold, the default is used when unset or out of range
 	settings := conf.Setting()
-	healthyDataThreshold := time.Duration(settings.Realtime.RTSP.Health.HealthyDataThreshold) * time.Second
-
-	// Validate threshold: must be positive and within reasonable limits (max 30 minutes)
-	const maxHealthyDataThreshold = 30 * time.Minute
-	if healthyDataThreshold <= 0 || healthyDataThreshold > maxHealthyDataThreshold {
-		healthyDataThreshold = defaultHealthyDataThreshold
-	}
+	healthyDataThreshold := settings.Realtime.RTSP.Health.EffectiveHealthyDataThreshold()
 
 	// Consider unhealthy if no data for configured threshold
 	isHealthy := time.Since(lastData) < healthyDataThreshold
@@ -0,0 +1,90 @@
+package myaudio
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// normalizeTargetPeak is the peak level in dBFS that normalization brings
+// captured audio to, leaving headroom below clipping
+const normalizeTargetPeak = -1.0
+
+// normalizeRelease is the time constant of raising the normalization gain.
+// It is longer than an analysis window so that the gain does not follow the
+// level within a bird call, while reducing the gain on louder input is
+// immediate so that peaks do not clip.
+const normalizeRelease = 5 * time.Second
+
+// inputNormalizer holds the normalization gain of the sound card input
+// across capture callbacks
+var inputNormalizer peakNormalizer
+
+// applyInputGain applies the configured gain or peak normalization to 16-bit
+// little-endian samples in place, before the samples reach analysis
+func applyInputGain(samples []byte, audio *conf.AudioSettings) {
+	switch {
+	case audio.Normalize:
+		inputNormalizer.apply(samples, normalizeMaxFactor(audio))
+	case audio.Gain != 0:
+		applyGain(samples, audio.GainFactor())
+	}
+}
+
+// normalizeMaxFactor returns the largest amplification of normalization, the
+// configured gain when it is positive and MaxAudioGain otherwise
+func normalizeMaxFactor(audio *conf.AudioSettings) float64 {
+	if audio.Gain > 0 {
+		return audio.GainFactor()
+	}
+	return conf.DBToLinear(conf.MaxAudioGain)
+}
+
+// applyGain multiplies 16-bit little-endian samples by factor in place,
+// clipping samples that exceed the 16-bit range
+func applyGain(samples []byte, factor float64) {
+	for i := 0; i+1 < len(samples); i += 2 {
+		sample := float64(int16(binary.LittleEndian.Uint16(samples[i:]))) * factor //nolint:gosec // G115: audio sample conversion within 16-bit range
+		sample = max(min(math.Round(sample), math.MaxInt16), math.MinInt16)
+		binary.LittleEndian.PutUint16(samples[i:], uint16(int16(sample))) //nolint:gosec // G115: audio sample conversion within 16-bit range
+	}
+}
+
+// peakNormalizer brings the peak level of 16-bit audio to normalizeTargetPeak
+// with a smoothed gain, the gain drops at once when a frame would clip and
+// rises towards the level of quieter frames with the normalizeRelease time
+// constant
+type peakNormalizer struct {
+	mu   sync.Mutex
+	gain float64 // current linear gain, 0 before the first frame
+}
+
+// apply normalizes 16-bit little-endian samples in place, amplifying by at
+// most maxFactor so that silence is not raised to noise
+func (n *peakNormalizer) apply(samples []byte, maxFactor float64) {
+	var peak float64
+	for i := 0; i+1 < len(samples); i += 2 {
+		peak = max(peak, math.Abs(float64(int16(binary.LittleEndian.Uint16(samples[i:]))))) //nolint:gosec // G115: audio sample conversion within 16-bit range
+	}
+
+	target := maxFactor
+	if peak > 0 {
+		target = min(conf.DBToLinear(normalizeTargetPeak)*math.MaxInt16/peak, maxFactor)
+	}
+
+	n.mu.Lock()
+	switch {
+	case n.gain == 0, target < n.gain:
+		n.gain = target
+	default:
+		frame := time.Duration(len(samples)/2) * time.Second / conf.SampleRate
+		n.gain += (target - n.gain) * (1 - math.Exp(-frame.Seconds()/normalizeRelease.Seconds()))
+	}
+	gain := n.gain
+	n.mu.Unlock()
+
+	applyGain(samples, gain)
+}
@@ -0,0 +1,118 @@
+package myaudio
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// int16Samples encodes samples as 16-bit little-endian bytes
+func int16Samples(values ...int16) []byte {
+	samples := make([]byte, len(values)*2)
+	for i, v := range values {
+		binary.LittleEndian.PutUint16(samples[i*2:], uint16(v)) //nolint:gosec // G115: test sample conversion
+	}
+	return samples
+}
+
+// decodeInt16Samples decodes 16-bit little-endian bytes
+func decodeInt16Samples(samples []byte) []int16 {
+	values := make([]int16, len(samples)/2)
+	for i := range values {
+		values[i] = int16(binary.LittleEndian.Uint16(samples[i*2:])) //nolint:gosec // G115: test sample conversion
+	}
+	return values
+}
+
+func TestApplyGain(t *testing.T) {
+	t.Parallel()
+
+	samples := int16Samples(100, -100, 20000, -20000, 0)
+	applyGain(samples, conf.DBToLinear(20))
+	want := []int16{1000, -1000, math.MaxInt16, math.MinInt16, 0}
+	if got := decodeInt16Samples(samples); !slices.Equal(got, want) {
+		t.Errorf("applyGain() = %v, want %v", got, want)
+	}
+}
+
+// repeatSamples returns n copies of value
+func repeatSamples(value int16, n int) []int16 {
+	values := make([]int16, n)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
+
+func TestPeakNormalizer(t *testing.T) {
+	t.Parallel()
+
+	var n peakNormalizer
+	wantPeak := int16(math.Round(conf.DBToLinear(normalizeTargetPeak) * math.MaxInt16))
+
+	// The first frame is normalized at once
+	samples := int16Samples(1000, -2000, 500)
+	n.apply(samples, 100)
+	if got := decodeInt16Samples(samples); got[1] != -wantPeak {
+		t.Errorf("normalized peak = %d, want %d", got[1], -wantPeak)
+	}
+
+	// A louder frame lowers the gain at once so that it does not clip
+	samples = int16Samples(8000, -8000)
+	n.apply(samples, 100)
+	if got := decodeInt16Samples(samples); got[0] != wantPeak {
+		t.Errorf("peak after louder frame = %d, want %d", got[0], wantPeak)
+	}
+
+	// A quieter frame raises the gain gradually
+	frame := repeatSamples(2000, conf.SampleRate/10)
+	samples = int16Samples(frame...)
+	n.apply(samples, 100)
+	got := decodeInt16Samples(samples)[0]
+	if got <= 2000*wantPeak/8000 || got >= wantPeak {
+		t.Errorf("peak after quieter frame = %d, want between %d and %d", got, 2000*wantPeak/8000, wantPeak)
+	}
+
+	// The gain approaches the target after several release time constants
+	for range 10 * normalizeRelease / (time.Second / 10) {
+		samples = int16Samples(frame...)
+		n.apply(samples, 100)
+	}
+	if got := decodeInt16Samples(samples)[0]; got < wantPeak-10 || got > wantPeak {
+		t.Errorf("peak after release = %d, want %d", got, wantPeak)
+	}
+}
+
+func TestPeakNormalizerMaxFactor(t *testing.T) {
+	t.Parallel()
+
+	// Amplification is limited for quiet input
+	var n peakNormalizer
+	samples := int16Samples(10, -10)
+	n.apply(samples, 10)
+	if got := decodeInt16Samples(samples); !slices.Equal(got, []int16{100, -100}) {
+		t.Errorf("apply() of quiet input = %v, want [100 -100]", got)
+	}
+
+	// Silence stays silent
+	samples = int16Samples(0, 0)
+	n.apply(samples, 100)
+	if got := decodeInt16Samples(samples); !slices.Equal(got, []int16{0, 0}) {
+		t.Errorf("apply() of silence = %v, want [0 0]", got)
+	}
+}
+
+func TestNormalizeMaxFactor(t *testing.T) {
+	t.Parallel()
+
+	if got, want := normalizeMaxFactor(&conf.AudioSettings{Normalize: true}), conf.DBToLinear(conf.MaxAudioGain); got != want {
+		t.Errorf("normalizeMaxFactor() without gain = %v, want %v", got, want)
+	}
+	if got, want := normalizeMaxFactor(&conf.AudioSettings{Normalize: true, Gain: 12}), conf.DBToLinear(12); got != want {
+		t.Errorf("normalizeMaxFactor() with gain = %v, want %v", got, want)
+	}
+}
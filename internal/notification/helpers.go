@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/tphakala/birdnet-go/internal/conf"
 	"github.com/tphakala/birdnet-go/internal/privacy"
 )
 
@@ -36,11 +37,15 @@ func NotifySystemAlert(priority Priority, title, message string) {
 	_, _ = service.CreateWithComponent(TypeSystem, priority, title, message, "system")
 }
 
-// NotifyDetection creates a bird detection notification
+// NotifyDetection creates a bird detection notification, unless quiet hours
+// suppress notifications
 func NotifyDetection(species string, confidence float64, metadata map[string]any) {
 	if !IsInitialized() {
 		return
 	}
+	if settings := conf.GetSettings(); settings != nil && settings.QuietHoursSuppress(conf.QuietHoursNotifications, time.Now()) {
+		return
+	}
 
 	service := GetService()
 	if service == nil {
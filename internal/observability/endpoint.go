@@ -40,8 +40,11 @@ func NewEndpoint(settings *conf.Settings, metrics *Metrics) (*Endpoint, error) {
 		return nil, fmt.Errorf("telemetry not enabled in settings")
 	}
 
+	// Attach configured namespace and node identity labels to exported metrics
+	metrics.ApplyTelemetrySettings(settings.Realtime.Telemetry)
+
 	return &Endpoint{
-		listenAddress: settings.Realtime.Telemetry.Listen,
+		listenAddress: settings.Realtime.Telemetry.ListenAddress(),
 		metrics:       metrics,
 	}, nil
 }
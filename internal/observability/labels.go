@@ -0,0 +1,90 @@
+package observability
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tphakala/birdnet-go/internal/conf"
+)
+
+// labeledGatherer wraps a Gatherer and adds a metric name prefix and constant
+// labels to every gathered metric family. This allows node identity labels to
+// be attached uniformly without changing how individual collectors register.
+type labeledGatherer struct {
+	gatherer  prometheus.Gatherer
+	namespace string
+	labels    prometheus.Labels
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *labeledGatherer) Gather() ([]*dto.MetricFamily, error) {
+	// Gather may return partial results together with an error, decorate them anyway
+	families, err := g.gatherer.Gather()
+
+	// Sort label names once so that label pairs are added in a stable order
+	labelNames := make([]string, 0, len(g.labels))
+	for name := range g.labels {
+		labelNames = append(labelNames, name)
+	}
+	sort.Strings(labelNames)
+
+	for _, family := range families {
+		if g.namespace != "" {
+			family.Name = stringPtr(g.namespace + "_" + family.GetName())
+		}
+
+		for _, metric := range family.Metric {
+			for _, name := range labelNames {
+				if hasLabel(metric, name) {
+					// Never override a label set by the collector itself
+					continue
+				}
+				metric.Label = append(metric.Label, &dto.LabelPair{
+					Name:  stringPtr(name),
+					Value: stringPtr(g.labels[name]),
+				})
+			}
+			sort.Slice(metric.Label, func(i, j int) bool {
+				return metric.Label[i].GetName() < metric.Label[j].GetName()
+			})
+		}
+	}
+
+	return families, err
+}
+
+// stringPtr returns a pointer to a copy of s, as required by the protobuf types
+func stringPtr(s string) *string {
+	return &s
+}
+
+// hasLabel reports whether the metric already has a label with the given name
+func hasLabel(metric *dto.Metric, name string) bool {
+	for _, pair := range metric.Label {
+		if pair.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyTelemetrySettings configures the metric name prefix and constant labels
+// attached to all metrics exposed by the /metrics endpoint. It must be called
+// before the endpoint starts serving requests.
+func (m *Metrics) ApplyTelemetrySettings(settings conf.TelemetrySettings) {
+	m.namespace = settings.Namespace
+	m.constLabels = settings.PromLabels()
+}
+
+// gatherer returns the Gatherer used by the metrics endpoint
+func (m *Metrics) gatherer() prometheus.Gatherer {
+	if m.namespace == "" && len(m.constLabels) == 0 {
+		return m.registry
+	}
+	return &labeledGatherer{
+		gatherer:  m.registry,
+		namespace: m.namespace,
+		labels:    m.constLabels,
+	}
+}
@@ -0,0 +1,59 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestLabeledGatherer(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "detections_total",
+		Help: "test counter",
+	}, []string{"species", "node"})
+	registry.MustRegister(counter)
+	counter.WithLabelValues("great tit", "collector").Inc()
+
+	g := &labeledGatherer{
+		gatherer:  registry,
+		namespace: "backyard",
+		labels:    prometheus.Labels{"node": "garden", "site": "north"},
+	}
+
+	families, err := g.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	if len(families) != 1 {
+		t.Fatalf("expected 1 metric family, got %d", len(families))
+	}
+	if got := families[0].GetName(); got != "backyard_detections_total" {
+		t.Errorf("metric name = %q, want backyard_detections_total", got)
+	}
+
+	labels := map[string]string{}
+	var names []string
+	for _, pair := range families[0].Metric[0].Label {
+		labels[pair.GetName()] = pair.GetValue()
+		names = append(names, pair.GetName())
+	}
+
+	// Labels set by the collector take precedence over constant labels
+	want := map[string]string{"node": "collector", "site": "north", "species": "great tit"}
+	for name, value := range want {
+		if labels[name] != value {
+			t.Errorf("label %s = %q, want %q", name, labels[name], value)
+		}
+	}
+	if len(names) != len(want) {
+		t.Errorf("expected %d labels, got %v", len(want), names)
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Errorf("labels not sorted: %v", names)
+		}
+	}
+}
@@ -28,6 +28,9 @@ type Metrics struct {
 	MyAudio       *metrics.MyAudioMetrics
 	SoundLevel    *metrics.SoundLevelMetrics
 	HTTP          *metrics.HTTPMetrics
+
+	namespace   string            // optional metric name prefix
+	constLabels prometheus.Labels // constant labels added to all exported metrics
 }
 
 // NewMetrics creates a new instance of Metrics, initializing all metric collectors.
@@ -118,7 +121,7 @@ func (m *Metrics) RegisterHandlers(mux *http.ServeMux) {
 
 // metricsHandler is the HTTP handler for the /metrics endpoint.
 func (m *Metrics) metricsHandler(w http.ResponseWriter, r *http.Request) {
-	h := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+	h := promhttp.HandlerFor(m.gatherer(), promhttp.HandlerOpts{
 		ErrorLog:      log.New(os.Stderr, "metrics handler: ", log.LstdFlags),
 		ErrorHandling: promhttp.HTTPErrorOnError,
 	})
@@ -1,6 +1,7 @@
 package observation
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
@@ -56,7 +57,7 @@ func New(settings *conf.Settings, beginTime, endTime time.Time, species string,
 	scientificName, commonName, speciesCode := ParseSpeciesString(species)
 
 	// detectionTime is time now minus 3 seconds to account for the delay in the detection
-	now := time.Now()
+	now := time.Now().In(settings.Location())
 	date := now.Format("2006-01-02")
 	detectionTime := now.Add(-2 * time.Second)
 	timeStr := detectionTime.Format("15:04:05")
@@ -136,9 +137,9 @@ func WriteNotesTable(settings *conf.Settings, notes []datastore.Note, filename s
 		}
 
 		// Prepare the line for notes above the threshold, assuming note.BeginTime and note.EndTime are of type time.Time
-		line := fmt.Sprintf("%d\tSpectrogram 1\t1\t%s\t%s\t%s\t0\t15000\t%s\t%s\t%.4f\n",
+		line := fmt.Sprintf("%d\tSpectrogram 1\t1\t%s\t%s\t%s\t0\t15000\t%s\t%s\t%s\n",
 			i+1, notes[i].Source, notes[i].BeginTime.Format("15:04:05"), notes[i].EndTime.Format("15:04:05"),
-			notes[i].SpeciesCode, notes[i].CommonName, notes[i].Confidence)
+			notes[i].SpeciesCode, notes[i].CommonName, settings.FormatConfidence(notes[i].Confidence))
 
 		// Attempt to write the note
 		if _, err = w.Write([]byte(line)); err != nil {
@@ -203,10 +204,10 @@ func WriteNotesCsv(settings *conf.Settings, notes []datastore.Note, filename str
 			continue // Skip the current iteration as the note doesn't meet the threshold
 		}
 
-		line := fmt.Sprintf("%s,%s,%s,%s,%.4f\n",
+		line := fmt.Sprintf("%s,%s,%s,%s,%s\n",
 			notes[i].BeginTime.Format("2006-01-02 15:04:05"),
 			notes[i].EndTime.Format("2006-01-02 15:04:05"),
-			notes[i].ScientificName, notes[i].CommonName, notes[i].Confidence)
+			notes[i].ScientificName, notes[i].CommonName, settings.FormatConfidence(notes[i].Confidence))
 
 		if _, err = w.Write([]byte(line)); err != nil {
 			// Break out of the loop at the first sign of an error
@@ -224,3 +225,71 @@ func WriteNotesCsv(settings *conf.Settings, notes []datastore.Note, filename str
 	// Return nil if the writing operation completes successfully.
 	return nil
 }
+
+// jsonNote is a note in the JSON output of WriteNotesJSON
+type jsonNote struct {
+	Source         string  `json:"source"`
+	BeginTime      string  `json:"begin_time"`
+	EndTime        string  `json:"end_time"`
+	ScientificName string  `json:"scientific_name"`
+	CommonName     string  `json:"common_name"`
+	SpeciesCode    string  `json:"species_code,omitempty"`
+	Confidence     float64 `json:"confidence"`
+}
+
+// WriteNotesJSON writes the slice of notes to the specified destination as a JSON array.
+// If file output is disabled, the function writes to stdout.
+// The function returns an error if writing to the destination fails.
+func WriteNotesJSON(settings *conf.Settings, notes []datastore.Note, filename string) error {
+	// Define an io.Writer to abstract the writing operation.
+	var w io.Writer
+
+	// Determine the output destination, file or screen
+	if settings.Output.File.Enabled {
+		// Ensure the filename has a .json extension.
+		if !strings.HasSuffix(filename, ".json") {
+			filename += ".json"
+		}
+		// Create or truncate the file with the given filename.
+		file, err := os.Create(filename)
+		if err != nil {
+			return fmt.Errorf("failed to create file %s: %w", filename, err)
+		}
+		defer func() {
+			if err := file.Close(); err != nil {
+				fmt.Printf("failed to close JSON file: %v\n", err)
+			}
+		}()
+		w = file
+	} else {
+		// Print output to stdout if the file output is disabled
+		w = os.Stdout
+	}
+
+	detections := make([]jsonNote, 0, len(notes))
+	for i := range notes {
+		if notes[i].Confidence <= settings.BirdNET.Threshold {
+			continue // Skip the current iteration as the note doesn't meet the threshold
+		}
+		detections = append(detections, jsonNote{
+			Source:         notes[i].Source,
+			BeginTime:      notes[i].BeginTime.Format("2006-01-02 15:04:05"),
+			EndTime:        notes[i].EndTime.Format("2006-01-02 15:04:05"),
+			ScientificName: notes[i].ScientificName,
+			CommonName:     notes[i].CommonName,
+			SpeciesCode:    notes[i].SpeciesCode,
+			Confidence:     settings.ConfidenceValue(notes[i].Confidence),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(detections); err != nil {
+		return fmt.Errorf("failed to write notes to JSON: %w", err)
+	}
+
+	if settings.Output.File.Enabled {
+		fmt.Println("Output written to", filename)
+	}
+	return nil
+}
@@ -97,12 +97,8 @@ func (s *OAuth2Server) configureLocalNetworkCookieStore() {
 		}
 	case *sessions.FilesystemStore:
 		// Calculate MaxAge in seconds from the configured session duration
-		// If not configured, default to 7 days
 		// Note: MaxAge in the cookie store options requires an integer in seconds
-		maxAge := 86400 * 7 // 7 days in seconds
-		if s.Settings.Security.SessionDuration > 0 {
-			maxAge = int(s.Settings.Security.SessionDuration.Seconds())
-		}
+		maxAge := int(s.Settings.Security.EffectiveSessionDuration().Seconds())
 
 		store.Options = &sessions.Options{
 			Path:     "/",
@@ -244,7 +240,7 @@ func (s *OAuth2Server) HandleBasicAuthToken(c echo.Context) error {
 	c.Response().Header().Set("Content-Type", "application/json")
 
 	// Return the access token in the response body
-	expiresInSeconds := int(s.Settings.Security.BasicAuth.AccessTokenExp.Seconds())
+	expiresInSeconds := int(s.Settings.Security.BasicAuth.EffectiveAccessTokenExp().Seconds())
 	resp := map[string]interface{}{ // Use interface{} for mixed types
 		"access_token": accessToken, // This is sent to the client, unavoidable
 		"token_type":   "Bearer",
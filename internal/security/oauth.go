@@ -184,10 +184,7 @@ func InitializeGoth(settings *conf.Settings) {
 
 		// Configure session store options
 		store := gothic.Store.(*sessions.FilesystemStore)
-		maxAge := 86400 * 7 // 7 days
-		if settings.Security.SessionDuration > 0 {
-			maxAge = int(settings.Security.SessionDuration.Seconds())
-		}
+		maxAge := int(settings.Security.EffectiveSessionDuration().Seconds())
 		secureCookie := settings.Security.RedirectToHTTPS
 		store.Options = &sessions.Options{
 			Path:     "/",
@@ -362,7 +359,7 @@ func (s *OAuth2Server) GenerateAuthCode() (string, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	expiresAt := time.Now().Add(s.Settings.Security.BasicAuth.AuthCodeExp)
+	expiresAt := time.Now().Add(s.Settings.Security.BasicAuth.EffectiveAuthCodeExp())
 	s.authCodes[authCode] = AuthCode{
 		Code:      authCode,
 		ExpiresAt: expiresAt,
@@ -416,7 +413,7 @@ func (s *OAuth2Server) ExchangeAuthCode(ctx context.Context, code string) (strin
 		return "", err
 	}
 	accessToken := base64.URLEncoding.EncodeToString(tokenBytes)
-	expiresAt := time.Now().Add(s.Settings.Security.BasicAuth.AccessTokenExp)
+	expiresAt := time.Now().Add(s.Settings.Security.BasicAuth.EffectiveAccessTokenExp())
 
 	s.accessTokens[accessToken] = AccessToken{
 		Token:     accessToken,
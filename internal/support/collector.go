@@ -73,7 +73,7 @@ func defaultSensitiveKeys() []string {
 		"password", "token", "secret", "key", "api_key", "api_token",
 		"client_id", "client_secret", "webhook_url", "mqtt_password",
 		"id", "apikey", "username", "broker", "topic", "urls",
-		"mqtt_username", "mqtt_topic", "birdweather_id",
+		"mqtt_username", "mqtt_topic", "birdweather_id", "passphrase",
 	}
 }
 
@@ -77,6 +77,9 @@ func InitSentry(settings *conf.Settings) error {
 		enableDebugLogging()
 	}
 
+	// Tag events with the persistent system ID
+	ensureSystemID(settings)
+
 	// Initialize Sentry SDK
 	if err := initializeSentrySDK(settings); err != nil {
 		return err
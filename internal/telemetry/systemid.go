@@ -2,11 +2,9 @@
 package telemetry
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"log"
 
+	"github.com/tphakala/birdnet-go/internal/conf"
 	"github.com/tphakala/birdnet-go/internal/privacy"
 )
 
@@ -16,35 +14,20 @@ func GenerateSystemID() (string, error) {
 	return privacy.GenerateSystemID()
 }
 
-// LoadOrCreateSystemID loads an existing system ID from file or creates a new one
-func LoadOrCreateSystemID(configDir string) (string, error) {
-	// Ensure config directory exists
-	if err := os.MkdirAll(configDir, 0o755); err != nil {
-		return "", fmt.Errorf("failed to create config directory: %w", err)
+// ensureSystemID sets the persistent system ID on settings when it is missing,
+// falling back to a temporary ID for this session if it cannot be stored
+func ensureSystemID(settings *conf.Settings) {
+	if settings.SystemID != "" {
+		return
 	}
 
-	// Path to system ID file
-	idFile := filepath.Join(configDir, ".system_id")
-
-	// Try to read existing ID
-	if data, err := os.ReadFile(idFile); err == nil {
-		id := strings.TrimSpace(string(data))
-		if id != "" && privacy.IsValidSystemID(id) {
-			return id, nil
-		}
-	}
-
-	// Generate new ID
-	id, err := GenerateSystemID()
+	id, err := conf.EnsureSystemID()
 	if err != nil {
-		return "", err
-	}
-
-	// Save to file
-	if err := os.WriteFile(idFile, []byte(id), 0o600); err != nil {
-		return "", fmt.Errorf("failed to save system ID: %w", err)
+		log.Printf("Failed to load persistent system ID, using a temporary one: %v", err)
+		if id, err = GenerateSystemID(); err != nil {
+			log.Printf("Failed to generate system ID: %v", err)
+			return
+		}
 	}
-
-	return id, nil
+	settings.SystemID = id
 }
-
@@ -224,16 +224,18 @@ func validateWeatherData(data *datastore.HourlyWeather) error {
 
 // StartPolling starts the weather polling service
 func (s *Service) StartPolling(stopChan <-chan struct{}) {
-	interval := time.Duration(s.settings.Realtime.Weather.PollInterval) * time.Minute
+	weatherSettings := s.settings.Realtime.Weather
 
 	// Use the dedicated weather logger
 	weatherLogger.Info("Starting weather polling service",
-		"provider", s.settings.Realtime.Weather.Provider,
-		"interval_minutes", s.settings.Realtime.Weather.PollInterval,
+		"provider", weatherSettings.Provider,
+		"interval_minutes", weatherSettings.EffectivePollInterval().Minutes(),
+		"jitter_minutes", weatherSettings.PollJitter,
 	)
 
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	// Each poll is scheduled separately so that the jitter differs between polls
+	timer := time.NewTimer(weatherSettings.NextPollDelay())
+	defer timer.Stop()
 
 	// Initial fetch
 	if err := s.fetchAndSave(); err != nil {
@@ -243,12 +245,13 @@ func (s *Service) StartPolling(stopChan <-chan struct{}) {
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			weatherLogger.Info("Polling weather data...")
 			if err := s.fetchAndSave(); err != nil {
 				// Error is logged within fetchAndSave, maybe just warn here?
 				weatherLogger.Warn("Weather fetch poll failed", "error", err)
 			}
+			timer.Reset(weatherSettings.NextPollDelay())
 		case <-stopChan:
 			weatherLogger.Info("Stopping weather polling service")
 			return
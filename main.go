@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"runtime"
 	"runtime/pprof"
 	"strings"
@@ -100,7 +99,7 @@ func mainWithExitCode() int {
 	settings.BuildDate = buildDate
 
 	// Load or create system ID for telemetry
-	systemID, err := telemetry.LoadOrCreateSystemID(filepath.Dir(viper.ConfigFileUsed()))
+	systemID, err := conf.EnsureSystemID()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: Failed to load system ID: %v\n", err)
 		// Generate a temporary one for this session
@@ -148,6 +147,10 @@ func mainWithExitCode() int {
 		settings.ValidationWarnings = nil
 	}
 
+	// Reload the settings when the config file is edited while running, the
+	// reloaded values are applied to the live settings
+	conf.Watch(nil)
+
 	// Execute the root command
 	rootCmd := cmd.RootCommand(settings)
 	if err := rootCmd.Execute(); err != nil {